@@ -1,11 +1,376 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/redis/go-redis/v9"
+
+	"nexus-bridge/internal/adapters"
+	"nexus-bridge/internal/api"
+	"nexus-bridge/internal/config"
+	"nexus-bridge/internal/fees"
+	"nexus-bridge/internal/guardian"
+	"nexus-bridge/internal/models"
+	"nexus-bridge/internal/oracle"
+	"nexus-bridge/internal/ratelimit"
+	"nexus-bridge/internal/relayer"
+	"nexus-bridge/internal/supply"
+	"nexus-bridge/internal/webhooks"
+	"nexus-bridge/pkg/types"
 )
 
 func main() {
 	fmt.Println("NexusBridge API starting...")
-	log.Println("API service initialized")
-}
\ No newline at end of file
+
+	cfg := config.LoadConfig()
+
+	db, err := models.Connect(cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer db.Close()
+
+	stateManager := models.NewStateManager(db)
+	server := api.NewServer(cfg.API, stateManager)
+	server.Health.Database = db
+	server.Health.Scans = stateManager
+	server.Webhooks = stateManager
+	server.Reviews = stateManager
+	server.Delays = stateManager
+	server.APIKeys = stateManager
+	server.Limits = stateManager
+
+	if cfg.API.RateLimits.Backend == "redis" {
+		limiter, closeLimiter, err := newRedisLimiter(cfg.API.RateLimits.RedisURL)
+		if err != nil {
+			log.Fatalf("Failed to initialize rate limiter: %v", err)
+		}
+		defer closeLimiter()
+		server.Limiter = limiter
+	}
+
+	chains := connectChains(context.Background(), cfg)
+	defer func() {
+		for _, chain := range chains {
+			chain.Close()
+		}
+	}()
+	for chainID, chain := range chains {
+		server.AddTokenVerifier(chainID, chain)
+	}
+
+	// Every enabled chain is reported, including those that could not be
+	// reached; scan lag comes from the progress the relayer persists
+	for _, chainCfg := range cfg.Chains {
+		if !chainCfg.Enabled || chainCfg.Type != string(types.ChainTypeEthereum) || chainCfg.BridgeContract == "" {
+			continue
+		}
+		chainID := types.ChainID(chainCfg.ChainID)
+		if chain, connected := chains[chainID]; connected {
+			server.Health.AddChain(chain)
+			continue
+		}
+		server.Health.AddChain(unreachableChain{chainID: chainID})
+	}
+
+	// Proofs are built from stored signatures, so they do not need the
+	// destination to be reachable
+	for _, chainCfg := range cfg.Chains {
+		if !chainCfg.Enabled || chainCfg.Type != string(types.ChainTypeEthereum) || chainCfg.BridgeContract == "" {
+			continue
+		}
+		server.AddDestination(types.ChainID(chainCfg.ChainID), api.Destination{
+			BridgeContract: chainCfg.BridgeContract,
+			ExecuteMethod:  chainCfg.ExecuteMethod,
+		})
+	}
+
+	// Proofs count signatures as the relayers do, against the relayer sets
+	// the bridges authorize. relayer_config is left to the relayers.
+	relayerSync := relayer.NewRelayerSync(nil)
+	for chainID, chain := range chains {
+		relayerSync.AddChain(chainID, chain)
+	}
+	if err := relayerSync.Load(context.Background()); err != nil {
+		log.Printf("Proofs are unavailable: %v", err)
+	} else {
+		aggregator := relayer.NewSignatureAggregator(stateManager, stateManager, relayerSync)
+		aggregator.RequiredWeight = cfg.Relayer.RequiredWeight
+		server.Signatures = aggregator
+	}
+
+	calculator, err := newFeeCalculator(cfg, chains)
+	if err != nil {
+		log.Fatalf("Failed to initialize fee calculator: %v", err)
+	}
+	server.Fees = calculator
+
+	// Transfer changes made by the relayer reach open streams through the database
+	listener, err := models.NewTransferUpdateListener(cfg.Database.URL)
+	if err != nil {
+		log.Fatalf("Failed to listen for transfer updates: %v", err)
+	}
+	defer listener.Close()
+
+	listenCtx, stopListening := context.WithCancel(context.Background())
+	defer stopListening()
+	go listener.Run(listenCtx, func(update *models.TransferUpdate) {
+		if update == nil {
+			server.Updates.Resync()
+			return
+		}
+		server.Updates.Publish(*update)
+	})
+
+	// Webhook deliveries are queued by the database as transfers change status
+	deliverer := webhooks.NewDeliverer(stateManager)
+	deliverer.MaxAttempts = cfg.Webhooks.MaxAttempts
+	deliverer.BaseBackoff = cfg.Webhooks.BaseBackoff
+	deliverer.MaxBackoff = cfg.Webhooks.MaxBackoff
+	deliverer.PollInterval = cfg.Webhooks.PollInterval
+	go deliverer.Run(listenCtx)
+	go relayerSync.Run(listenCtx)
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+	monitor := newSupplyMonitor(cfg, stateManager, chains)
+	if err := monitor.Register(registry); err != nil {
+		log.Fatalf("Failed to register supply metrics: %v", err)
+	}
+	server.Metrics = registry
+
+	// Pauses come from the guardian process, which holds only PAUSER_ROLE.
+	// Unpausing needs ADMIN_ROLE, so the bridge endpoints are off without
+	// the unpause key.
+	if cfg.Guardian.UnpauseKey != "" {
+		bridges, closeBridges, err := newBridgeAdmin(listenCtx, cfg, stateManager)
+		if err != nil {
+			log.Fatalf("Failed to initialize bridge administration: %v", err)
+		}
+		defer closeBridges()
+		server.Guardian = bridges
+	}
+	go monitor.Run(listenCtx)
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- server.Start()
+	}()
+	log.Printf("API service listening on :%s", cfg.API.Port)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+
+	select {
+	case err := <-errs:
+		if err != nil {
+			log.Fatalf("API server stopped: %v", err)
+		}
+	case sig := <-signals:
+		log.Printf("Received %s, shutting down", sig)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.API.WriteTimeout+5*time.Second)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		log.Printf("Error during shutdown: %v", err)
+	}
+}
+
+// connectChains connects read-only adapters to every enabled chain with a
+// bridge contract. The API only simulates and calls contracts, so the
+// adapters hold no key. Chains that cannot be reached are skipped.
+func connectChains(ctx context.Context, cfg *config.Config) map[types.ChainID]*adapters.EthereumAdapter {
+	chains := make(map[types.ChainID]*adapters.EthereumAdapter)
+	for name, chainCfg := range cfg.Chains {
+		if !chainCfg.Enabled || chainCfg.Type != string(types.ChainTypeEthereum) || chainCfg.BridgeContract == "" {
+			continue
+		}
+
+		adapter := adapters.NewEthereumAdapter(nil)
+		connectCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		err := adapter.Connect(connectCtx, chainCfg.AdapterConfig())
+		cancel()
+		if err != nil {
+			log.Printf("Chain %s is unavailable: %v", name, err)
+			continue
+		}
+		chains[types.ChainID(chainCfg.ChainID)] = adapter
+	}
+	return chains
+}
+
+// unreachableChain is the health of a chain the API could not connect to
+type unreachableChain struct {
+	chainID types.ChainID
+}
+
+func (c unreachableChain) GetChainID() types.ChainID { return c.chainID }
+func (c unreachableChain) IsConnected() bool         { return false }
+
+func (c unreachableChain) HeadBlock(ctx context.Context) (uint64, error) {
+	return 0, fmt.Errorf("adapter not connected")
+}
+
+// newSupplyMonitor builds the supply monitor from the connected chains. A
+// chain's execute method tells whether its bridge holds collateral or mints
+// wrapped tokens.
+func newSupplyMonitor(cfg *config.Config, store supply.Store, chains map[types.ChainID]*adapters.EthereumAdapter) *supply.Monitor {
+	monitor := supply.NewMonitor(store)
+	monitor.Interval = cfg.Supply.Interval
+	monitor.ToleranceBps = int64(cfg.Supply.ToleranceBps)
+
+	for _, chainCfg := range cfg.Chains {
+		chain, connected := chains[types.ChainID(chainCfg.ChainID)]
+		if !connected {
+			continue
+		}
+		switch chainCfg.ExecuteMethod {
+		case "unlockTokens":
+			monitor.AddLockChain(types.ChainID(chainCfg.ChainID), chain)
+		case "mintTokens":
+			monitor.AddMintChain(types.ChainID(chainCfg.ChainID), chain)
+		}
+	}
+	return monitor
+}
+
+// newBridgeAdmin connects the unpause key to every enabled chain with a
+// bridge contract, for operators to read and lift pauses. Its triggers are
+// never run. Chains that cannot be reached cannot be unpaused.
+func newBridgeAdmin(ctx context.Context, cfg *config.Config, store guardian.Store) (*guardian.Guardian, func(), error) {
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(cfg.Guardian.UnpauseKey, "0x"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid unpause key: %w", err)
+	}
+
+	bridges := guardian.NewGuardian(store)
+	var chains []*adapters.EthereumAdapter
+	closeChains := func() {
+		for _, chain := range chains {
+			chain.Close()
+		}
+	}
+	for name, chainCfg := range cfg.Chains {
+		if !chainCfg.Enabled || chainCfg.Type != string(types.ChainTypeEthereum) || chainCfg.BridgeContract == "" {
+			continue
+		}
+
+		adapter := adapters.NewEthereumAdapter(key)
+		connectCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		err := adapter.Connect(connectCtx, chainCfg.AdapterConfig())
+		cancel()
+		if err != nil {
+			log.Printf("Chain %s cannot be unpaused: %v", name, err)
+			continue
+		}
+		chains = append(chains, adapter)
+		bridges.AddChain(types.ChainID(chainCfg.ChainID), adapter)
+	}
+
+	return bridges, closeChains, nil
+}
+
+// newFeeCalculator builds the fee calculator from the enabled chains. Only
+// connected chains can be quoted as a destination.
+func newFeeCalculator(cfg *config.Config, chains map[types.ChainID]*adapters.EthereumAdapter) (*fees.Calculator, error) {
+	converter, err := newConverter(cfg, chains)
+	if err != nil {
+		return nil, err
+	}
+
+	calculator := fees.NewCalculator(converter)
+	calculator.MarginBps = cfg.Fees.RelayerMarginBps
+	calculator.ToleranceBps = cfg.Fees.ToleranceBps
+	calculator.SignatureCount = int(cfg.Relayer.SignatureThreshold)
+
+	for _, chainCfg := range cfg.Chains {
+		if !chainCfg.Enabled || chainCfg.Type != string(types.ChainTypeEthereum) {
+			continue
+		}
+
+		chainID := types.ChainID(chainCfg.ChainID)
+		chainFees := fees.ChainFees{
+			BridgeContract:        chainCfg.BridgeContract,
+			ExecuteMethod:         chainCfg.ExecuteMethod,
+			FallbackGasLimit:      cfg.Fees.FallbackGasLimit,
+			RequiredConfirmations: chainCfg.RequiredConfirmations,
+			BlockTime:             chainCfg.BlockTime,
+		}
+		if chain, connected := chains[chainID]; connected {
+			chainFees.Estimator = chain
+		}
+
+		calculator.AddChain(chainID, chainFees)
+	}
+
+	return calculator, nil
+}
+
+// newConverter returns the price oracle when a price source is configured,
+// and the static conversion rates otherwise
+func newConverter(cfg *config.Config, chains map[types.ChainID]*adapters.EthereumAdapter) (fees.Converter, error) {
+	var sources []oracle.PriceOracle
+
+	if cfg.Fees.PriceFile != "" {
+		feed, err := oracle.NewFileFeed(cfg.Fees.PriceFile)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, feed)
+	}
+
+	feeds, err := oracle.ParseChainlinkFeeds(cfg.Fees.ChainlinkFeeds)
+	if err != nil {
+		return nil, err
+	}
+	if len(feeds) > 0 {
+		chainlink := oracle.NewChainlinkFeed()
+		for _, feed := range feeds {
+			chain, connected := chains[feed.Chain]
+			if !connected {
+				log.Printf("Chainlink feed %s for %s is unavailable: %s is not connected", feed.Address, feed.Asset, feed.Chain)
+				continue
+			}
+			chainlink.AddFeed(feed.Asset, chain, feed.Address, feed.Decimals)
+		}
+		sources = append(sources, chainlink)
+	}
+
+	if len(sources) == 0 {
+		return fees.ParseStaticRates(cfg.Fees.ConversionRates)
+	}
+
+	median := oracle.NewMedian(cfg.Fees.PriceMaxAge, sources...)
+	median.MinSources = cfg.Fees.PriceMinSources
+	return fees.NewOracleRates(median), nil
+}
+
+// newRedisLimiter returns a limiter sharing buckets between API replicas
+// through Redis, along with a function closing its connection
+func newRedisLimiter(url string) (*ratelimit.RedisLimiter, func() error, error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid Redis URL: %w", err)
+	}
+	client := redis.NewClient(opts)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	return ratelimit.NewRedisLimiter(client, "nexus-bridge:ratelimit:"), client.Close, nil
+}
@@ -0,0 +1,104 @@
+// Command bridgectl administers a NexusBridge deployment through its API
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"nexus-bridge/pkg/client"
+)
+
+const usage = `Usage: bridgectl [flags] <command>
+
+Commands:
+  delays list                            list transfers waiting out their delay
+  delays cancel -reason <text> <id>      fail a delayed transfer before execution
+
+Flags:
+`
+
+func main() {
+	flags := flag.NewFlagSet("bridgectl", flag.ExitOnError)
+	apiURL := flags.String("api", getEnv("NEXUS_API_URL", "http://localhost:8080"), "API base URL (NEXUS_API_URL)")
+	token := flags.String("token", os.Getenv("NEXUS_API_TOKEN"), "operator API key or admin key (NEXUS_API_TOKEN)")
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), usage)
+		flags.PrintDefaults()
+	}
+	flags.Parse(os.Args[1:])
+
+	api := client.New(*apiURL)
+	api.Token = *token
+
+	args := flags.Args()
+	if len(args) < 2 || args[0] != "delays" {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch args[1] {
+	case "list":
+		err = listDelays(api)
+	case "cancel":
+		err = cancelDelay(api, args[2:])
+	default:
+		flags.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "bridgectl: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// listDelays prints the waiting delays, soonest release first
+func listDelays(api *client.Client) error {
+	delays, err := api.ListDelays(context.Background())
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "TRANSFER\tAMOUNT\tROUTE\tRELEASE AT")
+	for _, delay := range delays {
+		amount, route := "", ""
+		if transfer := delay.Transfer; transfer != nil {
+			if transfer.Amount != nil && transfer.Amount.Int != nil {
+				amount = transfer.Amount.String()
+			}
+			route = fmt.Sprintf("%s -> %s", transfer.SourceChain, transfer.DestinationChain)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", delay.TransferID, amount, route, delay.ReleaseAt.Local().Format(time.RFC3339))
+	}
+	return w.Flush()
+}
+
+// cancelDelay cancels the delay of the transfer named in args
+func cancelDelay(api *client.Client, args []string) error {
+	flags := flag.NewFlagSet("delays cancel", flag.ExitOnError)
+	reason := flags.String("reason", "", "why the transfer is cancelled (required)")
+	flags.Parse(args)
+	if flags.NArg() != 1 || *reason == "" {
+		return fmt.Errorf("usage: bridgectl delays cancel -reason <text> <transfer-id>")
+	}
+
+	delay, err := api.CancelDelay(context.Background(), flags.Arg(0), *reason)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Cancelled transfer %s\n", delay.TransferID)
+	return nil
+}
+
+// getEnv returns an environment variable or a default value
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"nexus-bridge/internal/adapters"
+	"nexus-bridge/internal/config"
+	"nexus-bridge/internal/guardian"
+	"nexus-bridge/internal/models"
+	"nexus-bridge/internal/supply"
+	"nexus-bridge/pkg/types"
+)
+
+func main() {
+	fmt.Println("NexusBridge guardian starting...")
+
+	cfg := config.LoadConfig()
+	if cfg.Guardian.PrivateKey == "" {
+		log.Fatalf("GUARDIAN_PRIVATE_KEY is required")
+	}
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(cfg.Guardian.PrivateKey, "0x"))
+	if err != nil {
+		log.Fatalf("Invalid guardian key: %v", err)
+	}
+
+	db, err := models.Connect(cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer db.Close()
+	stateManager := models.NewStateManager(db)
+
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
+
+	chains := connectChains(ctx, cfg, key)
+	defer func() {
+		for _, chain := range chains {
+			chain.Close()
+		}
+	}()
+
+	triggers := splitList(cfg.Guardian.Triggers)
+	bridgeGuardian := guardian.NewGuardian(stateManager)
+	bridgeGuardian.Interval = cfg.Guardian.Interval
+	bridgeGuardian.VolumeWindow = cfg.Guardian.VolumeWindow
+	bridgeGuardian.VolumeBaseline = cfg.Guardian.VolumeBaseline
+	bridgeGuardian.VolumeFactor = int64(cfg.Guardian.VolumeFactor)
+	if err := bridgeGuardian.SetTriggers(triggers); err != nil {
+		log.Fatalf("Failed to initialize guardian: %v", err)
+	}
+	if err := bridgeGuardian.SetSupplyChecks(splitList(cfg.Guardian.SupplyChecks)); err != nil {
+		log.Fatalf("Failed to initialize guardian: %v", err)
+	}
+
+	for chainID, chain := range chains {
+		bridgeGuardian.AddChain(chainID, chain)
+
+		if !slices.Contains(triggers, guardian.TriggerUnlock) {
+			continue
+		}
+		events := make(chan types.Event, 100)
+		if err := chain.ListenForEvents(ctx, events); err != nil {
+			log.Fatalf("Failed to watch %s: %v", chainID, err)
+		}
+		go bridgeGuardian.Watch(ctx, events)
+	}
+
+	monitor := newSupplyMonitor(cfg, stateManager, chains)
+	monitor.OnBreach = bridgeGuardian.HandleSupplyBreach
+	go monitor.Run(ctx)
+	go bridgeGuardian.Run(ctx)
+	log.Printf("Guardian watching %d chains", len(chains))
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	sig := <-signals
+	log.Printf("Received %s, shutting down", sig)
+}
+
+// connectChains connects the guardian's key to every enabled chain with a
+// bridge contract. Chains that cannot be reached are not guarded.
+func connectChains(ctx context.Context, cfg *config.Config, key *ecdsa.PrivateKey) map[types.ChainID]*adapters.EthereumAdapter {
+	chains := make(map[types.ChainID]*adapters.EthereumAdapter)
+	for name, chainCfg := range cfg.Chains {
+		if !chainCfg.Enabled || chainCfg.Type != string(types.ChainTypeEthereum) || chainCfg.BridgeContract == "" {
+			continue
+		}
+
+		adapter := adapters.NewEthereumAdapter(key)
+		connectCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		err := adapter.Connect(connectCtx, chainCfg.AdapterConfig())
+		cancel()
+		if err != nil {
+			log.Printf("Chain %s is not guarded: %v", name, err)
+			continue
+		}
+		chains[types.ChainID(chainCfg.ChainID)] = adapter
+	}
+	return chains
+}
+
+// newSupplyMonitor builds the supply monitor whose breaches trip the
+// guardian. A chain's execute method tells whether its bridge holds
+// collateral or mints wrapped tokens.
+func newSupplyMonitor(cfg *config.Config, store supply.Store, chains map[types.ChainID]*adapters.EthereumAdapter) *supply.Monitor {
+	monitor := supply.NewMonitor(store)
+	monitor.Interval = cfg.Supply.Interval
+	monitor.ToleranceBps = int64(cfg.Supply.ToleranceBps)
+
+	for _, chainCfg := range cfg.Chains {
+		chain, connected := chains[types.ChainID(chainCfg.ChainID)]
+		if !connected {
+			continue
+		}
+		switch chainCfg.ExecuteMethod {
+		case "unlockTokens":
+			monitor.AddLockChain(types.ChainID(chainCfg.ChainID), chain)
+		case "mintTokens":
+			monitor.AddMintChain(types.ChainID(chainCfg.ChainID), chain)
+		}
+	}
+	return monitor
+}
+
+// splitList returns the non-empty entries of a comma-separated list
+func splitList(value string) []string {
+	var entries []string
+	for _, entry := range strings.Split(value, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
@@ -1,11 +1,337 @@
 package main
 
 import (
+	"context"
+	"crypto/ecdsa"
 	"fmt"
 	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"nexus-bridge/internal/adapters"
+	"nexus-bridge/internal/config"
+	"nexus-bridge/internal/fees"
+	"nexus-bridge/internal/models"
+	"nexus-bridge/internal/oracle"
+	"nexus-bridge/internal/relayer"
+	"nexus-bridge/internal/screening"
+	"nexus-bridge/pkg/types"
 )
 
 func main() {
 	fmt.Println("NexusBridge Relayer starting...")
-	log.Println("Relayer service initialized")
-}
\ No newline at end of file
+
+	cfg := config.LoadConfig()
+	if cfg.Relayer.PrivateKey == "" {
+		log.Fatalf("RELAYER_PRIVATE_KEY is required")
+	}
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(cfg.Relayer.PrivateKey, "0x"))
+	if err != nil {
+		log.Fatalf("Invalid relayer key: %v", err)
+	}
+
+	db, err := models.Connect(cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer db.Close()
+	stateManager := models.NewStateManager(db)
+
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
+
+	chains := connectChains(ctx, cfg, key)
+	defer func() {
+		for _, chain := range chains {
+			chain.Close()
+		}
+	}()
+	if len(chains) == 0 {
+		log.Fatalf("No chain could be connected")
+	}
+
+	// The bridges decide who may sign and how many signatures they need;
+	// the relayer refuses to start on a local config disagreeing with them
+	validator := relayer.NewValidator(key)
+	relayerSync := relayer.NewRelayerSync(stateManager)
+	relayerSync.Validator = validator
+
+	confirmations := relayer.NewConfirmationTracker(stateManager)
+	processed := relayer.NewProcessedCheck(stateManager)
+	submitter := relayer.NewSubmitter(models.NewTxOutboxRepository(db), stateManager)
+	submitter.Processed = processed
+	aggregator := relayer.NewSignatureAggregator(stateManager, stateManager, relayerSync)
+	aggregator.RequiredWeight = cfg.Relayer.RequiredWeight
+	destination := relayer.NewDestinationExecutor(aggregator, submitter)
+	scans := relayer.NewScanReporter(stateManager)
+
+	for _, chainCfg := range cfg.Chains {
+		chainID := types.ChainID(chainCfg.ChainID)
+		chain, connected := chains[chainID]
+		if !connected {
+			continue
+		}
+		validator.AddChain(chainID, chainCfg.ExecuteMethod)
+		relayerSync.AddChain(chainID, chain)
+		confirmations.AddChain(chainID, chain, chainCfg.RequiredConfirmations)
+		processed.AddChain(chainID, chain)
+		submitter.RegisterSender(chainID, chain)
+		destination.AddChain(chainID, chainCfg.BridgeContract, chainCfg.ExecuteMethod)
+		scans.AddChain(chain)
+	}
+
+	err = relayerSync.Start(ctx, relayer.LocalRelayerConfig{
+		Address:            validator.GetRelayerAddress(),
+		SignatureThreshold: cfg.Relayer.SignatureThreshold,
+		RelayerCount:       cfg.Relayer.RelayerCount,
+	})
+	if err != nil {
+		log.Fatalf("Failed to sync relayer set: %v", err)
+	}
+
+	signer, err := newSigner(cfg, stateManager, validator, processed, chains)
+	if err != nil {
+		log.Fatalf("Failed to initialize signer: %v", err)
+	}
+	signer.Aggregator = aggregator
+
+	// Outstanding destination transactions are settled before new ones are made
+	if err := submitter.Recover(ctx); err != nil {
+		log.Fatalf("Failed to recover destination transactions: %v", err)
+	}
+
+	// Locked transfers are pending once confirmed on their source chain.
+	// Every relayer signs the pending and signed transfers it has not signed
+	// yet. Transfers are signed once enough relayers signed them, then wait
+	// out any delay before their destination transaction is submitted.
+	gate := relayer.NewDelayGate(stateManager, destination)
+	gate.Delay = cfg.Relayer.TransferDelay
+	owner := executorOwner()
+	confirming := relayer.NewExecutor(stateManager, confirmations, owner, types.StatusConfirming)
+	signing := relayer.NewExecutor(stateManager, signer, owner, types.StatusPending)
+	signing.SkipSignedBy = validator.GetRelayerAddress()
+	cosigning := relayer.NewExecutor(stateManager, signer, owner, types.StatusSigned)
+	cosigning.SkipSignedBy = validator.GetRelayerAddress()
+	delivery := relayer.NewExecutor(stateManager, gate, owner, types.StatusSigned)
+
+	dispatcher := relayer.NewDispatcher(models.NewEventInboxRepository(db))
+	if err := dispatcher.RegisterHandler(types.EventTypeLock, relayer.NewLockHandler(stateManager)); err != nil {
+		log.Fatalf("Failed to register lock handler: %v", err)
+	}
+	if err := dispatcher.RegisterHandler(types.EventTypeUnlock, relayer.NewUnlockHandler(stateManager)); err != nil {
+		log.Fatalf("Failed to register unlock handler: %v", err)
+	}
+	if err := dispatcher.Recover(ctx); err != nil {
+		log.Fatalf("Failed to recover inbox events: %v", err)
+	}
+
+	events := make(chan types.Event, 100)
+	for chainID, chain := range chains {
+		if err := chain.ListenForEvents(ctx, events); err != nil {
+			log.Fatalf("Failed to watch %s: %v", chainID, err)
+		}
+	}
+
+	go dispatcher.Run(ctx, events)
+	go relayerSync.Run(ctx)
+	go scans.Run(ctx)
+	go gate.Run(ctx)
+	go confirming.Run(ctx)
+	go signing.Run(ctx)
+	go cosigning.Run(ctx)
+	go delivery.Run(ctx)
+	go reconcileOutbox(ctx, submitter, relayer.DefaultPollInterval)
+	log.Printf("Relayer %s watching %d chains", validator.GetRelayerAddress(), len(chains))
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	sig := <-signals
+	log.Printf("Received %s, shutting down", sig)
+}
+
+// connectChains connects the relayer's key to every enabled chain with a
+// bridge contract. Chains that cannot be reached are not relayed.
+func connectChains(ctx context.Context, cfg *config.Config, key *ecdsa.PrivateKey) map[types.ChainID]*adapters.EthereumAdapter {
+	chains := make(map[types.ChainID]*adapters.EthereumAdapter)
+	for name, chainCfg := range cfg.Chains {
+		if !chainCfg.Enabled || chainCfg.Type != string(types.ChainTypeEthereum) || chainCfg.BridgeContract == "" {
+			continue
+		}
+
+		adapter := adapters.NewEthereumAdapter(key)
+		connectCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		err := adapter.Connect(connectCtx, chainCfg.AdapterConfig())
+		cancel()
+		if err != nil {
+			log.Printf("Chain %s is not relayed: %v", name, err)
+			continue
+		}
+		chains[types.ChainID(chainCfg.ChainID)] = adapter
+	}
+	return chains
+}
+
+// newSigner builds the signer with its pre-sign checks. Transfers already
+// completed on their destination are reconciled before any other check
+// could send them to review.
+func newSigner(cfg *config.Config, stateManager *models.StateManager, validator types.SignatureValidator, processed *relayer.ProcessedCheck, chains map[types.ChainID]*adapters.EthereumAdapter) (*relayer.Signer, error) {
+	signer := relayer.NewSigner(validator, stateManager)
+	signer.Approvals = stateManager
+	signer.AddCheck(processed)
+	limits := relayer.NewLimitCheck(stateManager)
+	limits.ReviewOverCap = cfg.Relayer.ReviewOverCap
+	limits.Audit = stateManager
+	signer.AddCheck(limits)
+
+	if cfg.Relayer.RulesFile != "" {
+		rules, err := relayer.LoadRules(cfg.Relayer.RulesFile)
+		if err != nil {
+			return nil, err
+		}
+		ruleCheck := relayer.NewRuleCheck(stateManager, rules)
+		ruleCheck.DryRun = cfg.Relayer.RulesDryRun
+		for chainID, chain := range chains {
+			ruleCheck.AddChain(chainID, chain)
+		}
+		signer.AddCheck(ruleCheck)
+	}
+
+	var screeners screening.Screeners
+	for _, path := range splitList(cfg.Relayer.Denylists) {
+		list, err := screening.NewFileList(path)
+		if err != nil {
+			return nil, err
+		}
+		screeners = append(screeners, list)
+	}
+	if len(screeners) > 0 {
+		signer.AddCheck(relayer.NewScreeningCheck(screeners, stateManager))
+	}
+
+	calculator, err := newFeeCalculator(cfg, chains)
+	if err != nil {
+		return nil, err
+	}
+	signer.AddCheck(relayer.NewFeeCheck(calculator))
+
+	return signer, nil
+}
+
+// newFeeCalculator builds the calculator the fee check quotes transfers
+// with, configured like the API's so both agree on the fee due
+func newFeeCalculator(cfg *config.Config, chains map[types.ChainID]*adapters.EthereumAdapter) (*fees.Calculator, error) {
+	converter, err := newConverter(cfg, chains)
+	if err != nil {
+		return nil, err
+	}
+
+	calculator := fees.NewCalculator(converter)
+	calculator.MarginBps = cfg.Fees.RelayerMarginBps
+	calculator.ToleranceBps = cfg.Fees.ToleranceBps
+	calculator.SignatureCount = int(cfg.Relayer.SignatureThreshold)
+
+	for _, chainCfg := range cfg.Chains {
+		if !chainCfg.Enabled || chainCfg.Type != string(types.ChainTypeEthereum) {
+			continue
+		}
+
+		chainID := types.ChainID(chainCfg.ChainID)
+		chainFees := fees.ChainFees{
+			BridgeContract:        chainCfg.BridgeContract,
+			ExecuteMethod:         chainCfg.ExecuteMethod,
+			FallbackGasLimit:      cfg.Fees.FallbackGasLimit,
+			RequiredConfirmations: chainCfg.RequiredConfirmations,
+			BlockTime:             chainCfg.BlockTime,
+		}
+		if chain, connected := chains[chainID]; connected {
+			chainFees.Estimator = chain
+		}
+
+		calculator.AddChain(chainID, chainFees)
+	}
+
+	return calculator, nil
+}
+
+// newConverter returns the price oracle when a price source is configured,
+// and the static conversion rates otherwise
+func newConverter(cfg *config.Config, chains map[types.ChainID]*adapters.EthereumAdapter) (fees.Converter, error) {
+	var sources []oracle.PriceOracle
+
+	if cfg.Fees.PriceFile != "" {
+		feed, err := oracle.NewFileFeed(cfg.Fees.PriceFile)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, feed)
+	}
+
+	feeds, err := oracle.ParseChainlinkFeeds(cfg.Fees.ChainlinkFeeds)
+	if err != nil {
+		return nil, err
+	}
+	if len(feeds) > 0 {
+		chainlink := oracle.NewChainlinkFeed()
+		for _, feed := range feeds {
+			chain, connected := chains[feed.Chain]
+			if !connected {
+				log.Printf("Chainlink feed %s for %s is unavailable: %s is not connected", feed.Address, feed.Asset, feed.Chain)
+				continue
+			}
+			chainlink.AddFeed(feed.Asset, chain, feed.Address, feed.Decimals)
+		}
+		sources = append(sources, chainlink)
+	}
+
+	if len(sources) == 0 {
+		return fees.ParseStaticRates(cfg.Fees.ConversionRates)
+	}
+
+	median := oracle.NewMedian(cfg.Fees.PriceMaxAge, sources...)
+	median.MinSources = cfg.Fees.PriceMinSources
+	return fees.NewOracleRates(median), nil
+}
+
+// reconcileOutbox settles broadcast destination transactions as they are
+// mined, completing their transfers, until the context ends
+func reconcileOutbox(ctx context.Context, submitter *relayer.Submitter, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := submitter.Recover(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("Error reconciling destination transactions: %v", err)
+		}
+	}
+}
+
+// executorOwner identifies this process to the transfer leases it holds
+func executorOwner() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "relayer"
+	}
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
+}
+
+// splitList returns the non-empty entries of a comma-separated list
+func splitList(value string) []string {
+	var entries []string
+	for _, entry := range strings.Split(value, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
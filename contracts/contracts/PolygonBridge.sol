@@ -20,6 +20,8 @@ contract PolygonBridge is AccessControl, ReentrancyGuard, Pausable {
     // Role definitions
     bytes32 public constant RELAYER_ROLE = keccak256("RELAYER_ROLE");
     bytes32 public constant ADMIN_ROLE = keccak256("ADMIN_ROLE");
+    // Held by automated guardians: allows pause() and nothing else
+    bytes32 public constant PAUSER_ROLE = keccak256("PAUSER_ROLE");
 
     // Struct to store supported token information
     struct TokenInfo {
@@ -135,6 +137,7 @@ contract PolygonBridge is AccessControl, ReentrancyGuard, Pausable {
 
         _grantRole(DEFAULT_ADMIN_ROLE, _admin);
         _grantRole(ADMIN_ROLE, _admin);
+        _grantRole(PAUSER_ROLE, _admin);
 
         // Set up relayers
         for (uint256 i = 0; i < _relayers.length; i++) {
@@ -543,9 +546,10 @@ contract PolygonBridge is AccessControl, ReentrancyGuard, Pausable {
     }
 
     /**
-     * @dev Pauses the contract (emergency stop)
+     * @dev Pauses the contract (emergency stop). Only unpause() requires
+     * ADMIN_ROLE, so a guardian key holding PAUSER_ROLE cannot undo it.
      */
-    function pause() external onlyRole(ADMIN_ROLE) {
+    function pause() external onlyRole(PAUSER_ROLE) {
         _pause();
     }
 
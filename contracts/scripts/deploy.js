@@ -35,6 +35,18 @@ async function main() {
   await bridge.waitForDeployment();
   console.log("EthereumBridge deployed to:", bridge.target);
 
+  // The guardian key may only pause the bridge
+  if (process.env.GUARDIAN_ADDRESS) {
+    await bridge.grantRole(
+      await bridge.PAUSER_ROLE(),
+      process.env.GUARDIAN_ADDRESS
+    );
+    console.log(
+      "Granted PAUSER_ROLE to guardian:",
+      process.env.GUARDIAN_ADDRESS
+    );
+  }
+
   // Add token support
   console.log("\nAdding token support...");
   await bridge.addSupportedToken(mockToken.target);
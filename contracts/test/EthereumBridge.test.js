@@ -684,6 +684,44 @@ describe("EthereumBridge", function () {
       expect(await bridge.paused()).to.be.false;
     });
 
+    it("Should let a pauser pause but not unpause", async function () {
+      const { bridge, admin, user2: guardian } = await loadFixture(
+        deployEthereumBridgeFixture
+      );
+      await bridge
+        .connect(admin)
+        .grantRole(await bridge.PAUSER_ROLE(), guardian.address);
+
+      await bridge.connect(guardian).pause();
+      expect(await bridge.paused()).to.be.true;
+
+      await expect(
+        bridge.connect(guardian).unpause()
+      ).to.be.revertedWithCustomError(
+        bridge,
+        "AccessControlUnauthorizedAccount"
+      );
+      await expect(
+        bridge.connect(guardian).addRelayer(guardian.address)
+      ).to.be.revertedWithCustomError(
+        bridge,
+        "AccessControlUnauthorizedAccount"
+      );
+    });
+
+    it("Should not let anyone without PAUSER_ROLE pause", async function () {
+      const { bridge, attacker } = await loadFixture(
+        deployEthereumBridgeFixture
+      );
+
+      await expect(
+        bridge.connect(attacker).pause()
+      ).to.be.revertedWithCustomError(
+        bridge,
+        "AccessControlUnauthorizedAccount"
+      );
+    });
+
     it("Should allow emergency token recovery", async function () {
       const { bridge, token, admin, user1 } = await loadFixture(
         deployEthereumBridgeFixture
@@ -754,6 +754,29 @@ describe("PolygonBridge", function () {
     it("Should only allow admin to pause/unpause", async function () {
       await expect(polygonBridge.connect(user1).pause()).to.be.reverted;
     });
+
+    it("Should let a pauser pause but not unpause", async function () {
+      await polygonBridge.grantRole(
+        await polygonBridge.PAUSER_ROLE(),
+        user2.address
+      );
+
+      await polygonBridge.connect(user2).pause();
+      expect(await polygonBridge.paused()).to.be.true;
+
+      await expect(
+        polygonBridge.connect(user2).unpause()
+      ).to.be.revertedWithCustomError(
+        polygonBridge,
+        "AccessControlUnauthorizedAccount"
+      );
+      await expect(
+        polygonBridge.connect(user2).addRelayer(user2.address)
+      ).to.be.revertedWithCustomError(
+        polygonBridge,
+        "AccessControlUnauthorizedAccount"
+      );
+    });
   });
 
   describe("View Functions", function () {
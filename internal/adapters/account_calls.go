@@ -0,0 +1,29 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// IsContract reports whether an address holds contract code on the chain
+func (e *EthereumAdapter) IsContract(ctx context.Context, address string) (bool, error) {
+	if !common.IsHexAddress(address) {
+		return false, fmt.Errorf("invalid address: %s", address)
+	}
+
+	e.mu.RLock()
+	if !e.connected {
+		e.mu.RUnlock()
+		return false, fmt.Errorf("adapter not connected")
+	}
+	client := e.client
+	e.mu.RUnlock()
+
+	code, err := client.CodeAt(ctx, common.HexToAddress(address), nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to get code at %s: %w", address, err)
+	}
+	return len(code) > 0, nil
+}
@@ -0,0 +1,188 @@
+package adapters
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"nexus-bridge/pkg/types"
+)
+
+// Bridge methods that complete a transfer on the destination chain
+const (
+	// MethodUnlockTokens releases locked tokens on the chain they came from
+	MethodUnlockTokens = "unlockTokens"
+	// MethodMintTokens mints wrapped tokens for a token from another chain
+	MethodMintTokens = "mintTokens"
+)
+
+// bridgeCallsABI describes the bridge methods called off-chain
+const bridgeCallsABI = `[
+	{
+		"inputs": [
+			{"name": "transferId", "type": "bytes32"},
+			{"name": "token", "type": "address"},
+			{"name": "amount", "type": "uint256"},
+			{"name": "recipient", "type": "address"},
+			{"name": "signatures", "type": "bytes[]"}
+		],
+		"name": "unlockTokens",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{"name": "transferId", "type": "bytes32"},
+			{"name": "originalToken", "type": "address"},
+			{"name": "originalChainId", "type": "uint256"},
+			{"name": "amount", "type": "uint256"},
+			{"name": "recipient", "type": "address"},
+			{"name": "signatures", "type": "bytes[]"}
+		],
+		"name": "mintTokens",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	},
+	{
+		"inputs": [{"name": "token", "type": "address"}],
+		"name": "isTokenSupported",
+		"outputs": [{"name": "", "type": "bool"}],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{"name": "originalToken", "type": "address"},
+			{"name": "originalChainId", "type": "uint256"}
+		],
+		"name": "isTokenSupported",
+		"outputs": [{"name": "", "type": "bool"}],
+		"stateMutability": "view",
+		"type": "function"
+	}
+]`
+
+// Overloaded view methods are suffixed by declaration order
+const (
+	methodIsTokenSupported        = "isTokenSupported"
+	methodIsWrappedTokenSupported = "isTokenSupported0"
+)
+
+var bridgeCalls = mustParseABI(bridgeCallsABI)
+
+// mustParseABI parses an ABI that is known to be valid
+func mustParseABI(definition string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(definition))
+	if err != nil {
+		panic(fmt.Sprintf("invalid bridge ABI: %v", err))
+	}
+	return parsed
+}
+
+// PackExecuteCall encodes the call data that completes a transfer on its
+// destination chain with the given relayer signatures
+func PackExecuteCall(method string, transfer types.Transfer, signatures [][]byte) ([]byte, error) {
+	if !common.IsHexAddress(transfer.Token) {
+		return nil, fmt.Errorf("invalid token address: %s", transfer.Token)
+	}
+	if !common.IsHexAddress(transfer.Recipient) {
+		return nil, fmt.Errorf("invalid recipient address: %s", transfer.Recipient)
+	}
+	if transfer.Amount == nil || transfer.Amount.Int == nil {
+		return nil, fmt.Errorf("transfer amount is required")
+	}
+
+	transferID := common.HexToHash(transfer.ID)
+	token := common.HexToAddress(transfer.Token)
+	recipient := common.HexToAddress(transfer.Recipient)
+	if signatures == nil {
+		signatures = [][]byte{}
+	}
+
+	switch method {
+	case MethodUnlockTokens:
+		return bridgeCalls.Pack(method, transferID, token, transfer.Amount.Int, recipient, signatures)
+	case MethodMintTokens:
+		sourceChain := new(big.Int).SetUint64(uint64(transfer.SourceChain))
+		return bridgeCalls.Pack(method, transferID, token, sourceChain, transfer.Amount.Int, recipient, signatures)
+	default:
+		return nil, fmt.Errorf("unsupported bridge method: %s", method)
+	}
+}
+
+// TransferDigest returns the digest relayers sign to approve a transfer on
+// its destination bridge: the Ethereum signed message hash of the packed
+// transfer fields the bridge method verifies, ending with the destination
+// chain ID
+func TransferDigest(method string, transfer types.Transfer) ([]byte, error) {
+	if !common.IsHexAddress(transfer.Token) {
+		return nil, fmt.Errorf("invalid token address: %s", transfer.Token)
+	}
+	if !common.IsHexAddress(transfer.Recipient) {
+		return nil, fmt.Errorf("invalid recipient address: %s", transfer.Recipient)
+	}
+	if transfer.Amount == nil || transfer.Amount.Int == nil || transfer.Amount.Sign() < 0 {
+		return nil, fmt.Errorf("transfer amount is required")
+	}
+
+	packed := common.HexToHash(transfer.ID).Bytes()
+	packed = append(packed, common.HexToAddress(transfer.Token).Bytes()...)
+	switch method {
+	case MethodUnlockTokens:
+	case MethodMintTokens:
+		packed = append(packed, packUint256(uint64(transfer.SourceChain))...)
+	default:
+		return nil, fmt.Errorf("unsupported bridge method: %s", method)
+	}
+	packed = append(packed, common.LeftPadBytes(transfer.Amount.Bytes(), 32)...)
+	packed = append(packed, common.HexToAddress(transfer.Recipient).Bytes()...)
+	packed = append(packed, packUint256(uint64(transfer.DestinationChain))...)
+
+	return accounts.TextHash(crypto.Keccak256(packed)), nil
+}
+
+// packUint256 encodes a number as a packed uint256
+func packUint256(value uint64) []byte {
+	return common.LeftPadBytes(new(big.Int).SetUint64(value).Bytes(), 32)
+}
+
+// packIsTokenSupported encodes a supported-token query. Tokens native to the
+// bridge's chain are looked up by address; wrapped tokens by their original
+// token and chain.
+func packIsTokenSupported(token string, originalChain types.ChainID) ([]byte, error) {
+	if !common.IsHexAddress(token) {
+		return nil, fmt.Errorf("invalid token address: %s", token)
+	}
+
+	address := common.HexToAddress(token)
+	if originalChain == 0 {
+		return bridgeCalls.Pack(methodIsTokenSupported, address)
+	}
+	return bridgeCalls.Pack(methodIsWrappedTokenSupported, address, new(big.Int).SetUint64(uint64(originalChain)))
+}
+
+// unpackIsTokenSupported decodes the result of a supported-token query
+func unpackIsTokenSupported(originalChain types.ChainID, output []byte) (bool, error) {
+	method := methodIsTokenSupported
+	if originalChain != 0 {
+		method = methodIsWrappedTokenSupported
+	}
+
+	values, err := bridgeCalls.Unpack(method, output)
+	if err != nil {
+		return false, fmt.Errorf("failed to decode %s result: %w", method, err)
+	}
+	supported, ok := values[0].(bool)
+	if !ok {
+		return false, fmt.Errorf("unexpected %s result: %v", method, values[0])
+	}
+
+	return supported, nil
+}
@@ -0,0 +1,134 @@
+package adapters
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	bridgeTypes "nexus-bridge/pkg/types"
+)
+
+func createCallTransfer() bridgeTypes.Transfer {
+	return bridgeTypes.Transfer{
+		ID:               "0x00000000000000000000000000000000000000000000000000000000000000aa",
+		SourceChain:      bridgeTypes.ChainEthereum,
+		DestinationChain: bridgeTypes.ChainPolygon,
+		Token:            "0xA0b86a33E6441E6C7D3E4C2C4C6C6C6C6C6C6C6C",
+		Amount:           bridgeTypes.NewBigInt(big.NewInt(5000)),
+		Sender:           "0x742d35Cc6634C0532925a3b8D4C9db96590C4C4C",
+		Recipient:        "0x1234567890123456789012345678901234567890",
+	}
+}
+
+func TestPackExecuteCall_UnlockTokens(t *testing.T) {
+	transfer := createCallTransfer()
+	signatures := [][]byte{make([]byte, 65), make([]byte, 65)}
+
+	data, err := PackExecuteCall(MethodUnlockTokens, transfer, signatures)
+	require.NoError(t, err)
+
+	method := bridgeCalls.Methods[MethodUnlockTokens]
+	assert.Equal(t, method.ID, data[:4])
+
+	args, err := method.Inputs.Unpack(data[4:])
+	require.NoError(t, err)
+	assert.Equal(t, common.HexToHash(transfer.ID), common.Hash(args[0].([32]byte)))
+	assert.Equal(t, common.HexToAddress(transfer.Token), args[1])
+	assert.Equal(t, big.NewInt(5000), args[2])
+	assert.Equal(t, common.HexToAddress(transfer.Recipient), args[3])
+	assert.Len(t, args[4], 2)
+}
+
+func TestPackExecuteCall_MintTokens(t *testing.T) {
+	transfer := createCallTransfer()
+
+	data, err := PackExecuteCall(MethodMintTokens, transfer, nil)
+	require.NoError(t, err)
+
+	method := bridgeCalls.Methods[MethodMintTokens]
+	assert.Equal(t, method.ID, data[:4])
+
+	args, err := method.Inputs.Unpack(data[4:])
+	require.NoError(t, err)
+	assert.Equal(t, common.HexToAddress(transfer.Token), args[1])
+	assert.Equal(t, big.NewInt(int64(bridgeTypes.ChainEthereum)), args[2])
+	assert.Equal(t, big.NewInt(5000), args[3])
+	assert.Empty(t, args[5])
+}
+
+func TestPackExecuteCall_Invalid(t *testing.T) {
+	transfer := createCallTransfer()
+
+	_, err := PackExecuteCall("burnTokens", transfer, nil)
+	assert.Error(t, err)
+
+	invalid := transfer
+	invalid.Recipient = "not-an-address"
+	_, err = PackExecuteCall(MethodUnlockTokens, invalid, nil)
+	assert.Error(t, err)
+
+	invalid = transfer
+	invalid.Amount = nil
+	_, err = PackExecuteCall(MethodUnlockTokens, invalid, nil)
+	assert.Error(t, err)
+}
+
+func TestTransferDigest(t *testing.T) {
+	transfer := createCallTransfer()
+	word := func(n int64) string { return common.Bytes2Hex(common.LeftPadBytes(big.NewInt(n).Bytes(), 32)) }
+	id := transfer.ID[2:]
+	token := "a0b86a33e6441e6c7d3e4c2c4c6c6c6c6c6c6c6c"
+	recipient := "1234567890123456789012345678901234567890"
+
+	// unlockTokens signs (transferId, token, amount, recipient, chainid)
+	digest, err := TransferDigest(MethodUnlockTokens, transfer)
+	require.NoError(t, err)
+	packed := common.Hex2Bytes(id + token + word(5000) + recipient + word(int64(bridgeTypes.ChainPolygon)))
+	assert.Equal(t, crypto.Keccak256([]byte("\x19Ethereum Signed Message:\n32"), crypto.Keccak256(packed)), digest)
+
+	// mintTokens also signs the original chain after the original token
+	digest, err = TransferDigest(MethodMintTokens, transfer)
+	require.NoError(t, err)
+	packed = common.Hex2Bytes(id + token + word(int64(bridgeTypes.ChainEthereum)) + word(5000) + recipient + word(int64(bridgeTypes.ChainPolygon)))
+	assert.Equal(t, crypto.Keccak256([]byte("\x19Ethereum Signed Message:\n32"), crypto.Keccak256(packed)), digest)
+
+	_, err = TransferDigest("burnTokens", transfer)
+	assert.Error(t, err)
+
+	invalid := transfer
+	invalid.Token = "not-an-address"
+	_, err = TransferDigest(MethodUnlockTokens, invalid)
+	assert.Error(t, err)
+}
+
+func TestIsTokenSupportedCalls(t *testing.T) {
+	token := "0xA0b86a33E6441E6C7D3E4C2C4C6C6C6C6C6C6C6C"
+
+	// Native tokens use isTokenSupported(address)
+	data, err := packIsTokenSupported(token, 0)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.Keccak256([]byte("isTokenSupported(address)"))[:4], data[:4])
+
+	// Wrapped tokens use isTokenSupported(address,uint256)
+	data, err = packIsTokenSupported(token, bridgeTypes.ChainEthereum)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.Keccak256([]byte("isTokenSupported(address,uint256)"))[:4], data[:4])
+
+	_, err = packIsTokenSupported("not-an-address", 0)
+	assert.Error(t, err)
+
+	supported, err := unpackIsTokenSupported(0, common.LeftPadBytes([]byte{1}, 32))
+	require.NoError(t, err)
+	assert.True(t, supported)
+
+	supported, err = unpackIsTokenSupported(bridgeTypes.ChainEthereum, make([]byte, 32))
+	require.NoError(t, err)
+	assert.False(t, supported)
+
+	_, err = unpackIsTokenSupported(0, nil)
+	assert.Error(t, err)
+}
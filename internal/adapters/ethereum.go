@@ -3,6 +3,7 @@ package adapters
 import (
 	"context"
 	"crypto/ecdsa"
+	"errors"
 	"fmt"
 	"math/big"
 	"strings"
@@ -128,7 +129,6 @@ func (e *EthereumAdapter) SubmitTransaction(ctx context.Context, tx types.Transa
 		e.mu.RUnlock()
 		return nil, fmt.Errorf("adapter not connected")
 	}
-	client := e.client
 	e.mu.RUnlock()
 
 	// Get nonce for the transaction
@@ -136,6 +136,32 @@ func (e *EthereumAdapter) SubmitTransaction(ctx context.Context, tx types.Transa
 	if err != nil {
 		return nil, fmt.Errorf("failed to get nonce: %w", err)
 	}
+	tx.Nonce = nonce
+
+	signed, err := e.SignTransaction(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.BroadcastTransaction(ctx, *signed); err != nil {
+		return nil, err
+	}
+
+	return &types.TxResult{
+		TxHash: signed.TxHash,
+	}, nil
+}
+
+// SignTransaction signs a transaction using tx.Nonce without broadcasting it
+func (e *EthereumAdapter) SignTransaction(ctx context.Context, tx types.Transaction) (*types.SignedTransaction, error) {
+	e.mu.RLock()
+	if !e.connected {
+		e.mu.RUnlock()
+		return nil, fmt.Errorf("adapter not connected")
+	}
+	e.mu.RUnlock()
+
+	var err error
 
 	// Estimate gas if not provided
 	gasLimit := tx.GasLimit
@@ -162,7 +188,7 @@ func (e *EthereumAdapter) SubmitTransaction(ctx context.Context, tx types.Transa
 	}
 
 	ethTx := ethtypes.NewTransaction(
-		nonce,
+		tx.Nonce,
 		common.HexToAddress(tx.To),
 		value,
 		gasLimit,
@@ -177,16 +203,100 @@ func (e *EthereumAdapter) SubmitTransaction(ctx context.Context, tx types.Transa
 		return nil, fmt.Errorf("failed to sign transaction: %w", err)
 	}
 
+	raw, err := signedTx.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode signed transaction: %w", err)
+	}
+
+	return &types.SignedTransaction{
+		ChainID: e.config.ChainID,
+		From:    e.GetSenderAddress(),
+		Nonce:   tx.Nonce,
+		TxHash:  signedTx.Hash().Hex(),
+		Raw:     raw,
+	}, nil
+}
+
+// BroadcastTransaction sends a previously signed transaction
+func (e *EthereumAdapter) BroadcastTransaction(ctx context.Context, signed types.SignedTransaction) error {
+	e.mu.RLock()
+	if !e.connected {
+		e.mu.RUnlock()
+		return fmt.Errorf("adapter not connected")
+	}
+	client := e.client
+	e.mu.RUnlock()
+
+	var signedTx ethtypes.Transaction
+	if err := signedTx.UnmarshalBinary(signed.Raw); err != nil {
+		return fmt.Errorf("failed to decode signed transaction: %w", err)
+	}
+
 	// Send transaction
-	if err := client.SendTransaction(ctx, signedTx); err != nil {
-		return nil, fmt.Errorf("failed to send transaction: %w", err)
+	if err := client.SendTransaction(ctx, &signedTx); err != nil {
+		return fmt.Errorf("failed to send transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetTransactionResult returns the mined result of a transaction, or nil if
+// the transaction is not mined
+func (e *EthereumAdapter) GetTransactionResult(ctx context.Context, txHash string) (*types.TxResult, error) {
+	e.mu.RLock()
+	if !e.connected {
+		e.mu.RUnlock()
+		return nil, fmt.Errorf("adapter not connected")
+	}
+	client := e.client
+	e.mu.RUnlock()
+
+	receipt, err := client.TransactionReceipt(ctx, common.HexToHash(txHash))
+	if err != nil {
+		if errors.Is(err, ethereum.NotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get transaction receipt: %w", err)
 	}
 
 	return &types.TxResult{
-		TxHash: signedTx.Hash().Hex(),
+		TxHash:      receipt.TxHash.Hex(),
+		BlockNumber: receipt.BlockNumber.Uint64(),
+		GasUsed:     receipt.GasUsed,
+		Status:      receipt.Status == ethtypes.ReceiptStatusSuccessful,
 	}, nil
 }
 
+// PendingNonce returns the next nonce including pending transactions
+func (e *EthereumAdapter) PendingNonce(ctx context.Context) (uint64, error) {
+	e.mu.RLock()
+	if !e.connected {
+		e.mu.RUnlock()
+		return 0, fmt.Errorf("adapter not connected")
+	}
+	e.mu.RUnlock()
+
+	return e.getNonce(ctx)
+}
+
+// ConfirmedNonce returns the next nonce according to the latest block
+func (e *EthereumAdapter) ConfirmedNonce(ctx context.Context) (uint64, error) {
+	e.mu.RLock()
+	if !e.connected {
+		e.mu.RUnlock()
+		return 0, fmt.Errorf("adapter not connected")
+	}
+	client := e.client
+	e.mu.RUnlock()
+
+	return client.NonceAt(ctx, crypto.PubkeyToAddress(e.privateKey.PublicKey), nil)
+}
+
+// GetSenderAddress returns the address transactions are signed with
+func (e *EthereumAdapter) GetSenderAddress() string {
+	return crypto.PubkeyToAddress(e.privateKey.PublicKey).Hex()
+}
+
 // GetBlockConfirmations returns the number of confirmations for a transaction
 func (e *EthereumAdapter) GetBlockConfirmations(ctx context.Context, txHash string) (uint64, error) {
 	e.mu.RLock()
@@ -278,6 +388,87 @@ func (e *EthereumAdapter) IsConnected() bool {
 	return e.connected
 }
 
+// EstimateGas simulates a transaction and returns its gas limit, including
+// the same buffer used when submitting
+func (e *EthereumAdapter) EstimateGas(ctx context.Context, tx types.Transaction) (uint64, error) {
+	e.mu.RLock()
+	if !e.connected {
+		e.mu.RUnlock()
+		return 0, fmt.Errorf("adapter not connected")
+	}
+	e.mu.RUnlock()
+
+	return e.estimateGas(ctx, tx)
+}
+
+// SuggestGasPrice returns the chain's current gas price
+func (e *EthereumAdapter) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	e.mu.RLock()
+	if !e.connected {
+		e.mu.RUnlock()
+		return nil, fmt.Errorf("adapter not connected")
+	}
+	e.mu.RUnlock()
+
+	gasPrice, err := e.getGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas price: %w", err)
+	}
+
+	return gasPrice.Int, nil
+}
+
+// IsTokenSupported asks the bridge contract whether a token is supported.
+// Tokens are looked up by address when originalChain is zero, and as the
+// wrapped form of a token from originalChain otherwise.
+func (e *EthereumAdapter) IsTokenSupported(ctx context.Context, token string, originalChain types.ChainID) (bool, error) {
+	e.mu.RLock()
+	if !e.connected {
+		e.mu.RUnlock()
+		return false, fmt.Errorf("adapter not connected")
+	}
+	client := e.client
+	bridge := common.HexToAddress(e.config.BridgeContract)
+	e.mu.RUnlock()
+
+	data, err := packIsTokenSupported(token, originalChain)
+	if err != nil {
+		return false, err
+	}
+
+	output, err := client.CallContract(ctx, ethereum.CallMsg{To: &bridge, Data: data}, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to call isTokenSupported: %w", err)
+	}
+
+	return unpackIsTokenSupported(originalChain, output)
+}
+
+// HeadBlock returns the latest block number of the chain
+func (e *EthereumAdapter) HeadBlock(ctx context.Context) (uint64, error) {
+	e.mu.RLock()
+	if !e.connected {
+		e.mu.RUnlock()
+		return 0, fmt.Errorf("adapter not connected")
+	}
+	client := e.client
+	e.mu.RUnlock()
+
+	head, err := client.BlockNumber(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get head block: %w", err)
+	}
+
+	return head, nil
+}
+
+// ScannedBlock returns the last block scanned for events
+func (e *EthereumAdapter) ScannedBlock() uint64 {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.lastBlock
+}
+
 // Close closes the connection to the blockchain
 func (e *EthereumAdapter) Close() error {
 	e.mu.Lock()
@@ -294,6 +485,8 @@ func (e *EthereumAdapter) Close() error {
 	return nil
 }
 
+var _ types.TransactionSender = (*EthereumAdapter)(nil)
+
 // Private helper methods
 
 // loadBridgeABI loads the bridge contract ABI
@@ -502,6 +695,7 @@ func (e *EthereumAdapter) parseTokensLockedEvent(log ethtypes.Log) (*types.Event
 		Type:        types.EventTypeLock,
 		ChainID:     e.config.ChainID,
 		TxHash:      log.TxHash.Hex(),
+		LogIndex:    log.Index,
 		BlockNumber: log.BlockNumber,
 		TransferID:  transferID.Hex(),
 		Transfer:    transfer,
@@ -550,6 +744,7 @@ func (e *EthereumAdapter) parseTokensUnlockedEvent(log ethtypes.Log) (*types.Eve
 		Type:        types.EventTypeUnlock,
 		ChainID:     e.config.ChainID,
 		TxHash:      log.TxHash.Hex(),
+		LogIndex:    log.Index,
 		BlockNumber: log.BlockNumber,
 		TransferID:  transferID.Hex(),
 		Transfer:    transfer,
@@ -384,6 +384,50 @@ func TestEthereumAdapter_LoadBridgeABI(t *testing.T) {
 	assert.Equal(t, "TokensUnlocked", tokensUnlockedEvent.RawName)
 }
 
+func TestEthereumAdapter_TransactionSender(t *testing.T) {
+	privateKey := createTestPrivateKey()
+	adapter := NewEthereumAdapter(privateKey)
+
+	assert.Equal(t, crypto.PubkeyToAddress(privateKey.PublicKey).Hex(), adapter.GetSenderAddress())
+
+	// All chain operations require a connection
+	_, err := adapter.SignTransaction(context.Background(), bridgeTypes.Transaction{To: "0x1234567890123456789012345678901234567890"})
+	assert.Error(t, err)
+
+	err = adapter.BroadcastTransaction(context.Background(), bridgeTypes.SignedTransaction{Raw: []byte{0x01}})
+	assert.Error(t, err)
+
+	_, err = adapter.PendingNonce(context.Background())
+	assert.Error(t, err)
+
+	_, err = adapter.ConfirmedNonce(context.Background())
+	assert.Error(t, err)
+}
+
+func TestEthereumAdapter_ScanProgress(t *testing.T) {
+	adapter := NewEthereumAdapter(createTestPrivateKey())
+
+	_, err := adapter.HeadBlock(context.Background())
+	assert.Error(t, err)
+
+	adapter.lastBlock = 1234
+	assert.Equal(t, uint64(1234), adapter.ScannedBlock())
+}
+
+func TestEthereumAdapter_GasQuotes(t *testing.T) {
+	adapter := NewEthereumAdapter(createTestPrivateKey())
+
+	// Quotes require a connection
+	_, err := adapter.EstimateGas(context.Background(), bridgeTypes.Transaction{To: "0x1234567890123456789012345678901234567890"})
+	assert.Error(t, err)
+
+	_, err = adapter.SuggestGasPrice(context.Background())
+	assert.Error(t, err)
+
+	_, err = adapter.IsTokenSupported(context.Background(), "0x1234567890123456789012345678901234567890", 0)
+	assert.Error(t, err)
+}
+
 func TestEthereumAdapter_Close(t *testing.T) {
 	privateKey := createTestPrivateKey()
 	adapter := NewEthereumAdapter(privateKey)
@@ -0,0 +1,111 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+
+	"nexus-bridge/pkg/types"
+)
+
+// pauseCallsABI covers the emergency stop of both bridge contracts. pause
+// and unpause require ADMIN_ROLE.
+const pauseCallsABI = `[
+	{
+		"inputs": [],
+		"name": "pause",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "unpause",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "paused",
+		"outputs": [{"name": "", "type": "bool"}],
+		"stateMutability": "view",
+		"type": "function"
+	}
+]`
+
+var pauseCalls = mustParseABI(pauseCallsABI)
+
+// pauseGasLimit covers pause and unpause, which only flip a flag and emit an
+// event. It is fixed because estimating with the sender unset would revert.
+const pauseGasLimit = 100000
+
+// Paused reports whether the bridge contract is paused
+func (e *EthereumAdapter) Paused(ctx context.Context) (bool, error) {
+	e.mu.RLock()
+	if !e.connected {
+		e.mu.RUnlock()
+		return false, fmt.Errorf("adapter not connected")
+	}
+	client := e.client
+	bridge := common.HexToAddress(e.config.BridgeContract)
+	e.mu.RUnlock()
+
+	data, err := pauseCalls.Pack("paused")
+	if err != nil {
+		return false, err
+	}
+	output, err := client.CallContract(ctx, ethereum.CallMsg{To: &bridge, Data: data}, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to call paused: %w", err)
+	}
+	return unpackPaused(output)
+}
+
+// Pause sends a transaction pausing the bridge contract and returns its hash
+func (e *EthereumAdapter) Pause(ctx context.Context) (string, error) {
+	return e.submitPauseCall(ctx, "pause")
+}
+
+// Unpause sends a transaction unpausing the bridge contract and returns its
+// hash
+func (e *EthereumAdapter) Unpause(ctx context.Context) (string, error) {
+	return e.submitPauseCall(ctx, "unpause")
+}
+
+// submitPauseCall sends pause or unpause to the bridge contract
+func (e *EthereumAdapter) submitPauseCall(ctx context.Context, method string) (string, error) {
+	if e.privateKey == nil {
+		return "", fmt.Errorf("adapter has no key to send %s", method)
+	}
+
+	data, err := pauseCalls.Pack(method)
+	if err != nil {
+		return "", err
+	}
+
+	e.mu.RLock()
+	bridge := e.config.BridgeContract
+	e.mu.RUnlock()
+
+	result, err := e.SubmitTransaction(ctx, types.Transaction{To: bridge, Data: data, GasLimit: pauseGasLimit})
+	if err != nil {
+		return "", fmt.Errorf("failed to send %s: %w", method, err)
+	}
+	return result.TxHash, nil
+}
+
+// unpackPaused decodes the result of paused
+func unpackPaused(output []byte) (bool, error) {
+	values, err := pauseCalls.Unpack("paused", output)
+	if err != nil {
+		return false, fmt.Errorf("failed to decode paused result: %w", err)
+	}
+	paused, ok := values[0].(bool)
+	if !ok {
+		return false, fmt.Errorf("unexpected paused result: %v", values[0])
+	}
+	return paused, nil
+}
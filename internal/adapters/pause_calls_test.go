@@ -0,0 +1,33 @@
+package adapters
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPauseCalls_Selectors(t *testing.T) {
+	for _, method := range []string{"pause", "unpause", "paused"} {
+		assert.Equal(t, crypto.Keccak256([]byte(method + "()"))[:4], pauseCalls.Methods[method].ID, method)
+	}
+}
+
+func TestUnpackPaused(t *testing.T) {
+	output, err := pauseCalls.Methods["paused"].Outputs.Pack(true)
+	require.NoError(t, err)
+	paused, err := unpackPaused(output)
+	require.NoError(t, err)
+	assert.True(t, paused)
+
+	_, err = unpackPaused(nil)
+	assert.Error(t, err)
+}
+
+func TestPause_RequiresKey(t *testing.T) {
+	adapter := NewEthereumAdapter(nil)
+	_, err := adapter.Pause(context.Background())
+	assert.ErrorContains(t, err, "no key")
+}
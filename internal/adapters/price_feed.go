@@ -0,0 +1,117 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// aggregatorV3ABI describes the Chainlink AggregatorV3Interface methods read
+// by price feeds
+const aggregatorV3ABI = `[
+	{
+		"inputs": [],
+		"name": "decimals",
+		"outputs": [{"name": "", "type": "uint8"}],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "latestRoundData",
+		"outputs": [
+			{"name": "roundId", "type": "uint80"},
+			{"name": "answer", "type": "int256"},
+			{"name": "startedAt", "type": "uint256"},
+			{"name": "updatedAt", "type": "uint256"},
+			{"name": "answeredInRound", "type": "uint80"}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	}
+]`
+
+var aggregatorV3 = mustParseABI(aggregatorV3ABI)
+
+// PriceRound is the latest answer of a Chainlink aggregator
+type PriceRound struct {
+	RoundID         *big.Int
+	Answer          *big.Int
+	Decimals        uint8 // decimals of Answer
+	UpdatedAt       time.Time
+	AnsweredInRound *big.Int
+}
+
+// LatestRoundData reads the latest round of a Chainlink AggregatorV3 feed
+func (e *EthereumAdapter) LatestRoundData(ctx context.Context, feed string) (*PriceRound, error) {
+	e.mu.RLock()
+	if !e.connected {
+		e.mu.RUnlock()
+		return nil, fmt.Errorf("adapter not connected")
+	}
+	client := e.client
+	e.mu.RUnlock()
+
+	if !common.IsHexAddress(feed) {
+		return nil, fmt.Errorf("invalid feed address: %s", feed)
+	}
+	address := common.HexToAddress(feed)
+
+	call := func(method string) ([]byte, error) {
+		data, err := aggregatorV3.Pack(method)
+		if err != nil {
+			return nil, err
+		}
+		output, err := client.CallContract(ctx, ethereum.CallMsg{To: &address, Data: data}, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to call %s on %s: %w", method, feed, err)
+		}
+		return output, nil
+	}
+
+	decimals, err := call("decimals")
+	if err != nil {
+		return nil, err
+	}
+	round, err := call("latestRoundData")
+	if err != nil {
+		return nil, err
+	}
+
+	return unpackLatestRoundData(decimals, round)
+}
+
+// unpackLatestRoundData decodes the results of decimals and latestRoundData
+func unpackLatestRoundData(decimalsOutput, roundOutput []byte) (*PriceRound, error) {
+	values, err := aggregatorV3.Unpack("decimals", decimalsOutput)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode decimals result: %w", err)
+	}
+	decimals, ok := values[0].(uint8)
+	if !ok {
+		return nil, fmt.Errorf("unexpected decimals result: %v", values[0])
+	}
+
+	values, err = aggregatorV3.Unpack("latestRoundData", roundOutput)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode latestRoundData result: %w", err)
+	}
+	ints := make([]*big.Int, len(values))
+	for i, value := range values {
+		if ints[i], ok = value.(*big.Int); !ok {
+			return nil, fmt.Errorf("unexpected latestRoundData result: %v", values)
+		}
+	}
+
+	return &PriceRound{
+		RoundID:         ints[0],
+		Answer:          ints[1],
+		Decimals:        decimals,
+		UpdatedAt:       time.Unix(ints[3].Int64(), 0),
+		AnsweredInRound: ints[4],
+	}, nil
+}
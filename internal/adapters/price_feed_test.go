@@ -0,0 +1,35 @@
+package adapters
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnpackLatestRoundData(t *testing.T) {
+	data, err := aggregatorV3.Pack("latestRoundData")
+	require.NoError(t, err)
+	assert.Equal(t, crypto.Keccak256([]byte("latestRoundData()"))[:4], data)
+
+	decimals, err := aggregatorV3.Methods["decimals"].Outputs.Pack(uint8(8))
+	require.NoError(t, err)
+	round, err := aggregatorV3.Methods["latestRoundData"].Outputs.Pack(
+		big.NewInt(42), big.NewInt(300012345678), big.NewInt(1760000000), big.NewInt(1760000060), big.NewInt(41))
+	require.NoError(t, err)
+
+	result, err := unpackLatestRoundData(decimals, round)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), result.RoundID.Int64())
+	assert.Equal(t, int64(300012345678), result.Answer.Int64())
+	assert.Equal(t, uint8(8), result.Decimals)
+	assert.Equal(t, int64(1760000060), result.UpdatedAt.Unix())
+	assert.Equal(t, int64(41), result.AnsweredInRound.Int64())
+
+	_, err = unpackLatestRoundData(decimals, nil)
+	assert.Error(t, err)
+	_, err = unpackLatestRoundData(nil, round)
+	assert.Error(t, err)
+}
@@ -0,0 +1,175 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// processedCallsABI describes how a bridge records the transfers it
+// completed: the processed flag, and the event emitted by unlockTokens on
+// the Ethereum bridge or mintTokens on the Polygon bridge
+const processedCallsABI = `[
+	{
+		"inputs": [{"name": "transferId", "type": "bytes32"}],
+		"name": "isTransferProcessed",
+		"outputs": [{"name": "", "type": "bool"}],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "transferId", "type": "bytes32"},
+			{"indexed": true, "name": "recipient", "type": "address"},
+			{"indexed": true, "name": "token", "type": "address"},
+			{"indexed": false, "name": "amount", "type": "uint256"},
+			{"indexed": false, "name": "timestamp", "type": "uint256"}
+		],
+		"name": "TokensUnlocked",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "transferId", "type": "bytes32"},
+			{"indexed": true, "name": "recipient", "type": "address"},
+			{"indexed": true, "name": "wrappedToken", "type": "address"},
+			{"indexed": false, "name": "originalToken", "type": "address"},
+			{"indexed": false, "name": "originalChainId", "type": "uint256"},
+			{"indexed": false, "name": "amount", "type": "uint256"},
+			{"indexed": false, "name": "timestamp", "type": "uint256"}
+		],
+		"name": "TokensMinted",
+		"type": "event"
+	}
+]`
+
+var processedCalls = mustParseABI(processedCallsABI)
+
+// Execution logs are searched backwards from the head in windows small
+// enough for public RPC providers, down to a bounded depth
+const (
+	executionLogWindow   = 5000
+	executionLogLookback = 500000
+)
+
+// IsTransferProcessed reports whether the bridge contract has completed a
+// transfer as of the latest block with the chain's required confirmations,
+// so a completion that may still be reorged away is not reported
+func (e *EthereumAdapter) IsTransferProcessed(ctx context.Context, transferID string) (bool, error) {
+	e.mu.RLock()
+	if !e.connected {
+		e.mu.RUnlock()
+		return false, fmt.Errorf("adapter not connected")
+	}
+	client := e.client
+	bridge := common.HexToAddress(e.config.BridgeContract)
+	confirmations := e.config.RequiredConfirmations
+	e.mu.RUnlock()
+
+	head, err := client.BlockNumber(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get current block number: %w", err)
+	}
+
+	data, err := processedCalls.Pack("isTransferProcessed", common.HexToHash(transferID))
+	if err != nil {
+		return false, err
+	}
+	block := new(big.Int).SetUint64(confirmedBlock(head, confirmations))
+	output, err := client.CallContract(ctx, ethereum.CallMsg{To: &bridge, Data: data}, block)
+	if err != nil {
+		return false, fmt.Errorf("failed to call isTransferProcessed: %w", err)
+	}
+	return unpackIsTransferProcessed(output)
+}
+
+// FindTransferExecution returns the hash of the transaction that completed a
+// transfer on this chain, from its TokensUnlocked or TokensMinted log in a
+// confirmed block. It returns an empty hash if no log is found within the
+// searched blocks.
+func (e *EthereumAdapter) FindTransferExecution(ctx context.Context, transferID string) (string, error) {
+	e.mu.RLock()
+	if !e.connected {
+		e.mu.RUnlock()
+		return "", fmt.Errorf("adapter not connected")
+	}
+	client := e.client
+	bridge := common.HexToAddress(e.config.BridgeContract)
+	confirmations := e.config.RequiredConfirmations
+	e.mu.RUnlock()
+
+	latest, err := client.BlockNumber(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get current block number: %w", err)
+	}
+	head := confirmedBlock(latest, confirmations)
+
+	query := executionLogQuery(bridge, transferID)
+	var floor uint64
+	if head > executionLogLookback {
+		floor = head - executionLogLookback
+	}
+	for to := head; ; to -= executionLogWindow {
+		from := floor
+		if to > floor+executionLogWindow-1 {
+			from = to - executionLogWindow + 1
+		}
+		query.FromBlock = new(big.Int).SetUint64(from)
+		query.ToBlock = new(big.Int).SetUint64(to)
+
+		logs, err := client.FilterLogs(ctx, query)
+		if err != nil {
+			return "", fmt.Errorf("failed to filter execution logs: %w", err)
+		}
+		for i := len(logs) - 1; i >= 0; i-- {
+			if !logs[i].Removed {
+				return logs[i].TxHash.Hex(), nil
+			}
+		}
+
+		if from == floor {
+			return "", nil
+		}
+	}
+}
+
+// confirmedBlock returns the latest block with a number of confirmations,
+// counting the head block as one
+func confirmedBlock(head, confirmations uint64) uint64 {
+	if confirmations == 0 {
+		return head
+	}
+	if head+1 < confirmations {
+		return 0
+	}
+	return head + 1 - confirmations
+}
+
+// executionLogQuery selects the logs of a bridge completing a transfer
+func executionLogQuery(bridge common.Address, transferID string) ethereum.FilterQuery {
+	return ethereum.FilterQuery{
+		Addresses: []common.Address{bridge},
+		Topics: [][]common.Hash{
+			{processedCalls.Events["TokensUnlocked"].ID, processedCalls.Events["TokensMinted"].ID},
+			{common.HexToHash(transferID)},
+		},
+	}
+}
+
+// unpackIsTransferProcessed decodes the result of isTransferProcessed
+func unpackIsTransferProcessed(output []byte) (bool, error) {
+	values, err := processedCalls.Unpack("isTransferProcessed", output)
+	if err != nil {
+		return false, fmt.Errorf("failed to decode isTransferProcessed result: %w", err)
+	}
+	processed, ok := values[0].(bool)
+	if !ok {
+		return false, fmt.Errorf("unexpected isTransferProcessed result: %v", values[0])
+	}
+	return processed, nil
+}
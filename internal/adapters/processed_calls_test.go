@@ -0,0 +1,47 @@
+package adapters
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessedCalls_Signatures(t *testing.T) {
+	assert.Equal(t, crypto.Keccak256([]byte("isTransferProcessed(bytes32)"))[:4], processedCalls.Methods["isTransferProcessed"].ID)
+	assert.Equal(t, crypto.Keccak256Hash([]byte("TokensUnlocked(bytes32,address,address,uint256,uint256)")),
+		processedCalls.Events["TokensUnlocked"].ID)
+	assert.Equal(t, crypto.Keccak256Hash([]byte("TokensMinted(bytes32,address,address,address,uint256,uint256,uint256)")),
+		processedCalls.Events["TokensMinted"].ID)
+}
+
+func TestExecutionLogQuery(t *testing.T) {
+	bridge := common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	transferID := "0x00000000000000000000000000000000000000000000000000000000000000a1"
+
+	query := executionLogQuery(bridge, transferID)
+	assert.Equal(t, []common.Address{bridge}, query.Addresses)
+	require.Len(t, query.Topics, 2)
+	assert.Len(t, query.Topics[0], 2, "either completion event")
+	assert.Equal(t, []common.Hash{common.HexToHash(transferID)}, query.Topics[1])
+}
+
+func TestConfirmedBlock(t *testing.T) {
+	assert.Equal(t, uint64(89), confirmedBlock(100, 12))
+	assert.Equal(t, uint64(100), confirmedBlock(100, 1))
+	assert.Equal(t, uint64(100), confirmedBlock(100, 0))
+	assert.Equal(t, uint64(0), confirmedBlock(5, 12))
+}
+
+func TestUnpackIsTransferProcessed(t *testing.T) {
+	output, err := processedCalls.Methods["isTransferProcessed"].Outputs.Pack(true)
+	require.NoError(t, err)
+	processed, err := unpackIsTransferProcessed(output)
+	require.NoError(t, err)
+	assert.True(t, processed)
+
+	_, err = unpackIsTransferProcessed(nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,236 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// relayerSetCallsABI describes the relayers a bridge authorizes, how many
+// of them must sign, and the events changing either
+const relayerSetCallsABI = `[
+	{
+		"inputs": [],
+		"name": "getRelayers",
+		"outputs": [{"name": "", "type": "address[]"}],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "requiredSignatures",
+		"outputs": [{"name": "", "type": "uint256"}],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"anonymous": false,
+		"inputs": [{"indexed": true, "name": "relayer", "type": "address"}],
+		"name": "RelayerAdded",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [{"indexed": true, "name": "relayer", "type": "address"}],
+		"name": "RelayerRemoved",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [{"indexed": false, "name": "newRequiredSignatures", "type": "uint256"}],
+		"name": "RequiredSignaturesUpdated",
+		"type": "event"
+	}
+]`
+
+var relayerSetCalls = mustParseABI(relayerSetCallsABI)
+
+// RelayerSet is the relayers a bridge authorizes at a block
+type RelayerSet struct {
+	// Relayers are the authorized addresses, lowercase and sorted
+	Relayers           []string
+	RequiredSignatures uint64
+	BlockNumber        uint64
+}
+
+// Kinds of relayer set changes
+const (
+	RelayerAdded              = "RelayerAdded"
+	RelayerRemoved            = "RelayerRemoved"
+	RequiredSignaturesUpdated = "RequiredSignaturesUpdated"
+)
+
+// RelayerSetChange is an event of a bridge changing its relayer set
+type RelayerSetChange struct {
+	Kind        string
+	BlockNumber uint64
+	LogIndex    uint
+	// Relayer is the lowercase address added or removed
+	Relayer string
+	// RequiredSignatures is the new threshold of a RequiredSignaturesUpdated
+	RequiredSignatures uint64
+}
+
+// RelayerSet reads the authorized relayers and required signatures of the
+// bridge contract, both at the latest block
+func (e *EthereumAdapter) RelayerSet(ctx context.Context) (*RelayerSet, error) {
+	e.mu.RLock()
+	if !e.connected {
+		e.mu.RUnlock()
+		return nil, fmt.Errorf("adapter not connected")
+	}
+	client := e.client
+	bridge := common.HexToAddress(e.config.BridgeContract)
+	e.mu.RUnlock()
+
+	head, err := client.BlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current block number: %w", err)
+	}
+	block := new(big.Int).SetUint64(head)
+
+	outputs := make(map[string][]byte, 2)
+	for _, method := range []string{"getRelayers", "requiredSignatures"} {
+		data, err := relayerSetCalls.Pack(method)
+		if err != nil {
+			return nil, err
+		}
+		output, err := client.CallContract(ctx, ethereum.CallMsg{To: &bridge, Data: data}, block)
+		if err != nil {
+			return nil, fmt.Errorf("failed to call %s: %w", method, err)
+		}
+		outputs[method] = output
+	}
+
+	return unpackRelayerSet(outputs["getRelayers"], outputs["requiredSignatures"], head)
+}
+
+// RelayerSetChanges returns the relayer set events of the bridge contract
+// from a block, in order, and the last block searched. At most one window
+// of blocks is searched per call.
+func (e *EthereumAdapter) RelayerSetChanges(ctx context.Context, fromBlock uint64) ([]RelayerSetChange, uint64, error) {
+	e.mu.RLock()
+	if !e.connected {
+		e.mu.RUnlock()
+		return nil, 0, fmt.Errorf("adapter not connected")
+	}
+	client := e.client
+	bridge := common.HexToAddress(e.config.BridgeContract)
+	e.mu.RUnlock()
+
+	head, err := client.BlockNumber(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get current block number: %w", err)
+	}
+	if fromBlock > head {
+		return nil, fromBlock - 1, nil
+	}
+	toBlock := head
+	if toBlock-fromBlock >= executionLogWindow {
+		toBlock = fromBlock + executionLogWindow - 1
+	}
+
+	logs, err := client.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(fromBlock),
+		ToBlock:   new(big.Int).SetUint64(toBlock),
+		Addresses: []common.Address{bridge},
+		Topics: [][]common.Hash{{
+			relayerSetCalls.Events[RelayerAdded].ID,
+			relayerSetCalls.Events[RelayerRemoved].ID,
+			relayerSetCalls.Events[RequiredSignaturesUpdated].ID,
+		}},
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to filter relayer set logs: %w", err)
+	}
+
+	changes := make([]RelayerSetChange, 0, len(logs))
+	for _, log := range logs {
+		if log.Removed {
+			continue
+		}
+		change, err := parseRelayerSetLog(log)
+		if err != nil {
+			return nil, 0, err
+		}
+		changes = append(changes, change)
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].BlockNumber == changes[j].BlockNumber {
+			return changes[i].LogIndex < changes[j].LogIndex
+		}
+		return changes[i].BlockNumber < changes[j].BlockNumber
+	})
+
+	return changes, toBlock, nil
+}
+
+// parseRelayerSetLog decodes a relayer set event
+func parseRelayerSetLog(log ethtypes.Log) (RelayerSetChange, error) {
+	if len(log.Topics) == 0 {
+		return RelayerSetChange{}, fmt.Errorf("relayer set log without topics in %s", log.TxHash.Hex())
+	}
+	event, err := relayerSetCalls.EventByID(log.Topics[0])
+	if err != nil {
+		return RelayerSetChange{}, fmt.Errorf("unknown relayer set event in %s: %w", log.TxHash.Hex(), err)
+	}
+
+	change := RelayerSetChange{Kind: event.Name, BlockNumber: log.BlockNumber, LogIndex: log.Index}
+	switch event.Name {
+	case RelayerAdded, RelayerRemoved:
+		if len(log.Topics) != 2 {
+			return RelayerSetChange{}, fmt.Errorf("invalid %s log in %s", event.Name, log.TxHash.Hex())
+		}
+		change.Relayer = strings.ToLower(common.BytesToAddress(log.Topics[1].Bytes()).Hex())
+	case RequiredSignaturesUpdated:
+		values, err := event.Inputs.Unpack(log.Data)
+		if err != nil {
+			return RelayerSetChange{}, fmt.Errorf("failed to decode %s log: %w", event.Name, err)
+		}
+		required, ok := values[0].(*big.Int)
+		if !ok || !required.IsUint64() {
+			return RelayerSetChange{}, fmt.Errorf("unexpected %s value: %v", event.Name, values[0])
+		}
+		change.RequiredSignatures = required.Uint64()
+	}
+	return change, nil
+}
+
+// unpackRelayerSet decodes the results of getRelayers and
+// requiredSignatures
+func unpackRelayerSet(relayersOutput, requiredOutput []byte, block uint64) (*RelayerSet, error) {
+	values, err := relayerSetCalls.Unpack("getRelayers", relayersOutput)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode getRelayers result: %w", err)
+	}
+	addresses, ok := values[0].([]common.Address)
+	if !ok {
+		return nil, fmt.Errorf("unexpected getRelayers result: %v", values[0])
+	}
+
+	values, err = relayerSetCalls.Unpack("requiredSignatures", requiredOutput)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode requiredSignatures result: %w", err)
+	}
+	required, ok := values[0].(*big.Int)
+	if !ok || !required.IsUint64() {
+		return nil, fmt.Errorf("unexpected requiredSignatures result: %v", values[0])
+	}
+
+	set := &RelayerSet{
+		Relayers:           make([]string, len(addresses)),
+		RequiredSignatures: required.Uint64(),
+		BlockNumber:        block,
+	}
+	for i, address := range addresses {
+		set.Relayers[i] = strings.ToLower(address.Hex())
+	}
+	sort.Strings(set.Relayers)
+	return set, nil
+}
@@ -0,0 +1,75 @@
+package adapters
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelayerSetCalls_Signatures(t *testing.T) {
+	assert.Equal(t, crypto.Keccak256([]byte("getRelayers()"))[:4], relayerSetCalls.Methods["getRelayers"].ID)
+	assert.Equal(t, crypto.Keccak256([]byte("requiredSignatures()"))[:4], relayerSetCalls.Methods["requiredSignatures"].ID)
+	assert.Equal(t, crypto.Keccak256Hash([]byte("RelayerAdded(address)")), relayerSetCalls.Events[RelayerAdded].ID)
+	assert.Equal(t, crypto.Keccak256Hash([]byte("RelayerRemoved(address)")), relayerSetCalls.Events[RelayerRemoved].ID)
+	assert.Equal(t, crypto.Keccak256Hash([]byte("RequiredSignaturesUpdated(uint256)")),
+		relayerSetCalls.Events[RequiredSignaturesUpdated].ID)
+}
+
+func TestUnpackRelayerSet(t *testing.T) {
+	relayers, err := relayerSetCalls.Methods["getRelayers"].Outputs.Pack([]common.Address{
+		common.HexToAddress("0x00000000000000000000000000000000000000B2"),
+		common.HexToAddress("0x00000000000000000000000000000000000000a1"),
+	})
+	require.NoError(t, err)
+	required, err := relayerSetCalls.Methods["requiredSignatures"].Outputs.Pack(big.NewInt(2))
+	require.NoError(t, err)
+
+	set, err := unpackRelayerSet(relayers, required, 42)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"0x00000000000000000000000000000000000000a1",
+		"0x00000000000000000000000000000000000000b2",
+	}, set.Relayers)
+	assert.Equal(t, uint64(2), set.RequiredSignatures)
+	assert.Equal(t, uint64(42), set.BlockNumber)
+
+	_, err = unpackRelayerSet(nil, required, 42)
+	assert.Error(t, err)
+}
+
+func TestParseRelayerSetLog(t *testing.T) {
+	relayer := common.HexToAddress("0x00000000000000000000000000000000000000C3")
+
+	change, err := parseRelayerSetLog(ethtypes.Log{
+		Topics:      []common.Hash{relayerSetCalls.Events[RelayerRemoved].ID, common.BytesToHash(relayer.Bytes())},
+		BlockNumber: 7,
+		Index:       3,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, RelayerSetChange{
+		Kind:        RelayerRemoved,
+		BlockNumber: 7,
+		LogIndex:    3,
+		Relayer:     "0x00000000000000000000000000000000000000c3",
+	}, change)
+
+	data, err := relayerSetCalls.Events[RequiredSignaturesUpdated].Inputs.Pack(big.NewInt(3))
+	require.NoError(t, err)
+	change, err = parseRelayerSetLog(ethtypes.Log{
+		Topics: []common.Hash{relayerSetCalls.Events[RequiredSignaturesUpdated].ID},
+		Data:   data,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, RequiredSignaturesUpdated, change.Kind)
+	assert.Equal(t, uint64(3), change.RequiredSignatures)
+
+	_, err = parseRelayerSetLog(ethtypes.Log{Topics: []common.Hash{common.HexToHash("0x01")}})
+	assert.Error(t, err)
+	_, err = parseRelayerSetLog(ethtypes.Log{Topics: []common.Hash{relayerSetCalls.Events[RelayerAdded].ID}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,168 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+
+	"nexus-bridge/pkg/types"
+)
+
+// supplyCallsABI describes the views reporting how much of a token the
+// bridges hold and have minted
+const supplyCallsABI = `[
+	{
+		"inputs": [{"name": "token", "type": "address"}],
+		"name": "getLockedBalance",
+		"outputs": [{"name": "", "type": "uint256"}],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{"name": "originalToken", "type": "address"},
+			{"name": "originalChainId", "type": "uint256"}
+		],
+		"name": "getTotalMinted",
+		"outputs": [{"name": "", "type": "uint256"}],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{"name": "originalToken", "type": "address"},
+			{"name": "originalChainId", "type": "uint256"}
+		],
+		"name": "getWrappedToken",
+		"outputs": [{"name": "", "type": "address"}],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "totalSupply",
+		"outputs": [{"name": "", "type": "uint256"}],
+		"stateMutability": "view",
+		"type": "function"
+	}
+]`
+
+var supplyCalls = mustParseABI(supplyCallsABI)
+
+// LockedBalance returns how much of a token the bridge contract holds locked
+func (e *EthereumAdapter) LockedBalance(ctx context.Context, token string) (*big.Int, error) {
+	if !common.IsHexAddress(token) {
+		return nil, fmt.Errorf("invalid token address: %s", token)
+	}
+
+	output, err := e.callBridgeView(ctx, "getLockedBalance", common.HexToAddress(token))
+	if err != nil {
+		return nil, err
+	}
+	return unpackUint256("getLockedBalance", output)
+}
+
+// TotalMinted returns how much the bridge contract has minted of the wrapped
+// form of a token from another chain, net of burns
+func (e *EthereumAdapter) TotalMinted(ctx context.Context, originalToken string, originalChain types.ChainID) (*big.Int, error) {
+	if !common.IsHexAddress(originalToken) {
+		return nil, fmt.Errorf("invalid token address: %s", originalToken)
+	}
+
+	output, err := e.callBridgeView(ctx, "getTotalMinted", common.HexToAddress(originalToken), new(big.Int).SetUint64(uint64(originalChain)))
+	if err != nil {
+		return nil, err
+	}
+	return unpackUint256("getTotalMinted", output)
+}
+
+// WrappedToken returns the address of the wrapped form of a token from
+// another chain, or the zero address if the bridge does not map it
+func (e *EthereumAdapter) WrappedToken(ctx context.Context, originalToken string, originalChain types.ChainID) (string, error) {
+	if !common.IsHexAddress(originalToken) {
+		return "", fmt.Errorf("invalid token address: %s", originalToken)
+	}
+
+	output, err := e.callBridgeView(ctx, "getWrappedToken", common.HexToAddress(originalToken), new(big.Int).SetUint64(uint64(originalChain)))
+	if err != nil {
+		return "", err
+	}
+	return unpackAddress("getWrappedToken", output)
+}
+
+// TotalSupply returns the total supply of an ERC20 token
+func (e *EthereumAdapter) TotalSupply(ctx context.Context, token string) (*big.Int, error) {
+	e.mu.RLock()
+	if !e.connected {
+		e.mu.RUnlock()
+		return nil, fmt.Errorf("adapter not connected")
+	}
+	client := e.client
+	e.mu.RUnlock()
+
+	if !common.IsHexAddress(token) {
+		return nil, fmt.Errorf("invalid token address: %s", token)
+	}
+	address := common.HexToAddress(token)
+
+	data, err := supplyCalls.Pack("totalSupply")
+	if err != nil {
+		return nil, err
+	}
+	output, err := client.CallContract(ctx, ethereum.CallMsg{To: &address, Data: data}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call totalSupply on %s: %w", token, err)
+	}
+	return unpackUint256("totalSupply", output)
+}
+
+// callBridgeView calls a supply view of the bridge contract
+func (e *EthereumAdapter) callBridgeView(ctx context.Context, method string, args ...interface{}) ([]byte, error) {
+	e.mu.RLock()
+	if !e.connected {
+		e.mu.RUnlock()
+		return nil, fmt.Errorf("adapter not connected")
+	}
+	client := e.client
+	bridge := common.HexToAddress(e.config.BridgeContract)
+	e.mu.RUnlock()
+
+	data, err := supplyCalls.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+	output, err := client.CallContract(ctx, ethereum.CallMsg{To: &bridge, Data: data}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s: %w", method, err)
+	}
+	return output, nil
+}
+
+// unpackUint256 decodes the single uint256 result of a supply view
+func unpackUint256(method string, output []byte) (*big.Int, error) {
+	values, err := supplyCalls.Unpack(method, output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s result: %w", method, err)
+	}
+	amount, ok := values[0].(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("unexpected %s result: %v", method, values[0])
+	}
+	return amount, nil
+}
+
+// unpackAddress decodes the single address result of a supply view
+func unpackAddress(method string, output []byte) (string, error) {
+	values, err := supplyCalls.Unpack(method, output)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode %s result: %w", method, err)
+	}
+	address, ok := values[0].(common.Address)
+	if !ok {
+		return "", fmt.Errorf("unexpected %s result: %v", method, values[0])
+	}
+	return address.Hex(), nil
+}
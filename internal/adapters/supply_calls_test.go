@@ -0,0 +1,43 @@
+package adapters
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSupplyCalls_Selectors(t *testing.T) {
+	signatures := map[string]string{
+		"getLockedBalance": "getLockedBalance(address)",
+		"getTotalMinted":   "getTotalMinted(address,uint256)",
+		"getWrappedToken":  "getWrappedToken(address,uint256)",
+		"totalSupply":      "totalSupply()",
+	}
+	for method, signature := range signatures {
+		assert.Equal(t, crypto.Keccak256([]byte(signature))[:4], supplyCalls.Methods[method].ID, method)
+	}
+}
+
+func TestUnpackSupplyResults(t *testing.T) {
+	output, err := supplyCalls.Methods["getLockedBalance"].Outputs.Pack(big.NewInt(1500))
+	require.NoError(t, err)
+	amount, err := unpackUint256("getLockedBalance", output)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1500), amount.Int64())
+
+	wrapped := common.HexToAddress("0x00000000000000000000000000000000000000b1")
+	output, err = supplyCalls.Methods["getWrappedToken"].Outputs.Pack(wrapped)
+	require.NoError(t, err)
+	address, err := unpackAddress("getWrappedToken", output)
+	require.NoError(t, err)
+	assert.Equal(t, wrapped.Hex(), address)
+
+	_, err = unpackUint256("totalSupply", nil)
+	assert.Error(t, err)
+	_, err = unpackAddress("getWrappedToken", nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,164 @@
+package api
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"nexus-bridge/internal/models"
+)
+
+const (
+	// apiKeyPrefix marks a bearer token as an API key
+	apiKeyPrefix = "nbk_"
+	// apiKeyBytes is the number of random bytes in an API key
+	apiKeyBytes = 32
+	// apiKeyDisplayLength is how much of a key is kept to recognise it by
+	apiKeyDisplayLength = len(apiKeyPrefix) + 8
+)
+
+// createAPIKeyRequest is the body of a request to issue an API key. A rate
+// limit of zero applies the role's default limit.
+type createAPIKeyRequest struct {
+	Name      string      `json:"name"`
+	Role      models.Role `json:"role"`
+	RateLimit int         `json:"rate_limit"`
+}
+
+// createdAPIKeyResponse is a newly issued API key. The key itself is only
+// ever returned here.
+type createdAPIKeyResponse struct {
+	*models.APIKey
+	Key string `json:"key"`
+}
+
+// apiKeysResponse lists issued API keys
+type apiKeysResponse struct {
+	APIKeys []models.APIKey `json:"api_keys"`
+}
+
+// generateAPIKey returns a new random API key
+func generateAPIKey() (string, error) {
+	buf := make([]byte, apiKeyBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+	return apiKeyPrefix + hex.EncodeToString(buf), nil
+}
+
+// handleCreateAPIKey issues an API key with a role
+func (s *Server) handleCreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	if !s.apiKeysAvailable(w) {
+		return
+	}
+
+	var req createAPIKeyRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodyBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	secret, err := generateAPIKey()
+	if err != nil {
+		fmt.Printf("Error creating API key: %v\n", err)
+		writeError(w, http.StatusInternalServerError, "failed to create API key")
+		return
+	}
+
+	key := &models.APIKey{
+		Name:      req.Name,
+		Prefix:    secret[:apiKeyDisplayLength],
+		KeyHash:   models.HashAPIKey(secret),
+		Role:      req.Role,
+		RateLimit: req.RateLimit,
+		CreatedBy: actorFromContext(r.Context()),
+	}
+	if err := key.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	audit := s.auditAssigned(r, models.AuditAPIKeyCreated, models.AuditEntityAPIKey, &key.ID, nil)
+	if err := s.APIKeys.CreateAPIKey(r.Context(), key, audit); err != nil {
+		if writeAuditError(w, err) {
+			return
+		}
+		fmt.Printf("Error creating API key: %v\n", err)
+		writeError(w, http.StatusInternalServerError, "failed to create API key")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, createdAPIKeyResponse{APIKey: key, Key: secret})
+}
+
+// handleListAPIKeys returns every issued API key, including revoked ones
+func (s *Server) handleListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	if !s.apiKeysAvailable(w) {
+		return
+	}
+
+	keys, err := s.APIKeys.ListAPIKeys(r.Context())
+	if err != nil {
+		fmt.Printf("Error listing API keys: %v\n", err)
+		writeError(w, http.StatusInternalServerError, "failed to list API keys")
+		return
+	}
+	if keys == nil {
+		keys = []models.APIKey{}
+	}
+
+	writeJSON(w, http.StatusOK, apiKeysResponse{APIKeys: keys})
+}
+
+// handleRevokeAPIKey permanently disables an API key. Requests bearing it
+// are rejected from then on.
+func (s *Server) handleRevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	if !s.apiKeysAvailable(w) {
+		return
+	}
+
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid API key id: %s", r.PathValue("id")))
+		return
+	}
+
+	// The key before revocation only differs in its revocation time
+	performedBy := actorFromContext(r.Context())
+	audit := func(changed interface{}) (*models.AuditEntry, error) {
+		before := *changed.(*models.APIKey)
+		before.RevokedAt = nil
+		return models.NewAuditEntry(models.AuditAPIKeyRevoked, models.AuditEntityAPIKey, strconv.Itoa(id), performedBy, before, changed)
+	}
+	_, err = s.APIKeys.RevokeAPIKey(r.Context(), id, audit)
+	if errors.Is(err, models.ErrAPIKeyNotFound) {
+		writeError(w, http.StatusNotFound, "API key not found")
+		return
+	}
+	if writeAuditError(w, err) {
+		return
+	}
+	if err != nil {
+		fmt.Printf("Error revoking API key %d: %v\n", id, err)
+		writeError(w, http.StatusInternalServerError, "failed to revoke API key")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// apiKeysAvailable writes an error response if no API key store is
+// configured
+func (s *Server) apiKeysAvailable(w http.ResponseWriter) bool {
+	if s.APIKeys == nil {
+		writeError(w, http.StatusServiceUnavailable, "API keys are not available")
+		return false
+	}
+	return true
+}
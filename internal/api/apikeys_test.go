@@ -0,0 +1,101 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/internal/models"
+)
+
+func TestAPIKeys_CreateAndUse(t *testing.T) {
+	server, store := newAuthServer(t)
+
+	rec := doAdminRequest(t, server, http.MethodPost, "/api/v1/admin/api-keys", testAdminKey, `{"name":"explorer","role":"integrator","rate_limit":120}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+	var created createdAPIKeyResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+	assert.True(t, strings.HasPrefix(created.Key, apiKeyPrefix))
+	assert.Equal(t, created.Key[:apiKeyDisplayLength], created.Prefix)
+	assert.Equal(t, models.RoleIntegrator, created.Role)
+	assert.Equal(t, 120, created.RateLimit)
+	assert.Equal(t, "alice", created.CreatedBy)
+	assert.NotContains(t, rec.Body.String(), models.HashAPIKey(created.Key))
+
+	stored, err := store.GetAPIKeyByHash(context.Background(), models.HashAPIKey(created.Key))
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, stored.ID)
+
+	rec = doAdminRequest(t, server, http.MethodGet, "/api/v1/transfers", created.Key, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	trail, err := store.GetAuditTrail(context.Background(), models.AuditEntityAPIKey, strconv.Itoa(created.ID))
+	require.NoError(t, err)
+	require.Len(t, trail, 1)
+	assert.Equal(t, models.AuditAPIKeyCreated, trail[0].EventType)
+	assert.NotContains(t, string(trail[0].NewValues), created.Key)
+}
+
+func TestAPIKeys_InvalidRequests(t *testing.T) {
+	server, _ := newAuthServer(t)
+
+	for _, body := range []string{
+		`{"role":"integrator"}`,
+		`{"name":"explorer","role":"public"}`,
+		`{"name":"explorer","role":"root"}`,
+		`{"name":"explorer","role":"integrator","rate_limit":-1}`,
+		`{"name":"explorer","role":"integrator","scopes":[]}`,
+		`{"name":"` + strings.Repeat("x", models.MaxActorLength+1) + `","role":"integrator"}`,
+	} {
+		rec := doAdminRequest(t, server, http.MethodPost, "/api/v1/admin/api-keys", testAdminKey, body)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, body)
+	}
+
+	operator := issueKey(t, server.APIKeys, "ops", models.RoleOperator, 0)
+	rec := doAdminRequest(t, server, http.MethodPost, "/api/v1/admin/api-keys", operator, `{"name":"escalated","role":"admin"}`)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	server.APIKeys = nil
+	rec = doAdminRequest(t, server, http.MethodGet, "/api/v1/admin/api-keys", testAdminKey, "")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
+
+func TestAPIKeys_ListAndRevoke(t *testing.T) {
+	server, store := newAuthServer(t)
+	secret := issueKey(t, store, "explorer", models.RoleIntegrator, 0)
+	issueKey(t, store, "ops", models.RoleOperator, 0)
+
+	rec := doAdminRequest(t, server, http.MethodGet, "/api/v1/admin/api-keys", testAdminKey, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body apiKeysResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Len(t, body.APIKeys, 2)
+	assert.Equal(t, "explorer", body.APIKeys[0].Name)
+	assert.NotContains(t, rec.Body.String(), "key_hash")
+
+	id := strconv.Itoa(body.APIKeys[0].ID)
+	rec = doAdminRequest(t, server, http.MethodDelete, "/api/v1/admin/api-keys/"+id, testAdminKey, "")
+	require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+
+	rec = doAdminRequest(t, server, http.MethodGet, "/api/v1/transfers", secret, "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = doAdminRequest(t, server, http.MethodDelete, "/api/v1/admin/api-keys/"+id, testAdminKey, "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	rec = doAdminRequest(t, server, http.MethodDelete, "/api/v1/admin/api-keys/abc", testAdminKey, "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	trail, err := store.GetAuditTrail(context.Background(), models.AuditEntityAPIKey, id)
+	require.NoError(t, err)
+	require.Len(t, trail, 1)
+	assert.Equal(t, models.AuditAPIKeyRevoked, trail[0].EventType)
+	assert.Contains(t, string(trail[0].OldValues), `"name":"explorer"`)
+	assert.Contains(t, string(trail[0].NewValues), `"revoked_at"`)
+}
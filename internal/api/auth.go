@@ -0,0 +1,294 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/internal/ratelimit"
+)
+
+// contextKey keys values stored in request contexts
+type contextKey int
+
+const (
+	// actorKey holds the name of the authenticated operator
+	actorKey contextKey = iota
+	// ownerKey holds the credential the caller authenticated with
+	ownerKey
+	// webhookOwnerKey holds the owner webhook handlers are scoped to
+	webhookOwnerKey
+)
+
+// jwtLeeway absorbs clock skew between the API and token issuers
+const jwtLeeway = 30 * time.Second
+
+// errInvalidCredentials is returned for a bearer token that is not a live
+// API key, a valid JWT or an admin key
+var errInvalidCredentials = errors.New("invalid credentials")
+
+// caller is who a request was authenticated as
+type caller struct {
+	name      string // operator, key or token subject; empty when anonymous
+	owner     string // name qualified by the kind of credential
+	role      models.Role
+	limitKey  string // bucket the caller's requests are counted against
+	rateLimit int    // requests a minute overriding the role's limit, if set
+}
+
+// roleClaims are the claims of a JWT issued to an API caller
+type roleClaims struct {
+	Role      models.Role `json:"role"`
+	RateLimit int         `json:"rate_limit,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// handle registers a handler for callers holding at least a role
+func (s *Server) handle(pattern string, role models.Role, handler http.HandlerFunc) {
+	s.endpoints[pattern] = role
+	s.mux.HandleFunc(pattern, s.authorize(role, handler))
+}
+
+// transferResourcePattern serves every GET /api/v1/transfers/{id}/<name>
+// route. ServeMux rejects such patterns next to the by-tx route, as both
+// match /api/v1/transfers/by-tx/<name>, but takes by-tx over this one.
+const transferResourcePattern = "GET /api/v1/transfers/{id}/{resource}"
+
+// handleTransferResource registers a handler for GET
+// /api/v1/transfers/{id}/<name> for callers holding at least a role
+func (s *Server) handleTransferResource(name string, role models.Role, handler http.HandlerFunc) {
+	if len(s.subresources) == 0 {
+		s.mux.HandleFunc(transferResourcePattern, s.serveTransferResource)
+	}
+	s.endpoints["GET /api/v1/transfers/{id}/"+name] = role
+	s.subresources[name] = s.authorize(role, handler)
+}
+
+// serveTransferResource dispatches to the handler registered for the
+// resource named in the path
+func (s *Server) serveTransferResource(w http.ResponseWriter, r *http.Request) {
+	handler, exists := s.subresources[r.PathValue("resource")]
+	if !exists {
+		http.NotFound(w, r)
+		return
+	}
+	handler(w, r)
+}
+
+// handleProbe registers a handler that skips authentication and rate
+// limiting, so health probes keep working whatever the caller's limits
+func (s *Server) handleProbe(pattern string, handler http.HandlerFunc) {
+	s.endpoints[pattern] = models.RolePublic
+	s.mux.HandleFunc(pattern, handler)
+}
+
+// authorize only passes on requests from callers holding at least a role,
+// charging every request to the caller's rate limit first. The caller's name
+// is recorded in the request context for auditing.
+func (s *Server) authorize(required models.Role, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		c, err := s.authenticate(r)
+		if err != nil && !errors.Is(err, errInvalidCredentials) {
+			fmt.Printf("Error authenticating request: %v\n", err)
+			writeError(w, http.StatusInternalServerError, "failed to authenticate")
+			return
+		}
+
+		// Failed attempts count against the client's address like any
+		// anonymous request
+		if !s.allowRequest(w, r, c) {
+			return
+		}
+
+		switch {
+		case err != nil, c.name == "" && !c.role.Allows(required):
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm=%q`, required))
+			writeError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		case !c.role.Allows(required):
+			writeError(w, http.StatusForbidden, "forbidden")
+			return
+		}
+
+		ctx := r.Context()
+		if c.name != "" {
+			ctx = context.WithValue(ctx, actorKey, c.name)
+			ctx = context.WithValue(ctx, ownerKey, c.owner)
+		}
+		next(w, r.WithContext(ctx))
+	}
+}
+
+// authenticate identifies the caller from the bearer token, if any. Tokens
+// starting with the API key prefix are looked up by hash, tokens shaped like
+// a JWT are verified, and anything else is compared against the admin keys.
+// Callers without a token are anonymous and public.
+func (s *Server) authenticate(r *http.Request) (caller, error) {
+	anonymous := caller{role: models.RolePublic, limitKey: "ip:" + s.clientIP(r)}
+
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found || token == "" {
+		return anonymous, nil
+	}
+
+	var (
+		c   caller
+		err error
+	)
+	switch {
+	case strings.HasPrefix(token, apiKeyPrefix):
+		c, err = s.authenticateAPIKey(r.Context(), token)
+	case strings.Count(token, ".") == 2:
+		c, err = s.authenticateJWT(token)
+	default:
+		c, err = s.authenticateAdmin(token)
+	}
+	if err != nil {
+		return anonymous, err
+	}
+
+	return c, nil
+}
+
+// authenticateAPIKey returns the caller owning a live API key
+func (s *Server) authenticateAPIKey(ctx context.Context, token string) (caller, error) {
+	if s.APIKeys == nil {
+		return caller{}, errInvalidCredentials
+	}
+
+	key, err := s.APIKeys.GetAPIKeyByHash(ctx, models.HashAPIKey(token))
+	if errors.Is(err, models.ErrAPIKeyNotFound) {
+		return caller{}, errInvalidCredentials
+	}
+	if err != nil {
+		return caller{}, fmt.Errorf("failed to look up API key: %w", err)
+	}
+
+	return caller{
+		name:      key.Name,
+		owner:     "key:" + key.Name,
+		role:      key.Role,
+		limitKey:  "key:" + strconv.Itoa(key.ID),
+		rateLimit: key.RateLimit,
+	}, nil
+}
+
+// authenticateJWT returns the subject of an HS256 token signed with the
+// configured secret. Tokens must expire, carry a known role and have a
+// subject short enough to be recorded as the author of a change.
+func (s *Server) authenticateJWT(token string) (caller, error) {
+	if len(s.jwtSecret) == 0 {
+		return caller{}, errInvalidCredentials
+	}
+
+	var claims roleClaims
+	_, err := jwt.ParseWithClaims(token, &claims,
+		func(*jwt.Token) (interface{}, error) { return s.jwtSecret, nil },
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(jwtLeeway),
+	)
+	if err != nil || claims.Subject == "" || len(claims.Subject) > models.MaxActorLength ||
+		!claims.Role.IsValid() || claims.RateLimit < 0 {
+		return caller{}, errInvalidCredentials
+	}
+
+	return caller{
+		name:      claims.Subject,
+		owner:     "jwt:" + claims.Subject,
+		role:      claims.Role,
+		limitKey:  "jwt:" + claims.Subject,
+		rateLimit: claims.RateLimit,
+	}, nil
+}
+
+// authenticateAdmin returns the operator whose admin key is presented. Every
+// key is compared in constant time.
+func (s *Server) authenticateAdmin(token string) (caller, error) {
+	presented := sha256.Sum256([]byte(token))
+	operator := ""
+	for name, adminKey := range s.adminKeys {
+		expected := sha256.Sum256([]byte(adminKey))
+		if subtle.ConstantTimeCompare(presented[:], expected[:]) == 1 {
+			operator = name
+		}
+	}
+	if operator == "" {
+		return caller{}, errInvalidCredentials
+	}
+
+	return caller{name: operator, owner: "operator:" + operator, role: models.RoleAdmin, limitKey: "operator:" + operator}, nil
+}
+
+// allowRequest takes a token from the caller's bucket, writing the rate limit
+// headers and rejecting the request once the bucket is empty. Requests are
+// let through if the limiter fails, so an outage of its backend does not
+// take the API down with it.
+func (s *Server) allowRequest(w http.ResponseWriter, r *http.Request, c caller) bool {
+	perMinute := c.rateLimit
+	if perMinute == 0 {
+		perMinute = s.rateLimits[c.role]
+	}
+	if perMinute <= 0 || s.Limiter == nil {
+		return true
+	}
+
+	result, err := s.Limiter.Allow(r.Context(), c.limitKey, ratelimit.PerMinute(perMinute))
+	if err != nil {
+		fmt.Printf("Error checking rate limit of %s: %v\n", c.limitKey, err)
+		return true
+	}
+
+	w.Header().Set("X-RateLimit-Limit", strconv.Itoa(perMinute))
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	if !result.Allowed {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(result.RetryAfter.Seconds()))))
+		writeError(w, http.StatusTooManyRequests, "rate limit exceeded")
+		return false
+	}
+
+	return true
+}
+
+// clientIP returns the address anonymous requests are limited by. Behind a
+// trusted proxy this is the last address it appended to X-Forwarded-For,
+// since earlier entries are supplied by the client.
+func (s *Server) clientIP(r *http.Request) string {
+	if s.trustProxy {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			hops := strings.Split(forwarded, ",")
+			return strings.TrimSpace(hops[len(hops)-1])
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// actorFromContext returns the operator authenticated for a request
+func actorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey).(string)
+	return actor
+}
+
+// ownerFromContext returns the credential authenticated for a request. API
+// keys sharing a name, such as a rotated key and its replacement, share an
+// owner, while a token subject never matches a key name.
+func ownerFromContext(ctx context.Context) string {
+	owner, _ := ctx.Value(ownerKey).(string)
+	return owner
+}
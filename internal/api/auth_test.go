@@ -0,0 +1,260 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/internal/ratelimit"
+)
+
+const testJWTSecret = "jwt-secret"
+
+func newAuthServer(t *testing.T) (*Server, *models.MemoryStateManager) {
+	server, store := newTestServer(t, createTestTransfer(1))
+	server.adminKeys = map[string]string{"alice": testAdminKey}
+	server.jwtSecret = []byte(testJWTSecret)
+	server.APIKeys = store
+	server.Reviews = store
+	return server, store
+}
+
+// issueKey stores an API key and returns its secret
+func issueKey(t *testing.T, store models.APIKeyStore, name string, role models.Role, rateLimit int) string {
+	secret, err := generateAPIKey()
+	require.NoError(t, err)
+	key := &models.APIKey{
+		Name:      name,
+		Prefix:    secret[:apiKeyDisplayLength],
+		KeyHash:   models.HashAPIKey(secret),
+		Role:      role,
+		RateLimit: rateLimit,
+	}
+	require.NoError(t, store.CreateAPIKey(context.Background(), key, nil))
+	return secret
+}
+
+func signToken(t *testing.T, method jwt.SigningMethod, secret string, claims roleClaims) string {
+	token, err := jwt.NewWithClaims(method, claims).SignedString([]byte(secret))
+	require.NoError(t, err)
+	return token
+}
+
+func tokenClaims(subject string, role models.Role, expiresIn time.Duration) roleClaims {
+	return roleClaims{
+		Role: role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   subject,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiresIn)),
+		},
+	}
+}
+
+func TestAuth_APIKeyRoles(t *testing.T) {
+	server, store := newAuthServer(t)
+	integrator := issueKey(t, store, "explorer", models.RoleIntegrator, 0)
+	operator := issueKey(t, store, "ops", models.RoleOperator, 0)
+
+	rec := doAdminRequest(t, server, http.MethodGet, "/api/v1/transfers", integrator, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = doAdminRequest(t, server, http.MethodGet, "/api/v1/admin/reviews", integrator, "")
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Empty(t, rec.Header().Get("WWW-Authenticate"))
+
+	rec = doAdminRequest(t, server, http.MethodGet, "/api/v1/admin/reviews", operator, "")
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	rec = doAdminRequest(t, server, http.MethodGet, "/api/v1/admin/tokens", operator, "")
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec = doAdminRequest(t, server, http.MethodGet, "/api/v1/admin/reviews", "", "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, `Bearer realm="operator"`, rec.Header().Get("WWW-Authenticate"))
+
+	// Unknown keys are rejected even on public routes
+	rec = doAdminRequest(t, server, http.MethodGet, "/api/v1/transfers", "nbk_unknown", "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	server.APIKeys = nil
+	rec = doAdminRequest(t, server, http.MethodGet, "/api/v1/transfers", integrator, "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestAuth_AdminKeyAllowsEveryRoute(t *testing.T) {
+	server, _ := newAuthServer(t)
+
+	for _, path := range []string{"/api/v1/transfers", "/api/v1/admin/reviews", "/api/v1/admin/tokens", "/api/v1/admin/api-keys"} {
+		rec := doAdminRequest(t, server, http.MethodGet, path, testAdminKey, "")
+		assert.Equal(t, http.StatusOK, rec.Code, path)
+	}
+}
+
+func TestAuth_JWT(t *testing.T) {
+	server, _ := newAuthServer(t)
+
+	valid := signToken(t, jwt.SigningMethodHS256, testJWTSecret, tokenClaims("carol", models.RoleOperator, time.Hour))
+	rec := doAdminRequest(t, server, http.MethodGet, "/api/v1/admin/reviews", valid, "")
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	rec = doAdminRequest(t, server, http.MethodGet, "/api/v1/admin/tokens", valid, "")
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"Expired", signToken(t, jwt.SigningMethodHS256, testJWTSecret, tokenClaims("carol", models.RoleOperator, -time.Hour))},
+		{"WrongSecret", signToken(t, jwt.SigningMethodHS256, "other", tokenClaims("carol", models.RoleOperator, time.Hour))},
+		{"WrongAlgorithm", signToken(t, jwt.SigningMethodHS512, testJWTSecret, tokenClaims("carol", models.RoleOperator, time.Hour))},
+		{"UnknownRole", signToken(t, jwt.SigningMethodHS256, testJWTSecret, tokenClaims("carol", "root", time.Hour))},
+		{"MissingSubject", signToken(t, jwt.SigningMethodHS256, testJWTSecret, tokenClaims("", models.RoleOperator, time.Hour))},
+		{"SubjectTooLong", signToken(t, jwt.SigningMethodHS256, testJWTSecret, tokenClaims(strings.Repeat("x", models.MaxActorLength+1), models.RoleOperator, time.Hour))},
+		{"NoExpiry", signToken(t, jwt.SigningMethodHS256, testJWTSecret, roleClaims{
+			Role:             models.RoleOperator,
+			RegisteredClaims: jwt.RegisteredClaims{Subject: "carol"},
+		})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := doAdminRequest(t, server, http.MethodGet, "/api/v1/admin/reviews", tt.token, "")
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		})
+	}
+
+	// Tokens are refused when no secret is configured
+	server.jwtSecret = nil
+	rec = doAdminRequest(t, server, http.MethodGet, "/api/v1/admin/reviews", valid, "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestAuth_JWTActorIsAudited(t *testing.T) {
+	transfer := createTestTransfer(1)
+	server, store := newAuthServer(t)
+	require.NoError(t, store.MarkTransferForReview(context.Background(), transfer.ID, "fee too low"))
+
+	token := signToken(t, jwt.SigningMethodHS256, testJWTSecret, tokenClaims("carol", models.RoleOperator, time.Hour))
+	rec := decide(t, server, transfer.ID, "approve", token, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	trail, err := store.GetAuditTrail(context.Background(), models.AuditEntityTransfer, transfer.ID)
+	require.NoError(t, err)
+	require.Len(t, trail, 1)
+	assert.Equal(t, "carol", trail[0].PerformedBy)
+}
+
+func TestRateLimit_PerClientAddress(t *testing.T) {
+	server, _ := newAuthServer(t)
+	server.rateLimits[models.RolePublic] = 2
+
+	request := func(remoteAddr, forwardedFor string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/transfers", nil)
+		req.RemoteAddr = remoteAddr
+		if forwardedFor != "" {
+			req.Header.Set("X-Forwarded-For", forwardedFor)
+		}
+		rec := httptest.NewRecorder()
+		server.Handler().ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := request("10.0.0.1:1234", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "1", rec.Header().Get("X-RateLimit-Remaining"))
+
+	// Another port on the same host shares the bucket
+	rec = request("10.0.0.1:5678", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	rec = request("10.0.0.1:1234", "")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "30", rec.Header().Get("Retry-After"))
+
+	rec = request("10.0.0.2:1234", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// Forwarded addresses are ignored unless the proxy is trusted
+	rec = request("10.0.0.1:1234", "192.0.2.1")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	server.trustProxy = true
+	rec = request("10.0.0.1:1234", "198.51.100.7, 192.0.2.1")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	rec = request("10.0.0.1:1234", "203.0.113.9, 192.0.2.1")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	rec = request("10.0.0.1:1234", "192.0.2.1")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code, "only the proxy's own entry is trusted")
+
+	// Health checks are never limited
+	rec = request("10.0.0.1:1234", "")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/health", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	healthRec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(healthRec, req)
+	assert.NotEqual(t, http.StatusTooManyRequests, healthRec.Code)
+}
+
+func TestRateLimit_PerKey(t *testing.T) {
+	server, store := newAuthServer(t)
+	server.rateLimits[models.RoleIntegrator] = 1
+	limited := issueKey(t, store, "limited", models.RoleIntegrator, 0)
+	generous := issueKey(t, store, "generous", models.RoleIntegrator, 3)
+
+	rec := doAdminRequest(t, server, http.MethodGet, "/api/v1/transfers", limited, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	rec = doAdminRequest(t, server, http.MethodGet, "/api/v1/transfers", limited, "")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+
+	// A key's own limit overrides its role's, in a bucket of its own
+	for i := 0; i < 3; i++ {
+		rec = doAdminRequest(t, server, http.MethodGet, "/api/v1/transfers", generous, "")
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "3", rec.Header().Get("X-RateLimit-Limit"))
+	}
+	rec = doAdminRequest(t, server, http.MethodGet, "/api/v1/transfers", generous, "")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+
+	// Admins are unlimited by default
+	for i := 0; i < 5; i++ {
+		rec = doAdminRequest(t, server, http.MethodGet, "/api/v1/transfers", testAdminKey, "")
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get("X-RateLimit-Limit"))
+	}
+}
+
+func TestRateLimit_FailedAuthenticationIsCharged(t *testing.T) {
+	server, _ := newAuthServer(t)
+	server.rateLimits[models.RolePublic] = 1
+
+	rec := doAdminRequest(t, server, http.MethodGet, "/api/v1/admin/tokens", "wrong", "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	rec = doAdminRequest(t, server, http.MethodGet, "/api/v1/admin/tokens", "wrong", "")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+}
+
+// failingLimiter is a Limiter whose backend is down
+type failingLimiter struct{}
+
+func (failingLimiter) Allow(ctx context.Context, key string, limit ratelimit.Limit) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("connection refused")
+}
+
+func TestRateLimit_FailsOpen(t *testing.T) {
+	server, _ := newAuthServer(t)
+	server.rateLimits[models.RolePublic] = 1
+	server.Limiter = failingLimiter{}
+
+	for i := 0; i < 3; i++ {
+		rec := doRequest(t, server, "/api/v1/transfers")
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+}
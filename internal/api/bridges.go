@@ -0,0 +1,135 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"nexus-bridge/internal/guardian"
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+// BridgeGuardian reads and lifts the emergency pause of bridge contracts
+type BridgeGuardian interface {
+	Status(ctx context.Context) ([]guardian.BridgeStatus, error)
+	Unpause(ctx context.Context, chainID types.ChainID) (string, error)
+}
+
+// bridgeStatus is the pause state of a bridge with its last recorded change
+type bridgeStatus struct {
+	guardian.BridgeStatus
+	LastChange *models.AuditEntry `json:"last_change"`
+}
+
+// bridgesResponse lists the guarded bridges
+type bridgesResponse struct {
+	Bridges []bridgeStatus `json:"bridges"`
+}
+
+// unpauseBridgeRequest is the body of a request to unpause a bridge
+type unpauseBridgeRequest struct {
+	Reason string `json:"reason"`
+}
+
+// unpauseBridgeResponse identifies the unpause transaction
+type unpauseBridgeResponse struct {
+	ChainID types.ChainID `json:"chain_id"`
+	TxHash  string        `json:"tx_hash"`
+}
+
+// handleListBridges returns whether each guarded bridge is paused and why
+func (s *Server) handleListBridges(w http.ResponseWriter, r *http.Request) {
+	if !s.guardianAvailable(w) {
+		return
+	}
+
+	statuses, err := s.Guardian.Status(r.Context())
+	if err != nil {
+		fmt.Printf("Error reading bridge pause states: %v\n", err)
+		writeError(w, http.StatusBadGateway, "failed to read bridge pause states")
+		return
+	}
+
+	bridges := make([]bridgeStatus, 0, len(statuses))
+	for _, status := range statuses {
+		trail, err := s.store.GetAuditTrail(r.Context(), models.AuditEntityBridge, chainKey(status.ChainID))
+		if err != nil {
+			fmt.Printf("Error getting pause history of %s: %v\n", status.Chain, err)
+			writeError(w, http.StatusInternalServerError, "failed to get bridge pause history")
+			return
+		}
+
+		bridge := bridgeStatus{BridgeStatus: status}
+		if len(trail) > 0 {
+			bridge.LastChange = &trail[len(trail)-1]
+		}
+		bridges = append(bridges, bridge)
+	}
+
+	writeJSON(w, http.StatusOK, bridgesResponse{Bridges: bridges})
+}
+
+// handleUnpauseBridge lifts the pause of a chain's bridge, recording the
+// operator's reason
+func (s *Server) handleUnpauseBridge(w http.ResponseWriter, r *http.Request) {
+	if !s.guardianAvailable(w) {
+		return
+	}
+	chainID, err := types.ParseChainID(r.PathValue("chain"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req unpauseBridgeRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodyBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+	if req.Reason == "" {
+		writeError(w, http.StatusBadRequest, "reason is required")
+		return
+	}
+
+	txHash, err := s.Guardian.Unpause(r.Context(), chainID)
+	if err != nil {
+		switch {
+		case errors.Is(err, guardian.ErrUnknownChain):
+			writeError(w, http.StatusNotFound, "chain is not guarded")
+		case errors.Is(err, guardian.ErrNotPaused):
+			writeError(w, http.StatusConflict, "bridge is not paused")
+		default:
+			fmt.Printf("Error unpausing bridge on %s: %v\n", chainID, err)
+			writeError(w, http.StatusBadGateway, "failed to unpause bridge")
+		}
+		return
+	}
+
+	previous := guardian.BridgeChange{Paused: true}
+	change := guardian.BridgeChange{Paused: false, Reason: req.Reason, TxHash: txHash}
+	if !s.auditChange(w, r, models.AuditBridgeUnpaused, models.AuditEntityBridge, chainKey(chainID), previous, change) {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, unpauseBridgeResponse{ChainID: chainID, TxHash: txHash})
+}
+
+// guardianAvailable writes an error response if no guardian is configured
+func (s *Server) guardianAvailable(w http.ResponseWriter) bool {
+	if s.Guardian == nil {
+		writeError(w, http.StatusServiceUnavailable, "bridge guardian is not available")
+		return false
+	}
+	return true
+}
+
+// chainKey identifies a chain's bridge in the audit log
+func chainKey(chainID types.ChainID) string {
+	return strconv.FormatUint(uint64(chainID), 10)
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/internal/guardian"
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+// fakePauser is a bridge contract's pause flag
+type fakePauser struct {
+	paused bool
+}
+
+func (f *fakePauser) Paused(ctx context.Context) (bool, error) { return f.paused, nil }
+
+func (f *fakePauser) Pause(ctx context.Context) (string, error) {
+	f.paused = true
+	return "0xpause", nil
+}
+
+func (f *fakePauser) Unpause(ctx context.Context) (string, error) {
+	f.paused = false
+	return "0xunpause", nil
+}
+
+func newBridgeServer(t *testing.T) (*Server, *models.MemoryStateManager, *fakePauser) {
+	server, store, _ := newTokenServer(t)
+	server.APIKeys = store
+	bridge := &fakePauser{}
+	bridgeGuardian := guardian.NewGuardian(store)
+	bridgeGuardian.AddChain(types.ChainPolygon, bridge)
+	server.Guardian = bridgeGuardian
+
+	alarm := guardian.Alarm{Chain: types.ChainPolygon, Trigger: guardian.TriggerUnlock, Reason: "unlock without a lock"}
+	require.NoError(t, bridgeGuardian.Trip(context.Background(), alarm))
+	return server, store, bridge
+}
+
+func listBridges(t *testing.T, server *Server) []bridgeStatus {
+	rec := doAdminRequest(t, server, http.MethodGet, "/api/v1/admin/bridges", testAdminKey, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var body bridgesResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	return body.Bridges
+}
+
+func TestBridges_ListAndUnpause(t *testing.T) {
+	server, store, bridge := newBridgeServer(t)
+
+	bridges := listBridges(t, server)
+	require.Len(t, bridges, 1)
+	assert.Equal(t, types.ChainPolygon, bridges[0].ChainID)
+	assert.True(t, bridges[0].Paused)
+	require.NotNil(t, bridges[0].LastChange)
+	assert.Equal(t, models.AuditBridgePaused, bridges[0].LastChange.EventType)
+	assert.Equal(t, "guardian", bridges[0].LastChange.PerformedBy)
+
+	rec := doAdminRequest(t, server, http.MethodPost, "/api/v1/admin/bridges/polygon/unpause", "bob-secret", `{"reason":"replayed proof, relayer key rotated"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.JSONEq(t, `{"chain_id":137,"tx_hash":"0xunpause"}`, rec.Body.String())
+	assert.False(t, bridge.paused)
+
+	trail, err := store.GetAuditTrail(context.Background(), models.AuditEntityBridge, "137")
+	require.NoError(t, err)
+	require.Len(t, trail, 2)
+	assert.Equal(t, models.AuditBridgeUnpaused, trail[1].EventType)
+	assert.Equal(t, "bob", trail[1].PerformedBy)
+	assert.JSONEq(t, `{"paused":false,"reason":"replayed proof, relayer key rotated","tx_hash":"0xunpause"}`, string(trail[1].NewValues))
+
+	bridges = listBridges(t, server)
+	assert.False(t, bridges[0].Paused)
+	assert.Equal(t, models.AuditBridgeUnpaused, bridges[0].LastChange.EventType)
+
+	rec = doAdminRequest(t, server, http.MethodPost, "/api/v1/admin/bridges/137/unpause", testAdminKey, `{"reason":"again"}`)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestBridges_UnpauseValidation(t *testing.T) {
+	server, store, bridge := newBridgeServer(t)
+
+	tests := []struct {
+		name string
+		path string
+		body string
+		code int
+	}{
+		{"NoReason", "/api/v1/admin/bridges/polygon/unpause", `{}`, http.StatusBadRequest},
+		{"UnknownField", "/api/v1/admin/bridges/polygon/unpause", `{"reason":"x","force":true}`, http.StatusBadRequest},
+		{"InvalidChain", "/api/v1/admin/bridges/nowhere/unpause", `{"reason":"x"}`, http.StatusBadRequest},
+		{"UnguardedChain", "/api/v1/admin/bridges/ethereum/unpause", `{"reason":"x"}`, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := doAdminRequest(t, server, http.MethodPost, tt.path, testAdminKey, tt.body)
+			assert.Equal(t, tt.code, rec.Code, rec.Body.String())
+		})
+	}
+
+	// Integrators cannot lift a pause
+	key := issueKey(t, store, "partner", models.RoleIntegrator, 0)
+	rec := doAdminRequest(t, server, http.MethodPost, "/api/v1/admin/bridges/polygon/unpause", key, `{"reason":"x"}`)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.True(t, bridge.paused)
+}
+
+func TestBridges_Unavailable(t *testing.T) {
+	server, _, _ := newTokenServer(t)
+
+	rec := doAdminRequest(t, server, http.MethodGet, "/api/v1/admin/bridges", testAdminKey, "")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
@@ -0,0 +1,154 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+// delayQueueEntry is a waiting delay with the transfer it holds
+type delayQueueEntry struct {
+	models.TransferDelay
+	Transfer *types.Transfer `json:"transfer"`
+}
+
+// delaysResponse lists the delayed transfers
+type delaysResponse struct {
+	Delays []delayQueueEntry `json:"delays"`
+}
+
+// cancelDelayRequest is the body of a request to cancel a delayed transfer
+type cancelDelayRequest struct {
+	Reason string `json:"reason"`
+}
+
+// handleListDelays returns the transfers waiting out their delay, soonest
+// release first
+func (s *Server) handleListDelays(w http.ResponseWriter, r *http.Request) {
+	if !s.delaysAvailable(w) {
+		return
+	}
+
+	delays, err := s.Delays.ListWaitingDelays(r.Context())
+	if err != nil {
+		fmt.Printf("Error listing delays: %v\n", err)
+		writeError(w, http.StatusInternalServerError, "failed to list delays")
+		return
+	}
+
+	entries := make([]delayQueueEntry, 0, len(delays))
+	for _, delay := range delays {
+		transfer, err := s.store.GetTransfer(r.Context(), delay.TransferID)
+		if err != nil {
+			fmt.Printf("Error getting delayed transfer %s: %v\n", delay.TransferID, err)
+			writeError(w, http.StatusInternalServerError, "failed to list delays")
+			return
+		}
+		entries = append(entries, delayQueueEntry{TransferDelay: delay, Transfer: transfer})
+	}
+
+	writeJSON(w, http.StatusOK, delaysResponse{Delays: entries})
+}
+
+// handleCancelDelay fails the {id} transfer while it waits out its delay, so
+// it is never executed
+func (s *Server) handleCancelDelay(w http.ResponseWriter, r *http.Request) {
+	if !s.delaysAvailable(w) {
+		return
+	}
+
+	var req cancelDelayRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodyBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+	if req.Reason == "" {
+		writeError(w, http.StatusBadRequest, "reason is required")
+		return
+	}
+
+	transferID := r.PathValue("id")
+	before, err := s.Delays.GetTransferDelay(r.Context(), transferID)
+	if err != nil {
+		s.writeDelayError(w, transferID, err)
+		return
+	}
+	if before.Status != models.DelayWaiting {
+		writeError(w, http.StatusNotFound, "transfer is not delayed")
+		return
+	}
+
+	audit := s.audit(r, models.AuditTransferDelayCancelled, models.AuditEntityTransfer, transferID, before)
+	delay, err := s.Delays.CancelTransferDelay(r.Context(), transferID, actorFromContext(r.Context()), req.Reason, audit)
+	if err != nil {
+		s.writeDelayError(w, transferID, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, delay)
+}
+
+// writeDelayError writes the response for a failed delay lookup or
+// cancellation
+func (s *Server) writeDelayError(w http.ResponseWriter, transferID string, err error) {
+	if errors.Is(err, models.ErrDelayNotFound) {
+		writeError(w, http.StatusNotFound, "transfer is not delayed")
+		return
+	}
+	if writeAuditError(w, err) {
+		return
+	}
+	fmt.Printf("Error cancelling delay of transfer %s: %v\n", transferID, err)
+	writeError(w, http.StatusInternalServerError, "failed to cancel delay")
+}
+
+// delayServed writes a conflict response if a transfer is above its delay
+// threshold and its delay was not released, so its proof cannot be used to
+// execute it before operators had the chance to cancel it
+func (s *Server) delayServed(w http.ResponseWriter, r *http.Request, transfer types.Transfer) bool {
+	if s.Delays == nil || s.Limits == nil {
+		return true
+	}
+
+	delay, err := s.Delays.GetTransferDelay(r.Context(), transfer.ID)
+	if err == nil {
+		if delay.Status == models.DelayReleased {
+			return true
+		}
+		writeError(w, http.StatusConflict, fmt.Sprintf("transfer delay is %s", delay.Status))
+		return false
+	}
+	if !errors.Is(err, models.ErrDelayNotFound) {
+		fmt.Printf("Error getting delay of transfer %s: %v\n", transfer.ID, err)
+		writeError(w, http.StatusInternalServerError, "failed to get transfer delay")
+		return false
+	}
+
+	limits, err := s.Limits.GetTransferLimits(r.Context(), transfer)
+	if err != nil {
+		fmt.Printf("Error getting limits of transfer %s: %v\n", transfer.ID, err)
+		writeError(w, http.StatusInternalServerError, "failed to get transfer limits")
+		return false
+	}
+	threshold := models.DelayThreshold(limits)
+	if threshold != nil && transfer.Amount != nil && transfer.Amount.Int != nil && transfer.Amount.Cmp(threshold) > 0 {
+		writeError(w, http.StatusConflict, "transfer must wait out its delay")
+		return false
+	}
+	return true
+}
+
+// delaysAvailable writes an error response if no delay store is configured
+func (s *Server) delaysAvailable(w http.ResponseWriter) bool {
+	if s.Delays == nil {
+		writeError(w, http.StatusServiceUnavailable, "delays are not available")
+		return false
+	}
+	return true
+}
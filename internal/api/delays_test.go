@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+func newDelayServer(t *testing.T, transfers ...types.Transfer) (*Server, *models.MemoryStateManager) {
+	server, store := newTestServer(t, transfers...)
+	server.adminKeys = map[string]string{"alice": testAdminKey}
+	server.Delays = store
+	for i, transfer := range transfers {
+		releaseAt := time.Now().Add(time.Duration(len(transfers)-i) * time.Hour)
+		_, err := store.DelayTransfer(context.Background(), transfer.ID, releaseAt)
+		require.NoError(t, err)
+	}
+	return server, store
+}
+
+func TestListDelays(t *testing.T) {
+	server, _ := newDelayServer(t, createTestTransfer(1), createTestTransfer(2))
+
+	rec := doAdminRequest(t, server, http.MethodGet, "/api/v1/admin/delays", testAdminKey, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var body delaysResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Len(t, body.Delays, 2)
+	assert.Equal(t, createTestTransfer(2).ID, body.Delays[0].TransferID, "soonest release first")
+	assert.Equal(t, models.DelayWaiting, body.Delays[0].Status)
+	require.NotNil(t, body.Delays[0].Transfer)
+	assert.Equal(t, types.StatusDelayed, body.Delays[0].Transfer.Status)
+
+	rec = doAdminRequest(t, server, http.MethodGet, "/api/v1/admin/delays", "", "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	server.Delays = nil
+	rec = doAdminRequest(t, server, http.MethodGet, "/api/v1/admin/delays", testAdminKey, "")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
+
+func TestCancelDelay(t *testing.T) {
+	transfer := createTestTransfer(1)
+	server, store := newDelayServer(t, transfer)
+	path := "/api/v1/admin/delays/" + transfer.ID + "/cancel"
+
+	rec := doAdminRequest(t, server, http.MethodPost, path, testAdminKey, `{}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = doAdminRequest(t, server, http.MethodPost, path, testAdminKey, `{"reason": "recipient linked to an exploit"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var delay models.TransferDelay
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &delay))
+	assert.Equal(t, models.DelayCancelled, delay.Status)
+	assert.Equal(t, "alice", delay.CancelledBy)
+	assert.Equal(t, "recipient linked to an exploit", delay.Reason)
+
+	status, err := store.GetTransferStatus(context.Background(), transfer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, types.StatusFailed, *status)
+
+	trail, err := store.GetAuditTrail(context.Background(), models.AuditEntityTransfer, transfer.ID)
+	require.NoError(t, err)
+	require.Len(t, trail, 1)
+	assert.Equal(t, models.AuditTransferDelayCancelled, trail[0].EventType)
+	assert.Equal(t, "alice", trail[0].PerformedBy)
+	assert.Contains(t, string(trail[0].OldValues), `"status":"waiting"`)
+	assert.Contains(t, string(trail[0].NewValues), `"status":"cancelled"`)
+
+	// A delay can only be cancelled while it waits
+	rec = doAdminRequest(t, server, http.MethodPost, path, testAdminKey, `{"reason": "again"}`)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = doAdminRequest(t, server, http.MethodPost, "/api/v1/admin/delays/"+createTestTransfer(2).ID+"/cancel", testAdminKey, `{"reason": "missing"}`)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
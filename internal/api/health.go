@@ -0,0 +1,338 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+// HealthStatus summarises the health of a component
+type HealthStatus string
+
+const (
+	HealthOK       HealthStatus = "ok"
+	HealthDegraded HealthStatus = "degraded"
+	HealthDown     HealthStatus = "down"
+)
+
+const (
+	// DefaultMaxScanLag is how many blocks the event scan may trail the chain
+	// head before the chain is considered degraded
+	DefaultMaxScanLag = 100
+	// DefaultHealthCheckTimeout bounds the time spent on a readiness check
+	DefaultHealthCheckTimeout = 5 * time.Second
+	// DefaultReadinessCacheTTL is how long a readiness report is served
+	// before the checks run again
+	DefaultReadinessCacheTTL = 5 * time.Second
+)
+
+// DefaultStuckTransferSLAs is how long a transfer may stay in a status before
+// it is reported as stuck
+var DefaultStuckTransferSLAs = map[types.TransferStatus]time.Duration{
+	types.StatusPending:    30 * time.Minute,
+	types.StatusConfirming: 30 * time.Minute,
+	types.StatusSigned:     10 * time.Minute,
+	types.StatusExecuting:  15 * time.Minute,
+}
+
+// Database is the part of a connection pool needed for health checks
+type Database interface {
+	PingContext(ctx context.Context) error
+	Stats() sql.DBStats
+}
+
+// ChainMonitor reports the connectivity and head of a chain
+type ChainMonitor interface {
+	GetChainID() types.ChainID
+	IsConnected() bool
+	HeadBlock(ctx context.Context) (uint64, error)
+}
+
+// ScanProgress reports how far the relayer has scanned each chain
+type ScanProgress interface {
+	GetScannedBlock(ctx context.Context, chainID types.ChainID) (uint64, bool, error)
+}
+
+// DatabaseHealth is the readiness of the database
+type DatabaseHealth struct {
+	Status          HealthStatus `json:"status"`
+	Error           string       `json:"error,omitempty"`
+	OpenConnections int          `json:"open_connections"`
+	InUse           int          `json:"in_use"`
+	Idle            int          `json:"idle"`
+	MaxOpen         int          `json:"max_open"`
+	WaitCount       int64        `json:"wait_count"`
+	WaitDuration    string       `json:"wait_duration"`
+}
+
+// ChainHealth is the readiness of a single chain
+type ChainHealth struct {
+	ChainID      types.ChainID `json:"chain_id"`
+	Name         string        `json:"name"`
+	Status       HealthStatus  `json:"status"`
+	Connected    bool          `json:"connected"`
+	HeadBlock    uint64        `json:"head_block"`
+	ScannedBlock uint64        `json:"scanned_block"`
+	Lag          uint64        `json:"lag"`
+	Error        string        `json:"error,omitempty"`
+}
+
+// RouteHealth is the readiness of a source to destination route
+type RouteHealth struct {
+	SourceChain      types.ChainID `json:"source_chain"`
+	DestinationChain types.ChainID `json:"destination_chain"`
+	Status           HealthStatus  `json:"status"`
+}
+
+// ReadinessReport is the full readiness of the bridge
+type ReadinessReport struct {
+	Status         HealthStatus                 `json:"status"`
+	Database       DatabaseHealth               `json:"database"`
+	Chains         []ChainHealth                `json:"chains"`
+	Routes         []RouteHealth                `json:"routes"`
+	StuckTransfers map[types.TransferStatus]int `json:"stuck_transfers"`
+	CheckedAt      time.Time                    `json:"checked_at"`
+}
+
+// HealthChecker builds readiness reports from the database, the store and
+// the registered chains. Scan lag is measured against the progress the
+// relayer persists in Scans; without it, lag is not checked. Reports are
+// reused for CacheFor, so frequent callers do not each query every
+// dependency.
+type HealthChecker struct {
+	Database   Database
+	Scans      ScanProgress
+	store      models.Store
+	chains     []ChainMonitor
+	MaxScanLag uint64
+	StuckAfter map[types.TransferStatus]time.Duration
+	Timeout    time.Duration
+	CacheFor   time.Duration
+
+	mu        sync.Mutex
+	cached    ReadinessReport
+	checkedAt time.Time
+}
+
+// NewHealthChecker creates a health checker for a store
+func NewHealthChecker(store models.Store) *HealthChecker {
+	return &HealthChecker{
+		store:      store,
+		MaxScanLag: DefaultMaxScanLag,
+		StuckAfter: DefaultStuckTransferSLAs,
+		Timeout:    DefaultHealthCheckTimeout,
+		CacheFor:   DefaultReadinessCacheTTL,
+	}
+}
+
+// AddChain registers a chain whose health is reported
+func (h *HealthChecker) AddChain(chain ChainMonitor) {
+	h.chains = append(h.chains, chain)
+}
+
+// Check returns the latest readiness report, running the checks again once
+// it is older than CacheFor. Concurrent callers wait for a single run, which
+// is not cut short by the caller that started it going away.
+func (h *HealthChecker) Check(ctx context.Context) ReadinessReport {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.checkedAt.IsZero() && time.Since(h.checkedAt) < h.CacheFor {
+		return h.cached
+	}
+	h.cached = h.check(context.WithoutCancel(ctx))
+	h.checkedAt = time.Now()
+	return h.cached
+}
+
+// check runs every readiness check. The bridge is down when the database is
+// unreachable or no chain can be reached, and degraded when any chain is
+// unhealthy or transfers are stuck.
+func (h *HealthChecker) check(ctx context.Context) ReadinessReport {
+	ctx, cancel := context.WithTimeout(ctx, h.Timeout)
+	defer cancel()
+
+	report := ReadinessReport{
+		Status:         HealthOK,
+		Database:       h.checkDatabase(ctx),
+		Chains:         []ChainHealth{},
+		Routes:         []RouteHealth{},
+		StuckTransfers: make(map[types.TransferStatus]int),
+		CheckedAt:      time.Now().UTC(),
+	}
+
+	healthy := make(map[types.ChainID]bool)
+	reachable := 0
+	for _, chain := range h.chains {
+		health := h.checkChain(ctx, chain)
+		healthy[health.ChainID] = health.Status == HealthOK
+		if health.Status != HealthDown {
+			reachable++
+		}
+		if health.Status != HealthOK {
+			report.Status = HealthDegraded
+		}
+		report.Chains = append(report.Chains, health)
+	}
+	sort.Slice(report.Chains, func(i, j int) bool {
+		return report.Chains[i].ChainID < report.Chains[j].ChainID
+	})
+
+	for _, source := range report.Chains {
+		for _, destination := range report.Chains {
+			if source.ChainID == destination.ChainID {
+				continue
+			}
+			route := RouteHealth{
+				SourceChain:      source.ChainID,
+				DestinationChain: destination.ChainID,
+				Status:           HealthOK,
+			}
+			if !healthy[source.ChainID] || !healthy[destination.ChainID] {
+				route.Status = HealthDegraded
+			}
+			report.Routes = append(report.Routes, route)
+		}
+	}
+
+	if report.Database.Status == HealthOK {
+		for status, sla := range h.StuckAfter {
+			count, err := h.store.CountStuckTransfers(ctx, status, time.Now().Add(-sla))
+			if err != nil {
+				report.Database.Status = HealthDegraded
+				report.Database.Error = err.Error()
+				continue
+			}
+			report.StuckTransfers[status] = count
+			if count > 0 {
+				report.Status = HealthDegraded
+			}
+		}
+	}
+
+	if report.Database.Status == HealthDegraded {
+		report.Status = HealthDegraded
+	}
+	if report.Database.Status == HealthDown || (len(h.chains) > 0 && reachable == 0) {
+		report.Status = HealthDown
+	}
+
+	return report
+}
+
+// checkDatabase pings the database and collects pool statistics
+func (h *HealthChecker) checkDatabase(ctx context.Context) DatabaseHealth {
+	if h.Database == nil {
+		return DatabaseHealth{Status: HealthOK}
+	}
+
+	stats := h.Database.Stats()
+	health := DatabaseHealth{
+		Status:          HealthOK,
+		OpenConnections: stats.OpenConnections,
+		InUse:           stats.InUse,
+		Idle:            stats.Idle,
+		MaxOpen:         stats.MaxOpenConnections,
+		WaitCount:       stats.WaitCount,
+		WaitDuration:    stats.WaitDuration.String(),
+	}
+
+	if err := h.Database.PingContext(ctx); err != nil {
+		health.Status = HealthDown
+		health.Error = err.Error()
+	}
+
+	return health
+}
+
+// checkChain checks a chain's connectivity and how far its scan trails the head
+func (h *HealthChecker) checkChain(ctx context.Context, chain ChainMonitor) ChainHealth {
+	chainID := chain.GetChainID()
+	health := ChainHealth{
+		ChainID:   chainID,
+		Name:      chainID.String(),
+		Status:    HealthOK,
+		Connected: chain.IsConnected(),
+	}
+
+	if !health.Connected {
+		health.Status = HealthDown
+		health.Error = "adapter not connected"
+		return health
+	}
+
+	head, err := chain.HeadBlock(ctx)
+	if err != nil {
+		health.Status = HealthDown
+		health.Error = err.Error()
+		return health
+	}
+
+	health.HeadBlock = head
+	if h.Scans == nil {
+		return health
+	}
+
+	scanned, found, err := h.Scans.GetScannedBlock(ctx, chainID)
+	if err != nil {
+		health.Status = HealthDegraded
+		health.Error = err.Error()
+		return health
+	}
+	if !found {
+		health.Status = HealthDegraded
+		health.Error = "chain not scanned by the relayer"
+		return health
+	}
+
+	health.ScannedBlock = scanned
+	if head > scanned {
+		health.Lag = head - scanned
+	}
+	if health.Lag > h.MaxScanLag {
+		health.Status = HealthDegraded
+	}
+
+	return health
+}
+
+// livenessResponse is the body of the liveness endpoint
+type livenessResponse struct {
+	Status HealthStatus `json:"status"`
+}
+
+// handleHealth reports that the process is up
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, livenessResponse{Status: HealthOK})
+}
+
+// handleReady reports the readiness of the bridge. Degraded bridges still
+// accept traffic; the per-route status tells callers which routes to avoid.
+func (s *Server) handleReady(w http.ResponseWriter, r *http.Request) {
+	report := s.Health.Check(r.Context())
+
+	status := http.StatusOK
+	if report.Status == HealthDown {
+		status = http.StatusServiceUnavailable
+	}
+
+	writeJSON(w, status, report)
+}
+
+// handleMetrics serves the registered metrics in the Prometheus text format
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	if s.Metrics == nil {
+		writeError(w, http.StatusServiceUnavailable, "metrics are not available")
+		return
+	}
+
+	promhttp.HandlerFor(s.Metrics, promhttp.HandlerOpts{}).ServeHTTP(w, r)
+}
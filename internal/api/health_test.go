@@ -0,0 +1,220 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/internal/adapters"
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+var _ ChainMonitor = (*adapters.EthereumAdapter)(nil)
+
+// fakeDatabase is a Database with a configurable ping result
+type fakeDatabase struct {
+	pingErr error
+}
+
+func (f *fakeDatabase) PingContext(ctx context.Context) error {
+	return f.pingErr
+}
+
+func (f *fakeDatabase) Stats() sql.DBStats {
+	return sql.DBStats{MaxOpenConnections: 25, OpenConnections: 3, InUse: 1, Idle: 2}
+}
+
+// fakeChain is a ChainMonitor with a fixed head
+type fakeChain struct {
+	chainID   types.ChainID
+	connected bool
+	head      uint64
+}
+
+func (f *fakeChain) GetChainID() types.ChainID { return f.chainID }
+func (f *fakeChain) IsConnected() bool         { return f.connected }
+
+func (f *fakeChain) HeadBlock(ctx context.Context) (uint64, error) {
+	if !f.connected {
+		return 0, errors.New("adapter not connected")
+	}
+	return f.head, nil
+}
+
+func readReport(t *testing.T, server *Server) (int, ReadinessReport) {
+	rec := doRequest(t, server, "/api/v1/ready")
+	var report ReadinessReport
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+	return rec.Code, report
+}
+
+func TestHealth_Liveness(t *testing.T) {
+	server, _ := newTestServer(t)
+	server.Health.Database = &fakeDatabase{pingErr: errors.New("connection refused")}
+
+	// Liveness does not depend on any dependency
+	rec := doRequest(t, server, "/api/v1/health")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, rec.Body.String())
+}
+
+// recordScanned records the relayer's scan progress of a chain
+func recordScanned(t *testing.T, store *models.MemoryStateManager, chainID types.ChainID, block uint64) {
+	require.NoError(t, store.RecordScannedBlock(context.Background(), chainID, block))
+}
+
+func TestHealth_ReadyWhenHealthy(t *testing.T) {
+	server, store := newTestServer(t)
+	server.Health.Database = &fakeDatabase{}
+	server.Health.Scans = store
+	server.Health.AddChain(&fakeChain{chainID: types.ChainPolygon, connected: true, head: 1000})
+	server.Health.AddChain(&fakeChain{chainID: types.ChainEthereum, connected: true, head: 500})
+	recordScanned(t, store, types.ChainPolygon, 995)
+	recordScanned(t, store, types.ChainEthereum, 500)
+
+	code, report := readReport(t, server)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, HealthOK, report.Status)
+	assert.Equal(t, 25, report.Database.MaxOpen)
+	require.Len(t, report.Chains, 2)
+	assert.Equal(t, types.ChainEthereum, report.Chains[0].ChainID)
+	assert.Equal(t, uint64(995), report.Chains[1].ScannedBlock)
+	assert.Equal(t, uint64(5), report.Chains[1].Lag)
+	require.Len(t, report.Routes, 2)
+	for _, route := range report.Routes {
+		assert.Equal(t, HealthOK, route.Status)
+	}
+}
+
+func TestHealth_DegradedRoutes(t *testing.T) {
+	server, store := newTestServer(t)
+	server.Health.Scans = store
+	server.Health.AddChain(&fakeChain{chainID: types.ChainEthereum, connected: true, head: 500})
+	server.Health.AddChain(&fakeChain{chainID: types.ChainPolygon, connected: true, head: 5000})
+	server.Health.AddChain(&fakeChain{chainID: types.ChainCosmos, connected: false})
+	recordScanned(t, store, types.ChainEthereum, 500)
+	recordScanned(t, store, types.ChainPolygon, 100)
+
+	code, report := readReport(t, server)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, HealthDegraded, report.Status)
+
+	statuses := make(map[types.ChainID]HealthStatus)
+	for _, chain := range report.Chains {
+		statuses[chain.ChainID] = chain.Status
+	}
+	assert.Equal(t, HealthOK, statuses[types.ChainEthereum])
+	assert.Equal(t, HealthDegraded, statuses[types.ChainPolygon])
+	assert.Equal(t, HealthDown, statuses[types.ChainCosmos])
+
+	// Every route touches the lagging or the disconnected chain
+	require.Len(t, report.Routes, 6)
+	for _, route := range report.Routes {
+		assert.Equal(t, HealthDegraded, route.Status)
+	}
+}
+
+func TestHealth_DegradedWhenChainNotScanned(t *testing.T) {
+	server, store := newTestServer(t)
+	server.Health.Scans = store
+	server.Health.CacheFor = 0
+	server.Health.AddChain(&fakeChain{chainID: types.ChainEthereum, connected: true, head: 500})
+
+	// The API's own connection says nothing about the relayer's scan
+	code, report := readReport(t, server)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, HealthDegraded, report.Status)
+	require.Len(t, report.Chains, 1)
+	assert.Equal(t, uint64(500), report.Chains[0].HeadBlock)
+	assert.Equal(t, "chain not scanned by the relayer", report.Chains[0].Error)
+
+	recordScanned(t, store, types.ChainEthereum, 490)
+	_, report = readReport(t, server)
+	assert.Equal(t, HealthOK, report.Status)
+	assert.Equal(t, uint64(10), report.Chains[0].Lag)
+}
+
+func TestHealth_DownWhenDatabaseUnreachable(t *testing.T) {
+	server, _ := newTestServer(t)
+	server.Health.Database = &fakeDatabase{pingErr: errors.New("connection refused")}
+
+	code, report := readReport(t, server)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, HealthDown, report.Status)
+	assert.Equal(t, "connection refused", report.Database.Error)
+}
+
+func TestHealth_DownWhenNoChainReachable(t *testing.T) {
+	server, _ := newTestServer(t)
+	server.Health.AddChain(&fakeChain{chainID: types.ChainEthereum, connected: false})
+
+	code, report := readReport(t, server)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, HealthDown, report.Status)
+}
+
+func TestHealth_StuckTransfers(t *testing.T) {
+	server, _ := newTestServer(t, createTestTransfer(1))
+	server.Health.StuckAfter = map[types.TransferStatus]time.Duration{
+		types.StatusPending: -time.Minute, // anything pending counts as stuck
+		types.StatusSigned:  time.Minute,
+	}
+
+	code, report := readReport(t, server)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, HealthDegraded, report.Status)
+	assert.Equal(t, 1, report.StuckTransfers[types.StatusPending])
+	assert.Equal(t, 0, report.StuckTransfers[types.StatusSigned])
+}
+
+func TestMetrics(t *testing.T) {
+	server, _ := newTestServer(t)
+	server.rateLimits[models.RolePublic] = 60
+
+	rec := doRequest(t, server, "/api/v1/metrics")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	registry := prometheus.NewRegistry()
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{Name: "nexus_bridge_test", Help: "A test gauge."})
+	gauge.Set(42)
+	registry.MustRegister(gauge)
+	server.Metrics = registry
+
+	rec = doRequest(t, server, "/api/v1/metrics")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "nexus_bridge_test 42")
+	// Scrapes are charged to the caller's rate limit
+	assert.Equal(t, "60", rec.Header().Get("X-RateLimit-Limit"))
+}
+
+func TestHealth_ReadinessReportIsCached(t *testing.T) {
+	server, store := newTestServer(t)
+	server.rateLimits[models.RolePublic] = 60
+	server.Health.Scans = store
+	server.Health.AddChain(&fakeChain{chainID: types.ChainEthereum, connected: true, head: 500})
+
+	_, first := readReport(t, server)
+	assert.Equal(t, HealthDegraded, first.Status)
+
+	// Progress made within the cache period shows once the report expires
+	recordScanned(t, store, types.ChainEthereum, 500)
+	rec := doRequest(t, server, "/api/v1/ready")
+	var cached ReadinessReport
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &cached))
+	assert.Equal(t, first.CheckedAt, cached.CheckedAt)
+	assert.Equal(t, HealthDegraded, cached.Status)
+	assert.Equal(t, "60", rec.Header().Get("X-RateLimit-Limit"))
+
+	server.Health.CacheFor = 0
+	_, report := readReport(t, server)
+	assert.Equal(t, HealthOK, report.Status)
+}
@@ -0,0 +1,183 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+// tokenLimitsResponse lists the limits of a token
+type tokenLimitsResponse struct {
+	Limits []models.TokenLimit `json:"limits"`
+}
+
+// setTokenLimitRequest is the body of a request to set the limit of a
+// token. Without a destination chain the limit applies to every route.
+// Amounts are decimal strings in the token's base units, and omitted ones
+// are unlimited.
+type setTokenLimitRequest struct {
+	DestinationChain chainParam    `json:"destination_chain"`
+	MinAmount        *types.BigInt `json:"min_amount"`
+	MaxAmount        *types.BigInt `json:"max_amount"`
+	HourlyCap        *types.BigInt `json:"hourly_cap"`
+	DailyCap         *types.BigInt `json:"daily_cap"`
+	DelayThreshold   *types.BigInt `json:"delay_threshold"`
+}
+
+// handleListTokenLimits returns the limits of a token
+func (s *Server) handleListTokenLimits(w http.ResponseWriter, r *http.Request) {
+	if !s.limitsAvailable(w) {
+		return
+	}
+	token, ok := s.tokenFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	limits, err := s.Limits.ListTokenLimits(r.Context(), token.ID)
+	if err != nil {
+		fmt.Printf("Error listing limits of token %d: %v\n", token.ID, err)
+		writeError(w, http.StatusInternalServerError, "failed to list token limits")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, tokenLimitsResponse{Limits: limits})
+}
+
+// handleSetTokenLimit creates or replaces the limit of a token for a route
+func (s *Server) handleSetTokenLimit(w http.ResponseWriter, r *http.Request) {
+	if !s.limitsAvailable(w) {
+		return
+	}
+	token, ok := s.tokenFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	var req setTokenLimitRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodyBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	limit := &models.TokenLimit{
+		TokenID:          token.ID,
+		DestinationChain: types.ChainID(req.DestinationChain),
+		MinAmount:        req.MinAmount,
+		MaxAmount:        req.MaxAmount,
+		HourlyCap:        req.HourlyCap,
+		DailyCap:         req.DailyCap,
+		DelayThreshold:   req.DelayThreshold,
+	}
+	if limit.DestinationChain == token.ChainID {
+		writeError(w, http.StatusBadRequest, "destination chain must differ from the token's chain")
+		return
+	}
+	if err := limit.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	previous, ok := s.routeLimit(w, r, token.ID, limit.DestinationChain)
+	if !ok {
+		return
+	}
+
+	audit := s.auditAssigned(r, models.AuditTokenLimitSet, models.AuditEntityTokenLimit, &limit.ID, previous)
+	if err := s.Limits.SetTokenLimit(r.Context(), limit, audit); err != nil {
+		if errors.Is(err, models.ErrTokenNotFound) {
+			writeError(w, http.StatusNotFound, "token not found")
+			return
+		}
+		if writeAuditError(w, err) {
+			return
+		}
+		fmt.Printf("Error setting limit of token %d: %v\n", token.ID, err)
+		writeError(w, http.StatusInternalServerError, "failed to set token limit")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, limit)
+}
+
+// handleDeleteTokenLimit removes the limit of a token for the route named by
+// the destination_chain query parameter, or its token-wide limit
+func (s *Server) handleDeleteTokenLimit(w http.ResponseWriter, r *http.Request) {
+	if !s.limitsAvailable(w) {
+		return
+	}
+	token, ok := s.tokenFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	var destination types.ChainID
+	if value := r.URL.Query().Get("destination_chain"); value != "" {
+		chainID, err := types.ParseChainID(value)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		destination = chainID
+	}
+
+	previous, ok := s.routeLimit(w, r, token.ID, destination)
+	if !ok {
+		return
+	}
+	if previous == nil {
+		writeError(w, http.StatusNotFound, "token limit not found")
+		return
+	}
+
+	audit := s.audit(r, models.AuditTokenLimitRemoved, models.AuditEntityTokenLimit, strconv.Itoa(previous.ID), previous)
+	if err := s.Limits.DeleteTokenLimit(r.Context(), token.ID, destination, audit); err != nil {
+		if errors.Is(err, models.ErrTokenLimitNotFound) {
+			writeError(w, http.StatusNotFound, "token limit not found")
+			return
+		}
+		if writeAuditError(w, err) {
+			return
+		}
+		fmt.Printf("Error deleting limit of token %d: %v\n", token.ID, err)
+		writeError(w, http.StatusInternalServerError, "failed to delete token limit")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// routeLimit returns the current limit of a token for a route, or nil if it
+// has none, writing an error response if it cannot
+func (s *Server) routeLimit(w http.ResponseWriter, r *http.Request, tokenID int, destination types.ChainID) (*models.TokenLimit, bool) {
+	limits, err := s.Limits.ListTokenLimits(r.Context(), tokenID)
+	if err != nil {
+		fmt.Printf("Error listing limits of token %d: %v\n", tokenID, err)
+		writeError(w, http.StatusInternalServerError, "failed to get token limit")
+		return nil, false
+	}
+
+	for i := range limits {
+		if limits[i].DestinationChain == destination {
+			return &limits[i], true
+		}
+	}
+	return nil, true
+}
+
+// limitsAvailable writes an error response if no token limit store is
+// configured
+func (s *Server) limitsAvailable(w http.ResponseWriter) bool {
+	if s.Limits == nil {
+		writeError(w, http.StatusServiceUnavailable, "token limits are not available")
+		return false
+	}
+	return true
+}
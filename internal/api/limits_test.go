@@ -0,0 +1,107 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+func listTokenLimits(t *testing.T, server *Server, path string) []models.TokenLimit {
+	rec := doAdminRequest(t, server, http.MethodGet, path, testAdminKey, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var body tokenLimitsResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	return body.Limits
+}
+
+func TestTokenLimits_SetListDelete(t *testing.T) {
+	server, store, _ := newTokenServer(t)
+	server.Limits = store
+	token := addToken(t, server, "0x00000000000000000000000000000000000000b1", "BETA")
+	path := fmt.Sprintf("/api/v1/admin/tokens/%d/limits", token.ID)
+
+	assert.Empty(t, listTokenLimits(t, server, path))
+
+	rec := doAdminRequest(t, server, http.MethodPut, path, testAdminKey, `{"destination_chain":"polygon","max_amount":"5000","hourly_cap":"10000"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var route models.TokenLimit
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &route))
+	assert.Equal(t, types.ChainPolygon, route.DestinationChain)
+	assert.Equal(t, "5000", route.MaxAmount.String())
+	assert.Nil(t, route.MinAmount)
+
+	rec = doAdminRequest(t, server, http.MethodPut, path, testAdminKey, `{"min_amount":"100","daily_cap":"50000"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	// Setting a route again replaces its limit
+	rec = doAdminRequest(t, server, http.MethodPut, path, "bob-secret", `{"destination_chain":137,"max_amount":"6000"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	limits := listTokenLimits(t, server, path)
+	require.Len(t, limits, 2)
+	assert.Equal(t, types.ChainID(0), limits[0].DestinationChain)
+	assert.Equal(t, "100", limits[0].MinAmount.String())
+	assert.Equal(t, "6000", limits[1].MaxAmount.String())
+	assert.Nil(t, limits[1].HourlyCap)
+
+	rec = doAdminRequest(t, server, http.MethodDelete, path+"?destination_chain=polygon", testAdminKey, "")
+	require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+	rec = doAdminRequest(t, server, http.MethodDelete, path+"?destination_chain=polygon", testAdminKey, "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Len(t, listTokenLimits(t, server, path), 1)
+
+	trail, err := store.GetAuditTrail(context.Background(), models.AuditEntityTokenLimit, strconv.Itoa(route.ID))
+	require.NoError(t, err)
+	require.Len(t, trail, 3)
+	assert.Equal(t, models.AuditTokenLimitSet, trail[0].EventType)
+	assert.JSONEq(t, "null", string(trail[0].OldValues))
+	assert.Equal(t, "bob", trail[1].PerformedBy)
+	assert.Equal(t, models.AuditTokenLimitRemoved, trail[2].EventType)
+	assert.JSONEq(t, "null", string(trail[2].NewValues))
+}
+
+func TestTokenLimits_Invalid(t *testing.T) {
+	server, store, _ := newTokenServer(t)
+	server.Limits = store
+	token := addToken(t, server, "0x00000000000000000000000000000000000000b1", "BETA")
+	path := fmt.Sprintf("/api/v1/admin/tokens/%d/limits", token.ID)
+
+	for _, body := range []string{
+		`{}`,
+		`{"min_amount":"200","max_amount":"100"}`,
+		`{"max_amount":"0"}`,
+		`{"max_amount":100}`,
+		`{"destination_chain":"mars","max_amount":"100"}`,
+		`{"destination_chain":"ethereum","max_amount":"100"}`,
+		`{"max_amount":"100","weekly_cap":"1000"}`,
+	} {
+		rec := doAdminRequest(t, server, http.MethodPut, path, testAdminKey, body)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, body)
+	}
+	assert.Empty(t, listTokenLimits(t, server, path))
+
+	rec := doAdminRequest(t, server, http.MethodPut, "/api/v1/admin/tokens/999/limits", testAdminKey, `{"max_amount":"100"}`)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	rec = doAdminRequest(t, server, http.MethodDelete, path+"?destination_chain=mars", testAdminKey, "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	rec = doAdminRequest(t, server, http.MethodGet, path, "", "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestTokenLimits_Unavailable(t *testing.T) {
+	server, _, _ := newTokenServer(t)
+	token := addToken(t, server, "0x00000000000000000000000000000000000000b1", "BETA")
+
+	rec := doAdminRequest(t, server, http.MethodGet, fmt.Sprintf("/api/v1/admin/tokens/%d/limits", token.ID), testAdminKey, "")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
@@ -0,0 +1,27 @@
+package api
+
+import (
+	_ "embed"
+	"fmt"
+	"net/http"
+)
+
+// openAPIDocument describes every endpoint registered in routes. Tests check
+// it against the routes and the types the handlers encode.
+//
+//go:embed openapi.json
+var openAPIDocument []byte
+
+// OpenAPIDocument returns the OpenAPI 3 description of the API
+func OpenAPIDocument() []byte {
+	return openAPIDocument
+}
+
+// handleOpenAPI serves the OpenAPI document
+func (s *Server) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(openAPIDocument); err != nil {
+		fmt.Printf("Error writing OpenAPI document: %v\n", err)
+	}
+}
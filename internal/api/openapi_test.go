@@ -0,0 +1,220 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+// openAPISchema is the subset of an OpenAPI schema object the tests inspect
+type openAPISchema struct {
+	Ref        string                   `json:"$ref"`
+	Properties map[string]openAPISchema `json:"properties"`
+	AllOf      []openAPISchema          `json:"allOf"`
+}
+
+type openAPIOperation struct {
+	OperationID  string      `json:"operationId"`
+	RequiredRole models.Role `json:"x-required-role"`
+}
+
+type openAPIDoc struct {
+	OpenAPI    string                                 `json:"openapi"`
+	Paths      map[string]map[string]openAPIOperation `json:"paths"`
+	Components struct {
+		Schemas map[string]openAPISchema `json:"schemas"`
+	} `json:"components"`
+}
+
+var pathParam = regexp.MustCompile(`\{[a-z_]+\}`)
+
+func loadOpenAPI(t *testing.T) openAPIDoc {
+	var doc openAPIDoc
+	require.NoError(t, json.Unmarshal(OpenAPIDocument(), &doc))
+	return doc
+}
+
+func TestOpenAPI_Served(t *testing.T) {
+	server, _ := newTestServer(t)
+
+	rec := doRequest(t, server, "/api/v1/openapi.json")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.JSONEq(t, string(OpenAPIDocument()), rec.Body.String())
+	assert.True(t, strings.HasPrefix(loadOpenAPI(t).OpenAPI, "3."))
+}
+
+func TestOpenAPI_DocumentsEveryRoute(t *testing.T) {
+	server, _ := newTestServer(t)
+	doc := loadOpenAPI(t)
+
+	documented := make(map[string]models.Role)
+	operationIDs := make(map[string]bool)
+	for path, operations := range doc.Paths {
+		for method, operation := range operations {
+			pattern := strings.ToUpper(method) + " " + path
+			require.True(t, operation.RequiredRole.IsValid(), "%s has no x-required-role", pattern)
+			require.False(t, operationIDs[operation.OperationID], "duplicate operationId %s", operation.OperationID)
+			operationIDs[operation.OperationID] = true
+			documented[pattern] = operation.RequiredRole
+
+			// The documented path must reach the handler registered for it
+			// rather than a more general pattern
+			req := httptest.NewRequest(strings.ToUpper(method), pathParam.ReplaceAllString(path, "1"), nil)
+			_, matched := server.mux.Handler(req)
+			if matched == transferResourcePattern {
+				// Transfer resources share a pattern and are told apart
+				// by name
+				name := path[strings.LastIndex(path, "/")+1:]
+				if assert.Contains(t, server.subresources, name, pattern) {
+					matched = pattern
+				}
+			}
+			assert.Equal(t, pattern, matched)
+		}
+	}
+
+	assert.Equal(t, server.endpoints, documented)
+}
+
+func TestOpenAPI_RolesMatchRoutes(t *testing.T) {
+	server, _ := newTestServer(t)
+
+	for pattern, role := range server.endpoints {
+		if role == models.RolePublic {
+			continue
+		}
+		method, path, _ := strings.Cut(pattern, " ")
+		req := httptest.NewRequest(method, pathParam.ReplaceAllString(path, "1"), nil)
+		rec := httptest.NewRecorder()
+		server.Handler().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, pattern)
+		assert.Equal(t, `Bearer realm="`+string(role)+`"`, rec.Header().Get("WWW-Authenticate"), pattern)
+	}
+}
+
+func TestOpenAPI_SchemasMatchTypes(t *testing.T) {
+	doc := loadOpenAPI(t)
+
+	encoded := map[string]interface{}{
+		"Error":                 errorResponse{},
+		"Transfer":              types.Transfer{},
+		"TransferPage":          models.TransferPage{},
+		"Transfers":             transfersResponse{},
+		"TransferUpdate":        models.TransferUpdate{},
+		"ProofSignature":        proofSignature{},
+		"ExecuteParams":         executeParams{},
+		"TransferProof":         transferProof{},
+		"StreamRequest":         streamRequest{},
+		"StreamMessage":         streamMessage{},
+		"SupportedToken":        types.SupportedToken{},
+		"Tokens":                tokensResponse{},
+		"AddTokenRequest":       addTokenRequest{},
+		"TokenLimit":            models.TokenLimit{},
+		"TokenLimits":           tokenLimitsResponse{},
+		"SetTokenLimitRequest":  setTokenLimitRequest{},
+		"FeeEstimate":           types.FeeEstimate{},
+		"QuoteRequest":          quoteRequest{},
+		"Quote":                 quoteResponse{},
+		"Liveness":              livenessResponse{},
+		"DatabaseHealth":        DatabaseHealth{},
+		"ChainHealth":           ChainHealth{},
+		"RouteHealth":           RouteHealth{},
+		"ReadinessReport":       ReadinessReport{},
+		"ReviewVote":            models.ReviewVote{},
+		"TransferReview":        models.TransferReview{},
+		"ReviewQueueEntry":      reviewQueueEntry{},
+		"Reviews":               reviewsResponse{},
+		"ReviewDecisionRequest": reviewDecisionRequest{},
+		"TransferDelay":         models.TransferDelay{},
+		"DelayQueueEntry":       delayQueueEntry{},
+		"Delays":                delaysResponse{},
+		"CancelDelayRequest":    cancelDelayRequest{},
+		"Webhook":               models.Webhook{},
+		"CreatedWebhook":        createdWebhookResponse{},
+		"CreateWebhookRequest":  createWebhookRequest{},
+		"Webhooks":              webhooksResponse{},
+		"WebhookDelivery":       models.WebhookDelivery{},
+		"Deliveries":            deliveriesResponse{},
+		"APIKey":                models.APIKey{},
+		"CreatedAPIKey":         createdAPIKeyResponse{},
+		"CreateAPIKeyRequest":   createAPIKeyRequest{},
+		"APIKeys":               apiKeysResponse{},
+		"AuditEntry":            models.AuditEntry{},
+		"BridgeStatus":          bridgeStatus{},
+		"Bridges":               bridgesResponse{},
+		"UnpauseBridgeRequest":  unpauseBridgeRequest{},
+		"UnpauseBridgeResponse": unpauseBridgeResponse{},
+	}
+
+	for name, schema := range doc.Components.Schemas {
+		properties := schemaProperties(t, doc, schema)
+		if len(properties) == 0 {
+			continue
+		}
+		value, ok := encoded[name]
+		if !assert.True(t, ok, "schema %s is not checked against a type", name) {
+			continue
+		}
+		assert.Equal(t, jsonFields(reflect.TypeOf(value)), properties, name)
+	}
+}
+
+// schemaProperties returns the sorted property names of an object schema,
+// following references and merging allOf
+func schemaProperties(t *testing.T, doc openAPIDoc, schema openAPISchema) []string {
+	if schema.Ref != "" {
+		resolved, ok := doc.Components.Schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")]
+		require.True(t, ok, "unresolved reference %s", schema.Ref)
+		return schemaProperties(t, doc, resolved)
+	}
+
+	var names []string
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	for _, part := range schema.AllOf {
+		names = append(names, schemaProperties(t, doc, part)...)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// jsonFields returns the sorted names a struct type is encoded with,
+// including the fields of embedded structs
+func jsonFields(typ reflect.Type) []string {
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	var names []string
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if field.Anonymous && name == "" {
+			names = append(names, jsonFields(field.Type)...)
+			continue
+		}
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
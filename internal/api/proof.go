@@ -0,0 +1,164 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"nexus-bridge/internal/adapters"
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+// Destination is where transfers to a chain are completed
+type Destination struct {
+	BridgeContract string
+	// ExecuteMethod is the bridge method completing transfers:
+	// unlockTokens or mintTokens
+	ExecuteMethod string
+}
+
+// proofSignature is a relayer's signature of a transfer
+type proofSignature struct {
+	RelayerAddress string `json:"relayer_address"`
+	Signature      string `json:"signature"`
+}
+
+// executeParams are the arguments of the destination call, named as in the
+// bridge ABI. Token is set for unlockTokens, and OriginalToken and
+// OriginalChainID for mintTokens.
+type executeParams struct {
+	TransferID      string        `json:"transferId"`
+	Token           string        `json:"token,omitempty"`
+	OriginalToken   string        `json:"originalToken,omitempty"`
+	OriginalChainID string        `json:"originalChainId,omitempty"`
+	Amount          *types.BigInt `json:"amount"`
+	Recipient       string        `json:"recipient"`
+	Signatures      []string      `json:"signatures"`
+}
+
+// transferProof is everything needed to complete a transfer from any wallet
+type transferProof struct {
+	TransferID       string           `json:"transfer_id"`
+	DestinationChain types.ChainID    `json:"destination_chain"`
+	To               string           `json:"to"`
+	Method           string           `json:"method"`
+	Params           executeParams    `json:"params"`
+	Calldata         string           `json:"calldata"`
+	Signatures       []proofSignature `json:"signatures"`
+	Threshold        int              `json:"threshold"`
+}
+
+// AddDestination registers how transfers to a chain are completed, enabling
+// proofs for them
+func (s *Server) AddDestination(chainID types.ChainID, destination Destination) {
+	s.destinations[chainID] = destination
+}
+
+// handleGetTransferProof returns the relayer signatures collected for a
+// transfer with the destination call they authorize. Anyone may submit the
+// call, so users are not stuck when the relayers' executor is down. Only
+// distinct relayers active in relayer_config and authorized by the
+// destination bridge count toward its requiredSignatures.
+func (s *Server) handleGetTransferProof(w http.ResponseWriter, r *http.Request) {
+	transfer, err := s.store.GetTransfer(r.Context(), r.PathValue("id"))
+	if err != nil {
+		if errors.Is(err, models.ErrTransferNotFound) {
+			writeError(w, http.StatusNotFound, "transfer not found")
+			return
+		}
+		fmt.Printf("Error getting transfer: %v\n", err)
+		writeError(w, http.StatusInternalServerError, "failed to get transfer")
+		return
+	}
+
+	switch transfer.Status {
+	case types.StatusCompleted, types.StatusFailed, types.StatusUnderReview, types.StatusDelayed:
+		writeError(w, http.StatusConflict, fmt.Sprintf("transfer is %s", transfer.Status))
+		return
+	}
+	if !s.delayServed(w, r, *transfer) {
+		return
+	}
+
+	destination, exists := s.destinations[transfer.DestinationChain]
+	if !exists {
+		writeError(w, http.StatusServiceUnavailable, fmt.Sprintf("proofs are not available for %s", transfer.DestinationChain))
+		return
+	}
+
+	if s.Signatures == nil {
+		writeError(w, http.StatusServiceUnavailable, "proofs are not available: relayer set unknown")
+		return
+	}
+	aggregation, err := s.Signatures.Aggregate(r.Context(), *transfer)
+	if err != nil {
+		fmt.Printf("Error aggregating signatures of transfer %s: %v\n", transfer.ID, err)
+		writeError(w, http.StatusInternalServerError, "failed to aggregate signatures")
+		return
+	}
+	if !aggregation.Ready {
+		if uint64(len(aggregation.Signatures)) < aggregation.Required {
+			writeError(w, http.StatusConflict, fmt.Sprintf("transfer has %d of %d required signatures", len(aggregation.Signatures), aggregation.Required))
+			return
+		}
+		writeError(w, http.StatusConflict, fmt.Sprintf("transfer has %d of %d required signature weight", aggregation.Weight, aggregation.RequiredWeight))
+		return
+	}
+
+	proof, err := buildProof(*transfer, destination, aggregation.Signatures)
+	if err != nil {
+		fmt.Printf("Error encoding proof of transfer %s: %v\n", transfer.ID, err)
+		writeError(w, http.StatusInternalServerError, "failed to encode proof")
+		return
+	}
+	proof.Threshold = int(aggregation.Required)
+
+	writeJSON(w, http.StatusOK, proof)
+}
+
+// buildProof encodes the destination call completing a transfer with the
+// signatures the aggregator selected, the same the relayers submit
+func buildProof(transfer types.Transfer, destination Destination, signatures []types.Signature) (*transferProof, error) {
+	raw := make([][]byte, len(signatures))
+	encoded := make([]string, len(signatures))
+	proofSignatures := make([]proofSignature, len(signatures))
+	for i, signature := range signatures {
+		raw[i] = signature.Signature
+		encoded[i] = hexutil.Encode(signature.Signature)
+		proofSignatures[i] = proofSignature{RelayerAddress: signature.RelayerAddress, Signature: encoded[i]}
+	}
+
+	calldata, err := adapters.PackExecuteCall(destination.ExecuteMethod, transfer, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	params := executeParams{
+		TransferID: common.HexToHash(transfer.ID).Hex(),
+		Amount:     transfer.Amount,
+		Recipient:  common.HexToAddress(transfer.Recipient).Hex(),
+		Signatures: encoded,
+	}
+	token := common.HexToAddress(transfer.Token).Hex()
+	if destination.ExecuteMethod == adapters.MethodMintTokens {
+		params.OriginalToken = token
+		params.OriginalChainID = strconv.FormatUint(uint64(transfer.SourceChain), 10)
+	} else {
+		params.Token = token
+	}
+
+	return &transferProof{
+		TransferID:       transfer.ID,
+		DestinationChain: transfer.DestinationChain,
+		To:               common.HexToAddress(destination.BridgeContract).Hex(),
+		Method:           destination.ExecuteMethod,
+		Params:           params,
+		Calldata:         hexutil.Encode(calldata),
+		Signatures:       proofSignatures,
+	}, nil
+}
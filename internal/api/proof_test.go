@@ -0,0 +1,234 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/internal/adapters"
+	"nexus-bridge/internal/models"
+	"nexus-bridge/internal/relayer"
+	"nexus-bridge/pkg/types"
+)
+
+const testBridgeContract = "0x5FbDB2315678afecb367f032d93F642f64180aa3"
+
+// bridgeRelayers is the relayer set of the destination bridge
+type bridgeRelayers struct {
+	required   uint64
+	authorized map[string]bool
+}
+
+func (b *bridgeRelayers) RequiredSignatures(chainID types.ChainID) uint64 {
+	return b.required
+}
+
+func (b *bridgeRelayers) IsAuthorized(chainID types.ChainID, address string) bool {
+	return b.authorized[strings.ToLower(address)]
+}
+
+// proofRelayer returns the address of the nth test relayer
+func proofRelayer(n int) string {
+	return fmt.Sprintf("0x%040x", n)
+}
+
+// newProofServer serves a transfer to Polygon holding signatures from the
+// given number of active relayers, which a bridge requiring the given
+// number of signatures authorizes
+func newProofServer(t *testing.T, signatures int, required uint64, destination Destination) (*Server, *models.MemoryStateManager, types.Transfer) {
+	transfer := createTestTransfer(1)
+	transfer.Recipient = "0x8ba1f109551bD432803012645Ac136c22C4C4C4C"
+	server, store := newTestServer(t, transfer)
+	server.AddDestination(types.ChainPolygon, destination)
+
+	bridge := &bridgeRelayers{required: required, authorized: make(map[string]bool)}
+	var active []string
+	for i := 0; i < signatures; i++ {
+		active = append(active, proofRelayer(i+1))
+		bridge.authorized[proofRelayer(i+1)] = true
+		require.NoError(t, store.RecordSignature(context.Background(), transfer.ID, types.Signature{
+			RelayerAddress: proofRelayer(i + 1),
+			Signature:      append(make([]byte, 64), byte(27+i)),
+		}))
+	}
+	_, err := store.SetActiveRelayers(context.Background(), active)
+	require.NoError(t, err)
+	server.Signatures = relayer.NewSignatureAggregator(store, store, bridge)
+	return server, store, transfer
+}
+
+func TestGetTransferProof(t *testing.T) {
+	server, store, transfer := newProofServer(t, 2, 2, Destination{
+		BridgeContract: testBridgeContract,
+		ExecuteMethod:  adapters.MethodUnlockTokens,
+	})
+
+	rec := doRequest(t, server, "/api/v1/transfers/"+transfer.ID+"/proof")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var proof transferProof
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &proof))
+	assert.Equal(t, transfer.ID, proof.TransferID)
+	assert.Equal(t, types.ChainPolygon, proof.DestinationChain)
+	assert.Equal(t, testBridgeContract, proof.To)
+	assert.Equal(t, adapters.MethodUnlockTokens, proof.Method)
+	assert.Equal(t, 2, proof.Threshold)
+	require.Len(t, proof.Signatures, 2)
+	assert.Equal(t, common.HexToAddress(transfer.Recipient).Hex(), proof.Params.Recipient)
+	assert.Equal(t, common.HexToAddress(transfer.Token).Hex(), proof.Params.Token)
+	assert.Empty(t, proof.Params.OriginalToken)
+	assert.Equal(t, transfer.Amount.String(), proof.Params.Amount.String())
+
+	signatures, err := store.GetSignatures(context.Background(), transfer.ID)
+	require.NoError(t, err)
+	raw := make([][]byte, len(signatures))
+	for i, signature := range signatures {
+		raw[i] = signature.Signature
+		assert.Equal(t, hexutil.Encode(signature.Signature), proof.Params.Signatures[i])
+	}
+	calldata, err := adapters.PackExecuteCall(adapters.MethodUnlockTokens, transfer, raw)
+	require.NoError(t, err)
+	assert.Equal(t, hexutil.Encode(calldata), proof.Calldata)
+}
+
+func TestGetTransferProof_MintTokens(t *testing.T) {
+	server, store, transfer := newProofServer(t, 1, 1, Destination{
+		BridgeContract: testBridgeContract,
+		ExecuteMethod:  adapters.MethodMintTokens,
+	})
+
+	rec := doRequest(t, server, "/api/v1/transfers/"+transfer.ID+"/proof")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var proof transferProof
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &proof))
+	assert.Equal(t, 1, proof.Threshold)
+	assert.Empty(t, proof.Params.Token)
+	assert.Equal(t, common.HexToAddress(transfer.Token).Hex(), proof.Params.OriginalToken)
+	assert.Equal(t, "1", proof.Params.OriginalChainID)
+
+	signatures, err := store.GetSignatures(context.Background(), transfer.ID)
+	require.NoError(t, err)
+	calldata, err := adapters.PackExecuteCall(adapters.MethodMintTokens, transfer, [][]byte{signatures[0].Signature})
+	require.NoError(t, err)
+	assert.Equal(t, hexutil.Encode(calldata), proof.Calldata)
+}
+
+func TestGetTransferProof_Unavailable(t *testing.T) {
+	destination := Destination{
+		BridgeContract: testBridgeContract,
+		ExecuteMethod:  adapters.MethodUnlockTokens,
+	}
+
+	t.Run("below threshold", func(t *testing.T) {
+		server, _, transfer := newProofServer(t, 1, 2, destination)
+		rec := doRequest(t, server, "/api/v1/transfers/"+transfer.ID+"/proof")
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		assert.Contains(t, rec.Body.String(), "1 of 2 required signatures")
+	})
+
+	t.Run("signatures that do not count", func(t *testing.T) {
+		server, store, transfer := newProofServer(t, 1, 2, destination)
+		ctx := context.Background()
+		// A second signature of the same relayer, one of a relayer the
+		// bridge does not authorize and one of an inactive relayer
+		for _, address := range []string{strings.ToUpper(proofRelayer(1)), proofRelayer(8), proofRelayer(9)} {
+			require.NoError(t, store.RecordSignature(ctx, transfer.ID, types.Signature{
+				RelayerAddress: address,
+				Signature:      append(make([]byte, 64), 27),
+			}))
+		}
+		_, err := store.SetActiveRelayers(ctx, []string{proofRelayer(1), proofRelayer(8)})
+		require.NoError(t, err)
+		bridge := &bridgeRelayers{required: 2, authorized: map[string]bool{proofRelayer(1): true, proofRelayer(9): true}}
+		server.Signatures = relayer.NewSignatureAggregator(store, store, bridge)
+
+		rec := doRequest(t, server, "/api/v1/transfers/"+transfer.ID+"/proof")
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		assert.Contains(t, rec.Body.String(), "1 of 2 required signatures")
+	})
+
+	t.Run("relayer set unknown", func(t *testing.T) {
+		server, _, transfer := newProofServer(t, 2, 2, destination)
+		server.Signatures = nil
+		rec := doRequest(t, server, "/api/v1/transfers/"+transfer.ID+"/proof")
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	})
+
+	t.Run("completed", func(t *testing.T) {
+		server, store, transfer := newProofServer(t, 2, 2, destination)
+		require.NoError(t, store.UpdateTransferStatus(context.Background(), transfer.ID, types.StatusCompleted))
+		rec := doRequest(t, server, "/api/v1/transfers/"+transfer.ID+"/proof")
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+
+	t.Run("unknown transfer", func(t *testing.T) {
+		server, _, _ := newProofServer(t, 2, 2, destination)
+		rec := doRequest(t, server, "/api/v1/transfers/"+createTestTransfer(2).ID+"/proof")
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("destination not configured", func(t *testing.T) {
+		transfer := createTestTransfer(1)
+		server, _ := newTestServer(t, transfer)
+		rec := doRequest(t, server, "/api/v1/transfers/"+transfer.ID+"/proof")
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	})
+}
+
+func TestTransferResources_KeepByTxRoute(t *testing.T) {
+	server, _, transfer := newProofServer(t, 0, 1, Destination{})
+
+	rec := doRequest(t, server, "/api/v1/transfers/by-tx/proof")
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "by-tx lookups take precedence")
+
+	rec = doRequest(t, server, "/api/v1/transfers/"+transfer.ID+"/unknown")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestGetTransferProof_Delayed(t *testing.T) {
+	server, store, transfer := newProofServer(t, 2, 2, Destination{
+		BridgeContract: testBridgeContract,
+		ExecuteMethod:  adapters.MethodUnlockTokens,
+	})
+	server.Delays = store
+	server.Limits = store
+
+	token := &types.SupportedToken{
+		ChainID:      types.ChainEthereum,
+		TokenAddress: strings.ToLower(transfer.Token),
+		Name:         "USD Coin",
+		Symbol:       "USDC",
+		Decimals:     18,
+		Enabled:      true,
+	}
+	require.NoError(t, store.AddSupportedToken(context.Background(), token, nil))
+	limit := &models.TokenLimit{TokenID: token.ID, DelayThreshold: types.NewBigInt(big.NewInt(1))}
+	require.NoError(t, store.SetTokenLimit(context.Background(), limit, nil))
+	path := "/api/v1/transfers/" + transfer.ID + "/proof"
+
+	// A proof would let anyone execute the transfer before it is delayed
+	rec := doRequest(t, server, path)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Contains(t, rec.Body.String(), "wait out its delay")
+
+	_, err := store.DelayTransfer(context.Background(), transfer.ID, time.Now())
+	require.NoError(t, err)
+	rec = doRequest(t, server, path)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	_, err = store.ReleaseDueDelays(context.Background(), time.Now())
+	require.NoError(t, err)
+	rec = doRequest(t, server, path)
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+}
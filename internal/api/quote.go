@@ -0,0 +1,122 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"nexus-bridge/internal/fees"
+	"nexus-bridge/pkg/types"
+)
+
+// maxRequestBodyBytes bounds the size of JSON request bodies
+const maxRequestBodyBytes = 64 << 10
+
+// chainParam is a chain given by name or numeric ID in a JSON body
+type chainParam types.ChainID
+
+// UnmarshalJSON accepts a chain as a JSON string or number
+func (c *chainParam) UnmarshalJSON(data []byte) error {
+	chainID, err := types.ParseChainID(strings.Trim(string(data), `"`))
+	if err != nil {
+		return err
+	}
+	*c = chainParam(chainID)
+	return nil
+}
+
+// quoteRequest is the body of a quote request
+type quoteRequest struct {
+	SourceChain      chainParam `json:"source_chain"`
+	DestinationChain chainParam `json:"destination_chain"`
+	Token            string     `json:"token"`
+	Amount           string     `json:"amount"`
+	Recipient        string     `json:"recipient"`
+}
+
+// quoteResponse is the fee quote for a prospective transfer
+type quoteResponse struct {
+	SourceChain      types.ChainID      `json:"source_chain"`
+	DestinationChain types.ChainID      `json:"destination_chain"`
+	Token            string             `json:"token"`
+	Amount           *types.BigInt      `json:"amount"`
+	Fee              *types.FeeEstimate `json:"fee"`
+	QuotedAt         time.Time          `json:"quoted_at"`
+}
+
+// handleQuote quotes the fee for a prospective transfer
+func (s *Server) handleQuote(w http.ResponseWriter, r *http.Request) {
+	if s.Fees == nil {
+		writeError(w, http.StatusServiceUnavailable, "fee quotes are not available")
+		return
+	}
+
+	transfer, err := parseQuoteRequest(w, r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	estimate, err := s.Fees.EstimateFee(r.Context(), transfer)
+	if err != nil {
+		if errors.Is(err, fees.ErrUnsupportedRoute) || errors.Is(err, fees.ErrNoRate) {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		fmt.Printf("Error estimating fee: %v\n", err)
+		writeError(w, http.StatusInternalServerError, "failed to estimate fee")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, quoteResponse{
+		SourceChain:      transfer.SourceChain,
+		DestinationChain: transfer.DestinationChain,
+		Token:            transfer.Token,
+		Amount:           transfer.Amount,
+		Fee:              estimate,
+		QuotedAt:         time.Now().UTC(),
+	})
+}
+
+// parseQuoteRequest decodes and validates a quote request into the transfer
+// it would create
+func parseQuoteRequest(w http.ResponseWriter, r *http.Request) (types.Transfer, error) {
+	var req quoteRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodyBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		return types.Transfer{}, fmt.Errorf("invalid request body: %v", err)
+	}
+
+	if req.SourceChain == 0 || req.DestinationChain == 0 {
+		return types.Transfer{}, fmt.Errorf("source_chain and destination_chain are required")
+	}
+	if req.SourceChain == req.DestinationChain {
+		return types.Transfer{}, fmt.Errorf("source and destination chains must be different")
+	}
+	if !common.IsHexAddress(req.Token) {
+		return types.Transfer{}, fmt.Errorf("invalid token address")
+	}
+	if !common.IsHexAddress(req.Recipient) {
+		return types.Transfer{}, fmt.Errorf("invalid recipient address")
+	}
+
+	amount, ok := new(big.Int).SetString(req.Amount, 10)
+	if !ok || amount.Sign() <= 0 {
+		return types.Transfer{}, fmt.Errorf("amount must be a positive integer")
+	}
+
+	return types.Transfer{
+		SourceChain:      types.ChainID(req.SourceChain),
+		DestinationChain: types.ChainID(req.DestinationChain),
+		Token:            req.Token,
+		Amount:           types.NewBigInt(amount),
+		Recipient:        req.Recipient,
+	}, nil
+}
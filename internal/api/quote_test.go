@@ -0,0 +1,125 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/internal/fees"
+	"nexus-bridge/pkg/types"
+)
+
+// fakeFees is a FeeCalculator returning a fixed estimate
+type fakeFees struct {
+	estimate *types.FeeEstimate
+	err      error
+	quoted   []types.Transfer
+}
+
+func (f *fakeFees) EstimateFee(ctx context.Context, transfer types.Transfer) (*types.FeeEstimate, error) {
+	f.quoted = append(f.quoted, transfer)
+	return f.estimate, f.err
+}
+
+func (f *fakeFees) GetGasPrice(ctx context.Context, chainID types.ChainID) (*big.Int, error) {
+	return big.NewInt(1), nil
+}
+
+func (f *fakeFees) ValidateFee(ctx context.Context, transfer types.Transfer, providedFee *big.Int) error {
+	return nil
+}
+
+func newFakeFees() *fakeFees {
+	return &fakeFees{estimate: &types.FeeEstimate{
+		SourceChainFee:      types.NewBigInt(big.NewInt(0)),
+		DestinationChainFee: types.NewBigInt(big.NewInt(1000)),
+		RelayerFee:          types.NewBigInt(big.NewInt(100)),
+		TotalFee:            types.NewBigInt(big.NewInt(1100)),
+		EstimatedTime:       144,
+	}}
+}
+
+func postJSON(t *testing.T, server *Server, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, req)
+	return rec
+}
+
+func quoteBody(source, destination, amount string) string {
+	return fmt.Sprintf(`{"source_chain":%s,"destination_chain":%s,"token":"0xA0b86a33E6441E6C7D3E4C2C4C6C6C6C6C6C6C6C","amount":%q,"recipient":"0x1234567890123456789012345678901234567890"}`,
+		source, destination, amount)
+}
+
+func TestQuote(t *testing.T) {
+	server, _ := newTestServer(t)
+	calculator := newFakeFees()
+	server.Fees = calculator
+
+	// Chains may be given by name or numeric ID
+	rec := postJSON(t, server, "/api/v1/quote", quoteBody(`"ethereum"`, "137", "5000000"))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var raw map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &raw))
+	assert.Equal(t, float64(1), raw["source_chain"])
+	assert.Equal(t, float64(137), raw["destination_chain"])
+	assert.Equal(t, "5000000", raw["amount"])
+	fee := raw["fee"].(map[string]interface{})
+	assert.Equal(t, "1100", fee["total_fee"])
+	assert.Equal(t, float64(144), fee["estimated_time_seconds"])
+
+	require.Len(t, calculator.quoted, 1)
+	assert.Equal(t, types.ChainEthereum, calculator.quoted[0].SourceChain)
+	assert.Equal(t, types.ChainPolygon, calculator.quoted[0].DestinationChain)
+	assert.Equal(t, int64(5000000), calculator.quoted[0].Amount.Int64())
+}
+
+func TestQuote_BadRequests(t *testing.T) {
+	server, _ := newTestServer(t)
+	server.Fees = newFakeFees()
+
+	for name, body := range map[string]string{
+		"malformed":     `{"source_chain":`,
+		"unknown field": `{"source_chain":1,"destination_chain":137,"fee":"1"}`,
+		"unknown chain": quoteBody(`"mars"`, "137", "1"),
+		"missing chain": `{"source_chain":1,"token":"0xA0b86a33E6441E6C7D3E4C2C4C6C6C6C6C6C6C6C","amount":"1","recipient":"0x1234567890123456789012345678901234567890"}`,
+		"same chain":    quoteBody("1", "1", "1"),
+		"zero amount":   quoteBody("1", "137", "0"),
+		"bad amount":    quoteBody("1", "137", "1.5"),
+		"bad recipient": `{"source_chain":1,"destination_chain":137,"token":"0xA0b86a33E6441E6C7D3E4C2C4C6C6C6C6C6C6C6C","amount":"1","recipient":"bob"}`,
+	} {
+		rec := postJSON(t, server, "/api/v1/quote", body)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, name)
+	}
+}
+
+func TestQuote_CalculatorErrors(t *testing.T) {
+	server, _ := newTestServer(t)
+
+	// No calculator configured
+	rec := postJSON(t, server, "/api/v1/quote", quoteBody("1", "137", "1"))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	calculator := newFakeFees()
+	server.Fees = calculator
+
+	calculator.err = fmt.Errorf("%w: cosmos", fees.ErrUnsupportedRoute)
+	rec = postJSON(t, server, "/api/v1/quote", quoteBody("1", "118", "1"))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	calculator.err = errors.New("connection refused")
+	rec = postJSON(t, server, "/api/v1/quote", quoteBody("1", "137", "1"))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.JSONEq(t, `{"error":"failed to estimate fee"}`, rec.Body.String())
+}
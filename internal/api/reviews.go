@@ -0,0 +1,143 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+// reviewQueueEntry is an open review with the transfer it holds
+type reviewQueueEntry struct {
+	models.TransferReview
+	Transfer *types.Transfer `json:"transfer"`
+}
+
+// reviewsResponse lists the review queue
+type reviewsResponse struct {
+	Reviews []reviewQueueEntry `json:"reviews"`
+	Quorum  int                `json:"quorum"`
+}
+
+// reviewDecisionRequest is the optional body of an approval and the
+// required body of a rejection
+type reviewDecisionRequest struct {
+	Reason string `json:"reason"`
+}
+
+// handleListReviews returns the transfers held for review, oldest first,
+// with the reason each was flagged and the votes cast so far
+func (s *Server) handleListReviews(w http.ResponseWriter, r *http.Request) {
+	if !s.reviewsAvailable(w) {
+		return
+	}
+
+	reviews, err := s.Reviews.ListOpenReviews(r.Context())
+	if err != nil {
+		fmt.Printf("Error listing reviews: %v\n", err)
+		writeError(w, http.StatusInternalServerError, "failed to list reviews")
+		return
+	}
+
+	entries := make([]reviewQueueEntry, 0, len(reviews))
+	for _, review := range reviews {
+		transfer, err := s.store.GetTransfer(r.Context(), review.TransferID)
+		if err != nil {
+			fmt.Printf("Error getting transfer %s under review: %v\n", review.TransferID, err)
+			writeError(w, http.StatusInternalServerError, "failed to list reviews")
+			return
+		}
+		entries = append(entries, reviewQueueEntry{TransferReview: review, Transfer: transfer})
+	}
+
+	writeJSON(w, http.StatusOK, reviewsResponse{Reviews: entries, Quorum: s.quorum()})
+}
+
+// handleApproveReview votes to return a transfer under review to the status
+// it was flagged in, so relayers pick it up again
+func (s *Server) handleApproveReview(w http.ResponseWriter, r *http.Request) {
+	s.voteOnReview(w, r, models.DecisionApprove, models.AuditTransferApproved)
+}
+
+// handleRejectReview votes to fail a transfer under review
+func (s *Server) handleRejectReview(w http.ResponseWriter, r *http.Request) {
+	s.voteOnReview(w, r, models.DecisionReject, models.AuditTransferRejected)
+}
+
+// voteOnReview records the authenticated operator's decision on the review
+// of the {id} transfer. The review is resolved once the quorum of operators
+// agree; until then it stays open.
+func (s *Server) voteOnReview(w http.ResponseWriter, r *http.Request, decision models.ReviewDecision, eventType string) {
+	if !s.reviewsAvailable(w) {
+		return
+	}
+
+	var req reviewDecisionRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodyBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+	if decision == models.DecisionReject && req.Reason == "" {
+		writeError(w, http.StatusBadRequest, "reason is required")
+		return
+	}
+
+	transferID := r.PathValue("id")
+	before, err := s.Reviews.GetOpenReview(r.Context(), transferID)
+	if err != nil {
+		s.writeReviewError(w, transferID, err)
+		return
+	}
+
+	vote := models.ReviewVote{
+		Operator: actorFromContext(r.Context()),
+		Decision: decision,
+		Reason:   req.Reason,
+	}
+	audit := s.audit(r, eventType, models.AuditEntityTransfer, transferID, before)
+	review, err := s.Reviews.VoteOnReview(r.Context(), transferID, vote, s.quorum(), audit)
+	if err != nil {
+		s.writeReviewError(w, transferID, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, review)
+}
+
+// writeReviewError writes the response for a failed review lookup or vote
+func (s *Server) writeReviewError(w http.ResponseWriter, transferID string, err error) {
+	switch {
+	case errors.Is(err, models.ErrReviewNotFound):
+		writeError(w, http.StatusNotFound, "transfer is not under review")
+	case errors.Is(err, models.ErrAlreadyVoted):
+		writeError(w, http.StatusConflict, "operator has already voted on this review")
+	case writeAuditError(w, err):
+	default:
+		fmt.Printf("Error voting on review of transfer %s: %v\n", transferID, err)
+		writeError(w, http.StatusInternalServerError, "failed to record decision")
+	}
+}
+
+// quorum returns how many distinct operators must agree on a review
+func (s *Server) quorum() int {
+	if s.reviewQuorum < 1 {
+		return 1
+	}
+	return s.reviewQuorum
+}
+
+// reviewsAvailable writes an error response if no review store is
+// configured
+func (s *Server) reviewsAvailable(w http.ResponseWriter) bool {
+	if s.Reviews == nil {
+		writeError(w, http.StatusServiceUnavailable, "reviews are not available")
+		return false
+	}
+	return true
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+const bobAdminKey = "bob-secret"
+
+func newReviewServer(t *testing.T, quorum int, transfers ...types.Transfer) (*Server, *models.MemoryStateManager) {
+	server, store := newTestServer(t, transfers...)
+	server.adminKeys = map[string]string{"alice": testAdminKey, "bob": bobAdminKey}
+	server.Reviews = store
+	server.reviewQuorum = quorum
+	for _, transfer := range transfers {
+		require.NoError(t, store.MarkTransferForReview(context.Background(), transfer.ID, "fee too low"))
+	}
+	return server, store
+}
+
+func decide(t *testing.T, server *Server, transferID, action, key, body string) *httptest.ResponseRecorder {
+	return doAdminRequest(t, server, http.MethodPost, "/api/v1/admin/reviews/"+transferID+"/"+action, key, body)
+}
+
+func TestListReviews(t *testing.T) {
+	server, _ := newReviewServer(t, 2, createTestTransfer(1), createTestTransfer(2))
+
+	rec := doAdminRequest(t, server, http.MethodGet, "/api/v1/admin/reviews", testAdminKey, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var body reviewsResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, 2, body.Quorum)
+	require.Len(t, body.Reviews, 2)
+	assert.Equal(t, createTestTransfer(1).ID, body.Reviews[0].TransferID)
+	assert.Equal(t, "fee too low", body.Reviews[0].Reason)
+	assert.Equal(t, types.StatusPending, body.Reviews[0].PreviousStatus)
+	require.NotNil(t, body.Reviews[0].Transfer)
+	assert.Equal(t, types.StatusUnderReview, body.Reviews[0].Transfer.Status)
+
+	rec = doAdminRequest(t, server, http.MethodGet, "/api/v1/admin/reviews", "", "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	server.Reviews = nil
+	rec = doAdminRequest(t, server, http.MethodGet, "/api/v1/admin/reviews", testAdminKey, "")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
+
+func TestApproveReview(t *testing.T) {
+	transfer := createTestTransfer(1)
+	server, store := newReviewServer(t, 1, transfer)
+
+	rec := decide(t, server, transfer.ID, "approve", testAdminKey, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var review models.TransferReview
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &review))
+	assert.Equal(t, models.ReviewApproved, review.Status)
+	require.Len(t, review.Votes, 1)
+	assert.Equal(t, "alice", review.Votes[0].Operator)
+
+	status, err := store.GetTransferStatus(context.Background(), transfer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, types.StatusPending, *status)
+
+	trail, err := store.GetAuditTrail(context.Background(), models.AuditEntityTransfer, transfer.ID)
+	require.NoError(t, err)
+	require.Len(t, trail, 1)
+	assert.Equal(t, models.AuditTransferApproved, trail[0].EventType)
+	assert.Equal(t, "alice", trail[0].PerformedBy)
+	assert.Contains(t, string(trail[0].OldValues), `"status":"open"`)
+	assert.Contains(t, string(trail[0].NewValues), `"status":"approved"`)
+
+	// The review is closed
+	rec = decide(t, server, transfer.ID, "approve", bobAdminKey, "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestRejectReview_RequiresQuorum(t *testing.T) {
+	transfer := createTestTransfer(1)
+	server, store := newReviewServer(t, 2, transfer)
+
+	rec := decide(t, server, transfer.ID, "reject", testAdminKey, "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "a rejection needs a reason")
+
+	rec = decide(t, server, transfer.ID, "reject", testAdminKey, `{"reason":"sanctioned recipient"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var review models.TransferReview
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &review))
+	assert.Equal(t, models.ReviewOpen, review.Status)
+
+	// The same operator cannot make up the quorum alone
+	rec = decide(t, server, transfer.ID, "reject", testAdminKey, `{"reason":"sanctioned recipient"}`)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	rec = decide(t, server, transfer.ID, "reject", bobAdminKey, `{"reason":"confirmed"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &review))
+	assert.Equal(t, models.ReviewRejected, review.Status)
+	assert.Equal(t, "confirmed", review.Resolution)
+
+	status, err := store.GetTransferStatus(context.Background(), transfer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, types.StatusFailed, *status)
+
+	trail, err := store.GetAuditTrail(context.Background(), models.AuditEntityTransfer, transfer.ID)
+	require.NoError(t, err)
+	require.Len(t, trail, 2)
+	assert.Equal(t, "alice", trail[0].PerformedBy)
+	assert.Equal(t, "bob", trail[1].PerformedBy)
+	assert.Equal(t, models.AuditTransferRejected, trail[1].EventType)
+}
+
+func TestReviewDecision_InvalidRequests(t *testing.T) {
+	transfer := createTestTransfer(1)
+	server, _ := newReviewServer(t, 1, transfer)
+
+	rec := decide(t, server, createTestTransfer(2).ID, "approve", testAdminKey, "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	rec = decide(t, server, transfer.ID, "approve", testAdminKey, `{"reason":"ok","extra":true}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	rec = decide(t, server, transfer.ID, "approve", "", "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
@@ -0,0 +1,180 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"nexus-bridge/internal/config"
+	"nexus-bridge/internal/models"
+	"nexus-bridge/internal/ratelimit"
+	"nexus-bridge/internal/relayer"
+	"nexus-bridge/pkg/types"
+)
+
+// Server is the NexusBridge HTTP API
+type Server struct {
+	store        models.Store
+	mux          *http.ServeMux
+	endpoints    map[string]models.Role
+	httpServer   *http.Server
+	Health       *HealthChecker
+	Fees         types.FeeCalculator
+	Updates      *UpdateBroker
+	Webhooks     models.WebhookStore
+	Reviews      models.ReviewStore
+	Delays       models.DelayStore
+	APIKeys      models.APIKeyStore
+	Limits       models.TokenLimitStore
+	Metrics      prometheus.Gatherer
+	Guardian     BridgeGuardian
+	Signatures   relayer.Aggregator
+	Limiter      ratelimit.Limiter
+	adminKeys    map[string]string
+	jwtSecret    []byte
+	trustProxy   bool
+	rateLimits   map[models.Role]int
+	reviewQuorum int
+	verifiers    map[types.ChainID]TokenVerifier
+	destinations map[types.ChainID]Destination
+	subresources map[string]http.HandlerFunc
+}
+
+// NewServer creates an API server backed by a store
+func NewServer(cfg config.APIConfig, store models.Store) *Server {
+	s := &Server{
+		store:        store,
+		mux:          http.NewServeMux(),
+		endpoints:    make(map[string]models.Role),
+		Health:       NewHealthChecker(store),
+		Updates:      NewUpdateBroker(),
+		Limiter:      ratelimit.NewMemoryLimiter(),
+		adminKeys:    cfg.AdminKeys,
+		jwtSecret:    []byte(cfg.JWTSecret),
+		trustProxy:   cfg.TrustProxy,
+		rateLimits:   roleRateLimits(cfg.RateLimits),
+		reviewQuorum: cfg.ReviewQuorum,
+		verifiers:    make(map[types.ChainID]TokenVerifier),
+		destinations: make(map[types.ChainID]Destination),
+		subresources: make(map[string]http.HandlerFunc),
+	}
+	s.routes()
+
+	s.httpServer = &http.Server{
+		Addr:         ":" + cfg.Port,
+		Handler:      s.mux,
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+		IdleTimeout:  cfg.IdleTimeout,
+	}
+
+	return s
+}
+
+// roleRateLimits returns the requests a minute allowed to each role
+func roleRateLimits(cfg config.RateLimitConfig) map[models.Role]int {
+	return map[models.Role]int{
+		models.RolePublic:     cfg.Public,
+		models.RoleIntegrator: cfg.Integrator,
+		models.RoleOperator:   cfg.Operator,
+		models.RoleAdmin:      cfg.Admin,
+	}
+}
+
+// routes registers every API endpoint with the least role allowed to call
+// it. The liveness check is left unauthenticated and unlimited for probes.
+func (s *Server) routes() {
+	s.handleProbe("GET /api/v1/health", s.handleHealth)
+	s.handle("GET /api/v1/ready", models.RolePublic, s.handleReady)
+	s.handle("GET /api/v1/metrics", models.RolePublic, s.handleMetrics)
+
+	s.handle("GET /api/v1/openapi.json", models.RolePublic, s.handleOpenAPI)
+	s.handle("POST /api/v1/quote", models.RolePublic, s.handleQuote)
+	s.handle("GET /api/v1/transfers", models.RolePublic, s.handleListTransfers)
+	s.handle("GET /api/v1/transfers/by-tx/{hash}", models.RolePublic, s.handleGetTransfersByTx)
+	s.handle("GET /api/v1/transfers/stream", models.RolePublic, s.handleStreamEvents)
+	s.handle("GET /api/v1/transfers/ws", models.RolePublic, s.handleStreamWebSocket)
+	s.handle("GET /api/v1/transfers/{id}", models.RolePublic, s.handleGetTransfer)
+	s.handleTransferResource("proof", models.RolePublic, s.handleGetTransferProof)
+	s.handle("GET /api/v1/tokens", models.RolePublic, s.handleListTokens)
+
+	s.handle("GET /api/v1/admin/reviews", models.RoleOperator, s.handleListReviews)
+	s.handle("POST /api/v1/admin/reviews/{id}/approve", models.RoleOperator, s.handleApproveReview)
+	s.handle("POST /api/v1/admin/reviews/{id}/reject", models.RoleOperator, s.handleRejectReview)
+
+	s.handle("GET /api/v1/admin/delays", models.RoleOperator, s.handleListDelays)
+	s.handle("POST /api/v1/admin/delays/{id}/cancel", models.RoleOperator, s.handleCancelDelay)
+
+	s.handle("GET /api/v1/webhooks", models.RoleIntegrator, ownWebhooks(s.handleListWebhooks))
+	s.handle("POST /api/v1/webhooks", models.RoleIntegrator, ownWebhooks(s.handleCreateWebhook))
+	s.handle("GET /api/v1/webhooks/{id}", models.RoleIntegrator, ownWebhooks(s.handleGetWebhook))
+	s.handle("DELETE /api/v1/webhooks/{id}", models.RoleIntegrator, ownWebhooks(s.handleDeleteWebhook))
+	s.handle("GET /api/v1/webhooks/{id}/deliveries", models.RoleIntegrator, ownWebhooks(s.handleListDeliveries))
+	s.handle("POST /api/v1/webhooks/{id}/deliveries/{delivery}/replay", models.RoleIntegrator, ownWebhooks(s.handleReplayDelivery))
+
+	s.handle("GET /api/v1/admin/webhooks", models.RoleOperator, s.handleListWebhooks)
+	s.handle("POST /api/v1/admin/webhooks", models.RoleOperator, s.handleCreateWebhook)
+	s.handle("GET /api/v1/admin/webhooks/{id}", models.RoleOperator, s.handleGetWebhook)
+	s.handle("DELETE /api/v1/admin/webhooks/{id}", models.RoleOperator, s.handleDeleteWebhook)
+	s.handle("GET /api/v1/admin/webhooks/{id}/deliveries", models.RoleOperator, s.handleListDeliveries)
+	s.handle("POST /api/v1/admin/webhooks/{id}/deliveries/{delivery}/replay", models.RoleOperator, s.handleReplayDelivery)
+
+	s.handle("GET /api/v1/admin/bridges", models.RoleOperator, s.handleListBridges)
+	s.handle("POST /api/v1/admin/bridges/{chain}/unpause", models.RoleOperator, s.handleUnpauseBridge)
+
+	s.handle("GET /api/v1/admin/tokens", models.RoleAdmin, s.handleAdminListTokens)
+	s.handle("POST /api/v1/admin/tokens", models.RoleAdmin, s.handleAddToken)
+	s.handle("POST /api/v1/admin/tokens/{id}/enable", models.RoleAdmin, s.handleEnableToken)
+	s.handle("POST /api/v1/admin/tokens/{id}/disable", models.RoleAdmin, s.handleDisableToken)
+	s.handle("DELETE /api/v1/admin/tokens/{id}", models.RoleAdmin, s.handleDeleteToken)
+	s.handle("GET /api/v1/admin/tokens/{id}/limits", models.RoleAdmin, s.handleListTokenLimits)
+	s.handle("PUT /api/v1/admin/tokens/{id}/limits", models.RoleAdmin, s.handleSetTokenLimit)
+	s.handle("DELETE /api/v1/admin/tokens/{id}/limits", models.RoleAdmin, s.handleDeleteTokenLimit)
+
+	s.handle("GET /api/v1/admin/api-keys", models.RoleAdmin, s.handleListAPIKeys)
+	s.handle("POST /api/v1/admin/api-keys", models.RoleAdmin, s.handleCreateAPIKey)
+	s.handle("DELETE /api/v1/admin/api-keys/{id}", models.RoleAdmin, s.handleRevokeAPIKey)
+}
+
+// Handler returns the HTTP handler serving the API
+func (s *Server) Handler() http.Handler {
+	return s.mux
+}
+
+// Start serves the API until Shutdown is called
+func (s *Server) Start() error {
+	if err := s.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve API: %w", err)
+	}
+	return nil
+}
+
+// Shutdown gracefully stops the server, waiting for in-flight requests.
+// Open streams are closed first so they do not hold up the shutdown.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.Updates.Close()
+	return s.httpServer.Shutdown(ctx)
+}
+
+// errorResponse is the body of every non-2xx response
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// writeJSON writes a JSON response with the given status code
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		fmt.Printf("Error encoding response: %v\n", err)
+	}
+}
+
+// writeError writes a JSON error response
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Error: message})
+}
@@ -0,0 +1,557 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"nexus-bridge/internal/models"
+)
+
+// Stream subscription fields
+const (
+	streamTransferID = "transfer_id"
+	streamSender     = "sender"
+	streamRecipient  = "recipient"
+)
+
+// Stream message types
+const (
+	streamTypeTransfer     = "transfer"
+	streamTypeResync       = "resync"
+	streamTypeSubscribed   = "subscribed"
+	streamTypeUnsubscribed = "unsubscribed"
+	streamTypeError        = "error"
+)
+
+const (
+	// streamBufferSize is how many messages a subscriber may fall behind
+	// before it is disconnected
+	streamBufferSize = 64
+	// maxStreamFilters bounds the transfers and addresses one stream watches
+	maxStreamFilters = 100
+	// streamKeepAliveInterval is how often idle event streams send a comment
+	streamKeepAliveInterval = 15 * time.Second
+
+	wsWriteWait       = 10 * time.Second
+	wsPongWait        = 60 * time.Second
+	wsPingInterval    = wsPongWait * 9 / 10
+	wsMaxMessageBytes = 4 << 10
+)
+
+// streamFields lists the fields a stream can subscribe by
+var streamFields = []string{streamTransferID, streamSender, streamRecipient}
+
+// upgrader accepts WebSocket connections from any origin. Streams only carry
+// public transfer data and are not authenticated by cookies.
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     func(*http.Request) bool { return true },
+}
+
+// streamMessage is a message sent to stream clients
+type streamMessage struct {
+	Type         string                 `json:"type"`
+	Transfer     *models.TransferUpdate `json:"transfer,omitempty"`
+	Subscription *streamRequest         `json:"subscription,omitempty"`
+	Error        string                 `json:"error,omitempty"`
+}
+
+// streamRequest changes the subscriptions of a WebSocket stream
+type streamRequest struct {
+	Action     string `json:"action,omitempty"`
+	TransferID string `json:"transfer_id,omitempty"`
+	Sender     string `json:"sender,omitempty"`
+	Recipient  string `json:"recipient,omitempty"`
+}
+
+// streamFilter is the set of transfers and addresses a stream watches. An
+// update matches if any of its fields is watched.
+type streamFilter struct {
+	mu     sync.Mutex
+	values map[string]map[string]struct{}
+}
+
+// newStreamFilter creates a filter watching nothing
+func newStreamFilter() *streamFilter {
+	values := make(map[string]map[string]struct{}, len(streamFields))
+	for _, field := range streamFields {
+		values[field] = make(map[string]struct{})
+	}
+	return &streamFilter{values: values}
+}
+
+// parseStreamFilter builds a filter from query parameters. Each field may be
+// repeated or hold a comma-separated list.
+func parseStreamFilter(query url.Values) (*streamFilter, error) {
+	filter := newStreamFilter()
+	for _, field := range streamFields {
+		for _, param := range query[field] {
+			for _, value := range strings.Split(param, ",") {
+				if err := filter.add(field, value); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return filter, nil
+}
+
+// add watches a value of a field. Values are compared case-insensitively.
+func (f *streamFilter) add(field, value string) error {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return fmt.Errorf("%s is empty", field)
+	}
+	if field == streamTransferID && !isHexHash(value) {
+		return fmt.Errorf("invalid transfer_id: %s", value)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, exists := f.values[field][value]; exists {
+		return nil
+	}
+	if f.size() >= maxStreamFilters {
+		return fmt.Errorf("a stream may watch at most %d transfers and addresses", maxStreamFilters)
+	}
+	f.values[field][value] = struct{}{}
+	return nil
+}
+
+// remove stops watching a value of a field
+func (f *streamFilter) remove(field, value string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.values[field], strings.ToLower(strings.TrimSpace(value)))
+}
+
+// apply adds or removes every field set in a request
+func (f *streamFilter) apply(req streamRequest) error {
+	if req.Action != "subscribe" && req.Action != "unsubscribe" {
+		return fmt.Errorf("unknown action: %q", req.Action)
+	}
+
+	fields := map[string]string{
+		streamTransferID: req.TransferID,
+		streamSender:     req.Sender,
+		streamRecipient:  req.Recipient,
+	}
+
+	set := false
+	for _, field := range streamFields {
+		value := fields[field]
+		if value == "" {
+			continue
+		}
+		set = true
+
+		if req.Action == "unsubscribe" {
+			f.remove(field, value)
+			continue
+		}
+		if err := f.add(field, value); err != nil {
+			return err
+		}
+	}
+	if !set {
+		return fmt.Errorf("one of transfer_id, sender or recipient is required")
+	}
+	return nil
+}
+
+// matches reports whether an update concerns a watched transfer or address
+func (f *streamFilter) matches(update models.TransferUpdate) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, byID := f.values[streamTransferID][strings.ToLower(update.TransferID)]
+	_, bySender := f.values[streamSender][strings.ToLower(update.Sender)]
+	_, byRecipient := f.values[streamRecipient][strings.ToLower(update.Recipient)]
+	return byID || bySender || byRecipient
+}
+
+// transferIDs returns the watched transfer IDs
+func (f *streamFilter) transferIDs() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ids := make([]string, 0, len(f.values[streamTransferID]))
+	for id := range f.values[streamTransferID] {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// empty reports whether the filter watches nothing
+func (f *streamFilter) empty() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.size() == 0
+}
+
+// size counts the watched values. The caller must hold the lock.
+func (f *streamFilter) size() int {
+	size := 0
+	for _, values := range f.values {
+		size += len(values)
+	}
+	return size
+}
+
+// subscription delivers the messages matching a filter to one stream
+type subscription struct {
+	filter   *streamFilter
+	messages chan streamMessage
+}
+
+// UpdateBroker fans transfer updates out to the streams watching them.
+// Streams that fall too far behind are disconnected rather than slowing
+// delivery to everyone else.
+type UpdateBroker struct {
+	mu          sync.Mutex
+	subscribers map[*subscription]struct{}
+	closed      bool
+}
+
+// NewUpdateBroker creates a broker with no subscribers
+func NewUpdateBroker() *UpdateBroker {
+	return &UpdateBroker{
+		subscribers: make(map[*subscription]struct{}),
+	}
+}
+
+// Publish delivers an update to every stream watching the transfer
+func (b *UpdateBroker) Publish(update models.TransferUpdate) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subscribers {
+		if sub.filter.matches(update) {
+			update := update
+			b.deliver(sub, streamMessage{Type: streamTypeTransfer, Transfer: &update})
+		}
+	}
+}
+
+// Resync tells every stream that updates may have been missed, so streams
+// resend the current state of the transfers they watch
+func (b *UpdateBroker) Resync() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subscribers {
+		b.deliver(sub, streamMessage{Type: streamTypeResync})
+	}
+}
+
+// Close disconnects every stream. Streams opened afterwards end immediately.
+func (b *UpdateBroker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for sub := range b.subscribers {
+		b.drop(sub)
+	}
+}
+
+// subscribe registers a stream watching a filter
+func (b *UpdateBroker) subscribe(filter *streamFilter) *subscription {
+	sub := &subscription{
+		filter:   filter,
+		messages: make(chan streamMessage, streamBufferSize),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		close(sub.messages)
+		return sub
+	}
+	b.subscribers[sub] = struct{}{}
+	return sub
+}
+
+// unsubscribe removes a stream if it is still registered
+func (b *UpdateBroker) unsubscribe(sub *subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, exists := b.subscribers[sub]; exists {
+		b.drop(sub)
+	}
+}
+
+// deliver queues a message for a stream, dropping the stream if its buffer
+// is full. The caller must hold the lock.
+func (b *UpdateBroker) deliver(sub *subscription, msg streamMessage) {
+	select {
+	case sub.messages <- msg:
+	default:
+		b.drop(sub)
+	}
+}
+
+// drop removes a stream and closes its messages. The caller must hold the lock.
+func (b *UpdateBroker) drop(sub *subscription) {
+	delete(b.subscribers, sub)
+	close(sub.messages)
+}
+
+// Subscribers returns the number of open streams
+func (b *UpdateBroker) Subscribers() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subscribers)
+}
+
+// handleStreamEvents streams the updates of watched transfers as
+// Server-Sent Events, starting with the current state of each watched ID
+func (s *Server) handleStreamEvents(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseStreamFilter(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if filter.empty() {
+		writeError(w, http.StatusBadRequest, "one of transfer_id, sender or recipient is required")
+		return
+	}
+
+	// Streams outlive the server's write timeout
+	controller := http.NewResponseController(w)
+	if err := controller.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		fmt.Printf("Error clearing stream write deadline: %v\n", err)
+	}
+
+	// Subscribe before reading the snapshot so no update is missed between them
+	sub := s.Updates.subscribe(filter)
+	defer s.Updates.unsubscribe(sub)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	if err := s.writeSnapshotEvents(w, r.Context(), filter); err != nil {
+		return
+	}
+	if err := controller.Flush(); err != nil {
+		return
+	}
+
+	keepAlive := time.NewTicker(streamKeepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case msg, ok := <-sub.messages:
+			if !ok {
+				return
+			}
+			if msg.Type == streamTypeResync {
+				err = s.writeSnapshotEvents(w, r.Context(), filter)
+			}
+			if err == nil {
+				err = writeEvent(w, msg)
+			}
+		case <-keepAlive.C:
+			_, err = fmt.Fprint(w, ": keepalive\n\n")
+		}
+
+		if err == nil {
+			err = controller.Flush()
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// writeSnapshotEvents writes the current state of every watched transfer
+func (s *Server) writeSnapshotEvents(w http.ResponseWriter, ctx context.Context, filter *streamFilter) error {
+	for _, update := range s.streamSnapshot(ctx, filter.transferIDs()) {
+		update := update
+		if err := writeEvent(w, streamMessage{Type: streamTypeTransfer, Transfer: &update}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeEvent writes a message as a Server-Sent Event named by its type
+func writeEvent(w http.ResponseWriter, msg streamMessage) error {
+	var data interface{} = struct{}{}
+	if msg.Transfer != nil {
+		data = msg.Transfer
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", msg.Type, encoded)
+	return err
+}
+
+// handleStreamWebSocket streams the updates of watched transfers over a
+// WebSocket. Transfers and addresses can be watched from the query string
+// and by sending subscribe and unsubscribe requests.
+func (s *Server) handleStreamWebSocket(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseStreamFilter(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader has already replied
+		return
+	}
+	defer conn.Close()
+
+	sub := s.Updates.subscribe(filter)
+	defer s.Updates.unsubscribe(sub)
+
+	requests := make(chan streamRequest)
+	readErrs := make(chan error, 1)
+	stop := make(chan struct{})
+	defer close(stop)
+	go readStreamRequests(conn, requests, readErrs, stop)
+
+	if err := s.writeSnapshotMessages(conn, r.Context(), filter.transferIDs()); err != nil {
+		return
+	}
+
+	ping := time.NewTicker(wsPingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-readErrs:
+			return
+		case req := <-requests:
+			err = s.handleStreamRequest(conn, r.Context(), filter, req)
+		case msg, ok := <-sub.messages:
+			if !ok {
+				conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseGoingAway, ""),
+					time.Now().Add(wsWriteWait))
+				return
+			}
+			if msg.Type == streamTypeResync {
+				err = s.writeSnapshotMessages(conn, r.Context(), filter.transferIDs())
+			}
+			if err == nil {
+				err = writeMessage(conn, msg)
+			}
+		case <-ping.C:
+			err = conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait))
+		}
+
+		if err != nil {
+			return
+		}
+	}
+}
+
+// handleStreamRequest applies a subscription change, acknowledging it or
+// reporting why it failed. Newly watched transfers start with their current
+// state.
+func (s *Server) handleStreamRequest(conn *websocket.Conn, ctx context.Context, filter *streamFilter, req streamRequest) error {
+	if err := filter.apply(req); err != nil {
+		return writeMessage(conn, streamMessage{Type: streamTypeError, Error: err.Error()})
+	}
+
+	msgType := streamTypeUnsubscribed
+	if req.Action == "subscribe" {
+		msgType = streamTypeSubscribed
+	}
+	if err := writeMessage(conn, streamMessage{Type: msgType, Subscription: &req}); err != nil {
+		return err
+	}
+
+	if req.Action == "subscribe" && req.TransferID != "" {
+		return s.writeSnapshotMessages(conn, ctx, []string{req.TransferID})
+	}
+	return nil
+}
+
+// writeSnapshotMessages writes the current state of the given transfers
+func (s *Server) writeSnapshotMessages(conn *websocket.Conn, ctx context.Context, ids []string) error {
+	for _, update := range s.streamSnapshot(ctx, ids) {
+		update := update
+		if err := writeMessage(conn, streamMessage{Type: streamTypeTransfer, Transfer: &update}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeMessage writes a message as a JSON text frame
+func writeMessage(conn *websocket.Conn, msg streamMessage) error {
+	conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+	return conn.WriteJSON(msg)
+}
+
+// readStreamRequests reads subscription changes from a WebSocket until the
+// connection fails or stop is closed. Pongs extend the read deadline.
+func readStreamRequests(conn *websocket.Conn, requests chan<- streamRequest, errs chan<- error, stop <-chan struct{}) {
+	conn.SetReadLimit(wsMaxMessageBytes)
+	conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+
+	for {
+		var req streamRequest
+		if err := conn.ReadJSON(&req); err != nil {
+			var syntaxErr *json.SyntaxError
+			var typeErr *json.UnmarshalTypeError
+			if !errors.As(err, &syntaxErr) && !errors.As(err, &typeErr) {
+				errs <- err
+				return
+			}
+			// Malformed requests are answered with an error, not a disconnect
+			req = streamRequest{}
+		}
+
+		select {
+		case requests <- req:
+		case <-stop:
+			return
+		}
+	}
+}
+
+// streamSnapshot returns the current state of the transfers that exist
+func (s *Server) streamSnapshot(ctx context.Context, ids []string) []models.TransferUpdate {
+	updates := make([]models.TransferUpdate, 0, len(ids))
+	for _, id := range ids {
+		transfer, err := s.store.GetTransfer(ctx, id)
+		if err != nil {
+			if !errors.Is(err, models.ErrTransferNotFound) {
+				fmt.Printf("Error getting transfer %s for stream: %v\n", id, err)
+			}
+			continue
+		}
+		updates = append(updates, models.UpdateFromTransfer(*transfer))
+	}
+	return updates
+}
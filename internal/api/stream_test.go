@@ -0,0 +1,241 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+// sseEvent is a Server-Sent Event read from a stream
+type sseEvent struct {
+	name string
+	data string
+}
+
+// readEvent reads the next event from a stream, skipping comments
+func readEvent(t *testing.T, reader *bufio.Reader) sseEvent {
+	t.Helper()
+
+	var event sseEvent
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+
+		switch {
+		case line == "" && event.name != "":
+			return event
+		case strings.HasPrefix(line, "event: "):
+			event.name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			event.data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func decodeUpdate(t *testing.T, data string) models.TransferUpdate {
+	t.Helper()
+	var update models.TransferUpdate
+	require.NoError(t, json.Unmarshal([]byte(data), &update))
+	return update
+}
+
+// newStreamServer serves a test server over HTTP. Streams are closed before
+// the HTTP server so it does not wait on them.
+func newStreamServer(t *testing.T, transfers ...types.Transfer) (*Server, *models.MemoryStateManager, *httptest.Server) {
+	server, store := newTestServer(t, transfers...)
+	httpServer := httptest.NewServer(server.Handler())
+	t.Cleanup(httpServer.Close)
+	t.Cleanup(server.Updates.Close)
+	return server, store, httpServer
+}
+
+func waitForSubscribers(t *testing.T, server *Server, count int) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		return server.Updates.Subscribers() == count
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func openEventStream(t *testing.T, url string) *bufio.Reader {
+	t.Helper()
+
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	return bufio.NewReader(resp.Body)
+}
+
+func TestStreamEvents_TransferID(t *testing.T) {
+	transfer := createTestTransfer(1)
+	server, store, httpServer := newStreamServer(t, transfer)
+
+	reader := openEventStream(t, httpServer.URL+"/api/v1/transfers/stream?transfer_id="+transfer.ID)
+
+	// The stream starts with the current state of the transfer
+	event := readEvent(t, reader)
+	assert.Equal(t, "transfer", event.name)
+	assert.Equal(t, types.StatusPending, decodeUpdate(t, event.data).Status)
+
+	// Updates to other transfers are not delivered
+	other := models.UpdateFromTransfer(createTestTransfer(2))
+	other.Sender = "0x0000000000000000000000000000000000000001"
+	other.Recipient = other.Sender
+	server.Updates.Publish(other)
+
+	update := models.UpdateFromTransfer(transfer)
+	update.Status = types.StatusConfirming
+	update.Confirmations = 3
+	server.Updates.Publish(update)
+
+	event = readEvent(t, reader)
+	received := decodeUpdate(t, event.data)
+	assert.Equal(t, transfer.ID, received.TransferID)
+	assert.Equal(t, types.StatusConfirming, received.Status)
+	assert.Equal(t, uint64(3), received.Confirmations)
+
+	// A resync resends the stored state before announcing the resync
+	require.NoError(t, store.UpdateTransferStatus(context.Background(), transfer.ID, types.StatusSigned))
+	server.Updates.Resync()
+
+	event = readEvent(t, reader)
+	assert.Equal(t, types.StatusSigned, decodeUpdate(t, event.data).Status)
+	assert.Equal(t, "resync", readEvent(t, reader).name)
+
+	// Closing the broker ends the stream
+	server.Updates.Close()
+	_, err := reader.ReadString('\n')
+	assert.Error(t, err)
+}
+
+func TestStreamEvents_Address(t *testing.T) {
+	transfer := createTestTransfer(1)
+	server, _, httpServer := newStreamServer(t)
+
+	reader := openEventStream(t, httpServer.URL+"/api/v1/transfers/stream?recipient="+strings.ToUpper(transfer.Recipient))
+	waitForSubscribers(t, server, 1)
+
+	server.Updates.Publish(models.UpdateFromTransfer(transfer))
+
+	event := readEvent(t, reader)
+	assert.Equal(t, "transfer", event.name)
+	assert.Equal(t, transfer.ID, decodeUpdate(t, event.data).TransferID)
+}
+
+func TestStreamEvents_InvalidFilters(t *testing.T) {
+	server, _ := newTestServer(t)
+
+	for _, query := range []string{"", "?transfer_id=0x123", "?sender="} {
+		rec := doRequest(t, server, "/api/v1/transfers/stream"+query)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+	}
+}
+
+func dialStream(t *testing.T, httpServer *httptest.Server, query string) *websocket.Conn {
+	t.Helper()
+
+	url := "ws" + strings.TrimPrefix(httpServer.URL, "http") + "/api/v1/transfers/ws" + query
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func readMessage(t *testing.T, conn *websocket.Conn) streamMessage {
+	t.Helper()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+
+	var msg streamMessage
+	require.NoError(t, conn.ReadJSON(&msg))
+	return msg
+}
+
+func TestStreamWebSocket(t *testing.T) {
+	first := createTestTransfer(1)
+	second := createTestTransfer(2)
+	server, _, httpServer := newStreamServer(t, first, second)
+
+	conn := dialStream(t, httpServer, "?transfer_id="+first.ID)
+
+	msg := readMessage(t, conn)
+	require.Equal(t, "transfer", msg.Type)
+	assert.Equal(t, first.ID, msg.Transfer.TransferID)
+
+	// Subscribing to another transfer is acknowledged, then sends its state
+	require.NoError(t, conn.WriteJSON(streamRequest{Action: "subscribe", TransferID: second.ID}))
+	msg = readMessage(t, conn)
+	assert.Equal(t, "subscribed", msg.Type)
+	assert.Equal(t, second.ID, msg.Subscription.TransferID)
+	msg = readMessage(t, conn)
+	assert.Equal(t, second.ID, msg.Transfer.TransferID)
+
+	update := models.UpdateFromTransfer(second)
+	update.Status = types.StatusCompleted
+	update.DestinationTxHash = "0xdef"
+	server.Updates.Publish(update)
+
+	msg = readMessage(t, conn)
+	assert.Equal(t, "transfer", msg.Type)
+	assert.Equal(t, types.StatusCompleted, msg.Transfer.Status)
+	assert.Equal(t, "0xdef", msg.Transfer.DestinationTxHash)
+
+	// Invalid requests are answered without closing the stream
+	require.NoError(t, conn.WriteJSON(streamRequest{Action: "subscribe", TransferID: "0x123"}))
+	msg = readMessage(t, conn)
+	assert.Equal(t, "error", msg.Type)
+	assert.Contains(t, msg.Error, "invalid transfer_id")
+
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte("not json")))
+	assert.Equal(t, "error", readMessage(t, conn).Type)
+
+	require.NoError(t, conn.WriteJSON(streamRequest{Action: "unsubscribe", TransferID: first.ID}))
+	assert.Equal(t, "unsubscribed", readMessage(t, conn).Type)
+
+	server.Updates.Publish(models.UpdateFromTransfer(first))
+	server.Updates.Publish(update)
+	msg = readMessage(t, conn)
+	assert.Equal(t, second.ID, msg.Transfer.TransferID)
+
+	// Closing the broker closes the connection
+	server.Updates.Close()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+	_, _, err := conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseGoingAway), "unexpected error: %v", err)
+}
+
+func TestUpdateBroker_DropsSlowSubscribers(t *testing.T) {
+	broker := NewUpdateBroker()
+	filter := newStreamFilter()
+	require.NoError(t, filter.add(streamTransferID, createTestTransfer(1).ID))
+	sub := broker.subscribe(filter)
+
+	update := models.UpdateFromTransfer(createTestTransfer(1))
+	for i := 0; i <= streamBufferSize; i++ {
+		broker.Publish(update)
+	}
+	assert.Equal(t, 0, broker.Subscribers())
+
+	received := 0
+	for range sub.messages {
+		received++
+	}
+	assert.Equal(t, streamBufferSize, received)
+
+	// Unsubscribing a dropped stream is harmless
+	broker.unsubscribe(sub)
+}
@@ -0,0 +1,297 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+// TokenVerifier checks whether a chain's bridge contract supports a token
+type TokenVerifier interface {
+	IsTokenSupported(ctx context.Context, token string, originalChain types.ChainID) (bool, error)
+}
+
+// AddTokenVerifier registers the verifier used when tokens are added on a chain
+func (s *Server) AddTokenVerifier(chainID types.ChainID, verifier TokenVerifier) {
+	s.verifiers[chainID] = verifier
+}
+
+// tokensResponse lists supported tokens
+type tokensResponse struct {
+	Tokens []types.SupportedToken `json:"tokens"`
+}
+
+// addTokenRequest is the body of a request to add a supported token.
+// OriginalChain is set for wrapped tokens minted on the chain.
+type addTokenRequest struct {
+	ChainID       chainParam `json:"chain_id"`
+	TokenAddress  string     `json:"token_address"`
+	Name          string     `json:"name"`
+	Symbol        string     `json:"symbol"`
+	Decimals      uint8      `json:"decimals"`
+	IsNative      bool       `json:"is_native"`
+	Enabled       *bool      `json:"enabled"`
+	OriginalChain chainParam `json:"original_chain"`
+}
+
+// handleListTokens returns the enabled tokens, optionally for a single chain
+func (s *Server) handleListTokens(w http.ResponseWriter, r *http.Request) {
+	var (
+		tokens []types.SupportedToken
+		err    error
+	)
+
+	if value := r.URL.Query().Get("chain"); value != "" {
+		chainID, parseErr := types.ParseChainID(value)
+		if parseErr != nil {
+			writeError(w, http.StatusBadRequest, parseErr.Error())
+			return
+		}
+		tokens, err = s.store.GetSupportedTokens(r.Context(), chainID)
+	} else {
+		var all []types.SupportedToken
+		all, err = s.store.ListSupportedTokens(r.Context())
+		for _, token := range all {
+			if token.Enabled {
+				tokens = append(tokens, token)
+			}
+		}
+	}
+	if err != nil {
+		fmt.Printf("Error listing tokens: %v\n", err)
+		writeError(w, http.StatusInternalServerError, "failed to list tokens")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, tokensResponse{Tokens: nonNilTokens(tokens)})
+}
+
+// handleAdminListTokens returns every token, including disabled ones
+func (s *Server) handleAdminListTokens(w http.ResponseWriter, r *http.Request) {
+	tokens, err := s.store.ListSupportedTokens(r.Context())
+	if err != nil {
+		fmt.Printf("Error listing tokens: %v\n", err)
+		writeError(w, http.StatusInternalServerError, "failed to list tokens")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, tokensResponse{Tokens: nonNilTokens(tokens)})
+}
+
+// handleAddToken adds a supported token once the chain's bridge contract
+// confirms it supports the token
+func (s *Server) handleAddToken(w http.ResponseWriter, r *http.Request) {
+	var req addTokenRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodyBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+	if req.ChainID == 0 {
+		writeError(w, http.StatusBadRequest, "chain_id is required")
+		return
+	}
+	if !common.IsHexAddress(req.TokenAddress) {
+		writeError(w, http.StatusBadRequest, "invalid token address")
+		return
+	}
+
+	token := &types.SupportedToken{
+		ChainID:      types.ChainID(req.ChainID),
+		TokenAddress: common.HexToAddress(req.TokenAddress).Hex(),
+		Name:         req.Name,
+		Symbol:       req.Symbol,
+		Decimals:     req.Decimals,
+		IsNative:     req.IsNative,
+		Enabled:      req.Enabled == nil || *req.Enabled,
+	}
+
+	existing, err := s.store.ListSupportedTokens(r.Context())
+	if err != nil {
+		fmt.Printf("Error listing tokens: %v\n", err)
+		writeError(w, http.StatusInternalServerError, "failed to add token")
+		return
+	}
+	for _, other := range existing {
+		if other.ChainID == token.ChainID && other.TokenAddress == token.TokenAddress {
+			writeError(w, http.StatusConflict, "token already exists")
+			return
+		}
+	}
+
+	verifier, exists := s.verifiers[token.ChainID]
+	if !exists {
+		writeError(w, http.StatusServiceUnavailable, fmt.Sprintf("cannot verify tokens on %s", token.ChainID))
+		return
+	}
+	supported, err := verifier.IsTokenSupported(r.Context(), token.TokenAddress, types.ChainID(req.OriginalChain))
+	if err != nil {
+		fmt.Printf("Error verifying token on %s: %v\n", token.ChainID, err)
+		writeError(w, http.StatusBadGateway, "failed to verify token on chain")
+		return
+	}
+	if !supported {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("token is not supported by the %s bridge contract", token.ChainID))
+		return
+	}
+
+	audit := s.auditAssigned(r, models.AuditTokenCreated, models.AuditEntitySupportedToken, &token.ID, nil)
+	if err := s.store.AddSupportedToken(r.Context(), token, audit); err != nil {
+		if writeAuditError(w, err) {
+			return
+		}
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, token)
+}
+
+// handleEnableToken enables a supported token
+func (s *Server) handleEnableToken(w http.ResponseWriter, r *http.Request) {
+	s.setTokenEnabled(w, r, true)
+}
+
+// handleDisableToken disables a supported token
+func (s *Server) handleDisableToken(w http.ResponseWriter, r *http.Request) {
+	s.setTokenEnabled(w, r, false)
+}
+
+// setTokenEnabled enables or disables a token. Requests that change nothing
+// succeed without being audited.
+func (s *Server) setTokenEnabled(w http.ResponseWriter, r *http.Request, enabled bool) {
+	token, ok := s.tokenFromPath(w, r)
+	if !ok {
+		return
+	}
+	if token.Enabled == enabled {
+		writeJSON(w, http.StatusOK, token)
+		return
+	}
+
+	eventType := models.AuditTokenDisabled
+	if enabled {
+		eventType = models.AuditTokenEnabled
+	}
+	audit := s.audit(r, eventType, models.AuditEntitySupportedToken, strconv.Itoa(token.ID), token)
+	if err := s.store.SetTokenEnabled(r.Context(), token.ID, enabled, audit); err != nil {
+		if writeAuditError(w, err) {
+			return
+		}
+		fmt.Printf("Error updating token %d: %v\n", token.ID, err)
+		writeError(w, http.StatusInternalServerError, "failed to update token")
+		return
+	}
+
+	updated := *token
+	updated.Enabled = enabled
+
+	writeJSON(w, http.StatusOK, updated)
+}
+
+// handleDeleteToken removes a supported token
+func (s *Server) handleDeleteToken(w http.ResponseWriter, r *http.Request) {
+	token, ok := s.tokenFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	audit := s.audit(r, models.AuditTokenDeleted, models.AuditEntitySupportedToken, strconv.Itoa(token.ID), token)
+	if err := s.store.DeleteSupportedToken(r.Context(), token.ID, audit); err != nil {
+		if writeAuditError(w, err) {
+			return
+		}
+		fmt.Printf("Error deleting token %d: %v\n", token.ID, err)
+		writeError(w, http.StatusInternalServerError, "failed to delete token")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// tokenFromPath loads the token named by the {id} path value, writing an
+// error response if it cannot
+func (s *Server) tokenFromPath(w http.ResponseWriter, r *http.Request) (*types.SupportedToken, bool) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil || id <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid token ID")
+		return nil, false
+	}
+
+	token, err := s.store.GetSupportedToken(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, models.ErrTokenNotFound) {
+			writeError(w, http.StatusNotFound, "token not found")
+			return nil, false
+		}
+		fmt.Printf("Error getting token %d: %v\n", id, err)
+		writeError(w, http.StatusInternalServerError, "failed to get token")
+		return nil, false
+	}
+
+	return token, true
+}
+
+// audit returns the AuditFunc recording a change to an entity by the
+// authenticated operator, which the store writes in the transaction making
+// the change
+func (s *Server) audit(r *http.Request, eventType, entityType, entityID string, oldValue interface{}) models.AuditFunc {
+	performedBy := actorFromContext(r.Context())
+	return func(changed interface{}) (*models.AuditEntry, error) {
+		return models.NewAuditEntry(eventType, entityType, entityID, performedBy, oldValue, changed)
+	}
+}
+
+// auditAssigned is audit for an entity whose ID may only be assigned by
+// the change itself, such as a creation
+func (s *Server) auditAssigned(r *http.Request, eventType, entityType string, id *int, oldValue interface{}) models.AuditFunc {
+	performedBy := actorFromContext(r.Context())
+	return func(changed interface{}) (*models.AuditEntry, error) {
+		return models.NewAuditEntry(eventType, entityType, strconv.Itoa(*id), performedBy, oldValue, changed)
+	}
+}
+
+// writeAuditError writes the response for a change rolled back because its
+// audit entry could not be recorded, reporting whether err was one
+func writeAuditError(w http.ResponseWriter, err error) bool {
+	if !errors.Is(err, models.ErrAuditFailed) {
+		return false
+	}
+	fmt.Printf("Error recording audit entry: %v\n", err)
+	writeError(w, http.StatusInternalServerError, "failed to record audit entry")
+	return true
+}
+
+// auditChange records a change made by the authenticated operator outside
+// the store, such as an on-chain transaction, writing an error response if
+// it cannot
+func (s *Server) auditChange(w http.ResponseWriter, r *http.Request, eventType, entityType, entityID string, oldValue, newValue interface{}) bool {
+	entry, err := models.NewAuditEntry(eventType, entityType, entityID, actorFromContext(r.Context()), oldValue, newValue)
+	if err == nil {
+		err = s.store.RecordAudit(r.Context(), entry)
+	}
+	if err != nil {
+		fmt.Printf("Error recording audit entry for %s %s: %v\n", entityType, entityID, err)
+		writeError(w, http.StatusInternalServerError, "failed to record audit entry")
+		return false
+	}
+
+	return true
+}
+
+// nonNilTokens encodes an empty token list as [] rather than null
+func nonNilTokens(tokens []types.SupportedToken) []types.SupportedToken {
+	if tokens == nil {
+		return []types.SupportedToken{}
+	}
+	return tokens
+}
@@ -0,0 +1,201 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/internal/adapters"
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+var _ TokenVerifier = (*adapters.EthereumAdapter)(nil)
+
+const testAdminKey = "alice-secret"
+
+// fakeVerifier is a TokenVerifier backed by a set of lowercase token addresses
+type fakeVerifier struct {
+	supported map[string]bool
+	err       error
+	queries   []types.ChainID
+}
+
+func (f *fakeVerifier) IsTokenSupported(ctx context.Context, token string, originalChain types.ChainID) (bool, error) {
+	f.queries = append(f.queries, originalChain)
+	return f.supported[strings.ToLower(token)], f.err
+}
+
+func newTokenServer(t *testing.T) (*Server, *models.MemoryStateManager, *fakeVerifier) {
+	server, store := newTestServer(t)
+	server.adminKeys = map[string]string{"alice": testAdminKey, "bob": "bob-secret"}
+	verifier := &fakeVerifier{supported: map[string]bool{
+		"0xa0b86a33e6441e6c7d3e4c2c4c6c6c6c6c6c6c6c": true,
+		"0x00000000000000000000000000000000000000b1": true,
+	}}
+	server.AddTokenVerifier(types.ChainEthereum, verifier)
+	return server, store, verifier
+}
+
+func doAdminRequest(t *testing.T, server *Server, method, path, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if key != "" {
+		req.Header.Set("Authorization", "Bearer "+key)
+	}
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, req)
+	return rec
+}
+
+func addTokenBody(address, symbol string) string {
+	return fmt.Sprintf(`{"chain_id":"ethereum","token_address":%q,"name":"%s Token","symbol":%q,"decimals":18}`, address, symbol, symbol)
+}
+
+func addToken(t *testing.T, server *Server, address, symbol string) types.SupportedToken {
+	rec := doAdminRequest(t, server, http.MethodPost, "/api/v1/admin/tokens", testAdminKey, addTokenBody(address, symbol))
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+	var token types.SupportedToken
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &token))
+	return token
+}
+
+func listTokens(t *testing.T, server *Server, path, key string) []types.SupportedToken {
+	rec := doAdminRequest(t, server, http.MethodGet, path, key, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var body tokensResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	return body.Tokens
+}
+
+func TestTokens_AdminRequiresKey(t *testing.T) {
+	server, _, _ := newTokenServer(t)
+
+	for _, key := range []string{"", "wrong"} {
+		rec := doAdminRequest(t, server, http.MethodPost, "/api/v1/admin/tokens", key, addTokenBody("0x00000000000000000000000000000000000000b1", "BETA"))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Equal(t, `Bearer realm="admin"`, rec.Header().Get("WWW-Authenticate"))
+
+		rec = doAdminRequest(t, server, http.MethodDelete, "/api/v1/admin/tokens/1", key, "")
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	}
+
+	// Admin endpoints are closed when no keys are configured
+	server.adminKeys = nil
+	rec := doAdminRequest(t, server, http.MethodGet, "/api/v1/admin/tokens", testAdminKey, "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestTokens_AddAndList(t *testing.T) {
+	server, store, verifier := newTokenServer(t)
+
+	// Addresses are stored checksummed
+	token := addToken(t, server, "0xa0b86a33e6441e6c7d3e4c2c4c6c6c6c6c6c6c6c", "ALPHA")
+	assert.Equal(t, common.HexToAddress("0xa0b86a33e6441e6c7d3e4c2c4c6c6c6c6c6c6c6c").Hex(), token.TokenAddress)
+	assert.True(t, token.Enabled)
+	assert.Equal(t, []types.ChainID{0}, verifier.queries)
+
+	rec := doAdminRequest(t, server, http.MethodPost, "/api/v1/admin/tokens", testAdminKey,
+		`{"chain_id":1,"token_address":"0x00000000000000000000000000000000000000b1","name":"Beta","symbol":"BETA","decimals":6,"enabled":false,"original_chain":"polygon"}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	assert.Equal(t, types.ChainPolygon, verifier.queries[1])
+
+	// Public listings only show enabled tokens
+	public := listTokens(t, server, "/api/v1/tokens", "")
+	require.Len(t, public, 1)
+	assert.Equal(t, "ALPHA", public[0].Symbol)
+	assert.Len(t, listTokens(t, server, "/api/v1/tokens?chain=ethereum", ""), 1)
+	assert.Empty(t, listTokens(t, server, "/api/v1/tokens?chain=polygon", ""))
+	assert.Len(t, listTokens(t, server, "/api/v1/admin/tokens", testAdminKey), 2)
+
+	trail, err := store.GetAuditTrail(context.Background(), models.AuditEntitySupportedToken, fmt.Sprint(token.ID))
+	require.NoError(t, err)
+	require.Len(t, trail, 1)
+	assert.Equal(t, models.AuditTokenCreated, trail[0].EventType)
+	assert.Equal(t, "alice", trail[0].PerformedBy)
+	assert.JSONEq(t, "null", string(trail[0].OldValues))
+}
+
+func TestTokens_AddRejected(t *testing.T) {
+	server, store, verifier := newTokenServer(t)
+	addToken(t, server, "0x00000000000000000000000000000000000000b1", "BETA")
+
+	cases := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{"malformed", `{"chain_id":`, http.StatusBadRequest},
+		{"missing chain", `{"token_address":"0x00000000000000000000000000000000000000b1"}`, http.StatusBadRequest},
+		{"bad address", addTokenBody("0x01", "BAD"), http.StatusBadRequest},
+		{"not supported on chain", addTokenBody("0x00000000000000000000000000000000000000c1", "GAMMA"), http.StatusBadRequest},
+		{"duplicate", addTokenBody("0x00000000000000000000000000000000000000B1", "BETA"), http.StatusConflict},
+		{"no verifier", `{"chain_id":"polygon","token_address":"0x00000000000000000000000000000000000000b1","name":"Beta","symbol":"BETA"}`, http.StatusServiceUnavailable},
+		{"invalid token", `{"chain_id":1,"token_address":"0xA0b86a33E6441E6C7D3E4C2C4C6C6C6C6C6C6C6C","name":"","symbol":"ALPHA"}`, http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		rec := doAdminRequest(t, server, http.MethodPost, "/api/v1/admin/tokens", testAdminKey, tc.body)
+		assert.Equal(t, tc.status, rec.Code, tc.name)
+	}
+
+	verifier.err = errors.New("connection refused")
+	rec := doAdminRequest(t, server, http.MethodPost, "/api/v1/admin/tokens", testAdminKey, addTokenBody("0xA0b86a33E6441E6C7D3E4C2C4C6C6C6C6C6C6C6C", "ALPHA"))
+	assert.Equal(t, http.StatusBadGateway, rec.Code)
+
+	tokens, err := store.ListSupportedTokens(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, tokens, 1)
+}
+
+func TestTokens_EnableDisableDelete(t *testing.T) {
+	server, store, _ := newTokenServer(t)
+	token := addToken(t, server, "0x00000000000000000000000000000000000000b1", "BETA")
+	path := fmt.Sprintf("/api/v1/admin/tokens/%d", token.ID)
+
+	rec := doAdminRequest(t, server, http.MethodPost, path+"/disable", "bob-secret", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Empty(t, listTokens(t, server, "/api/v1/tokens", ""))
+
+	// Repeating a change is a no-op and is not audited
+	rec = doAdminRequest(t, server, http.MethodPost, path+"/disable", "bob-secret", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = doAdminRequest(t, server, http.MethodPost, path+"/enable", testAdminKey, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, listTokens(t, server, "/api/v1/tokens", ""), 1)
+
+	rec = doAdminRequest(t, server, http.MethodDelete, path, testAdminKey, "")
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Empty(t, listTokens(t, server, "/api/v1/admin/tokens", testAdminKey))
+
+	rec = doAdminRequest(t, server, http.MethodDelete, path, testAdminKey, "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	rec = doAdminRequest(t, server, http.MethodPost, "/api/v1/admin/tokens/abc/enable", testAdminKey, "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	trail, err := store.GetAuditTrail(context.Background(), models.AuditEntitySupportedToken, fmt.Sprint(token.ID))
+	require.NoError(t, err)
+	require.Len(t, trail, 4)
+	assert.Equal(t, models.AuditTokenDisabled, trail[1].EventType)
+	assert.Equal(t, "bob", trail[1].PerformedBy)
+	assert.Equal(t, models.AuditTokenEnabled, trail[2].EventType)
+	assert.Equal(t, models.AuditTokenDeleted, trail[3].EventType)
+	assert.JSONEq(t, "null", string(trail[3].NewValues))
+}
+
+func TestTokens_ListBadChain(t *testing.T) {
+	server, _, _ := newTokenServer(t)
+
+	rec := doRequest(t, server, "/api/v1/tokens?chain=mars")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
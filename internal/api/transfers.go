@@ -0,0 +1,155 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+// transfersResponse lists the transfers sharing a transaction hash
+type transfersResponse struct {
+	Transfers []types.Transfer `json:"transfers"`
+}
+
+// handleGetTransfer returns a single transfer by ID
+func (s *Server) handleGetTransfer(w http.ResponseWriter, r *http.Request) {
+	transfer, err := s.store.GetTransfer(r.Context(), r.PathValue("id"))
+	if err != nil {
+		if errors.Is(err, models.ErrTransferNotFound) {
+			writeError(w, http.StatusNotFound, "transfer not found")
+			return
+		}
+		fmt.Printf("Error getting transfer: %v\n", err)
+		writeError(w, http.StatusInternalServerError, "failed to get transfer")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, transfer)
+}
+
+// handleListTransfers returns a page of transfers matching the query filters
+func (s *Server) handleListTransfers(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseTransferFilter(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, err := s.store.SearchTransfers(r.Context(), filter)
+	if err != nil {
+		fmt.Printf("Error searching transfers: %v\n", err)
+		writeError(w, http.StatusInternalServerError, "failed to search transfers")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, page)
+}
+
+// handleGetTransfersByTx returns the transfers whose source or destination
+// transaction has the given hash. A single source transaction may lock
+// several transfers.
+func (s *Server) handleGetTransfersByTx(w http.ResponseWriter, r *http.Request) {
+	hash := r.PathValue("hash")
+	if !isHexHash(hash) {
+		writeError(w, http.StatusBadRequest, "invalid transaction hash")
+		return
+	}
+
+	page, err := s.store.SearchTransfers(r.Context(), models.TransferFilter{
+		TxHash: hash,
+		Limit:  models.MaxTransferPageSize,
+	})
+	if err != nil {
+		fmt.Printf("Error searching transfers by tx: %v\n", err)
+		writeError(w, http.StatusInternalServerError, "failed to search transfers")
+		return
+	}
+
+	if len(page.Transfers) == 0 {
+		writeError(w, http.StatusNotFound, "no transfers found for transaction")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, transfersResponse{Transfers: page.Transfers})
+}
+
+// parseTransferFilter builds a transfer filter from query parameters
+func parseTransferFilter(query url.Values) (models.TransferFilter, error) {
+	filter := models.TransferFilter{
+		Sender:    query.Get("sender"),
+		Recipient: query.Get("recipient"),
+		Token:     query.Get("token"),
+		Cursor:    query.Get("cursor"),
+	}
+
+	if status := query.Get("status"); status != "" {
+		filter.Status = types.TransferStatus(status)
+		if !filter.Status.IsValid() {
+			return filter, fmt.Errorf("invalid status: %s", status)
+		}
+	}
+
+	chains := []struct {
+		param  string
+		target *types.ChainID
+	}{
+		{"chain", &filter.Chain},
+		{"source_chain", &filter.SourceChain},
+		{"destination_chain", &filter.DestinationChain},
+	}
+	for _, chain := range chains {
+		if value := query.Get(chain.param); value != "" {
+			chainID, err := types.ParseChainID(value)
+			if err != nil {
+				return filter, fmt.Errorf("invalid %s: %s", chain.param, value)
+			}
+			*chain.target = chainID
+		}
+	}
+
+	times := []struct {
+		param  string
+		target *time.Time
+	}{
+		{"created_after", &filter.CreatedAfter},
+		{"created_before", &filter.CreatedBefore},
+	}
+	for _, bound := range times {
+		if value := query.Get(bound.param); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return filter, fmt.Errorf("invalid %s: expected RFC3339 time", bound.param)
+			}
+			*bound.target = parsed.UTC()
+		}
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil || parsed <= 0 {
+			return filter, fmt.Errorf("invalid limit: %s", limit)
+		}
+		filter.Limit = parsed
+	}
+
+	return filter, filter.Validate()
+}
+
+// isHexHash reports whether a value is a 0x-prefixed 32-byte hex hash
+func isHexHash(value string) bool {
+	if len(value) != 66 || value[:2] != "0x" {
+		return false
+	}
+	for _, c := range value[2:] {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
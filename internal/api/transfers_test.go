@@ -0,0 +1,172 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/internal/config"
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+func createTestTransfer(n int) types.Transfer {
+	return types.Transfer{
+		ID:               fmt.Sprintf("0x%064x", n),
+		SourceChain:      types.ChainEthereum,
+		DestinationChain: types.ChainPolygon,
+		Token:            "0xA0b86a33E6441E6C7D3E4C2C4C6C6C6C6C6C6C6C",
+		Amount:           types.NewBigInt(big.NewInt(1000000000000000000)),
+		Sender:           "0x742d35Cc6634C0532925a3b8D4C9db96590C4C4C",
+		Recipient:        "0x8ba1f109551bD432803012645Hac136c22C4C4C",
+		Status:           types.StatusPending,
+		SourceTxHash:     fmt.Sprintf("0x%064x", 1000+n),
+	}
+}
+
+func newTestServer(t *testing.T, transfers ...types.Transfer) (*Server, *models.MemoryStateManager) {
+	store := models.NewMemoryStateManager()
+	for _, transfer := range transfers {
+		require.NoError(t, store.RecordTransfer(context.Background(), transfer))
+	}
+	return NewServer(config.APIConfig{Port: "0", ReadTimeout: time.Second}, store), store
+}
+
+func doRequest(t *testing.T, server *Server, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestGetTransfer(t *testing.T) {
+	transfer := createTestTransfer(1)
+	server, _ := newTestServer(t, transfer)
+
+	rec := doRequest(t, server, "/api/v1/transfers/"+transfer.ID)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	// Amounts are encoded as decimal strings
+	var raw map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &raw))
+	assert.Equal(t, "1000000000000000000", raw["amount"])
+	assert.Equal(t, transfer.ID, raw["id"])
+
+	rec = doRequest(t, server, "/api/v1/transfers/"+createTestTransfer(2).ID)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestListTransfers_Filters(t *testing.T) {
+	first := createTestTransfer(1)
+	second := createTestTransfer(2)
+	second.Status = types.StatusCompleted
+	second.SourceChain = types.ChainPolygon
+	second.DestinationChain = types.ChainEthereum
+	server, _ := newTestServer(t, first, second)
+
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		{"", []string{second.ID, first.ID}},
+		{"?status=completed", []string{second.ID}},
+		{"?source_chain=ethereum", []string{first.ID}},
+		{"?chain=137", []string{second.ID, first.ID}},
+		{"?sender=" + first.Sender, []string{second.ID, first.ID}},
+		{"?token=0x0000000000000000000000000000000000000000", []string{}},
+		{"?created_after=" + time.Now().Add(time.Hour).UTC().Format(time.RFC3339), []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			rec := doRequest(t, server, "/api/v1/transfers"+tt.query)
+			require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+			var page models.TransferPage
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+			ids := []string{}
+			for _, transfer := range page.Transfers {
+				ids = append(ids, transfer.ID)
+			}
+			assert.Equal(t, tt.expected, ids)
+		})
+	}
+}
+
+func TestListTransfers_Pagination(t *testing.T) {
+	server, _ := newTestServer(t, createTestTransfer(1), createTestTransfer(2), createTestTransfer(3))
+
+	rec := doRequest(t, server, "/api/v1/transfers?limit=2")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var page models.TransferPage
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+	require.Len(t, page.Transfers, 2)
+	require.NotEmpty(t, page.NextCursor)
+
+	rec = doRequest(t, server, "/api/v1/transfers?limit=2&cursor="+page.NextCursor)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var next models.TransferPage
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &next))
+	require.Len(t, next.Transfers, 1)
+	assert.Equal(t, createTestTransfer(1).ID, next.Transfers[0].ID)
+	assert.Empty(t, next.NextCursor)
+}
+
+func TestListTransfers_InvalidParameters(t *testing.T) {
+	server, _ := newTestServer(t)
+
+	for _, query := range []string{
+		"?status=unknown",
+		"?chain=solana",
+		"?created_after=yesterday",
+		"?limit=-1",
+		"?cursor=%25%25",
+	} {
+		t.Run(query, func(t *testing.T) {
+			rec := doRequest(t, server, "/api/v1/transfers"+query)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Contains(t, rec.Body.String(), `"error"`)
+		})
+	}
+}
+
+func TestGetTransfersByTx(t *testing.T) {
+	transfer := createTestTransfer(1)
+	server, store := newTestServer(t, transfer)
+	destinationTx := fmt.Sprintf("0x%064x", 9999)
+	require.NoError(t, store.MarkTransferComplete(context.Background(), transfer.ID, destinationTx))
+
+	for _, hash := range []string{transfer.SourceTxHash, destinationTx} {
+		rec := doRequest(t, server, "/api/v1/transfers/by-tx/"+hash)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var body transfersResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		require.Len(t, body.Transfers, 1)
+		assert.Equal(t, transfer.ID, body.Transfers[0].ID)
+	}
+
+	rec := doRequest(t, server, "/api/v1/transfers/by-tx/"+fmt.Sprintf("0x%064x", 1))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = doRequest(t, server, "/api/v1/transfers/by-tx/not-a-hash")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	server, _ := newTestServer(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/transfers", nil)
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
@@ -0,0 +1,293 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/internal/webhooks"
+	"nexus-bridge/pkg/types"
+)
+
+const (
+	// defaultDeliveryLimit is the page size of a delivery listing
+	defaultDeliveryLimit = 50
+	// maxDeliveryLimit bounds the page size of a delivery listing
+	maxDeliveryLimit = 500
+)
+
+// createWebhookRequest is the body of a request to register a webhook.
+// Scope fields left empty match every transfer.
+type createWebhookRequest struct {
+	URL              string     `json:"url"`
+	Address          string     `json:"address"`
+	Token            string     `json:"token"`
+	SourceChain      chainParam `json:"source_chain"`
+	DestinationChain chainParam `json:"destination_chain"`
+	Events           []string   `json:"events"`
+	Enabled          *bool      `json:"enabled"`
+}
+
+// createdWebhookResponse is a newly registered webhook. Its signing secret
+// is only ever returned here.
+type createdWebhookResponse struct {
+	*models.Webhook
+	Secret string `json:"secret"`
+}
+
+// webhooksResponse lists registered webhooks
+type webhooksResponse struct {
+	Webhooks []models.Webhook `json:"webhooks"`
+}
+
+// deliveriesResponse lists a webhook's deliveries
+type deliveriesResponse struct {
+	Deliveries []models.WebhookDelivery `json:"deliveries"`
+}
+
+// handleCreateWebhook registers a webhook with a generated signing secret
+func (s *Server) handleCreateWebhook(w http.ResponseWriter, r *http.Request) {
+	if !s.webhooksAvailable(w) {
+		return
+	}
+
+	var req createWebhookRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodyBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+	if req.Address != "" && !common.IsHexAddress(req.Address) {
+		writeError(w, http.StatusBadRequest, "invalid address")
+		return
+	}
+	if req.Token != "" && !common.IsHexAddress(req.Token) {
+		writeError(w, http.StatusBadRequest, "invalid token address")
+		return
+	}
+
+	secret, err := webhooks.GenerateSecret()
+	if err != nil {
+		fmt.Printf("Error creating webhook: %v\n", err)
+		writeError(w, http.StatusInternalServerError, "failed to create webhook")
+		return
+	}
+
+	webhook := &models.Webhook{
+		URL:              req.URL,
+		Secret:           secret,
+		Address:          req.Address,
+		Token:            req.Token,
+		SourceChain:      types.ChainID(req.SourceChain),
+		DestinationChain: types.ChainID(req.DestinationChain),
+		Events:           req.Events,
+		Enabled:          req.Enabled == nil || *req.Enabled,
+		CreatedBy:        actorFromContext(r.Context()),
+	}
+	webhook.Owner, _ = webhookOwner(r.Context())
+	if err := webhook.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	audit := s.auditAssigned(r, models.AuditWebhookCreated, models.AuditEntityWebhook, &webhook.ID, nil)
+	if err := s.Webhooks.CreateWebhook(r.Context(), webhook, audit); err != nil {
+		if writeAuditError(w, err) {
+			return
+		}
+		fmt.Printf("Error creating webhook: %v\n", err)
+		writeError(w, http.StatusInternalServerError, "failed to create webhook")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, createdWebhookResponse{Webhook: webhook, Secret: secret})
+}
+
+// handleListWebhooks returns every registered webhook, or only the
+// caller's on their own routes
+func (s *Server) handleListWebhooks(w http.ResponseWriter, r *http.Request) {
+	if !s.webhooksAvailable(w) {
+		return
+	}
+
+	list, err := s.Webhooks.ListWebhooks(r.Context())
+	if err != nil {
+		fmt.Printf("Error listing webhooks: %v\n", err)
+		writeError(w, http.StatusInternalServerError, "failed to list webhooks")
+		return
+	}
+	owned := []models.Webhook{}
+	for _, webhook := range list {
+		if webhookInScope(r.Context(), webhook) {
+			owned = append(owned, webhook)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, webhooksResponse{Webhooks: owned})
+}
+
+// handleGetWebhook returns a registered webhook
+func (s *Server) handleGetWebhook(w http.ResponseWriter, r *http.Request) {
+	webhook, ok := s.webhookFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, webhook)
+}
+
+// handleDeleteWebhook removes a webhook along with its deliveries
+func (s *Server) handleDeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	webhook, ok := s.webhookFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	audit := s.audit(r, models.AuditWebhookDeleted, models.AuditEntityWebhook, strconv.Itoa(webhook.ID), webhook)
+	if err := s.Webhooks.DeleteWebhook(r.Context(), webhook.ID, audit); err != nil {
+		if writeAuditError(w, err) {
+			return
+		}
+		fmt.Printf("Error deleting webhook %d: %v\n", webhook.ID, err)
+		writeError(w, http.StatusInternalServerError, "failed to delete webhook")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleListDeliveries returns a webhook's deliveries, newest first,
+// optionally in a single status
+func (s *Server) handleListDeliveries(w http.ResponseWriter, r *http.Request) {
+	webhook, ok := s.webhookFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	status := models.DeliveryStatus(query.Get("status"))
+	if status != "" && !status.IsValid() {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid status: %s", status))
+		return
+	}
+	limit := defaultDeliveryLimit
+	if value := query.Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 || parsed > maxDeliveryLimit {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid limit: %s", value))
+			return
+		}
+		limit = parsed
+	}
+
+	deliveries, err := s.Webhooks.ListDeliveries(r.Context(), webhook.ID, status, limit)
+	if err != nil {
+		fmt.Printf("Error listing deliveries of webhook %d: %v\n", webhook.ID, err)
+		writeError(w, http.StatusInternalServerError, "failed to list deliveries")
+		return
+	}
+	if deliveries == nil {
+		deliveries = []models.WebhookDelivery{}
+	}
+
+	writeJSON(w, http.StatusOK, deliveriesResponse{Deliveries: deliveries})
+}
+
+// handleReplayDelivery queues a delivery to be sent again with a fresh set
+// of attempts, typically after it was dead-lettered
+func (s *Server) handleReplayDelivery(w http.ResponseWriter, r *http.Request) {
+	webhook, ok := s.webhookFromPath(w, r)
+	if !ok {
+		return
+	}
+	deliveryID, err := strconv.ParseInt(r.PathValue("delivery"), 10, 64)
+	if err != nil || deliveryID <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid delivery ID")
+		return
+	}
+
+	entityID := strconv.FormatInt(deliveryID, 10)
+	audit := s.audit(r, models.AuditWebhookDeliveryReplayed, models.AuditEntityWebhookDelivery, entityID, nil)
+	delivery, err := s.Webhooks.ReplayDelivery(r.Context(), webhook.ID, deliveryID, audit)
+	if err != nil {
+		if errors.Is(err, models.ErrDeliveryNotFound) {
+			writeError(w, http.StatusNotFound, "delivery not found")
+			return
+		}
+		if writeAuditError(w, err) {
+			return
+		}
+		fmt.Printf("Error replaying delivery %d: %v\n", deliveryID, err)
+		writeError(w, http.StatusInternalServerError, "failed to replay delivery")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, delivery)
+}
+
+// webhookFromPath loads the webhook named by the {id} path value, writing an
+// error response if it cannot
+func (s *Server) webhookFromPath(w http.ResponseWriter, r *http.Request) (*models.Webhook, bool) {
+	if !s.webhooksAvailable(w) {
+		return nil, false
+	}
+
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil || id <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid webhook ID")
+		return nil, false
+	}
+
+	webhook, err := s.Webhooks.GetWebhook(r.Context(), id)
+	if err != nil && !errors.Is(err, models.ErrWebhookNotFound) {
+		fmt.Printf("Error getting webhook %d: %v\n", id, err)
+		writeError(w, http.StatusInternalServerError, "failed to get webhook")
+		return nil, false
+	}
+	// Webhooks of other integrators are not revealed to exist
+	if err != nil || !webhookInScope(r.Context(), *webhook) {
+		writeError(w, http.StatusNotFound, "webhook not found")
+		return nil, false
+	}
+
+	return webhook, true
+}
+
+// ownWebhooks scopes a webhook handler to the webhooks owned by the caller,
+// so integrators manage their own webhooks without seeing anyone else's
+func ownWebhooks(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), webhookOwnerKey, ownerFromContext(r.Context()))
+		next(w, r.WithContext(ctx))
+	}
+}
+
+// webhookOwner returns the owner a request's webhook handler is scoped to,
+// if any
+func webhookOwner(ctx context.Context) (string, bool) {
+	owner, scoped := ctx.Value(webhookOwnerKey).(string)
+	return owner, scoped
+}
+
+// webhookInScope reports whether a request may manage a webhook
+func webhookInScope(ctx context.Context, webhook models.Webhook) bool {
+	owner, scoped := webhookOwner(ctx)
+	return !scoped || webhook.Owner == owner
+}
+
+// webhooksAvailable writes an error response if no webhook store is
+// configured
+func (s *Server) webhooksAvailable(w http.ResponseWriter) bool {
+	if s.Webhooks == nil {
+		writeError(w, http.StatusServiceUnavailable, "webhooks are not available")
+		return false
+	}
+	return true
+}
@@ -0,0 +1,254 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+func newWebhookServer(t *testing.T) (*Server, *models.MemoryStateManager) {
+	server, store := newTestServer(t)
+	server.adminKeys = map[string]string{"alice": testAdminKey}
+	server.Webhooks = store
+	return server, store
+}
+
+func createWebhook(t *testing.T, server *Server, body string) createdWebhookResponse {
+	rec := doAdminRequest(t, server, http.MethodPost, "/api/v1/admin/webhooks", testAdminKey, body)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+	created := createdWebhookResponse{Webhook: &models.Webhook{}}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+	return created
+}
+
+func TestCreateWebhook(t *testing.T) {
+	server, store := newWebhookServer(t)
+
+	created := createWebhook(t, server, `{"url":"https://example.com/hook","source_chain":"ethereum","events":["transfer.completed"]}`)
+	assert.True(t, strings.HasPrefix(created.Secret, "whsec_"))
+	assert.Equal(t, "https://example.com/hook", created.URL)
+	assert.Equal(t, types.ChainEthereum, created.SourceChain)
+	assert.Equal(t, []string{"transfer.completed"}, []string(created.Events))
+	assert.True(t, created.Enabled)
+	assert.Equal(t, "alice", created.CreatedBy)
+
+	// The secret is stored for signing but never returned again
+	rec := doAdminRequest(t, server, http.MethodGet, fmt.Sprintf("/api/v1/admin/webhooks/%d", created.ID), testAdminKey, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.NotContains(t, rec.Body.String(), created.Secret)
+	stored, err := store.GetWebhook(context.Background(), created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, created.Secret, stored.Secret)
+
+	trail, err := store.GetAuditTrail(context.Background(), models.AuditEntityWebhook, fmt.Sprint(created.ID))
+	require.NoError(t, err)
+	require.Len(t, trail, 1)
+	assert.Equal(t, models.AuditWebhookCreated, trail[0].EventType)
+	assert.Equal(t, "alice", trail[0].PerformedBy)
+	assert.NotContains(t, string(trail[0].NewValues), created.Secret)
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"MissingURL", `{}`},
+		{"RelativeURL", `{"url":"/hook"}`},
+		{"InvalidAddress", `{"url":"https://example.com","address":"alice"}`},
+		{"InvalidToken", `{"url":"https://example.com","token":"0x1234"}`},
+		{"SameChains", `{"url":"https://example.com","source_chain":"ethereum","destination_chain":1}`},
+		{"UnknownEvent", `{"url":"https://example.com","events":["transfer.done"]}`},
+		{"UnknownField", `{"url":"https://example.com","secret":"mine"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := doAdminRequest(t, server, http.MethodPost, "/api/v1/admin/webhooks", testAdminKey, tt.body)
+			assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+		})
+	}
+}
+
+func TestWebhookEndpoints_RequireAdmin(t *testing.T) {
+	server, _ := newWebhookServer(t)
+
+	rec := doAdminRequest(t, server, http.MethodGet, "/api/v1/admin/webhooks", "", "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	rec = doAdminRequest(t, server, http.MethodPost, "/api/v1/admin/webhooks", "wrong", `{"url":"https://example.com"}`)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestWebhooks_IntegratorsManageTheirOwn(t *testing.T) {
+	server, store := newWebhookServer(t)
+	server.APIKeys = store
+	server.jwtSecret = []byte(testJWTSecret)
+	acme := issueKey(t, store, "acme", models.RoleIntegrator, 0)
+	rotated := issueKey(t, store, "acme", models.RoleIntegrator, 0)
+	other := issueKey(t, store, "other", models.RoleIntegrator, 0)
+	impostor := signToken(t, jwt.SigningMethodHS256, testJWTSecret, tokenClaims("acme", models.RoleIntegrator, time.Hour))
+	operators := createWebhook(t, server, `{"url":"https://example.com/ops"}`)
+
+	rec := doAdminRequest(t, server, http.MethodPost, "/api/v1/webhooks", acme, `{"url":"https://acme.example/hook"}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	created := createdWebhookResponse{Webhook: &models.Webhook{}}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+	assert.Equal(t, "key:acme", created.Owner)
+	assert.Equal(t, "acme", created.CreatedBy)
+
+	listOwn := func(key string) []models.Webhook {
+		rec := doAdminRequest(t, server, http.MethodGet, "/api/v1/webhooks", key, "")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var body webhooksResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		return body.Webhooks
+	}
+	require.Len(t, listOwn(rotated), 1, "keys sharing a name share their webhooks")
+	assert.Equal(t, created.ID, listOwn(acme)[0].ID)
+	assert.Empty(t, listOwn(other))
+	assert.Empty(t, listOwn(impostor), "a token subject does not match a key name")
+
+	// Webhooks of others are not found, whoever registered them
+	for _, path := range []string{
+		fmt.Sprintf("/api/v1/webhooks/%d", created.ID),
+		fmt.Sprintf("/api/v1/webhooks/%d/deliveries", created.ID),
+	} {
+		rec = doAdminRequest(t, server, http.MethodGet, path, other, "")
+		assert.Equal(t, http.StatusNotFound, rec.Code, path)
+	}
+	rec = doAdminRequest(t, server, http.MethodGet, fmt.Sprintf("/api/v1/webhooks/%d", operators.ID), acme, "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	rec = doAdminRequest(t, server, http.MethodDelete, fmt.Sprintf("/api/v1/webhooks/%d", created.ID), other, "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	// Integrators stay out of the operators' routes, which see every webhook
+	rec = doAdminRequest(t, server, http.MethodGet, "/api/v1/admin/webhooks", acme, "")
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	rec = doAdminRequest(t, server, http.MethodGet, "/api/v1/webhooks", "", "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	rec = doAdminRequest(t, server, http.MethodGet, "/api/v1/admin/webhooks", testAdminKey, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var all webhooksResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &all))
+	assert.Len(t, all.Webhooks, 2)
+
+	rec = doAdminRequest(t, server, http.MethodGet, fmt.Sprintf("/api/v1/webhooks/%d/deliveries", created.ID), acme, "")
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	rec = doAdminRequest(t, server, http.MethodDelete, fmt.Sprintf("/api/v1/webhooks/%d", created.ID), rotated, "")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Empty(t, listOwn(acme))
+}
+
+func TestWebhookEndpoints_Unavailable(t *testing.T) {
+	server, _ := newWebhookServer(t)
+	server.Webhooks = nil
+
+	rec := doAdminRequest(t, server, http.MethodGet, "/api/v1/admin/webhooks", testAdminKey, "")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	rec = doAdminRequest(t, server, http.MethodGet, "/api/v1/admin/webhooks/1", testAdminKey, "")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
+
+func TestListAndDeleteWebhooks(t *testing.T) {
+	server, store := newWebhookServer(t)
+
+	rec := doAdminRequest(t, server, http.MethodGet, "/api/v1/admin/webhooks", testAdminKey, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"webhooks":[]}`, rec.Body.String())
+
+	first := createWebhook(t, server, `{"url":"https://example.com/one"}`)
+	second := createWebhook(t, server, `{"url":"https://example.com/two","enabled":false}`)
+
+	rec = doAdminRequest(t, server, http.MethodGet, "/api/v1/admin/webhooks", testAdminKey, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body webhooksResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Len(t, body.Webhooks, 2)
+	assert.Equal(t, first.ID, body.Webhooks[0].ID)
+	assert.False(t, body.Webhooks[1].Enabled)
+	assert.NotContains(t, rec.Body.String(), "whsec_")
+
+	path := fmt.Sprintf("/api/v1/admin/webhooks/%d", second.ID)
+	rec = doAdminRequest(t, server, http.MethodDelete, path, testAdminKey, "")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	rec = doAdminRequest(t, server, http.MethodDelete, path, testAdminKey, "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	rec = doAdminRequest(t, server, http.MethodGet, "/api/v1/admin/webhooks/abc", testAdminKey, "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	trail, err := store.GetAuditTrail(context.Background(), models.AuditEntityWebhook, fmt.Sprint(second.ID))
+	require.NoError(t, err)
+	require.Len(t, trail, 2)
+	assert.Equal(t, models.AuditWebhookDeleted, trail[1].EventType)
+}
+
+func TestWebhookDeliveries(t *testing.T) {
+	server, store := newWebhookServer(t)
+	ctx := context.Background()
+	webhook := createWebhook(t, server, `{"url":"https://example.com/hook"}`)
+
+	transfer := createTestTransfer(1)
+	require.NoError(t, store.RecordTransfer(ctx, transfer))
+	require.NoError(t, store.MarkTransferComplete(ctx, transfer.ID, "0xdest"))
+
+	// Dead-letter the oldest delivery
+	claimed, err := store.ClaimDeliveries(ctx, 1, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	require.NoError(t, store.MarkDeliveryFailed(ctx, claimed[0].ID, 500, "server error", time.Time{}))
+
+	path := fmt.Sprintf("/api/v1/admin/webhooks/%d/deliveries", webhook.ID)
+	listDeliveries := func(query string) []models.WebhookDelivery {
+		rec := doAdminRequest(t, server, http.MethodGet, path+query, testAdminKey, "")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var body deliveriesResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		return body.Deliveries
+	}
+
+	all := listDeliveries("")
+	require.Len(t, all, 2)
+	assert.Equal(t, "transfer.completed", all[0].EventType)
+	assert.Len(t, listDeliveries("?limit=1"), 1)
+
+	dead := listDeliveries("?status=dead")
+	require.Len(t, dead, 1)
+	assert.Equal(t, claimed[0].ID, dead[0].ID)
+	assert.Equal(t, "server error", dead[0].LastError)
+
+	for _, query := range []string{"?status=lost", "?limit=0", "?limit=1000"} {
+		rec := doAdminRequest(t, server, http.MethodGet, path+query, testAdminKey, "")
+		assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+	}
+
+	replayPath := fmt.Sprintf("%s/%d/replay", path, dead[0].ID)
+	rec := doAdminRequest(t, server, http.MethodPost, replayPath, testAdminKey, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var replayed models.WebhookDelivery
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &replayed))
+	assert.Equal(t, models.DeliveryPending, replayed.Status)
+	assert.Equal(t, 0, replayed.Attempts)
+	assert.Empty(t, listDeliveries("?status=dead"))
+
+	trail, err := store.GetAuditTrail(ctx, models.AuditEntityWebhookDelivery, fmt.Sprint(dead[0].ID))
+	require.NoError(t, err)
+	require.Len(t, trail, 1)
+	assert.Equal(t, models.AuditWebhookDeliveryReplayed, trail[0].EventType)
+
+	rec = doAdminRequest(t, server, http.MethodPost, fmt.Sprintf("%s/%d/replay", path, 999), testAdminKey, "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	rec = doAdminRequest(t, server, http.MethodPost, path+"/abc/replay", testAdminKey, "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	rec = doAdminRequest(t, server, http.MethodGet, "/api/v1/admin/webhooks/999/deliveries", testAdminKey, "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -3,7 +3,10 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 	"time"
+
+	"nexus-bridge/pkg/types"
 )
 
 // Config holds all configuration for the application
@@ -12,6 +15,10 @@ type Config struct {
 	Chains   map[string]ChainConfig
 	API      APIConfig
 	Relayer  RelayerConfig
+	Fees     FeeConfig
+	Webhooks WebhookConfig
+	Supply   SupplyConfig
+	Guardian GuardianConfig
 	Logging  LoggingConfig
 }
 
@@ -34,15 +41,48 @@ type ChainConfig struct {
 	RequiredConfirmations uint64
 	BlockTime             time.Duration
 	GasLimit              uint64
+	ExecuteMethod         string // bridge method completing transfers: unlockTokens or mintTokens
 	Enabled               bool
 }
 
+// AdapterConfig returns the chain configuration used by chain adapters
+func (c ChainConfig) AdapterConfig() types.ChainConfig {
+	return types.ChainConfig{
+		ChainID:               types.ChainID(c.ChainID),
+		Name:                  c.Name,
+		Type:                  types.ChainType(c.Type),
+		RPC:                   c.RPCURL,
+		WSS:                   c.WSSURL,
+		BridgeContract:        c.BridgeContract,
+		RequiredConfirmations: c.RequiredConfirmations,
+		BlockTime:             c.BlockTime,
+		GasLimit:              c.GasLimit,
+		Enabled:               c.Enabled,
+	}
+}
+
 // APIConfig holds API server configuration
 type APIConfig struct {
 	Port         string
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
 	IdleTimeout  time.Duration
+	AdminKeys    map[string]string // operator name -> admin API key
+	ReviewQuorum int               // distinct operators needed to decide a review
+	JWTSecret    string            // HMAC key verifying role tokens; empty rejects every token
+	TrustProxy   bool              // take client addresses from X-Forwarded-For
+	RateLimits   RateLimitConfig
+}
+
+// RateLimitConfig holds per-role request limits in requests a minute. A limit
+// of zero leaves the role unlimited.
+type RateLimitConfig struct {
+	Backend    string // memory or redis
+	RedisURL   string
+	Public     int
+	Integrator int
+	Operator   int
+	Admin      int
 }
 
 // RelayerConfig holds relayer-specific configuration
@@ -51,6 +91,50 @@ type RelayerConfig struct {
 	PrivateKey        string
 	SignatureThreshold uint64
 	RelayerCount      uint64
+	RulesFile         string // JSON rules holding suspicious transfers for review
+	RulesDryRun       bool   // log rule matches without holding transfers
+	Denylists         string // comma-separated CSV or JSON denylist files, read again when they change
+	TransferDelay     time.Duration // how long transfers above a delay threshold wait before execution
+	RequiredWeight    int           // threshold_weight ready signatures must sum to; 0 matches the required signatures
+	ReviewOverCap     bool          // review transfers not fitting a cap's remaining volume instead of queueing them
+}
+
+// FeeConfig holds fee quoting settings
+type FeeConfig struct {
+	RelayerMarginBps uint64
+	ToleranceBps     uint64
+	FallbackGasLimit uint64
+	ConversionRates  string        // comma-separated chain:token=rate entries
+	PriceFile        string        // JSON file of USD prices, read again when it changes
+	ChainlinkFeeds   string        // comma-separated chain:asset=feed entries
+	PriceMaxAge      time.Duration // prices older than this are ignored
+	PriceMinSources  int           // fresh prices needed to convert with the oracle
+}
+
+// WebhookConfig holds webhook delivery settings
+type WebhookConfig struct {
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	PollInterval time.Duration
+}
+
+// SupplyConfig holds supply monitor settings
+type SupplyConfig struct {
+	Interval     time.Duration
+	ToleranceBps uint64 // delta allowed, in basis points of the locked collateral
+}
+
+// GuardianConfig holds emergency pause settings
+type GuardianConfig struct {
+	PrivateKey     string // key holding PAUSER_ROLE on the bridges; none disables the guardian
+	UnpauseKey     string // key holding ADMIN_ROLE, used by the API to unpause; none disables the bridge endpoints
+	Triggers       string // comma-separated triggers that pause: supply, unlock, volume
+	SupplyChecks   string // comma-separated supply checks whose breach pauses
+	Interval       time.Duration
+	VolumeWindow   time.Duration
+	VolumeBaseline time.Duration
+	VolumeFactor   int
 }
 
 // LoggingConfig holds logging configuration
@@ -79,6 +163,7 @@ func LoadConfig() *Config {
 				RequiredConfirmations: uint64(getEnvAsInt("ETHEREUM_CONFIRMATIONS", 12)),
 				BlockTime:             getEnvAsDuration("ETHEREUM_BLOCK_TIME", "12s"),
 				GasLimit:              uint64(getEnvAsInt("ETHEREUM_GAS_LIMIT", 21000)),
+				ExecuteMethod:         getEnv("ETHEREUM_EXECUTE_METHOD", "unlockTokens"),
 				Enabled:               getEnvAsBool("ETHEREUM_ENABLED", true),
 			},
 			"polygon": {
@@ -91,6 +176,7 @@ func LoadConfig() *Config {
 				RequiredConfirmations: uint64(getEnvAsInt("POLYGON_CONFIRMATIONS", 20)),
 				BlockTime:             getEnvAsDuration("POLYGON_BLOCK_TIME", "2s"),
 				GasLimit:              uint64(getEnvAsInt("POLYGON_GAS_LIMIT", 21000)),
+				ExecuteMethod:         getEnv("POLYGON_EXECUTE_METHOD", "mintTokens"),
 				Enabled:               getEnvAsBool("POLYGON_ENABLED", true),
 			},
 			"hardhat": {
@@ -103,6 +189,7 @@ func LoadConfig() *Config {
 				RequiredConfirmations: uint64(getEnvAsInt("HARDHAT_CONFIRMATIONS", 1)),
 				BlockTime:             getEnvAsDuration("HARDHAT_BLOCK_TIME", "1s"),
 				GasLimit:              uint64(getEnvAsInt("HARDHAT_GAS_LIMIT", 21000)),
+				ExecuteMethod:         getEnv("HARDHAT_EXECUTE_METHOD", "unlockTokens"),
 				Enabled:               getEnvAsBool("HARDHAT_ENABLED", true),
 			},
 		},
@@ -111,12 +198,60 @@ func LoadConfig() *Config {
 			ReadTimeout:  getEnvAsDuration("API_READ_TIMEOUT", "10s"),
 			WriteTimeout: getEnvAsDuration("API_WRITE_TIMEOUT", "10s"),
 			IdleTimeout:  getEnvAsDuration("API_IDLE_TIMEOUT", "60s"),
+			AdminKeys:    getEnvAsMap("API_ADMIN_KEYS"),
+			ReviewQuorum: getEnvAsInt("API_REVIEW_QUORUM", 1),
+			JWTSecret:    getEnv("API_JWT_SECRET", ""),
+			TrustProxy:   getEnvAsBool("API_TRUST_PROXY", false),
+			RateLimits: RateLimitConfig{
+				Backend:    getEnv("API_RATE_LIMIT_BACKEND", "memory"),
+				RedisURL:   getEnv("REDIS_URL", "redis://localhost:6379"),
+				Public:     getEnvAsInt("API_RATE_LIMIT_PUBLIC", 60),
+				Integrator: getEnvAsInt("API_RATE_LIMIT_INTEGRATOR", 600),
+				Operator:   getEnvAsInt("API_RATE_LIMIT_OPERATOR", 0),
+				Admin:      getEnvAsInt("API_RATE_LIMIT_ADMIN", 0),
+			},
 		},
 		Relayer: RelayerConfig{
 			Port:               getEnv("RELAYER_PORT", "8081"),
 			PrivateKey:         getEnv("RELAYER_PRIVATE_KEY", ""),
 			SignatureThreshold: uint64(getEnvAsInt("SIGNATURE_THRESHOLD", 2)),
 			RelayerCount:       uint64(getEnvAsInt("RELAYER_COUNT", 3)),
+			RulesFile:          getEnv("RELAYER_RULES_FILE", ""),
+			RulesDryRun:        getEnvAsBool("RELAYER_RULES_DRY_RUN", false),
+			Denylists:          getEnv("SCREENING_DENYLISTS", ""),
+			TransferDelay:      getEnvAsDuration("RELAYER_TRANSFER_DELAY", "1h"),
+			RequiredWeight:     getEnvAsInt("RELAYER_REQUIRED_WEIGHT", 0),
+			ReviewOverCap:      getEnvAsBool("RELAYER_REVIEW_OVER_CAP", false),
+		},
+		Fees: FeeConfig{
+			RelayerMarginBps: uint64(getEnvAsInt("FEE_RELAYER_MARGIN_BPS", 1000)),
+			ToleranceBps:     uint64(getEnvAsInt("FEE_TOLERANCE_BPS", 2000)),
+			FallbackGasLimit: uint64(getEnvAsInt("FEE_FALLBACK_GAS_LIMIT", 300000)),
+			ConversionRates:  getEnv("FEE_CONVERSION_RATES", ""),
+			PriceFile:        getEnv("FEE_PRICE_FILE", ""),
+			ChainlinkFeeds:   getEnv("FEE_CHAINLINK_FEEDS", ""),
+			PriceMaxAge:      getEnvAsDuration("FEE_PRICE_MAX_AGE", "1h"),
+			PriceMinSources:  getEnvAsInt("FEE_PRICE_MIN_SOURCES", 1),
+		},
+		Webhooks: WebhookConfig{
+			MaxAttempts:  getEnvAsInt("WEBHOOK_MAX_ATTEMPTS", 8),
+			BaseBackoff:  getEnvAsDuration("WEBHOOK_BASE_BACKOFF", "30s"),
+			MaxBackoff:   getEnvAsDuration("WEBHOOK_MAX_BACKOFF", "1h"),
+			PollInterval: getEnvAsDuration("WEBHOOK_POLL_INTERVAL", "5s"),
+		},
+		Supply: SupplyConfig{
+			Interval:     getEnvAsDuration("SUPPLY_CHECK_INTERVAL", "1m"),
+			ToleranceBps: uint64(getEnvAsInt("SUPPLY_TOLERANCE_BPS", 10)),
+		},
+		Guardian: GuardianConfig{
+			PrivateKey:     getEnv("GUARDIAN_PRIVATE_KEY", ""),
+			UnpauseKey:     getEnv("GUARDIAN_UNPAUSE_KEY", ""),
+			Triggers:       getEnv("GUARDIAN_TRIGGERS", "supply,unlock,volume"),
+			SupplyChecks:   getEnv("GUARDIAN_SUPPLY_CHECKS", "collateral,minted"),
+			Interval:       getEnvAsDuration("GUARDIAN_INTERVAL", "1m"),
+			VolumeWindow:   getEnvAsDuration("GUARDIAN_VOLUME_WINDOW", "1h"),
+			VolumeBaseline: getEnvAsDuration("GUARDIAN_VOLUME_BASELINE", "24h"),
+			VolumeFactor:   getEnvAsInt("GUARDIAN_VOLUME_FACTOR", 5),
 		},
 		Logging: LoggingConfig{
 			Level:  getEnv("LOG_LEVEL", "info"),
@@ -151,6 +286,18 @@ func getEnvAsBool(key string, defaultValue bool) bool {
 	return defaultValue
 }
 
+// getEnvAsMap parses comma-separated name:value pairs, skipping malformed ones
+func getEnvAsMap(key string) map[string]string {
+	result := make(map[string]string)
+	for _, pair := range strings.Split(os.Getenv(key), ",") {
+		name, value, found := strings.Cut(strings.TrimSpace(pair), ":")
+		if found && name != "" && value != "" {
+			result[name] = value
+		}
+	}
+	return result
+}
+
 func getEnvAsDuration(key string, defaultValue string) time.Duration {
 	if value := os.Getenv(key); value != "" {
 		if duration, err := time.ParseDuration(value); err == nil {
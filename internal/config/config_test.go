@@ -81,4 +81,39 @@ func TestGetEnvFunctions(t *testing.T) {
 	if getEnvAsDuration("NON_EXISTENT_DURATION", "5s") != 5*time.Second {
 		t.Error("getEnvAsDuration should return default value for non-existent key")
 	}
-}
\ No newline at end of file
+
+	// Test getEnvAsMap
+	os.Setenv("TEST_MAP", "alice:key-1, bob:key:2,malformed,:empty,carol:")
+	defer os.Unsetenv("TEST_MAP")
+
+	values := getEnvAsMap("TEST_MAP")
+	if len(values) != 2 || values["alice"] != "key-1" || values["bob"] != "key:2" {
+		t.Errorf("getEnvAsMap should parse name:value pairs, got %v", values)
+	}
+
+	if len(getEnvAsMap("NON_EXISTENT_MAP")) != 0 {
+		t.Error("getEnvAsMap should return an empty map for non-existent key")
+	}
+}
+func TestChainConfig_AdapterConfig(t *testing.T) {
+	config := LoadConfig()
+
+	polygon := config.Chains["polygon"]
+	adapterConfig := polygon.AdapterConfig()
+
+	if uint64(adapterConfig.ChainID) != polygon.ChainID {
+		t.Errorf("expected chain ID %d, got %d", polygon.ChainID, adapterConfig.ChainID)
+	}
+	if adapterConfig.RPC != polygon.RPCURL {
+		t.Errorf("expected RPC %s, got %s", polygon.RPCURL, adapterConfig.RPC)
+	}
+	if adapterConfig.BlockTime != polygon.BlockTime {
+		t.Errorf("expected block time %s, got %s", polygon.BlockTime, adapterConfig.BlockTime)
+	}
+	if polygon.ExecuteMethod != "mintTokens" {
+		t.Errorf("expected polygon to execute with mintTokens, got %s", polygon.ExecuteMethod)
+	}
+	if config.Chains["ethereum"].ExecuteMethod != "unlockTokens" {
+		t.Errorf("expected ethereum to execute with unlockTokens, got %s", config.Chains["ethereum"].ExecuteMethod)
+	}
+}
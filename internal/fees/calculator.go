@@ -0,0 +1,229 @@
+package fees
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"nexus-bridge/internal/adapters"
+	"nexus-bridge/pkg/types"
+)
+
+const (
+	// DefaultFallbackGasLimit is charged when the destination call cannot be
+	// simulated, which is the norm before the transfer has been signed
+	DefaultFallbackGasLimit = 300000
+	// DefaultRelayerMarginBps is the relayer margin on destination gas
+	DefaultRelayerMarginBps = 1000
+	// DefaultToleranceBps is how far below the current quote a paid fee may be
+	DefaultToleranceBps = 2000
+	// DefaultSignatureCount is how many placeholder signatures a simulated
+	// destination call carries
+	DefaultSignatureCount = 2
+
+	// signatureLength is the length of an ECDSA signature with recovery ID
+	signatureLength = 65
+	// bpsDenominator is the number of basis points in one
+	bpsDenominator = 10000
+)
+
+var (
+	// ErrUnsupportedRoute is returned when a chain of a transfer is not registered
+	ErrUnsupportedRoute = errors.New("unsupported route")
+	// ErrInsufficientFee is returned when a paid fee does not cover the quote
+	ErrInsufficientFee = errors.New("insufficient fee")
+)
+
+// GasEstimator simulates transactions and prices gas on a chain
+type GasEstimator interface {
+	EstimateGas(ctx context.Context, tx types.Transaction) (uint64, error)
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+}
+
+// ChainFees is the fee configuration of a chain. Chains that are only ever a
+// source need no estimator.
+type ChainFees struct {
+	Estimator             GasEstimator
+	BridgeContract        string
+	ExecuteMethod         string
+	FallbackGasLimit      uint64
+	RequiredConfirmations uint64
+	BlockTime             time.Duration
+}
+
+// Calculator prices transfers in the transferred token. The destination fee
+// is the gas of the unlockTokens or mintTokens call, simulated against the
+// destination bridge and converted into the token; the relayer fee is a
+// margin on top of it. Users pay their own source chain gas.
+type Calculator struct {
+	converter      Converter
+	chains         map[types.ChainID]ChainFees
+	mu             sync.RWMutex
+	MarginBps      uint64
+	ToleranceBps   uint64
+	SignatureCount int
+}
+
+var _ types.FeeCalculator = (*Calculator)(nil)
+
+// NewCalculator creates a fee calculator that converts gas with a converter
+func NewCalculator(converter Converter) *Calculator {
+	return &Calculator{
+		converter:      converter,
+		chains:         make(map[types.ChainID]ChainFees),
+		MarginBps:      DefaultRelayerMarginBps,
+		ToleranceBps:   DefaultToleranceBps,
+		SignatureCount: DefaultSignatureCount,
+	}
+}
+
+// AddChain registers the fee configuration of a chain
+func (c *Calculator) AddChain(chainID types.ChainID, chain ChainFees) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if chain.FallbackGasLimit == 0 {
+		chain.FallbackGasLimit = DefaultFallbackGasLimit
+	}
+	c.chains[chainID] = chain
+}
+
+// EstimateFee quotes the fee for a transfer
+func (c *Calculator) EstimateFee(ctx context.Context, transfer types.Transfer) (*types.FeeEstimate, error) {
+	if transfer.Amount == nil || transfer.Amount.Int == nil || transfer.Amount.Sign() <= 0 {
+		return nil, fmt.Errorf("amount must be positive")
+	}
+	if transfer.SourceChain == transfer.DestinationChain {
+		return nil, fmt.Errorf("source and destination chains must be different")
+	}
+
+	source, err := c.chain(transfer.SourceChain)
+	if err != nil {
+		return nil, err
+	}
+	destination, err := c.chain(transfer.DestinationChain)
+	if err != nil {
+		return nil, err
+	}
+	if destination.Estimator == nil {
+		return nil, fmt.Errorf("%w: %s is not a destination", ErrUnsupportedRoute, transfer.DestinationChain)
+	}
+
+	gasLimit, err := c.simulate(ctx, destination, transfer)
+	if err != nil {
+		return nil, err
+	}
+
+	gasPrice, err := destination.Estimator.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s gas price: %w", transfer.DestinationChain, err)
+	}
+
+	gasCost := new(big.Int).Mul(new(big.Int).SetUint64(gasLimit), gasPrice)
+	destinationFee, err := c.converter.NativeToToken(ctx, transfer.DestinationChain, gasCost, transfer.Token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert gas into token: %w", err)
+	}
+
+	relayerFee := applyBps(destinationFee, c.MarginBps)
+	totalFee := new(big.Int).Add(destinationFee, relayerFee)
+
+	return &types.FeeEstimate{
+		SourceChainFee:      types.NewBigInt(big.NewInt(0)),
+		DestinationChainFee: types.NewBigInt(destinationFee),
+		RelayerFee:          types.NewBigInt(relayerFee),
+		TotalFee:            types.NewBigInt(totalFee),
+		EstimatedTime:       int64((time.Duration(source.RequiredConfirmations) * source.BlockTime).Seconds()),
+	}, nil
+}
+
+// GetGasPrice returns the current gas price of a chain
+func (c *Calculator) GetGasPrice(ctx context.Context, chainID types.ChainID) (*big.Int, error) {
+	chain, err := c.chain(chainID)
+	if err != nil {
+		return nil, err
+	}
+	if chain.Estimator == nil {
+		return nil, fmt.Errorf("%w: no gas estimator for %s", ErrUnsupportedRoute, chainID)
+	}
+
+	gasPrice, err := chain.Estimator.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s gas price: %w", chainID, err)
+	}
+
+	return gasPrice, nil
+}
+
+// ValidateFee checks a paid fee against a fresh quote. The fee may fall short
+// of the quote by ToleranceBps to absorb gas price movement since the user
+// was quoted.
+func (c *Calculator) ValidateFee(ctx context.Context, transfer types.Transfer, providedFee *big.Int) error {
+	estimate, err := c.EstimateFee(ctx, transfer)
+	if err != nil {
+		return err
+	}
+
+	tolerance := c.ToleranceBps
+	if tolerance > bpsDenominator {
+		tolerance = bpsDenominator
+	}
+	required := applyBps(estimate.TotalFee.Int, bpsDenominator-tolerance)
+
+	if providedFee == nil || providedFee.Cmp(required) < 0 {
+		provided := "0"
+		if providedFee != nil {
+			provided = providedFee.String()
+		}
+		return fmt.Errorf("%w: provided %s, required %s", ErrInsufficientFee, provided, required)
+	}
+
+	return nil
+}
+
+// chain returns the fee configuration of a registered chain
+func (c *Calculator) chain(chainID types.ChainID) (ChainFees, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	chain, exists := c.chains[chainID]
+	if !exists {
+		return ChainFees{}, fmt.Errorf("%w: %s", ErrUnsupportedRoute, chainID)
+	}
+	return chain, nil
+}
+
+// simulate estimates the gas of the destination call. The relayer signatures
+// do not exist yet, so the call carries placeholders of the right size; when
+// the bridge rejects them the fallback gas limit is charged instead.
+func (c *Calculator) simulate(ctx context.Context, chain ChainFees, transfer types.Transfer) (uint64, error) {
+	signatures := make([][]byte, c.SignatureCount)
+	for i := range signatures {
+		signatures[i] = make([]byte, signatureLength)
+	}
+
+	data, err := adapters.PackExecuteCall(chain.ExecuteMethod, transfer, signatures)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode destination call: %w", err)
+	}
+
+	gasLimit, err := chain.Estimator.EstimateGas(ctx, types.Transaction{
+		To:   chain.BridgeContract,
+		Data: data,
+	})
+	if err != nil {
+		return chain.FallbackGasLimit, nil
+	}
+
+	return gasLimit, nil
+}
+
+// applyBps returns amount × bps / 10000, rounded up
+func applyBps(amount *big.Int, bps uint64) *big.Int {
+	result := new(big.Int).Mul(amount, new(big.Int).SetUint64(bps))
+	result.Add(result, big.NewInt(bpsDenominator-1))
+	return result.Quo(result, big.NewInt(bpsDenominator))
+}
@@ -0,0 +1,156 @@
+package fees
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/internal/adapters"
+	"nexus-bridge/pkg/types"
+)
+
+const testToken = "0xA0b86a33E6441E6C7D3E4C2C4C6C6C6C6C6C6C6C"
+
+// fakeEstimator is a GasEstimator with fixed results that records simulations
+type fakeEstimator struct {
+	gas         uint64
+	gasErr      error
+	gasPrice    *big.Int
+	simulations []types.Transaction
+}
+
+func (f *fakeEstimator) EstimateGas(ctx context.Context, tx types.Transaction) (uint64, error) {
+	f.simulations = append(f.simulations, tx)
+	return f.gas, f.gasErr
+}
+
+func (f *fakeEstimator) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return f.gasPrice, nil
+}
+
+var _ GasEstimator = (*adapters.EthereumAdapter)(nil)
+
+func createQuoteTransfer() types.Transfer {
+	return types.Transfer{
+		SourceChain:      types.ChainEthereum,
+		DestinationChain: types.ChainPolygon,
+		Token:            testToken,
+		Amount:           types.NewBigInt(big.NewInt(1000000)),
+		Recipient:        "0x1234567890123456789012345678901234567890",
+	}
+}
+
+// newTestCalculator prices Polygon gas at 1 token unit per 1000 wei
+func newTestCalculator(estimator *fakeEstimator) *Calculator {
+	rates := NewStaticRates()
+	rates.SetRate(types.ChainPolygon, testToken, big.NewRat(1, 1000))
+
+	calculator := NewCalculator(rates)
+	calculator.AddChain(types.ChainEthereum, ChainFees{
+		RequiredConfirmations: 12,
+		BlockTime:             12 * time.Second,
+	})
+	calculator.AddChain(types.ChainPolygon, ChainFees{
+		Estimator:             estimator,
+		BridgeContract:        "0x00000000000000000000000000000000000000b1",
+		ExecuteMethod:         adapters.MethodMintTokens,
+		RequiredConfirmations: 20,
+		BlockTime:             2 * time.Second,
+	})
+	return calculator
+}
+
+func TestCalculator_EstimateFee(t *testing.T) {
+	estimator := &fakeEstimator{gas: 200000, gasPrice: big.NewInt(30000)}
+	calculator := newTestCalculator(estimator)
+
+	estimate, err := calculator.EstimateFee(context.Background(), createQuoteTransfer())
+	require.NoError(t, err)
+
+	// 200000 gas × 30000 wei = 6e9 wei = 6e6 token units, plus a 10% margin
+	assert.Equal(t, int64(0), estimate.SourceChainFee.Int64())
+	assert.Equal(t, int64(6000000), estimate.DestinationChainFee.Int64())
+	assert.Equal(t, int64(600000), estimate.RelayerFee.Int64())
+	assert.Equal(t, int64(6600000), estimate.TotalFee.Int64())
+	assert.Equal(t, int64(144), estimate.EstimatedTime) // 12 confirmations × 12s
+
+	// The simulation calls the destination bridge's mint method
+	require.Len(t, estimator.simulations, 1)
+	assert.Equal(t, "0x00000000000000000000000000000000000000b1", estimator.simulations[0].To)
+	expected, err := adapters.PackExecuteCall(adapters.MethodMintTokens, createQuoteTransfer(),
+		[][]byte{make([]byte, 65), make([]byte, 65)})
+	require.NoError(t, err)
+	assert.Equal(t, expected, estimator.simulations[0].Data)
+}
+
+func TestCalculator_FallbackGasLimit(t *testing.T) {
+	estimator := &fakeEstimator{gasErr: errors.New("execution reverted"), gasPrice: big.NewInt(1000)}
+	calculator := newTestCalculator(estimator)
+	calculator.MarginBps = 0
+
+	estimate, err := calculator.EstimateFee(context.Background(), createQuoteTransfer())
+	require.NoError(t, err)
+	assert.Equal(t, int64(DefaultFallbackGasLimit), estimate.TotalFee.Int64())
+}
+
+func TestCalculator_UnsupportedRoutes(t *testing.T) {
+	calculator := newTestCalculator(&fakeEstimator{gasPrice: big.NewInt(1)})
+
+	// Ethereum has no estimator, so it can only be a source
+	transfer := createQuoteTransfer()
+	transfer.SourceChain, transfer.DestinationChain = types.ChainPolygon, types.ChainEthereum
+	_, err := calculator.EstimateFee(context.Background(), transfer)
+	assert.ErrorIs(t, err, ErrUnsupportedRoute)
+
+	transfer = createQuoteTransfer()
+	transfer.SourceChain = types.ChainCosmos
+	_, err = calculator.EstimateFee(context.Background(), transfer)
+	assert.ErrorIs(t, err, ErrUnsupportedRoute)
+
+	transfer = createQuoteTransfer()
+	transfer.Token = "0x00000000000000000000000000000000000000c1"
+	_, err = calculator.EstimateFee(context.Background(), transfer)
+	assert.ErrorIs(t, err, ErrNoRate)
+
+	transfer = createQuoteTransfer()
+	transfer.Amount = types.NewBigInt(big.NewInt(0))
+	_, err = calculator.EstimateFee(context.Background(), transfer)
+	assert.Error(t, err)
+}
+
+func TestCalculator_GetGasPrice(t *testing.T) {
+	calculator := newTestCalculator(&fakeEstimator{gasPrice: big.NewInt(42)})
+
+	gasPrice, err := calculator.GetGasPrice(context.Background(), types.ChainPolygon)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), gasPrice.Int64())
+
+	_, err = calculator.GetGasPrice(context.Background(), types.ChainEthereum)
+	assert.ErrorIs(t, err, ErrUnsupportedRoute)
+}
+
+func TestCalculator_ValidateFee(t *testing.T) {
+	estimator := &fakeEstimator{gas: 100000, gasPrice: big.NewInt(10000)}
+	calculator := newTestCalculator(estimator)
+	calculator.MarginBps = 0
+	transfer := createQuoteTransfer()
+
+	// The quote is 1,000,000 and 20% tolerance is allowed
+	assert.NoError(t, calculator.ValidateFee(context.Background(), transfer, big.NewInt(1000000)))
+	assert.NoError(t, calculator.ValidateFee(context.Background(), transfer, big.NewInt(800000)))
+
+	err := calculator.ValidateFee(context.Background(), transfer, big.NewInt(799999))
+	assert.ErrorIs(t, err, ErrInsufficientFee)
+
+	err = calculator.ValidateFee(context.Background(), transfer, nil)
+	assert.ErrorIs(t, err, ErrInsufficientFee)
+
+	calculator.ToleranceBps = 0
+	err = calculator.ValidateFee(context.Background(), transfer, big.NewInt(999999))
+	assert.ErrorIs(t, err, ErrInsufficientFee)
+}
@@ -0,0 +1,55 @@
+package fees
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"nexus-bridge/internal/oracle"
+	"nexus-bridge/pkg/types"
+)
+
+// OracleRates is a Converter deriving rates from the USD prices of a
+// chain's native currency and of the token
+type OracleRates struct {
+	oracle oracle.PriceOracle
+}
+
+var _ Converter = (*OracleRates)(nil)
+
+// NewOracleRates creates a converter pricing through an oracle
+func NewOracleRates(prices oracle.PriceOracle) *OracleRates {
+	return &OracleRates{oracle: prices}
+}
+
+// NativeToToken converts a native amount into a token at current prices,
+// rounding up
+func (o *OracleRates) NativeToToken(ctx context.Context, chainID types.ChainID, amount *big.Int, token string) (*big.Int, error) {
+	native, err := o.price(ctx, oracle.Native(chainID))
+	if err != nil {
+		return nil, err
+	}
+	tokenPrice, err := o.price(ctx, oracle.Token(token))
+	if err != nil {
+		return nil, err
+	}
+
+	rate := new(big.Rat).Quo(native.PerBaseUnit(), tokenPrice.PerBaseUnit())
+	return convert(amount, rate), nil
+}
+
+// price returns the price of an asset, reporting unpriced assets as ErrNoRate
+func (o *OracleRates) price(ctx context.Context, asset oracle.Asset) (oracle.Price, error) {
+	price, err := o.oracle.Price(ctx, asset)
+	if errors.Is(err, oracle.ErrNoPrice) {
+		return oracle.Price{}, fmt.Errorf("%w: %v", ErrNoRate, err)
+	}
+	if err != nil {
+		return oracle.Price{}, err
+	}
+	if price.USD == nil || price.USD.Sign() <= 0 {
+		return oracle.Price{}, fmt.Errorf("%w: %s price is not positive", ErrNoRate, asset)
+	}
+	return price, nil
+}
@@ -0,0 +1,61 @@
+package fees
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/internal/oracle"
+	"nexus-bridge/pkg/types"
+)
+
+func TestOracleRates_NativeToToken(t *testing.T) {
+	prices := oracle.NewStaticFeed()
+	prices.SetPrice(oracle.Native(types.ChainPolygon), oracle.Price{USD: big.NewRat(1, 2), Decimals: 18})
+	prices.SetPrice(oracle.Token(testToken), oracle.Price{USD: big.NewRat(1, 1), Decimals: 6})
+	rates := NewOracleRates(prices)
+
+	// 0.01 POL at $0.50 is $0.005, or 5000 base units of a $1 token with 6
+	// decimals
+	amount, err := rates.NativeToToken(context.Background(), types.ChainPolygon, big.NewInt(10000000000000000), testToken)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5000), amount.Int64())
+
+	// Conversions round up
+	amount, err = rates.NativeToToken(context.Background(), types.ChainPolygon, big.NewInt(1), testToken)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), amount.Int64())
+
+	_, err = rates.NativeToToken(context.Background(), types.ChainEthereum, big.NewInt(1), testToken)
+	assert.ErrorIs(t, err, ErrNoRate)
+	_, err = rates.NativeToToken(context.Background(), types.ChainPolygon, big.NewInt(1), "0x0000000000000000000000000000000000000001")
+	assert.ErrorIs(t, err, ErrNoRate)
+}
+
+func TestCalculator_OracleRates(t *testing.T) {
+	prices := oracle.NewStaticFeed()
+	prices.SetPrice(oracle.Native(types.ChainPolygon), oracle.Price{USD: big.NewRat(1, 2), Decimals: 18})
+	prices.SetPrice(oracle.Token(testToken), oracle.Price{USD: big.NewRat(1, 1), Decimals: 6})
+	median := oracle.NewMedian(0, prices)
+
+	calculator := NewCalculator(NewOracleRates(median))
+	calculator.MarginBps = 0
+	calculator.AddChain(types.ChainEthereum, ChainFees{})
+	calculator.AddChain(types.ChainPolygon, ChainFees{
+		Estimator:     &fakeEstimator{gas: 100000, gasPrice: big.NewInt(100000000000)},
+		ExecuteMethod: "mintTokens",
+	})
+
+	// 100000 gas at 100 gwei is 0.01 POL, or $0.005
+	estimate, err := calculator.EstimateFee(context.Background(), createQuoteTransfer())
+	require.NoError(t, err)
+	assert.Equal(t, "5000", estimate.TotalFee.String())
+
+	// Quotes fail rather than rely on too few prices
+	median.MinSources = 2
+	_, err = calculator.EstimateFee(context.Background(), createQuoteTransfer())
+	assert.ErrorIs(t, err, ErrNoRate)
+}
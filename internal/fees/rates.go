@@ -0,0 +1,114 @@
+package fees
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"nexus-bridge/pkg/types"
+)
+
+// ErrNoRate is returned when no conversion rate is known for a token
+var ErrNoRate = errors.New("no conversion rate")
+
+// Converter converts amounts of a chain's native currency into a token
+type Converter interface {
+	// NativeToToken converts an amount in the smallest native unit of a chain
+	// into the smallest unit of a token
+	NativeToToken(ctx context.Context, chainID types.ChainID, amount *big.Int, token string) (*big.Int, error)
+}
+
+// rateKey identifies the rate between a chain's native currency and a token
+type rateKey struct {
+	chainID types.ChainID
+	token   string
+}
+
+// StaticRates is a Converter with fixed rates. A rate is the number of token
+// base units one native base unit (e.g. one wei) is worth.
+type StaticRates struct {
+	rates map[rateKey]*big.Rat
+	mu    sync.RWMutex
+}
+
+var _ Converter = (*StaticRates)(nil)
+
+// NewStaticRates creates an empty set of static rates
+func NewStaticRates() *StaticRates {
+	return &StaticRates{
+		rates: make(map[rateKey]*big.Rat),
+	}
+}
+
+// ParseStaticRates parses rates written as comma-separated
+// "chain:token=rate" entries, where chain is a name or numeric ID and rate is
+// a decimal or fraction, e.g. "polygon:0xA0b8...=0.0000005,1:0xA0b8...=1/2"
+func ParseStaticRates(spec string) (*StaticRates, error) {
+	rates := NewStaticRates()
+
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		pair, value, found := strings.Cut(entry, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid rate %q: expected chain:token=rate", entry)
+		}
+		chain, token, found := strings.Cut(pair, ":")
+		if !found {
+			return nil, fmt.Errorf("invalid rate %q: expected chain:token=rate", entry)
+		}
+
+		chainID, err := types.ParseChainID(strings.TrimSpace(chain))
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate %q: %w", entry, err)
+		}
+		rate, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+		if !ok || rate.Sign() < 0 {
+			return nil, fmt.Errorf("invalid rate %q: bad rate %s", entry, value)
+		}
+
+		rates.SetRate(chainID, strings.TrimSpace(token), rate)
+	}
+
+	return rates, nil
+}
+
+// SetRate sets the rate between a chain's native currency and a token
+func (s *StaticRates) SetRate(chainID types.ChainID, token string, rate *big.Rat) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rates[rateKey{chainID: chainID, token: strings.ToLower(token)}] = new(big.Rat).Set(rate)
+}
+
+// NativeToToken converts a native amount into a token, rounding up
+func (s *StaticRates) NativeToToken(ctx context.Context, chainID types.ChainID, amount *big.Int, token string) (*big.Int, error) {
+	s.mu.RLock()
+	rate, exists := s.rates[rateKey{chainID: chainID, token: strings.ToLower(token)}]
+	s.mu.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("%w: %s native to %s", ErrNoRate, chainID, token)
+	}
+
+	return convert(amount, rate), nil
+}
+
+// convert multiplies an amount by a rate, rounding up so conversions never
+// undercharge
+func convert(amount *big.Int, rate *big.Rat) *big.Int {
+	product := new(big.Rat).Mul(new(big.Rat).SetInt(amount), rate)
+
+	result, remainder := new(big.Int).QuoRem(product.Num(), product.Denom(), new(big.Int))
+	if remainder.Sign() > 0 {
+		result.Add(result, big.NewInt(1))
+	}
+
+	return result
+}
@@ -0,0 +1,52 @@
+package fees
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/pkg/types"
+)
+
+func TestStaticRates_NativeToToken(t *testing.T) {
+	rates := NewStaticRates()
+	rates.SetRate(types.ChainEthereum, testToken, big.NewRat(2, 3))
+
+	// Token addresses are matched case-insensitively and results round up
+	amount, err := rates.NativeToToken(context.Background(), types.ChainEthereum, big.NewInt(10), "0xa0b86a33e6441e6c7d3e4c2c4c6c6c6c6c6c6c6c")
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), amount.Int64())
+
+	amount, err = rates.NativeToToken(context.Background(), types.ChainEthereum, big.NewInt(9), testToken)
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), amount.Int64())
+
+	_, err = rates.NativeToToken(context.Background(), types.ChainPolygon, big.NewInt(10), testToken)
+	assert.ErrorIs(t, err, ErrNoRate)
+}
+
+func TestParseStaticRates(t *testing.T) {
+	rates, err := ParseStaticRates("polygon:" + testToken + "=0.5, 1:" + testToken + "=3/2,")
+	require.NoError(t, err)
+
+	amount, err := rates.NativeToToken(context.Background(), types.ChainPolygon, big.NewInt(100), testToken)
+	require.NoError(t, err)
+	assert.Equal(t, int64(50), amount.Int64())
+
+	amount, err = rates.NativeToToken(context.Background(), types.ChainEthereum, big.NewInt(100), testToken)
+	require.NoError(t, err)
+	assert.Equal(t, int64(150), amount.Int64())
+
+	empty, err := ParseStaticRates("")
+	require.NoError(t, err)
+	_, err = empty.NativeToToken(context.Background(), types.ChainEthereum, big.NewInt(1), testToken)
+	assert.ErrorIs(t, err, ErrNoRate)
+
+	for _, spec := range []string{"polygon" + testToken + "=1", "polygon:" + testToken, "mars:" + testToken + "=1", "polygon:" + testToken + "=abc", "polygon:" + testToken + "=-1"} {
+		_, err := ParseStaticRates(spec)
+		assert.Error(t, err, spec)
+	}
+}
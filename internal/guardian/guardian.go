@@ -0,0 +1,279 @@
+package guardian
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"nexus-bridge/internal/adapters"
+	"nexus-bridge/internal/models"
+	"nexus-bridge/internal/supply"
+	"nexus-bridge/pkg/types"
+)
+
+const (
+	// DefaultInterval is how often polled triggers are checked
+	DefaultInterval = time.Minute
+	// DefaultVolumeWindow is the window whose volume is compared with the
+	// baseline
+	DefaultVolumeWindow = time.Hour
+	// DefaultVolumeBaseline is the period the usual volume is taken from
+	DefaultVolumeBaseline = 24 * time.Hour
+	// DefaultVolumeFactor is how many times its usual volume a route must
+	// see for a spike
+	DefaultVolumeFactor = 5
+
+	// pauseRetryAfter is how long a pause that has been sent but not mined
+	// keeps the guardian from sending another
+	pauseRetryAfter = 5 * time.Minute
+
+	// actor is recorded in the audit log for automatic pauses
+	actor = "guardian"
+)
+
+// Triggers that pause a bridge
+const (
+	// TriggerSupply fires on a supply invariant breach
+	TriggerSupply = "supply"
+	// TriggerUnlock fires on an unlock without a matching recorded lock
+	TriggerUnlock = "unlock"
+	// TriggerVolume fires when a route's signed volume spikes
+	TriggerVolume = "volume"
+)
+
+var (
+	// ErrUnknownChain is returned for a chain the guardian cannot pause
+	ErrUnknownChain = errors.New("chain is not guarded")
+	// ErrNotPaused is returned when unpausing a bridge that is not paused
+	ErrNotPaused = errors.New("bridge is not paused")
+)
+
+// Pauser pauses and unpauses a chain's bridge contract
+type Pauser interface {
+	Paused(ctx context.Context) (bool, error)
+	Pause(ctx context.Context) (string, error)
+	Unpause(ctx context.Context) (string, error)
+}
+
+var _ Pauser = (*adapters.EthereumAdapter)(nil)
+
+// Store provides the transfers the triggers look at and records pauses
+type Store interface {
+	GetTransfer(ctx context.Context, transferID string) (*types.Transfer, error)
+	ListSupportedTokens(ctx context.Context) ([]types.SupportedToken, error)
+	SignedVolume(ctx context.Context, query models.VolumeQuery) (*big.Int, error)
+	RecordAudit(ctx context.Context, entry *models.AuditEntry) error
+}
+
+// Alarm asks for a chain's bridge to be paused
+type Alarm struct {
+	Chain   types.ChainID
+	Trigger string
+	Reason  string
+}
+
+// BridgeChange is the audited state of a bridge after a pause or unpause
+type BridgeChange struct {
+	Paused  bool   `json:"paused"`
+	Trigger string `json:"trigger,omitempty"`
+	Reason  string `json:"reason"`
+	TxHash  string `json:"tx_hash"`
+}
+
+// BridgeStatus is whether a guarded chain's bridge is paused
+type BridgeStatus struct {
+	ChainID types.ChainID `json:"chain_id"`
+	Chain   string        `json:"chain"`
+	Paused  bool          `json:"paused"`
+}
+
+// Guardian pauses bridge contracts when a trigger fires, with a key holding
+// only the contracts' PAUSER_ROLE. It never unpauses on its own; operators
+// do so with Unpause once the cause is understood, through a Guardian whose
+// pausers hold ADMIN_ROLE.
+type Guardian struct {
+	store          Store
+	pausers        map[types.ChainID]Pauser
+	triggers       map[string]bool
+	supplyChecks   map[string]bool
+	sent           map[types.ChainID]time.Time
+	mu             sync.Mutex
+	now            func() time.Time
+	Interval       time.Duration
+	VolumeWindow   time.Duration
+	VolumeBaseline time.Duration
+	VolumeFactor   int64
+}
+
+// NewGuardian creates a guardian with every trigger enabled
+func NewGuardian(store Store) *Guardian {
+	return &Guardian{
+		store:          store,
+		pausers:        make(map[types.ChainID]Pauser),
+		triggers:       map[string]bool{TriggerSupply: true, TriggerUnlock: true, TriggerVolume: true},
+		supplyChecks:   map[string]bool{supply.CheckCollateral: true, supply.CheckMinted: true},
+		sent:           make(map[types.ChainID]time.Time),
+		now:            time.Now,
+		Interval:       DefaultInterval,
+		VolumeWindow:   DefaultVolumeWindow,
+		VolumeBaseline: DefaultVolumeBaseline,
+		VolumeFactor:   DefaultVolumeFactor,
+	}
+}
+
+// AddChain registers the bridge of a chain to guard
+func (g *Guardian) AddChain(chainID types.ChainID, pauser Pauser) {
+	g.pausers[chainID] = pauser
+}
+
+// SetTriggers enables only the named triggers
+func (g *Guardian) SetTriggers(names []string) error {
+	triggers := make(map[string]bool)
+	for _, name := range names {
+		switch name {
+		case TriggerSupply, TriggerUnlock, TriggerVolume:
+			triggers[name] = true
+		default:
+			return fmt.Errorf("unknown guardian trigger: %s", name)
+		}
+	}
+	g.triggers = triggers
+	return nil
+}
+
+// SetSupplyChecks makes only breaches of the named supply checks pause a
+// bridge. By default only the on-chain checks, collateral and minted, do:
+// the database checks also drift on ingestion lag or a database restore.
+func (g *Guardian) SetSupplyChecks(names []string) error {
+	checks := make(map[string]bool)
+	for _, name := range names {
+		switch name {
+		case supply.CheckCollateral, supply.CheckMinted, supply.CheckLockedDB, supply.CheckWrappedDB:
+			checks[name] = true
+		default:
+			return fmt.Errorf("unknown supply check: %s", name)
+		}
+	}
+	g.supplyChecks = checks
+	return nil
+}
+
+// Run checks the polled triggers until the context ends
+func (g *Guardian) Run(ctx context.Context) error {
+	ticker := time.NewTicker(g.Interval)
+	defer ticker.Stop()
+
+	for {
+		if g.triggers[TriggerVolume] {
+			g.checkVolume(ctx)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Trip pauses the bridge named by an alarm unless it is already paused or
+// the alarm's trigger is disabled, and records why
+func (g *Guardian) Trip(ctx context.Context, alarm Alarm) error {
+	if !g.triggers[alarm.Trigger] {
+		return nil
+	}
+	pauser, exists := g.pausers[alarm.Chain]
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrUnknownChain, alarm.Chain)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if sent, pending := g.sent[alarm.Chain]; pending && g.now().Sub(sent) < pauseRetryAfter {
+		return nil
+	}
+	paused, err := pauser.Paused(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read pause state on %s: %w", alarm.Chain, err)
+	}
+	if paused {
+		return nil
+	}
+
+	fmt.Printf("Pausing bridge on %s (%s): %s\n", alarm.Chain, alarm.Trigger, alarm.Reason)
+	txHash, err := pauser.Pause(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to pause bridge on %s: %w", alarm.Chain, err)
+	}
+	g.sent[alarm.Chain] = g.now()
+
+	change := BridgeChange{Paused: true, Trigger: alarm.Trigger, Reason: alarm.Reason, TxHash: txHash}
+	entry, err := models.NewAuditEntry(models.AuditBridgePaused, models.AuditEntityBridge, chainKey(alarm.Chain), actor, nil, change)
+	if err == nil {
+		err = g.store.RecordAudit(ctx, entry)
+	}
+	if err != nil {
+		return fmt.Errorf("bridge on %s paused in %s but not recorded: %w", alarm.Chain, txHash, err)
+	}
+	return nil
+}
+
+// Unpause unpauses a chain's bridge and returns the transaction hash
+func (g *Guardian) Unpause(ctx context.Context, chainID types.ChainID) (string, error) {
+	pauser, exists := g.pausers[chainID]
+	if !exists {
+		return "", fmt.Errorf("%w: %s", ErrUnknownChain, chainID)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	paused, err := pauser.Paused(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to read pause state on %s: %w", chainID, err)
+	}
+	if !paused {
+		return "", fmt.Errorf("%w: %s", ErrNotPaused, chainID)
+	}
+
+	txHash, err := pauser.Unpause(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to unpause bridge on %s: %w", chainID, err)
+	}
+	delete(g.sent, chainID)
+	return txHash, nil
+}
+
+// Status reads the pause state of every guarded bridge
+func (g *Guardian) Status(ctx context.Context) ([]BridgeStatus, error) {
+	statuses := make([]BridgeStatus, 0, len(g.pausers))
+	for _, chainID := range g.chainIDs() {
+		paused, err := g.pausers[chainID].Paused(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read pause state on %s: %w", chainID, err)
+		}
+		statuses = append(statuses, BridgeStatus{ChainID: chainID, Chain: chainID.String(), Paused: paused})
+	}
+	return statuses, nil
+}
+
+// chainIDs returns the guarded chains in a stable order
+func (g *Guardian) chainIDs() []types.ChainID {
+	chainIDs := make([]types.ChainID, 0, len(g.pausers))
+	for chainID := range g.pausers {
+		chainIDs = append(chainIDs, chainID)
+	}
+	sort.Slice(chainIDs, func(i, j int) bool { return chainIDs[i] < chainIDs[j] })
+	return chainIDs
+}
+
+// chainKey identifies a chain's bridge in the audit log
+func chainKey(chainID types.ChainID) string {
+	return strconv.FormatUint(uint64(chainID), 10)
+}
@@ -0,0 +1,312 @@
+package guardian
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/internal/supply"
+	"nexus-bridge/pkg/types"
+)
+
+const testToken = "0xA0b86a33E6441E6C7D3E4C2C4C6C6C6C6C6C6C6C"
+
+// fakePauser is a bridge contract's pause flag
+type fakePauser struct {
+	paused bool
+	pauses int
+	err    error
+}
+
+func (f *fakePauser) Paused(ctx context.Context) (bool, error) {
+	return f.paused, f.err
+}
+
+func (f *fakePauser) Pause(ctx context.Context) (string, error) {
+	if f.err != nil {
+		return "", f.err
+	}
+	f.pauses++
+	return fmt.Sprintf("0x%064x", f.pauses), nil
+}
+
+func (f *fakePauser) Unpause(ctx context.Context) (string, error) {
+	f.paused = false
+	return "0xunpause", nil
+}
+
+// volumeStore is a store with fixed signed volumes by route and window
+type volumeStore struct {
+	*models.MemoryStateManager
+	volumes map[string]int64
+	now     time.Time
+}
+
+func (s *volumeStore) SignedVolume(ctx context.Context, query models.VolumeQuery) (*big.Int, error) {
+	key := fmt.Sprintf("%s>%s/%s", query.SourceChain, query.DestinationChain, s.now.Sub(query.Since))
+	return big.NewInt(s.volumes[key]), nil
+}
+
+func newTestGuardian(t *testing.T) (*Guardian, *models.MemoryStateManager, map[types.ChainID]*fakePauser) {
+	store := models.NewMemoryStateManager()
+	guardian := NewGuardian(store)
+	pausers := map[types.ChainID]*fakePauser{types.ChainEthereum: {}, types.ChainPolygon: {}}
+	for chainID, pauser := range pausers {
+		guardian.AddChain(chainID, pauser)
+	}
+	return guardian, store, pausers
+}
+
+func pauseTrail(t *testing.T, store *models.MemoryStateManager, chainID types.ChainID) []BridgeChange {
+	entries, err := store.GetAuditTrail(context.Background(), models.AuditEntityBridge, chainKey(chainID))
+	require.NoError(t, err)
+
+	changes := make([]BridgeChange, len(entries))
+	for i, entry := range entries {
+		assert.Equal(t, models.AuditBridgePaused, entry.EventType)
+		assert.Equal(t, "guardian", entry.PerformedBy)
+		require.NoError(t, json.Unmarshal(entry.NewValues, &changes[i]))
+	}
+	return changes
+}
+
+func TestGuardian_Trip(t *testing.T) {
+	ctx := context.Background()
+	guardian, store, pausers := newTestGuardian(t)
+	now := time.Now()
+	guardian.now = func() time.Time { return now }
+
+	alarm := Alarm{Chain: types.ChainPolygon, Trigger: TriggerVolume, Reason: "volume spike"}
+	require.NoError(t, guardian.Trip(ctx, alarm))
+	assert.Equal(t, 1, pausers[types.ChainPolygon].pauses)
+	assert.Zero(t, pausers[types.ChainEthereum].pauses)
+
+	changes := pauseTrail(t, store, types.ChainPolygon)
+	require.Len(t, changes, 1)
+	assert.Equal(t, BridgeChange{Paused: true, Trigger: TriggerVolume, Reason: "volume spike", TxHash: fmt.Sprintf("0x%064x", 1)}, changes[0])
+
+	// A pause that is not mined yet is not sent again until it may have
+	// been dropped
+	require.NoError(t, guardian.Trip(ctx, alarm))
+	assert.Equal(t, 1, pausers[types.ChainPolygon].pauses)
+	now = now.Add(pauseRetryAfter)
+	require.NoError(t, guardian.Trip(ctx, alarm))
+	assert.Equal(t, 2, pausers[types.ChainPolygon].pauses)
+
+	// Paused bridges are left alone
+	pausers[types.ChainEthereum].paused = true
+	require.NoError(t, guardian.Trip(ctx, Alarm{Chain: types.ChainEthereum, Trigger: TriggerSupply}))
+	assert.Zero(t, pausers[types.ChainEthereum].pauses)
+	assert.Empty(t, pauseTrail(t, store, types.ChainEthereum))
+
+	assert.ErrorIs(t, guardian.Trip(ctx, Alarm{Chain: types.ChainCosmos, Trigger: TriggerSupply}), ErrUnknownChain)
+}
+
+func TestGuardian_DisabledTrigger(t *testing.T) {
+	guardian, _, pausers := newTestGuardian(t)
+	require.NoError(t, guardian.SetTriggers([]string{TriggerSupply}))
+
+	require.NoError(t, guardian.Trip(context.Background(), Alarm{Chain: types.ChainPolygon, Trigger: TriggerVolume}))
+	assert.Zero(t, pausers[types.ChainPolygon].pauses)
+
+	assert.Error(t, guardian.SetTriggers([]string{"supply", "typo"}))
+}
+
+func TestGuardian_PauseFailure(t *testing.T) {
+	guardian, store, pausers := newTestGuardian(t)
+	pausers[types.ChainPolygon].err = errors.New("execution reverted")
+
+	err := guardian.Trip(context.Background(), Alarm{Chain: types.ChainPolygon, Trigger: TriggerUnlock})
+	assert.ErrorContains(t, err, "execution reverted")
+	assert.Empty(t, pauseTrail(t, store, types.ChainPolygon))
+}
+
+func TestGuardian_Unpause(t *testing.T) {
+	ctx := context.Background()
+	guardian, _, pausers := newTestGuardian(t)
+
+	_, err := guardian.Unpause(ctx, types.ChainEthereum)
+	assert.ErrorIs(t, err, ErrNotPaused)
+	_, err = guardian.Unpause(ctx, types.ChainCosmos)
+	assert.ErrorIs(t, err, ErrUnknownChain)
+
+	require.NoError(t, guardian.Trip(ctx, Alarm{Chain: types.ChainEthereum, Trigger: TriggerSupply}))
+	pausers[types.ChainEthereum].paused = true
+
+	statuses, err := guardian.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []BridgeStatus{
+		{ChainID: types.ChainEthereum, Chain: "ethereum", Paused: true},
+		{ChainID: types.ChainPolygon, Chain: "polygon", Paused: false},
+	}, statuses)
+
+	txHash, err := guardian.Unpause(ctx, types.ChainEthereum)
+	require.NoError(t, err)
+	assert.Equal(t, "0xunpause", txHash)
+	assert.False(t, pausers[types.ChainEthereum].paused)
+
+	// A condition that persists pauses the bridge again
+	require.NoError(t, guardian.Trip(ctx, Alarm{Chain: types.ChainEthereum, Trigger: TriggerSupply}))
+	assert.Equal(t, 2, pausers[types.ChainEthereum].pauses)
+}
+
+func TestGuardian_SupplyBreach(t *testing.T) {
+	guardian, store, pausers := newTestGuardian(t)
+
+	guardian.HandleSupplyBreach(context.Background(), supply.TokenSupply{
+		Chain:  types.ChainEthereum,
+		Symbol: "USDC",
+		Deltas: []supply.Delta{
+			{Check: supply.CheckCollateral, Chain: types.ChainEthereum, Amount: big.NewInt(500), Breach: true},
+			{Check: supply.CheckMinted, Chain: types.ChainPolygon, Amount: big.NewInt(1)},
+		},
+	})
+
+	assert.Equal(t, 1, pausers[types.ChainEthereum].pauses)
+	assert.Zero(t, pausers[types.ChainPolygon].pauses)
+	changes := pauseTrail(t, store, types.ChainEthereum)
+	require.Len(t, changes, 1)
+	assert.Equal(t, TriggerSupply, changes[0].Trigger)
+	assert.Equal(t, "USDC collateral check is off by 500 base units", changes[0].Reason)
+}
+
+func TestGuardian_SupplyBreachOfDatabaseCheck(t *testing.T) {
+	guardian, _, pausers := newTestGuardian(t)
+	breach := supply.TokenSupply{
+		Chain:  types.ChainEthereum,
+		Symbol: "USDC",
+		Deltas: []supply.Delta{
+			{Check: supply.CheckLockedDB, Chain: types.ChainEthereum, Amount: big.NewInt(500), Breach: true},
+		},
+	}
+
+	// Drift between the database and the chain does not pause by default
+	guardian.HandleSupplyBreach(context.Background(), breach)
+	assert.Zero(t, pausers[types.ChainEthereum].pauses)
+
+	require.NoError(t, guardian.SetSupplyChecks([]string{supply.CheckCollateral, supply.CheckLockedDB}))
+	guardian.HandleSupplyBreach(context.Background(), breach)
+	assert.Equal(t, 1, pausers[types.ChainEthereum].pauses)
+
+	assert.Error(t, guardian.SetSupplyChecks([]string{"balance"}))
+}
+
+func TestGuardian_CheckUnlock(t *testing.T) {
+	ctx := context.Background()
+	transfer := types.Transfer{
+		ID:               fmt.Sprintf("0x%064x", 1),
+		SourceChain:      types.ChainPolygon,
+		DestinationChain: types.ChainEthereum,
+		Token:            testToken,
+		Amount:           types.NewBigInt(big.NewInt(1000)),
+		Sender:           "0x742d35Cc6634C0532925a3b8D4C9db96590C4C4C",
+		Recipient:        "0x8ba1f109551bD432803012645Hac136c22C4C4C",
+		Status:           types.StatusSigned,
+	}
+	unlock := func(change func(*types.Transfer)) types.Event {
+		unlocked := transfer
+		unlocked.Token = "0xa0b86a33e6441e6c7d3e4c2c4c6c6c6c6c6c6c6c"
+		change(&unlocked)
+		return types.Event{
+			ID:         "0xabc-0",
+			Type:       types.EventTypeUnlock,
+			ChainID:    types.ChainEthereum,
+			TxHash:     "0xabc",
+			TransferID: unlocked.ID,
+			Transfer:   unlocked,
+		}
+	}
+
+	tests := []struct {
+		name   string
+		event  types.Event
+		paused bool
+		reason string
+	}{
+		{"Matching", unlock(func(*types.Transfer) {}), false, ""},
+		{"NoLock", unlock(func(u *types.Transfer) { u.ID = fmt.Sprintf("0x%064x", 2) }), true,
+			fmt.Sprintf("unlock of transfer 0x%064x in 0xabc has no recorded lock", 2)},
+		{"OtherAmount", unlock(func(u *types.Transfer) { u.Amount = types.NewBigInt(big.NewInt(5000)) }), true,
+			fmt.Sprintf("transfer %s unlocked 5000 but locked 1000", transfer.ID)},
+		{"OtherRecipient", unlock(func(u *types.Transfer) { u.Recipient = "0x0000000000000000000000000000000000000bad" }), true,
+			fmt.Sprintf("transfer %s unlocked to 0x0000000000000000000000000000000000000bad but was sent to %s", transfer.ID, transfer.Recipient)},
+		{"OtherToken", unlock(func(u *types.Transfer) { u.Token = "0x00000000000000000000000000000000000000b1" }), true,
+			fmt.Sprintf("transfer %s unlocked 0x00000000000000000000000000000000000000b1 but locked %s", transfer.ID, testToken)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			guardian, store, pausers := newTestGuardian(t)
+			require.NoError(t, store.RecordTransfer(ctx, transfer))
+
+			require.NoError(t, guardian.CheckUnlock(ctx, tt.event))
+			if !tt.paused {
+				assert.Zero(t, pausers[types.ChainEthereum].pauses)
+				return
+			}
+			assert.Equal(t, 1, pausers[types.ChainEthereum].pauses)
+			changes := pauseTrail(t, store, types.ChainEthereum)
+			require.Len(t, changes, 1)
+			assert.Equal(t, TriggerUnlock, changes[0].Trigger)
+			assert.Equal(t, tt.reason, changes[0].Reason)
+		})
+	}
+
+	// An unlock on another chain than the transfer's destination
+	guardian, store, pausers := newTestGuardian(t)
+	require.NoError(t, store.RecordTransfer(ctx, transfer))
+	event := unlock(func(*types.Transfer) {})
+	event.ChainID = types.ChainPolygon
+	require.NoError(t, guardian.CheckUnlock(ctx, event))
+	assert.Equal(t, 1, pausers[types.ChainPolygon].pauses)
+
+	// Locks are not checked
+	event.Type = types.EventTypeLock
+	event.TransferID = "unknown"
+	require.NoError(t, guardian.CheckUnlock(ctx, event))
+	assert.Equal(t, 1, pausers[types.ChainPolygon].pauses)
+}
+
+func TestGuardian_VolumeAlarms(t *testing.T) {
+	now := time.Now()
+	store := &volumeStore{MemoryStateManager: models.NewMemoryStateManager(), now: now}
+	token := &types.SupportedToken{ChainID: types.ChainEthereum, TokenAddress: testToken, Name: "USD Coin", Symbol: "USDC", Decimals: 6, Enabled: true}
+	require.NoError(t, store.AddSupportedToken(context.Background(), token, nil))
+
+	guardian := NewGuardian(store)
+	guardian.now = func() time.Time { return now }
+	guardian.AddChain(types.ChainEthereum, &fakePauser{})
+	guardian.AddChain(types.ChainPolygon, &fakePauser{})
+
+	// 230 over the 23 hours before the last is 10 an hour
+	store.volumes = map[string]int64{
+		"ethereum>polygon/1h0m0s":  50,
+		"ethereum>polygon/24h0m0s": 280,
+		"polygon>ethereum/1h0m0s":  51,
+		"polygon>ethereum/24h0m0s": 281,
+	}
+	alarms, err := guardian.VolumeAlarms(context.Background())
+	require.NoError(t, err)
+	require.Len(t, alarms, 1)
+	assert.Equal(t, types.ChainEthereum, alarms[0].Chain)
+	assert.Equal(t, TriggerVolume, alarms[0].Trigger)
+	assert.Equal(t, "USDC volume from polygon to ethereum over the last 1h0m0s is 51 base units, over 5 times the usual 10", alarms[0].Reason)
+
+	// Routes with no usual volume are skipped
+	store.volumes = map[string]int64{"ethereum>polygon/1h0m0s": 1000, "ethereum>polygon/24h0m0s": 1000}
+	alarms, err = guardian.VolumeAlarms(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, alarms)
+
+	guardian.VolumeBaseline = guardian.VolumeWindow
+	_, err = guardian.VolumeAlarms(context.Background())
+	assert.Error(t, err)
+}
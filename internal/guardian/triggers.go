@@ -0,0 +1,183 @@
+package guardian
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/internal/supply"
+	"nexus-bridge/pkg/types"
+)
+
+// HandleSupplyBreach pauses the bridge on each chain where one of the
+// pausing supply checks of a token breached. Breaches of other checks are
+// only logged, alongside the monitor's own alert. It is meant as the supply
+// monitor's breach hook.
+func (g *Guardian) HandleSupplyBreach(ctx context.Context, tokenSupply supply.TokenSupply) {
+	for _, breach := range tokenSupply.Breaches() {
+		if !g.supplyChecks[breach.Check] {
+			fmt.Printf("Not pausing %s on %s %s check breach, which does not pause\n",
+				breach.Chain, tokenSupply.Symbol, breach.Check)
+			continue
+		}
+		alarm := Alarm{
+			Chain:   breach.Chain,
+			Trigger: TriggerSupply,
+			Reason: fmt.Sprintf("%s %s check is off by %s base units",
+				tokenSupply.Symbol, breach.Check, breach.Amount),
+		}
+		if err := g.Trip(ctx, alarm); err != nil {
+			fmt.Printf("Error pausing on supply breach: %v\n", err)
+		}
+	}
+}
+
+// Watch checks the unlock events of a chain until the channel is closed or
+// the context ends
+func (g *Guardian) Watch(ctx context.Context, events <-chan types.Event) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if err := g.CheckUnlock(ctx, event); err != nil {
+				fmt.Printf("Error checking unlock %s: %v\n", event.ID, err)
+			}
+		}
+	}
+}
+
+// CheckUnlock pauses the bridge that released tokens if the unlock does not
+// match a recorded transfer to that chain. Other events are ignored.
+func (g *Guardian) CheckUnlock(ctx context.Context, event types.Event) error {
+	if event.Type != types.EventTypeUnlock {
+		return nil
+	}
+
+	reason, err := g.unmatchedUnlock(ctx, event)
+	if err != nil || reason == "" {
+		return err
+	}
+	return g.Trip(ctx, Alarm{Chain: event.ChainID, Trigger: TriggerUnlock, Reason: reason})
+}
+
+// unmatchedUnlock explains why an unlock has no matching lock, or returns
+// an empty string if it has one
+func (g *Guardian) unmatchedUnlock(ctx context.Context, event types.Event) (string, error) {
+	unlocked := event.Transfer
+	transfer, err := g.store.GetTransfer(ctx, event.TransferID)
+	if errors.Is(err, models.ErrTransferNotFound) {
+		return fmt.Sprintf("unlock of transfer %s in %s has no recorded lock", event.TransferID, event.TxHash), nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case transfer.DestinationChain != event.ChainID:
+		return fmt.Sprintf("transfer %s unlocked on %s was sent to %s", transfer.ID, event.ChainID, transfer.DestinationChain), nil
+	case !strings.EqualFold(transfer.Token, unlocked.Token):
+		return fmt.Sprintf("transfer %s unlocked %s but locked %s", transfer.ID, unlocked.Token, transfer.Token), nil
+	case !strings.EqualFold(transfer.Recipient, unlocked.Recipient):
+		return fmt.Sprintf("transfer %s unlocked to %s but was sent to %s", transfer.ID, unlocked.Recipient, transfer.Recipient), nil
+	case unlocked.Amount == nil || transfer.Amount == nil || unlocked.Amount.Cmp(transfer.Amount.Int) != 0:
+		return fmt.Sprintf("transfer %s unlocked %s but locked %s", transfer.ID, unlocked.Amount, transfer.Amount), nil
+	}
+	return "", nil
+}
+
+// checkVolume pauses the bridges of routes whose volume spiked
+func (g *Guardian) checkVolume(ctx context.Context) {
+	alarms, err := g.VolumeAlarms(ctx)
+	if err != nil && ctx.Err() == nil {
+		fmt.Printf("Error checking transfer volume: %v\n", err)
+	}
+	for _, alarm := range alarms {
+		if err := g.Trip(ctx, alarm); err != nil {
+			fmt.Printf("Error pausing on volume spike: %v\n", err)
+		}
+	}
+}
+
+// VolumeAlarms compares the volume signed on every route between guarded
+// chains over the last VolumeWindow with its usual volume over the rest of
+// VolumeBaseline. A route with VolumeFactor times its usual volume raises
+// an alarm for its destination, where the bridge pays out. Routes without
+// usual volume are skipped.
+func (g *Guardian) VolumeAlarms(ctx context.Context) ([]Alarm, error) {
+	if g.VolumeBaseline <= g.VolumeWindow {
+		return nil, fmt.Errorf("volume baseline %s must be longer than the window %s", g.VolumeBaseline, g.VolumeWindow)
+	}
+
+	tokens, err := g.store.ListSupportedTokens(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tokens: %w", err)
+	}
+
+	var alarms []Alarm
+	for _, token := range tokens {
+		for _, chainID := range g.chainIDs() {
+			if chainID == token.ChainID {
+				continue
+			}
+			// Out of the token's chain and back into it
+			routes := [][2]types.ChainID{{token.ChainID, chainID}}
+			if _, guarded := g.pausers[token.ChainID]; guarded {
+				routes = append(routes, [2]types.ChainID{chainID, token.ChainID})
+			}
+
+			for _, route := range routes {
+				alarm, err := g.checkRoute(ctx, token, route[0], route[1])
+				if err != nil {
+					return alarms, err
+				}
+				if alarm != nil {
+					alarms = append(alarms, *alarm)
+				}
+			}
+		}
+	}
+	return alarms, nil
+}
+
+// checkRoute compares a route's recent volume with its usual volume
+func (g *Guardian) checkRoute(ctx context.Context, token types.SupportedToken, source, destination types.ChainID) (*Alarm, error) {
+	now := g.now()
+	query := models.VolumeQuery{SourceChain: source, DestinationChain: destination, Token: token.TokenAddress}
+
+	query.Since = now.Add(-g.VolumeWindow)
+	recent, err := g.store.SignedVolume(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	query.Since = now.Add(-g.VolumeBaseline)
+	total, err := g.store.SignedVolume(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// The usual volume of a window, from the baseline before it
+	usual := new(big.Int).Sub(total, recent)
+	usual.Mul(usual, big.NewInt(int64(g.VolumeWindow)))
+	usual.Quo(usual, big.NewInt(int64(g.VolumeBaseline-g.VolumeWindow)))
+	if usual.Sign() == 0 {
+		return nil, nil
+	}
+
+	threshold := new(big.Int).Mul(usual, big.NewInt(g.VolumeFactor))
+	if recent.Cmp(threshold) <= 0 {
+		return nil, nil
+	}
+	return &Alarm{
+		Chain:   destination,
+		Trigger: TriggerVolume,
+		Reason: fmt.Sprintf("%s volume from %s to %s over the last %s is %s base units, over %d times the usual %s",
+			token.Symbol, source, destination, g.VolumeWindow, recent, g.VolumeFactor, usual),
+	}, nil
+}
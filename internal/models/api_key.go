@@ -0,0 +1,193 @@
+package models
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ErrAPIKeyNotFound is returned when an API key does not exist or is revoked
+var ErrAPIKeyNotFound = errors.New("API key not found")
+
+// Role is the access level of an API caller. Each role may do everything
+// the roles below it may.
+type Role string
+
+const (
+	// RolePublic is every caller, authenticated or not
+	RolePublic Role = "public"
+	// RoleIntegrator is an application built on the bridge
+	RoleIntegrator Role = "integrator"
+	// RoleOperator runs the bridge day to day
+	RoleOperator Role = "operator"
+	// RoleAdmin changes the bridge's configuration
+	RoleAdmin Role = "admin"
+)
+
+// roleRanks orders the roles from least to most privileged
+var roleRanks = map[Role]int{
+	RolePublic:     0,
+	RoleIntegrator: 1,
+	RoleOperator:   2,
+	RoleAdmin:      3,
+}
+
+// IsValid reports whether the role is one of the known roles
+func (r Role) IsValid() bool {
+	_, known := roleRanks[r]
+	return known
+}
+
+// Allows reports whether the role grants the access of another role
+func (r Role) Allows(required Role) bool {
+	return r.IsValid() && roleRanks[r] >= roleRanks[required]
+}
+
+// APIKey is a credential issued to an integrator or operator. Only a hash
+// of the key is stored; Prefix identifies the key without revealing it.
+type APIKey struct {
+	ID        int        `json:"id" db:"id"`
+	Name      string     `json:"name" db:"name"`
+	Prefix    string     `json:"prefix" db:"prefix"`
+	KeyHash   string     `json:"-" db:"key_hash"`
+	Role      Role       `json:"role" db:"role"`
+	RateLimit int        `json:"rate_limit" db:"rate_limit"`
+	CreatedBy string     `json:"created_by" db:"created_by"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+}
+
+// HashAPIKey returns the stored form of an API key. Keys are long random
+// strings, so a plain SHA-256 is enough to make a leaked table useless.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Validate validates an API key before it is created
+func (k *APIKey) Validate() error {
+	if k.Name == "" {
+		return fmt.Errorf("API key name is required")
+	}
+	if len(k.Name) > MaxActorLength {
+		return fmt.Errorf("API key name cannot exceed %d characters", MaxActorLength)
+	}
+	if len(k.KeyHash) != sha256.Size*2 {
+		return fmt.Errorf("API key hash is required")
+	}
+	if !k.Role.IsValid() || k.Role == RolePublic {
+		return fmt.Errorf("invalid API key role: %s", k.Role)
+	}
+	if k.RateLimit < 0 {
+		return fmt.Errorf("rate limit cannot be negative")
+	}
+	return nil
+}
+
+// APIKeyStore persists API keys
+type APIKeyStore interface {
+	CreateAPIKey(ctx context.Context, key *APIKey, audit AuditFunc) error
+
+	// GetAPIKeyByHash returns the active key with a hash
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (*APIKey, error)
+
+	// ListAPIKeys returns every key, including revoked ones, oldest first
+	ListAPIKeys(ctx context.Context) ([]APIKey, error)
+
+	// RevokeAPIKey permanently disables an active key, returning it
+	RevokeAPIKey(ctx context.Context, id int, audit AuditFunc) (*APIKey, error)
+}
+
+// APIKeyRepository handles database operations for API keys
+type APIKeyRepository struct {
+	db *sqlx.DB
+}
+
+// NewAPIKeyRepository creates a new API key repository
+func NewAPIKeyRepository(db *sqlx.DB) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+const apiKeyColumns = `
+	id, name, prefix, key_hash, role, rate_limit, COALESCE(created_by, '') AS created_by,
+	created_at, revoked_at`
+
+// Create inserts a new API key
+func (r *APIKeyRepository) Create(ctx context.Context, key *APIKey, audit AuditFunc) error {
+	if err := key.Validate(); err != nil {
+		return fmt.Errorf("API key validation failed: %w", err)
+	}
+
+	query := `
+		INSERT INTO api_keys (name, prefix, key_hash, role, rate_limit, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at`
+
+	return inAuditedTx(ctx, r.db, audit, func(tx *sqlx.Tx) (interface{}, error) {
+		err := tx.QueryRowxContext(ctx, query,
+			key.Name, key.Prefix, key.KeyHash, key.Role, key.RateLimit, nullableString(key.CreatedBy),
+		).Scan(&key.ID, &key.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create API key: %w", err)
+		}
+		return key, nil
+	})
+}
+
+// GetByHash returns the active key with a hash
+func (r *APIKeyRepository) GetByHash(ctx context.Context, keyHash string) (*APIKey, error) {
+	var key APIKey
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE key_hash = $1 AND revoked_at IS NULL`
+
+	if err := r.db.GetContext(ctx, &key, query, keyHash); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrAPIKeyNotFound
+		}
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	return &key, nil
+}
+
+// List returns every key in creation order
+func (r *APIKeyRepository) List(ctx context.Context) ([]APIKey, error) {
+	keys := []APIKey{}
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys ORDER BY id ASC`
+
+	if err := r.db.SelectContext(ctx, &keys, query); err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// Revoke disables an active key, returning it
+func (r *APIKeyRepository) Revoke(ctx context.Context, id int, audit AuditFunc) (*APIKey, error) {
+	var key APIKey
+	query := `
+		UPDATE api_keys
+		SET revoked_at = $1
+		WHERE id = $2 AND revoked_at IS NULL
+		RETURNING ` + apiKeyColumns
+
+	err := inAuditedTx(ctx, r.db, audit, func(tx *sqlx.Tx) (interface{}, error) {
+		if err := tx.GetContext(ctx, &key, query, time.Now(), id); err != nil {
+			if err == sql.ErrNoRows {
+				return nil, fmt.Errorf("%w: %d", ErrAPIKeyNotFound, id)
+			}
+			return nil, fmt.Errorf("failed to revoke API key: %w", err)
+		}
+		return &key, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
@@ -0,0 +1,157 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"nexus-bridge/internal/models/testutil"
+)
+
+func TestStateManager_APIKeyContract(t *testing.T) {
+	runAPIKeyContract(t, func(t *testing.T) APIKeyStore {
+		db := testutil.SetupTestDB(t)
+		t.Cleanup(func() { testutil.CleanupTestDB(t, db) })
+		return NewStateManager(db)
+	})
+}
+
+func TestMemoryStateManager_APIKeyContract(t *testing.T) {
+	runAPIKeyContract(t, func(t *testing.T) APIKeyStore {
+		return NewMemoryStateManager()
+	})
+}
+
+func TestRole_Allows(t *testing.T) {
+	tests := []struct {
+		role     Role
+		required Role
+		allowed  bool
+	}{
+		{RolePublic, RolePublic, true},
+		{RolePublic, RoleIntegrator, false},
+		{RoleIntegrator, RolePublic, true},
+		{RoleOperator, RoleIntegrator, true},
+		{RoleOperator, RoleAdmin, false},
+		{RoleAdmin, RoleOperator, true},
+		{Role("root"), RolePublic, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.role.Allows(tt.required); got != tt.allowed {
+			t.Errorf("Expected %s allows %s to be %v, got %v", tt.role, tt.required, tt.allowed, got)
+		}
+	}
+}
+
+func TestAPIKey_Validate(t *testing.T) {
+	valid := func() APIKey {
+		return APIKey{Name: "explorer", Prefix: "nbk_1234", KeyHash: HashAPIKey("nbk_secret"), Role: RoleIntegrator}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*APIKey)
+		valid  bool
+	}{
+		{"Valid", func(k *APIKey) {}, true},
+		{"MissingName", func(k *APIKey) { k.Name = "" }, false},
+		{"LongestName", func(k *APIKey) { k.Name = strings.Repeat("x", MaxActorLength) }, true},
+		{"NameTooLong", func(k *APIKey) { k.Name = strings.Repeat("x", MaxActorLength+1) }, false},
+		{"MissingHash", func(k *APIKey) { k.KeyHash = "" }, false},
+		{"PublicRole", func(k *APIKey) { k.Role = RolePublic }, false},
+		{"UnknownRole", func(k *APIKey) { k.Role = "root" }, false},
+		{"NegativeRateLimit", func(k *APIKey) { k.RateLimit = -1 }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := valid()
+			tt.modify(&key)
+			err := key.Validate()
+			if tt.valid && err != nil {
+				t.Errorf("Expected valid key, got %v", err)
+			}
+			if !tt.valid && err == nil {
+				t.Error("Expected validation error")
+			}
+		})
+	}
+}
+
+func runAPIKeyContract(t *testing.T, newStore func(t *testing.T) APIKeyStore) {
+	ctx := context.Background()
+
+	t.Run("CreateAndLookUp", func(t *testing.T) {
+		store := newStore(t)
+		key := &APIKey{
+			Name:      "explorer",
+			Prefix:    "nbk_1234",
+			KeyHash:   HashAPIKey("nbk_explorer"),
+			Role:      RoleIntegrator,
+			RateLimit: 120,
+			CreatedBy: "alice",
+		}
+		if err := store.CreateAPIKey(ctx, key, nil); err != nil {
+			t.Fatalf("Failed to create API key: %v", err)
+		}
+		if key.ID == 0 || key.CreatedAt.IsZero() {
+			t.Errorf("Expected ID and creation time to be set, got %+v", key)
+		}
+
+		found, err := store.GetAPIKeyByHash(ctx, HashAPIKey("nbk_explorer"))
+		if err != nil {
+			t.Fatalf("Failed to get API key: %v", err)
+		}
+		if found.ID != key.ID || found.Role != RoleIntegrator || found.RateLimit != 120 || found.CreatedBy != "alice" {
+			t.Errorf("Unexpected API key: %+v", found)
+		}
+
+		if _, err := store.GetAPIKeyByHash(ctx, HashAPIKey("nbk_unknown")); !errors.Is(err, ErrAPIKeyNotFound) {
+			t.Errorf("Expected ErrAPIKeyNotFound, got %v", err)
+		}
+
+		duplicate := *key
+		duplicate.Name = "copy"
+		if err := store.CreateAPIKey(ctx, &duplicate, nil); err == nil {
+			t.Error("Expected a duplicate key hash to be rejected")
+		}
+	})
+
+	t.Run("Revoke", func(t *testing.T) {
+		store := newStore(t)
+		first := &APIKey{Name: "first", Prefix: "nbk_1", KeyHash: HashAPIKey("nbk_first"), Role: RoleOperator}
+		second := &APIKey{Name: "second", Prefix: "nbk_2", KeyHash: HashAPIKey("nbk_second"), Role: RoleAdmin}
+		for _, key := range []*APIKey{first, second} {
+			if err := store.CreateAPIKey(ctx, key, nil); err != nil {
+				t.Fatalf("Failed to create API key: %v", err)
+			}
+		}
+
+		revoked, err := store.RevokeAPIKey(ctx, first.ID, nil)
+		if err != nil {
+			t.Fatalf("Failed to revoke API key: %v", err)
+		}
+		if revoked.ID != first.ID || revoked.RevokedAt == nil {
+			t.Errorf("Expected the revoked key back, got %+v", revoked)
+		}
+		if _, err := store.GetAPIKeyByHash(ctx, first.KeyHash); !errors.Is(err, ErrAPIKeyNotFound) {
+			t.Errorf("Expected a revoked key not to be found, got %v", err)
+		}
+		if _, err := store.RevokeAPIKey(ctx, first.ID, nil); !errors.Is(err, ErrAPIKeyNotFound) {
+			t.Errorf("Expected revoking twice to fail, got %v", err)
+		}
+
+		keys, err := store.ListAPIKeys(ctx)
+		if err != nil {
+			t.Fatalf("Failed to list API keys: %v", err)
+		}
+		if len(keys) != 2 || keys[0].ID != first.ID || keys[1].ID != second.ID {
+			t.Fatalf("Expected both keys oldest first, got %+v", keys)
+		}
+		if keys[0].RevokedAt == nil || keys[1].RevokedAt != nil {
+			t.Errorf("Expected only the first key to be revoked, got %+v", keys)
+		}
+	})
+}
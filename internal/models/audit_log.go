@@ -0,0 +1,231 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Audited event types
+const (
+	AuditAPIKeyCreated = "api_key_created"
+	AuditAPIKeyRevoked = "api_key_revoked"
+
+	AuditBridgePaused   = "bridge_paused"
+	AuditBridgeUnpaused = "bridge_unpaused"
+
+	AuditTokenCreated  = "token_created"
+	AuditTokenEnabled  = "token_enabled"
+	AuditTokenDisabled = "token_disabled"
+	AuditTokenDeleted  = "token_deleted"
+
+	AuditTokenLimitSet     = "token_limit_set"
+	AuditTokenLimitRemoved = "token_limit_removed"
+
+	AuditTransferApproved       = "transfer_approved"
+	AuditTransferRejected       = "transfer_rejected"
+	AuditTransferScreened       = "transfer_screened"
+	AuditTransferOverCap        = "transfer_over_cap"
+	AuditTransferDelayCancelled = "transfer_delay_cancelled"
+
+	AuditWebhookCreated          = "webhook_created"
+	AuditWebhookDeleted          = "webhook_deleted"
+	AuditWebhookDeliveryReplayed = "webhook_delivery_replayed"
+)
+
+// Audited entity types
+const (
+	AuditEntityAPIKey          = "api_key"
+	AuditEntityBridge          = "bridge"
+	AuditEntitySupportedToken  = "supported_token"
+	AuditEntityTokenLimit      = "token_limit"
+	AuditEntityTransfer        = "transfer"
+	AuditEntityWebhook         = "webhook"
+	AuditEntityWebhookDelivery = "webhook_delivery"
+)
+
+// MaxActorLength is the longest name recorded as the author of a change:
+// an operator, an API key or a token subject
+const MaxActorLength = 64
+
+// ErrAuditFailed is wrapped by store methods whose change was rolled back
+// because its audit entry could not be recorded
+var ErrAuditFailed = errors.New("failed to record audit entry")
+
+// AuditFunc builds the audit entry of a change from the entity as changed,
+// which is nil for deletions. Store methods taking one record the entry in
+// the transaction making the change, so a change whose entry cannot be
+// recorded is rolled back. A nil AuditFunc records nothing.
+type AuditFunc func(changed interface{}) (*AuditEntry, error)
+
+// AuditEntry records a change made by an operator
+type AuditEntry struct {
+	ID          int             `json:"id" db:"id"`
+	EventType   string          `json:"event_type" db:"event_type"`
+	EntityType  string          `json:"entity_type" db:"entity_type"`
+	EntityID    string          `json:"entity_id" db:"entity_id"`
+	OldValues   json.RawMessage `json:"old_values" db:"old_values"`
+	NewValues   json.RawMessage `json:"new_values" db:"new_values"`
+	PerformedBy string          `json:"performed_by" db:"performed_by"`
+	PerformedAt time.Time       `json:"performed_at" db:"performed_at"`
+	Description string          `json:"description" db:"description"`
+}
+
+// NewAuditEntry creates an audit entry, encoding the entity before and after
+// the change. Either may be nil for creations and deletions.
+func NewAuditEntry(eventType, entityType, entityID, performedBy string, oldValue, newValue interface{}) (*AuditEntry, error) {
+	entry := &AuditEntry{
+		EventType:   eventType,
+		EntityType:  entityType,
+		EntityID:    entityID,
+		PerformedBy: performedBy,
+	}
+
+	var err error
+	if entry.OldValues, err = encodeAuditValue(oldValue); err != nil {
+		return nil, err
+	}
+	if entry.NewValues, err = encodeAuditValue(newValue); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// encodeAuditValue encodes an audited value as JSON, keeping nil as null
+func encodeAuditValue(value interface{}) (json.RawMessage, error) {
+	if value == nil {
+		return json.RawMessage("null"), nil
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode audit value: %w", err)
+	}
+	return encoded, nil
+}
+
+// validateAuditEntry validates an audit entry before it is recorded
+func validateAuditEntry(entry *AuditEntry) error {
+	if entry.EventType == "" {
+		return fmt.Errorf("audit event type is required")
+	}
+	if entry.EntityType == "" || entry.EntityID == "" {
+		return fmt.Errorf("audited entity is required")
+	}
+	if entry.PerformedBy == "" {
+		return fmt.Errorf("audit actor is required")
+	}
+	if len(entry.PerformedBy) > MaxActorLength {
+		return fmt.Errorf("audit actor cannot exceed %d characters", MaxActorLength)
+	}
+	return nil
+}
+
+// AuditLogRepository handles database operations for the audit log
+type AuditLogRepository struct {
+	db *sqlx.DB
+}
+
+// NewAuditLogRepository creates a new audit log repository
+func NewAuditLogRepository(db *sqlx.DB) *AuditLogRepository {
+	return &AuditLogRepository{db: db}
+}
+
+// Create appends an entry to the audit log
+func (r *AuditLogRepository) Create(ctx context.Context, entry *AuditEntry) error {
+	return insertAuditEntry(ctx, r.db, entry)
+}
+
+// insertAuditEntry appends an entry to the audit log
+func insertAuditEntry(ctx context.Context, q sqlx.QueryerContext, entry *AuditEntry) error {
+	if err := validateAuditEntry(entry); err != nil {
+		return fmt.Errorf("audit entry validation failed: %w", err)
+	}
+
+	query := `
+		INSERT INTO audit_log (event_type, entity_type, entity_id, old_values, new_values, performed_by, description)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, performed_at`
+
+	row := q.QueryRowxContext(ctx, query,
+		entry.EventType, entry.EntityType, entry.EntityID, nullableJSON(entry.OldValues),
+		nullableJSON(entry.NewValues), entry.PerformedBy, entry.Description)
+	if err := row.Scan(&entry.ID, &entry.PerformedAt); err != nil {
+		return fmt.Errorf("failed to record audit entry: %w", err)
+	}
+
+	return nil
+}
+
+// recordAudit records the entry audit builds for a change inside the
+// transaction making it
+func recordAudit(ctx context.Context, tx *sqlx.Tx, audit AuditFunc, changed interface{}) error {
+	if audit == nil {
+		return nil
+	}
+
+	entry, err := audit(changed)
+	if err == nil {
+		err = insertAuditEntry(ctx, tx, entry)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrAuditFailed, err)
+	}
+	return nil
+}
+
+// inAuditedTx runs change in a transaction, recording the audit entry of
+// the entity it returns before committing
+func inAuditedTx(ctx context.Context, db *sqlx.DB, audit AuditFunc, change func(tx *sqlx.Tx) (interface{}, error)) error {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	changed, err := change(tx)
+	if err != nil {
+		return err
+	}
+	if err := recordAudit(ctx, tx, audit, changed); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// GetByEntity returns the audit trail of an entity, oldest first
+func (r *AuditLogRepository) GetByEntity(ctx context.Context, entityType, entityID string) ([]AuditEntry, error) {
+	entries := []AuditEntry{}
+	query := `
+		SELECT id, event_type, entity_type, entity_id,
+			COALESCE(old_values, 'null'::jsonb) AS old_values,
+			COALESCE(new_values, 'null'::jsonb) AS new_values,
+			COALESCE(performed_by, '') AS performed_by, performed_at,
+			COALESCE(description, '') AS description
+		FROM audit_log
+		WHERE entity_type = $1 AND entity_id = $2
+		ORDER BY performed_at ASC, id ASC`
+
+	if err := r.db.SelectContext(ctx, &entries, query, entityType, entityID); err != nil {
+		return nil, fmt.Errorf("failed to get audit trail: %w", err)
+	}
+
+	return entries, nil
+}
+
+// nullableJSON stores empty and null JSON values as SQL NULL
+func nullableJSON(value json.RawMessage) interface{} {
+	if len(value) == 0 || string(value) == "null" {
+		return nil
+	}
+	return []byte(value)
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"fmt"
+
+	"nexus-bridge/internal/config"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+)
+
+// Connect opens a Postgres connection pool configured from cfg
+func Connect(cfg config.DatabaseConfig) (*sqlx.DB, error) {
+	db, err := sqlx.Connect("postgres", cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+
+	return db, nil
+}
@@ -0,0 +1,297 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"nexus-bridge/pkg/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ErrDelayNotFound is returned when a transfer has no delay, or none still
+// waiting where one is required
+var ErrDelayNotFound = errors.New("transfer is not delayed")
+
+// DelayStatus represents the lifecycle of a transfer delay
+type DelayStatus string
+
+const (
+	// DelayWaiting holds the transfer until its release time
+	DelayWaiting DelayStatus = "waiting"
+	// DelayReleased returned the transfer to the status it was delayed in
+	DelayReleased DelayStatus = "released"
+	// DelayCancelled failed the transfer
+	DelayCancelled DelayStatus = "cancelled"
+)
+
+// TransferDelay holds a large transfer back from execution for a while, so
+// operators can cancel it if it turns out to be an exploit
+type TransferDelay struct {
+	ID             int                  `json:"id" db:"id"`
+	TransferID     string               `json:"transfer_id" db:"transfer_id"`
+	PreviousStatus types.TransferStatus `json:"previous_status" db:"previous_status"`
+	Status         DelayStatus          `json:"status" db:"status"`
+	ReleaseAt      time.Time            `json:"release_at" db:"release_at"`
+	CancelledBy    string               `json:"cancelled_by,omitempty" db:"cancelled_by"`
+	Reason         string               `json:"reason,omitempty" db:"reason"`
+	CreatedAt      time.Time            `json:"created_at" db:"created_at"`
+	ResolvedAt     *time.Time           `json:"resolved_at,omitempty" db:"resolved_at"`
+}
+
+// DelayStore persists the delays of large transfers. Delayed transfers
+// are in the delayed status until they are released or cancelled.
+type DelayStore interface {
+	// DelayTransfer holds a transfer in the delayed status until releaseAt.
+	// A transfer is delayed at most once: if it already has a delay, that
+	// delay is returned unchanged.
+	DelayTransfer(ctx context.Context, transferID string, releaseAt time.Time) (*TransferDelay, error)
+
+	// GetTransferDelay returns the delay of a transfer
+	GetTransferDelay(ctx context.Context, transferID string) (*TransferDelay, error)
+
+	// ListWaitingDelays returns the delays still waiting, soonest release
+	// first
+	ListWaitingDelays(ctx context.Context) ([]TransferDelay, error)
+
+	// ReleaseDueDelays releases the waiting delays due at a time, returning
+	// their transfers to the status they were delayed in
+	ReleaseDueDelays(ctx context.Context, now time.Time) ([]TransferDelay, error)
+
+	// CancelTransferDelay cancels the waiting delay of a transfer and fails
+	// the transfer
+	CancelTransferDelay(ctx context.Context, transferID, operator, reason string, audit AuditFunc) (*TransferDelay, error)
+}
+
+// DelayRepository handles database operations for transfer delays
+type DelayRepository struct {
+	db *sqlx.DB
+}
+
+// NewDelayRepository creates a new delay repository
+func NewDelayRepository(db *sqlx.DB) *DelayRepository {
+	return &DelayRepository{db: db}
+}
+
+const delayColumns = `
+	id, transfer_id, previous_status, status, release_at, COALESCE(cancelled_by, '') AS cancelled_by,
+	COALESCE(reason, '') AS reason, created_at, resolved_at`
+
+// Delay moves a transfer to the delayed status and records when it is
+// released, unless the transfer was delayed before
+func (r *DelayRepository) Delay(ctx context.Context, transferID string, releaseAt time.Time) (*TransferDelay, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var status types.TransferStatus
+	err = tx.GetContext(ctx, &status, `SELECT status FROM transfers WHERE id = $1 FOR UPDATE`, transferID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", ErrTransferNotFound, transferID)
+		}
+		return nil, fmt.Errorf("failed to delay transfer: %w", err)
+	}
+
+	existing, err := getDelay(ctx, tx, transferID, false)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, ErrDelayNotFound) {
+		return nil, err
+	}
+
+	now := time.Now()
+	_, err = tx.ExecContext(ctx, `UPDATE transfers SET status = $1, updated_at = $2 WHERE id = $3`,
+		types.StatusDelayed, now, transferID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delay transfer: %w", err)
+	}
+
+	delay := &TransferDelay{
+		TransferID:     transferID,
+		PreviousStatus: status,
+		Status:         DelayWaiting,
+		ReleaseAt:      releaseAt,
+	}
+	query := `
+		INSERT INTO transfer_delays (transfer_id, previous_status, status, release_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at`
+
+	err = tx.QueryRowxContext(ctx, query, transferID, status, DelayWaiting, releaseAt, now).Scan(&delay.ID, &delay.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create delay: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit delay: %w", err)
+	}
+	return delay, nil
+}
+
+// Get returns the delay of a transfer
+func (r *DelayRepository) Get(ctx context.Context, transferID string) (*TransferDelay, error) {
+	return getDelay(ctx, r.db, transferID, false)
+}
+
+// ListWaiting returns the waiting delays, soonest release first
+func (r *DelayRepository) ListWaiting(ctx context.Context) ([]TransferDelay, error) {
+	delays := []TransferDelay{}
+	query := `SELECT ` + delayColumns + ` FROM transfer_delays WHERE status = $1 ORDER BY release_at ASC, id ASC`
+
+	if err := r.db.SelectContext(ctx, &delays, query, DelayWaiting); err != nil {
+		return nil, fmt.Errorf("failed to list delays: %w", err)
+	}
+	return delays, nil
+}
+
+// ReleaseDue releases the waiting delays due at a time. Their rows are
+// locked so concurrent relayers release each delay once.
+func (r *DelayRepository) ReleaseDue(ctx context.Context, now time.Time) ([]TransferDelay, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	delays := []TransferDelay{}
+	query := `
+		SELECT ` + delayColumns + `
+		FROM transfer_delays
+		WHERE status = $1 AND release_at <= $2
+		ORDER BY release_at ASC, id ASC
+		FOR UPDATE SKIP LOCKED`
+
+	if err := tx.SelectContext(ctx, &delays, query, DelayWaiting, now); err != nil {
+		return nil, fmt.Errorf("failed to get due delays: %w", err)
+	}
+
+	resolvedAt := time.Now()
+	for i := range delays {
+		delays[i].Status = DelayReleased
+		delays[i].ResolvedAt = &resolvedAt
+
+		_, err := tx.ExecContext(ctx, `UPDATE transfer_delays SET status = $1, resolved_at = $2 WHERE id = $3`,
+			DelayReleased, resolvedAt, delays[i].ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to release delay: %w", err)
+		}
+		_, err = tx.ExecContext(ctx, `UPDATE transfers SET status = $1, updated_at = $2 WHERE id = $3 AND status = $4`,
+			delays[i].PreviousStatus, resolvedAt, delays[i].TransferID, types.StatusDelayed)
+		if err != nil {
+			return nil, fmt.Errorf("failed to release delayed transfer: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit released delays: %w", err)
+	}
+	return delays, nil
+}
+
+// Cancel cancels the waiting delay of a transfer and fails the transfer
+func (r *DelayRepository) Cancel(ctx context.Context, transferID, operator, reason string, audit AuditFunc) (*TransferDelay, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	delay, err := getDelay(ctx, tx, transferID, true)
+	if err != nil {
+		return nil, err
+	}
+	if delay.Status != DelayWaiting {
+		return nil, fmt.Errorf("%w: %s was %s", ErrDelayNotFound, transferID, delay.Status)
+	}
+
+	now := time.Now()
+	delay.cancel(operator, reason, now)
+	_, err = tx.ExecContext(ctx, `UPDATE transfer_delays SET status = $1, cancelled_by = $2, reason = $3, resolved_at = $4 WHERE id = $5`,
+		delay.Status, operator, nullableString(reason), now, delay.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel delay: %w", err)
+	}
+	_, err = tx.ExecContext(ctx, `UPDATE transfers SET status = $1, updated_at = $2 WHERE id = $3`,
+		types.StatusFailed, now, transferID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fail cancelled transfer: %w", err)
+	}
+
+	if err := recordAudit(ctx, tx, audit, delay); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit cancellation: %w", err)
+	}
+	return delay, nil
+}
+
+// DelayThreshold returns the lowest delay threshold among the limits of a
+// transfer, or nil if none sets one
+func DelayThreshold(limits []TokenLimit) *big.Int {
+	var threshold *big.Int
+	for _, limit := range limits {
+		if limit.DelayThreshold == nil || limit.DelayThreshold.Int == nil {
+			continue
+		}
+		if threshold == nil || limit.DelayThreshold.Cmp(threshold) < 0 {
+			threshold = limit.DelayThreshold.Int
+		}
+	}
+	return threshold
+}
+
+// cancel marks a delay cancelled by an operator
+func (d *TransferDelay) cancel(operator, reason string, at time.Time) {
+	d.Status = DelayCancelled
+	d.CancelledBy = operator
+	d.Reason = reason
+	d.ResolvedAt = &at
+}
+
+// getDelay loads the delay of a transfer, optionally locking it for the
+// rest of a transaction
+func getDelay(ctx context.Context, q sqlx.QueryerContext, transferID string, lock bool) (*TransferDelay, error) {
+	query := `SELECT ` + delayColumns + ` FROM transfer_delays WHERE transfer_id = $1`
+	if lock {
+		query += ` FOR UPDATE`
+	}
+
+	var delay TransferDelay
+	if err := sqlx.GetContext(ctx, q, &delay, query, transferID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", ErrDelayNotFound, transferID)
+		}
+		return nil, fmt.Errorf("failed to get delay: %w", err)
+	}
+	return &delay, nil
+}
+
+// copyDelay returns a deep copy of a delay
+func copyDelay(delay TransferDelay) TransferDelay {
+	if delay.ResolvedAt != nil {
+		resolvedAt := *delay.ResolvedAt
+		delay.ResolvedAt = &resolvedAt
+	}
+	return delay
+}
+
+// sortDelaysByRelease orders delays by (release_at, id) ascending
+func sortDelaysByRelease(delays []TransferDelay) {
+	sort.SliceStable(delays, func(i, j int) bool {
+		if delays[i].ReleaseAt.Equal(delays[j].ReleaseAt) {
+			return delays[i].ID < delays[j].ID
+		}
+		return delays[i].ReleaseAt.Before(delays[j].ReleaseAt)
+	})
+}
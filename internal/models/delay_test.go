@@ -0,0 +1,159 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"nexus-bridge/internal/models/testutil"
+	"nexus-bridge/pkg/types"
+)
+
+// delayStateStore is a Store that also manages transfer delays
+type delayStateStore interface {
+	Store
+	DelayStore
+}
+
+func TestStateManager_DelayContract(t *testing.T) {
+	runDelayContract(t, func(t *testing.T) delayStateStore {
+		db := testutil.SetupTestDB(t)
+		t.Cleanup(func() { testutil.CleanupTestDB(t, db) })
+		return NewStateManager(db)
+	})
+}
+
+func TestMemoryStateManager_DelayContract(t *testing.T) {
+	runDelayContract(t, func(t *testing.T) delayStateStore {
+		return NewMemoryStateManager()
+	})
+}
+
+func mustDelay(t *testing.T, store DelayStore, transferID string, releaseAt time.Time) *TransferDelay {
+	t.Helper()
+	delay, err := store.DelayTransfer(context.Background(), transferID, releaseAt)
+	if err != nil {
+		t.Fatalf("Failed to delay transfer: %v", err)
+	}
+	return delay
+}
+
+// mustRecordSigned records a contract transfer that collected its signatures
+func mustRecordSigned(t *testing.T, store Store, n int) types.Transfer {
+	t.Helper()
+	transfer := contractTransfer(n)
+	mustRecord(t, store, transfer)
+	if err := store.UpdateTransferStatus(context.Background(), transfer.ID, types.StatusSigned); err != nil {
+		t.Fatalf("Failed to update status: %v", err)
+	}
+	return transfer
+}
+
+func runDelayContract(t *testing.T, newStore func(t *testing.T) delayStateStore) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	t.Run("DelayOnce", func(t *testing.T) {
+		store := newStore(t)
+		transfer := mustRecordSigned(t, store, 1)
+
+		delay := mustDelay(t, store, transfer.ID, now.Add(time.Hour))
+		if delay.ID == 0 || delay.Status != DelayWaiting || delay.PreviousStatus != types.StatusSigned || delay.CreatedAt.IsZero() {
+			t.Errorf("Unexpected delay: %+v", delay)
+		}
+		assertTransferStatus(t, store, transfer.ID, types.StatusDelayed)
+
+		// Delaying again keeps the first delay
+		again := mustDelay(t, store, transfer.ID, now.Add(48*time.Hour))
+		if again.ID != delay.ID || !again.ReleaseAt.Equal(now.Add(time.Hour)) {
+			t.Errorf("Expected the first delay, got %+v", again)
+		}
+
+		got, err := store.GetTransferDelay(ctx, transfer.ID)
+		if err != nil || got.ID != delay.ID {
+			t.Errorf("Expected the delay, got %+v/%v", got, err)
+		}
+		if _, err := store.GetTransferDelay(ctx, contractTransfer(2).ID); !errors.Is(err, ErrDelayNotFound) {
+			t.Errorf("Expected ErrDelayNotFound, got %v", err)
+		}
+		if _, err := store.DelayTransfer(ctx, contractTransfer(2).ID, now); !errors.Is(err, ErrTransferNotFound) {
+			t.Errorf("Expected ErrTransferNotFound, got %v", err)
+		}
+	})
+
+	t.Run("ReleaseDue", func(t *testing.T) {
+		store := newStore(t)
+		late, soon := mustRecordSigned(t, store, 1), mustRecordSigned(t, store, 2)
+		mustDelay(t, store, late.ID, now.Add(2*time.Hour))
+		mustDelay(t, store, soon.ID, now.Add(time.Hour))
+
+		waiting, err := store.ListWaitingDelays(ctx)
+		if err != nil {
+			t.Fatalf("Failed to list delays: %v", err)
+		}
+		if len(waiting) != 2 || waiting[0].TransferID != soon.ID || waiting[1].TransferID != late.ID {
+			t.Fatalf("Expected both delays soonest first, got %+v", waiting)
+		}
+
+		released, err := store.ReleaseDueDelays(ctx, now.Add(30*time.Minute))
+		if err != nil || len(released) != 0 {
+			t.Fatalf("Expected nothing due, got %+v/%v", released, err)
+		}
+
+		released, err = store.ReleaseDueDelays(ctx, now.Add(time.Hour))
+		if err != nil {
+			t.Fatalf("Failed to release delays: %v", err)
+		}
+		if len(released) != 1 || released[0].TransferID != soon.ID || released[0].Status != DelayReleased || released[0].ResolvedAt == nil {
+			t.Fatalf("Expected the sooner delay released, got %+v", released)
+		}
+		assertTransferStatus(t, store, soon.ID, types.StatusSigned)
+		assertTransferStatus(t, store, late.ID, types.StatusDelayed)
+
+		// A released transfer is not delayed again
+		delay := mustDelay(t, store, soon.ID, now.Add(time.Hour))
+		if delay.Status != DelayReleased {
+			t.Errorf("Expected the released delay, got %+v", delay)
+		}
+		assertTransferStatus(t, store, soon.ID, types.StatusSigned)
+
+		waiting, err = store.ListWaitingDelays(ctx)
+		if err != nil || len(waiting) != 1 || waiting[0].TransferID != late.ID {
+			t.Errorf("Expected the later delay waiting, got %+v/%v", waiting, err)
+		}
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		store := newStore(t)
+		transfer := mustRecordSigned(t, store, 1)
+		mustDelay(t, store, transfer.ID, now.Add(time.Hour))
+
+		delay, err := store.CancelTransferDelay(ctx, transfer.ID, "alice", "recipient drained the pool on polygon", nil)
+		if err != nil {
+			t.Fatalf("Failed to cancel delay: %v", err)
+		}
+		if delay.Status != DelayCancelled || delay.CancelledBy != "alice" || delay.Reason == "" || delay.ResolvedAt == nil {
+			t.Errorf("Unexpected cancelled delay: %+v", delay)
+		}
+		assertTransferStatus(t, store, transfer.ID, types.StatusFailed)
+
+		got, err := store.GetTransferDelay(ctx, transfer.ID)
+		if err != nil || got.Status != DelayCancelled || got.CancelledBy != "alice" {
+			t.Errorf("Expected the cancelled delay, got %+v/%v", got, err)
+		}
+
+		// Only waiting delays can be cancelled, and cancelled ones are not released
+		if _, err := store.CancelTransferDelay(ctx, transfer.ID, "bob", "again", nil); !errors.Is(err, ErrDelayNotFound) {
+			t.Errorf("Expected ErrDelayNotFound, got %v", err)
+		}
+		if _, err := store.CancelTransferDelay(ctx, contractTransfer(2).ID, "bob", "missing", nil); !errors.Is(err, ErrDelayNotFound) {
+			t.Errorf("Expected ErrDelayNotFound, got %v", err)
+		}
+		released, err := store.ReleaseDueDelays(ctx, now.Add(2*time.Hour))
+		if err != nil || len(released) != 0 {
+			t.Errorf("Expected nothing released, got %+v/%v", released, err)
+		}
+		assertTransferStatus(t, store, transfer.ID, types.StatusFailed)
+	})
+}
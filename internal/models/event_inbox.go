@@ -0,0 +1,211 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"nexus-bridge/pkg/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// InboxStatus represents the processing status of an observed event
+type InboxStatus string
+
+const (
+	InboxStatusReceived   InboxStatus = "received"
+	InboxStatusProcessing InboxStatus = "processing"
+	InboxStatusProcessed  InboxStatus = "processed"
+	InboxStatusFailed     InboxStatus = "failed"
+)
+
+// EventInbox stores observed chain events exactly once, keyed by
+// (chain, tx hash, log index), and tracks whether they have been handled
+type EventInbox interface {
+	// Record stores an event, returning false if it was already recorded
+	Record(ctx context.Context, event types.Event) (bool, error)
+
+	// Claim moves a received or failed event to processing. It returns false
+	// if the event is already processed, being processed, or has used up
+	// maxAttempts.
+	Claim(ctx context.Context, event types.Event, maxAttempts int) (bool, error)
+
+	// MarkProcessed marks a claimed event as handled
+	MarkProcessed(ctx context.Context, event types.Event) error
+
+	// MarkFailed releases a claimed event so it can be retried
+	MarkFailed(ctx context.Context, event types.Event, cause error) error
+
+	// ListPending returns received events and failed events with attempts
+	// left under maxAttempts, oldest first
+	ListPending(ctx context.Context, maxAttempts, limit int) ([]types.Event, error)
+
+	// ReleaseStale returns events stuck in processing since before the cutoff
+	// to received, e.g. after a crash. It returns the number released.
+	ReleaseStale(ctx context.Context, before time.Time) (int, error)
+}
+
+// EventInboxRepository handles database operations for the event inbox
+type EventInboxRepository struct {
+	db *sqlx.DB
+}
+
+// NewEventInboxRepository creates a new event inbox repository
+func NewEventInboxRepository(db *sqlx.DB) *EventInboxRepository {
+	return &EventInboxRepository{db: db}
+}
+
+var _ EventInbox = (*EventInboxRepository)(nil)
+
+// Record inserts an event into the inbox, ignoring duplicates
+func (r *EventInboxRepository) Record(ctx context.Context, event types.Event) (bool, error) {
+	if event.TxHash == "" {
+		return false, fmt.Errorf("event tx hash is required")
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	query := `
+		INSERT INTO event_inbox (
+			event_id, chain_id, tx_hash, log_index, block_number, event_type,
+			transfer_id, payload, status
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (chain_id, tx_hash, log_index) DO NOTHING`
+
+	result, err := r.db.ExecContext(ctx, query,
+		event.ID, event.ChainID, event.TxHash, event.LogIndex, event.BlockNumber,
+		event.Type, event.TransferID, payload, InboxStatusReceived)
+	if err != nil {
+		return false, fmt.Errorf("failed to record event: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected == 1, nil
+}
+
+// Claim atomically moves an event to processing
+func (r *EventInboxRepository) Claim(ctx context.Context, event types.Event, maxAttempts int) (bool, error) {
+	query := `
+		UPDATE event_inbox
+		SET status = $1, attempts = attempts + 1
+		WHERE chain_id = $2 AND tx_hash = $3 AND log_index = $4
+		  AND status IN ($5, $6) AND attempts < $7`
+
+	result, err := r.db.ExecContext(ctx, query,
+		InboxStatusProcessing, event.ChainID, event.TxHash, event.LogIndex,
+		InboxStatusReceived, InboxStatusFailed, maxAttempts)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim event: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected == 1, nil
+}
+
+// MarkProcessed marks a claimed event as handled
+func (r *EventInboxRepository) MarkProcessed(ctx context.Context, event types.Event) error {
+	query := `
+		UPDATE event_inbox
+		SET status = $1, last_error = NULL, processed_at = $2
+		WHERE chain_id = $3 AND tx_hash = $4 AND log_index = $5 AND status = $6`
+
+	return r.transition(ctx, "mark event processed", query,
+		InboxStatusProcessed, time.Now(), event.ChainID, event.TxHash, event.LogIndex, InboxStatusProcessing)
+}
+
+// MarkFailed releases a claimed event for a later retry
+func (r *EventInboxRepository) MarkFailed(ctx context.Context, event types.Event, cause error) error {
+	message := ""
+	if cause != nil {
+		message = cause.Error()
+	}
+
+	query := `
+		UPDATE event_inbox
+		SET status = $1, last_error = $2
+		WHERE chain_id = $3 AND tx_hash = $4 AND log_index = $5 AND status = $6`
+
+	return r.transition(ctx, "mark event failed", query,
+		InboxStatusFailed, message, event.ChainID, event.TxHash, event.LogIndex, InboxStatusProcessing)
+}
+
+// ListPending returns received events and failed events with attempts left,
+// oldest first. Exhausted events are skipped so they cannot crowd out newer
+// ones.
+func (r *EventInboxRepository) ListPending(ctx context.Context, maxAttempts, limit int) ([]types.Event, error) {
+	var payloads [][]byte
+	query := `
+		SELECT payload
+		FROM event_inbox
+		WHERE status IN ($1, $2) AND attempts < $3
+		ORDER BY received_at ASC, id ASC
+		LIMIT $4`
+
+	err := r.db.SelectContext(ctx, &payloads, query, InboxStatusReceived, InboxStatusFailed, maxAttempts, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending events: %w", err)
+	}
+
+	events := make([]types.Event, 0, len(payloads))
+	for _, payload := range payloads {
+		var event types.Event
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return nil, fmt.Errorf("failed to decode event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// ReleaseStale returns events stuck in processing to received
+func (r *EventInboxRepository) ReleaseStale(ctx context.Context, before time.Time) (int, error) {
+	query := `
+		UPDATE event_inbox
+		SET status = $1
+		WHERE status = $2 AND updated_at < $3`
+
+	result, err := r.db.ExecContext(ctx, query, InboxStatusReceived, InboxStatusProcessing, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to release stale events: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return int(rowsAffected), nil
+}
+
+// transition runs a status update that must affect exactly one claimed event
+func (r *EventInboxRepository) transition(ctx context.Context, action, query string, args ...interface{}) error {
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to %s: %w", action, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("failed to %s: event not claimed", action)
+	}
+
+	return nil
+}
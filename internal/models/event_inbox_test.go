@@ -0,0 +1,189 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"nexus-bridge/internal/models/testutil"
+	"nexus-bridge/pkg/types"
+)
+
+func TestEventInboxRepository_Contract(t *testing.T) {
+	runEventInboxContract(t, func(t *testing.T) EventInbox {
+		db := testutil.SetupTestDB(t)
+		t.Cleanup(func() { testutil.CleanupTestDB(t, db) })
+		return NewEventInboxRepository(db)
+	})
+}
+
+func TestMemoryEventInbox_Contract(t *testing.T) {
+	runEventInboxContract(t, func(t *testing.T) EventInbox {
+		return NewMemoryEventInbox()
+	})
+}
+
+func inboxEvent(logIndex uint) types.Event {
+	transfer := contractTransfer(1)
+	return types.Event{
+		ID:          "0xabcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890-0",
+		Type:        types.EventTypeLock,
+		ChainID:     types.ChainEthereum,
+		TxHash:      "0xabcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890",
+		LogIndex:    logIndex,
+		BlockNumber: 100,
+		TransferID:  transfer.ID,
+		Transfer:    transfer,
+		Timestamp:   time.Now(),
+	}
+}
+
+func runEventInboxContract(t *testing.T, newInbox func(t *testing.T) EventInbox) {
+	ctx := context.Background()
+
+	t.Run("RecordDeduplicates", func(t *testing.T) {
+		inbox := newInbox(t)
+		event := inboxEvent(0)
+
+		recorded, err := inbox.Record(ctx, event)
+		if err != nil || !recorded {
+			t.Fatalf("Expected first record to succeed, got %v/%v", recorded, err)
+		}
+		recorded, err = inbox.Record(ctx, event)
+		if err != nil || recorded {
+			t.Errorf("Expected duplicate record to be ignored, got %v/%v", recorded, err)
+		}
+
+		recorded, err = inbox.Record(ctx, inboxEvent(1))
+		if err != nil || !recorded {
+			t.Errorf("Expected event at another log index to be recorded, got %v/%v", recorded, err)
+		}
+	})
+
+	t.Run("ClaimOnce", func(t *testing.T) {
+		inbox := newInbox(t)
+		event := inboxEvent(0)
+		if _, err := inbox.Record(ctx, event); err != nil {
+			t.Fatalf("Failed to record event: %v", err)
+		}
+
+		claimed, err := inbox.Claim(ctx, event, 3)
+		if err != nil || !claimed {
+			t.Fatalf("Expected claim to succeed, got %v/%v", claimed, err)
+		}
+		claimed, err = inbox.Claim(ctx, event, 3)
+		if err != nil || claimed {
+			t.Errorf("Expected second claim to fail, got %v/%v", claimed, err)
+		}
+
+		if err := inbox.MarkProcessed(ctx, event); err != nil {
+			t.Fatalf("Failed to mark processed: %v", err)
+		}
+		claimed, err = inbox.Claim(ctx, event, 3)
+		if err != nil || claimed {
+			t.Errorf("Expected processed event not to be claimable, got %v/%v", claimed, err)
+		}
+	})
+
+	t.Run("FailedEventsRetryUntilMaxAttempts", func(t *testing.T) {
+		inbox := newInbox(t)
+		event := inboxEvent(0)
+		if _, err := inbox.Record(ctx, event); err != nil {
+			t.Fatalf("Failed to record event: %v", err)
+		}
+
+		for attempt := 0; attempt < 2; attempt++ {
+			claimed, err := inbox.Claim(ctx, event, 2)
+			if err != nil || !claimed {
+				t.Fatalf("Expected claim %d to succeed, got %v/%v", attempt, claimed, err)
+			}
+			if err := inbox.MarkFailed(ctx, event, errors.New("rpc timeout")); err != nil {
+				t.Fatalf("Failed to mark failed: %v", err)
+			}
+		}
+
+		claimed, err := inbox.Claim(ctx, event, 2)
+		if err != nil || claimed {
+			t.Errorf("Expected claim past max attempts to fail, got %v/%v", claimed, err)
+		}
+	})
+
+	t.Run("MarkRequiresClaim", func(t *testing.T) {
+		inbox := newInbox(t)
+		event := inboxEvent(0)
+		if _, err := inbox.Record(ctx, event); err != nil {
+			t.Fatalf("Failed to record event: %v", err)
+		}
+
+		if err := inbox.MarkProcessed(ctx, event); err == nil {
+			t.Error("Expected error marking an unclaimed event processed")
+		}
+	})
+
+	t.Run("ListPendingAndReleaseStale", func(t *testing.T) {
+		inbox := newInbox(t)
+		first, second := inboxEvent(0), inboxEvent(1)
+		for _, event := range []types.Event{first, second} {
+			if _, err := inbox.Record(ctx, event); err != nil {
+				t.Fatalf("Failed to record event: %v", err)
+			}
+		}
+		if _, err := inbox.Claim(ctx, first, 3); err != nil {
+			t.Fatalf("Failed to claim event: %v", err)
+		}
+
+		pending, err := inbox.ListPending(ctx, 3, 10)
+		if err != nil {
+			t.Fatalf("Failed to list pending: %v", err)
+		}
+		if len(pending) != 1 || pending[0].LogIndex != second.LogIndex {
+			t.Fatalf("Expected only the unclaimed event pending, got %v", pending)
+		}
+		if pending[0].Transfer.Amount.Cmp(second.Transfer.Amount.Int) != 0 {
+			t.Error("Expected pending event payload to round-trip")
+		}
+
+		released, err := inbox.ReleaseStale(ctx, time.Now().Add(time.Hour))
+		if err != nil || released != 1 {
+			t.Fatalf("Expected 1 stale event released, got %d/%v", released, err)
+		}
+
+		pending, err = inbox.ListPending(ctx, 3, 10)
+		if err != nil || len(pending) != 2 {
+			t.Errorf("Expected 2 pending events after release, got %d/%v", len(pending), err)
+		}
+	})
+
+	t.Run("ListPendingSkipsExhausted", func(t *testing.T) {
+		inbox := newInbox(t)
+		exhausted, fresh := inboxEvent(0), inboxEvent(1)
+		for _, event := range []types.Event{exhausted, fresh} {
+			if _, err := inbox.Record(ctx, event); err != nil {
+				t.Fatalf("Failed to record event: %v", err)
+			}
+		}
+		for i := 0; i < 2; i++ {
+			if claimed, err := inbox.Claim(ctx, exhausted, 2); err != nil || !claimed {
+				t.Fatalf("Failed to claim event: %v/%v", claimed, err)
+			}
+			if err := inbox.MarkFailed(ctx, exhausted, errors.New("handler failed")); err != nil {
+				t.Fatalf("Failed to mark event failed: %v", err)
+			}
+		}
+
+		pending, err := inbox.ListPending(ctx, 2, 1)
+		if err != nil {
+			t.Fatalf("Failed to list pending: %v", err)
+		}
+		if len(pending) != 1 || pending[0].LogIndex != fresh.LogIndex {
+			t.Errorf("Expected the exhausted event skipped, got %v", pending)
+		}
+
+		// A higher limit makes the event pending again
+		pending, err = inbox.ListPending(ctx, 3, 10)
+		if err != nil || len(pending) != 2 {
+			t.Errorf("Expected 2 pending events, got %d/%v", len(pending), err)
+		}
+	})
+}
@@ -0,0 +1,189 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"nexus-bridge/pkg/types"
+)
+
+// inboxKey identifies an event by its position on chain
+type inboxKey struct {
+	chainID  types.ChainID
+	txHash   string
+	logIndex uint
+}
+
+// memoryInboxEntry is an event together with its processing state
+type memoryInboxEntry struct {
+	event      types.Event
+	status     InboxStatus
+	attempts   int
+	lastError  string
+	sequence   int
+	receivedAt time.Time
+	updatedAt  time.Time
+}
+
+// MemoryEventInbox is a thread-safe in-memory EventInbox
+type MemoryEventInbox struct {
+	mu       sync.Mutex
+	entries  map[inboxKey]*memoryInboxEntry
+	sequence int
+}
+
+// NewMemoryEventInbox creates an empty in-memory event inbox
+func NewMemoryEventInbox() *MemoryEventInbox {
+	return &MemoryEventInbox{entries: make(map[inboxKey]*memoryInboxEntry)}
+}
+
+var _ EventInbox = (*MemoryEventInbox)(nil)
+
+// Record stores an event, ignoring duplicates
+func (m *MemoryEventInbox) Record(ctx context.Context, event types.Event) (bool, error) {
+	if event.TxHash == "" {
+		return false, fmt.Errorf("event tx hash is required")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := keyForEvent(event)
+	if _, exists := m.entries[key]; exists {
+		return false, nil
+	}
+
+	now := time.Now()
+	m.sequence++
+	m.entries[key] = &memoryInboxEntry{
+		event:      event,
+		status:     InboxStatusReceived,
+		sequence:   m.sequence,
+		receivedAt: now,
+		updatedAt:  now,
+	}
+
+	return true, nil
+}
+
+// Claim moves a received or failed event to processing
+func (m *MemoryEventInbox) Claim(ctx context.Context, event types.Event, maxAttempts int) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, exists := m.entries[keyForEvent(event)]
+	if !exists {
+		return false, nil
+	}
+	if entry.status != InboxStatusReceived && entry.status != InboxStatusFailed {
+		return false, nil
+	}
+	if entry.attempts >= maxAttempts {
+		return false, nil
+	}
+
+	entry.status = InboxStatusProcessing
+	entry.attempts++
+	entry.updatedAt = time.Now()
+
+	return true, nil
+}
+
+// MarkProcessed marks a claimed event as handled
+func (m *MemoryEventInbox) MarkProcessed(ctx context.Context, event types.Event) error {
+	return m.transition(event, "mark event processed", func(entry *memoryInboxEntry) {
+		entry.status = InboxStatusProcessed
+		entry.lastError = ""
+	})
+}
+
+// MarkFailed releases a claimed event for a later retry
+func (m *MemoryEventInbox) MarkFailed(ctx context.Context, event types.Event, cause error) error {
+	return m.transition(event, "mark event failed", func(entry *memoryInboxEntry) {
+		entry.status = InboxStatusFailed
+		if cause != nil {
+			entry.lastError = cause.Error()
+		}
+	})
+}
+
+// ListPending returns received events and failed events with attempts left,
+// oldest first
+func (m *MemoryEventInbox) ListPending(ctx context.Context, maxAttempts, limit int) ([]types.Event, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var pending []*memoryInboxEntry
+	for _, entry := range m.entries {
+		if (entry.status == InboxStatusReceived || entry.status == InboxStatusFailed) && entry.attempts < maxAttempts {
+			pending = append(pending, entry)
+		}
+	}
+
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].sequence < pending[j].sequence
+	})
+
+	events := make([]types.Event, 0, len(pending))
+	for _, entry := range pending {
+		if len(events) == limit {
+			break
+		}
+		events = append(events, entry.event)
+	}
+
+	return events, nil
+}
+
+// ReleaseStale returns events stuck in processing to received
+func (m *MemoryEventInbox) ReleaseStale(ctx context.Context, before time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	released := 0
+	for _, entry := range m.entries {
+		if entry.status == InboxStatusProcessing && entry.updatedAt.Before(before) {
+			entry.status = InboxStatusReceived
+			entry.updatedAt = time.Now()
+			released++
+		}
+	}
+
+	return released, nil
+}
+
+// Status returns the processing status and attempt count of an event
+func (m *MemoryEventInbox) Status(event types.Event) (InboxStatus, int, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, exists := m.entries[keyForEvent(event)]
+	if !exists {
+		return "", 0, false
+	}
+
+	return entry.status, entry.attempts, true
+}
+
+// transition updates an event that must currently be claimed
+func (m *MemoryEventInbox) transition(event types.Event, action string, apply func(entry *memoryInboxEntry)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, exists := m.entries[keyForEvent(event)]
+	if !exists || entry.status != InboxStatusProcessing {
+		return fmt.Errorf("failed to %s: event not claimed", action)
+	}
+
+	apply(entry)
+	entry.updatedAt = time.Now()
+
+	return nil
+}
+
+func keyForEvent(event types.Event) inboxKey {
+	return inboxKey{chainID: event.ChainID, txHash: event.TxHash, logIndex: event.LogIndex}
+}
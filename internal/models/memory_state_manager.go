@@ -0,0 +1,1521 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"nexus-bridge/pkg/types"
+)
+
+// MemoryStateManager is a thread-safe in-memory Store for tests and local
+// development. It enforces the same constraints as the SQL schema.
+type MemoryStateManager struct {
+	mu           sync.RWMutex
+	transfers    map[string]*types.Transfer
+	changed      map[string]time.Time
+	signatures   map[string][]types.Signature
+	tokens       []types.SupportedToken
+	nextTokenID  int
+	leases       map[string]transferLease
+	audit        []AuditEntry
+	webhooks     []Webhook
+	deliveries   []*WebhookDelivery
+	nextWebhook  int
+	nextDelivery int64
+	reviews      []*TransferReview
+	nextReview   int
+	apiKeys      []APIKey
+	nextAPIKey   int
+	limits       []TokenLimit
+	nextLimit    int
+	delays       []*TransferDelay
+	nextDelay    int
+	relayers     []RelayerConfig
+	nextRelayer  int
+	scanned      map[types.ChainID]uint64
+	volumeLocks  map[string]*sync.Mutex
+}
+
+// transferLease is an in-memory lease on a transfer
+type transferLease struct {
+	owner     string
+	expiresAt time.Time
+}
+
+// NewMemoryStateManager creates an empty in-memory state manager
+func NewMemoryStateManager() *MemoryStateManager {
+	return &MemoryStateManager{
+		transfers:    make(map[string]*types.Transfer),
+		changed:      make(map[string]time.Time),
+		signatures:   make(map[string][]types.Signature),
+		nextTokenID:  1,
+		nextWebhook:  1,
+		nextDelivery: 1,
+		nextReview:   1,
+		nextAPIKey:   1,
+		nextLimit:    1,
+		nextDelay:    1,
+		nextRelayer:  1,
+		leases:       make(map[string]transferLease),
+		scanned:      make(map[types.ChainID]uint64),
+		volumeLocks:  make(map[string]*sync.Mutex),
+	}
+}
+
+var (
+	_ Store              = (*MemoryStateManager)(nil)
+	_ TransferLeaser     = (*MemoryStateManager)(nil)
+	_ WebhookStore       = (*MemoryStateManager)(nil)
+	_ ReviewStore        = (*MemoryStateManager)(nil)
+	_ APIKeyStore        = (*MemoryStateManager)(nil)
+	_ TokenLimitStore    = (*MemoryStateManager)(nil)
+	_ RouteTotaler       = (*MemoryStateManager)(nil)
+	_ DelayStore         = (*MemoryStateManager)(nil)
+	_ RelayerConfigStore = (*MemoryStateManager)(nil)
+	_ ScanProgressStore  = (*MemoryStateManager)(nil)
+)
+
+// RecordTransfer records a new transfer
+func (m *MemoryStateManager) RecordTransfer(ctx context.Context, transfer types.Transfer) error {
+	if err := transfer.Validate(); err != nil {
+		return fmt.Errorf("transfer validation failed: %w", err)
+	}
+	if !transfer.Status.IsValid() {
+		return fmt.Errorf("invalid transfer status: %s", transfer.Status)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.transfers[transfer.ID]; exists {
+		return fmt.Errorf("failed to create transfer: duplicate transfer ID %s", transfer.ID)
+	}
+
+	now := time.Now()
+	transfer.CreatedAt = now
+	transfer.UpdatedAt = now
+	stored := copyTransfer(transfer)
+	m.transfers[transfer.ID] = &stored
+	m.changed[transfer.ID] = now
+	m.enqueueWebhookDeliveries(stored)
+
+	return nil
+}
+
+// GetTransferStatus returns the current status of a transfer
+func (m *MemoryStateManager) GetTransferStatus(ctx context.Context, transferID string) (*types.TransferStatus, error) {
+	transfer, err := m.GetTransfer(ctx, transferID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transfer: %w", err)
+	}
+
+	return &transfer.Status, nil
+}
+
+// UpdateTransferStatus updates the status of a transfer
+func (m *MemoryStateManager) UpdateTransferStatus(ctx context.Context, transferID string, status types.TransferStatus) error {
+	if !status.IsValid() {
+		return fmt.Errorf("invalid transfer status: %s", status)
+	}
+
+	return m.update(transferID, func(transfer *types.Transfer) {
+		transfer.Status = status
+	})
+}
+
+// MarkTransferComplete marks a transfer as completed
+func (m *MemoryStateManager) MarkTransferComplete(ctx context.Context, transferID string, destinationTxHash string) error {
+	return m.update(transferID, func(transfer *types.Transfer) {
+		transfer.DestinationTxHash = destinationTxHash
+		transfer.Status = types.StatusCompleted
+	})
+}
+
+// IsTransferProcessed checks if a transfer has already been processed
+func (m *MemoryStateManager) IsTransferProcessed(ctx context.Context, transferID string) (bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	transfer, exists := m.transfers[transferID]
+	if !exists {
+		return false, nil
+	}
+
+	return transfer.Status == types.StatusCompleted || transfer.Status == types.StatusFailed, nil
+}
+
+// GetTransfersInBlockRange returns transfers affected by a block range (for reorg handling)
+func (m *MemoryStateManager) GetTransfersInBlockRange(ctx context.Context, chainID types.ChainID, fromBlock, toBlock uint64) ([]types.Transfer, error) {
+	transfers := m.collect(func(transfer *types.Transfer) bool {
+		return transfer.SourceChain == chainID &&
+			transfer.BlockNumber >= fromBlock && transfer.BlockNumber <= toBlock
+	})
+
+	sort.SliceStable(transfers, func(i, j int) bool {
+		return transfers[i].BlockNumber < transfers[j].BlockNumber
+	})
+
+	return transfers, nil
+}
+
+// RecordSignature records a signature for a transfer
+func (m *MemoryStateManager) RecordSignature(ctx context.Context, transferID string, signature types.Signature) error {
+	if signature.RelayerAddress == "" {
+		return fmt.Errorf("relayer address is required")
+	}
+	if len(signature.Signature) == 0 {
+		return fmt.Errorf("signature data is required")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.transfers[transferID]; !exists {
+		return fmt.Errorf("failed to create signature: %w: %s", ErrTransferNotFound, transferID)
+	}
+	for _, existing := range m.signatures[transferID] {
+		if existing.RelayerAddress == signature.RelayerAddress {
+			return fmt.Errorf("failed to create signature: relayer %s already signed transfer %s", signature.RelayerAddress, transferID)
+		}
+	}
+
+	signature.Signature = append([]byte(nil), signature.Signature...)
+	signature.CreatedAt = time.Now()
+	m.signatures[transferID] = append(m.signatures[transferID], signature)
+
+	return nil
+}
+
+// GetSignatures returns all signatures for a transfer
+func (m *MemoryStateManager) GetSignatures(ctx context.Context, transferID string) ([]types.Signature, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	signatures := make([]types.Signature, 0, len(m.signatures[transferID]))
+	for _, signature := range m.signatures[transferID] {
+		signature.Signature = append([]byte(nil), signature.Signature...)
+		signatures = append(signatures, signature)
+	}
+
+	return signatures, nil
+}
+
+// MarkTransferForReview holds a transfer for manual review. A transfer
+// already under review keeps its open review.
+func (m *MemoryStateManager) MarkTransferForReview(ctx context.Context, transferID string, reason string) error {
+	return m.update(transferID, func(transfer *types.Transfer) {
+		if transfer.Status == types.StatusUnderReview {
+			return
+		}
+
+		m.reviews = append(m.reviews, &TransferReview{
+			ID:             m.nextReview,
+			TransferID:     transferID,
+			Reason:         reason,
+			PreviousStatus: transfer.Status,
+			Status:         ReviewOpen,
+			Votes:          []ReviewVote{},
+			CreatedAt:      time.Now(),
+		})
+		m.nextReview++
+		transfer.Status = types.StatusUnderReview
+	})
+}
+
+// GetTransfer returns a transfer by ID
+func (m *MemoryStateManager) GetTransfer(ctx context.Context, transferID string) (*types.Transfer, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	transfer, exists := m.transfers[transferID]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrTransferNotFound, transferID)
+	}
+
+	result := copyTransfer(*transfer)
+	return &result, nil
+}
+
+// GetTransfersByStatus returns transfers with a specific status, oldest first
+func (m *MemoryStateManager) GetTransfersByStatus(ctx context.Context, status types.TransferStatus) ([]types.Transfer, error) {
+	transfers := m.collect(func(transfer *types.Transfer) bool {
+		return transfer.Status == status
+	})
+
+	sort.SliceStable(transfers, func(i, j int) bool {
+		return transfers[i].CreatedAt.Before(transfers[j].CreatedAt)
+	})
+
+	return transfers, nil
+}
+
+// SearchTransfers returns a page of transfers matching the filter, newest first
+func (m *MemoryStateManager) SearchTransfers(ctx context.Context, filter TransferFilter) (*TransferPage, error) {
+	var cursor *transferCursor
+	if filter.Cursor != "" {
+		var err error
+		if cursor, err = decodeTransferCursor(filter.Cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	transfers := m.collect(func(transfer *types.Transfer) bool {
+		if !filter.Matches(*transfer) {
+			return false
+		}
+		return cursor == nil || transferBefore(*transfer, cursor.CreatedAt, cursor.ID)
+	})
+
+	sort.Slice(transfers, func(i, j int) bool {
+		return transferBefore(transfers[j], transfers[i].CreatedAt, transfers[i].ID)
+	})
+
+	pageSize := filter.pageSize()
+	if len(transfers) > pageSize+1 {
+		transfers = transfers[:pageSize+1]
+	}
+
+	return newTransferPage(transfers, pageSize), nil
+}
+
+// CountStuckTransfers counts transfers in a status they entered before a time
+func (m *MemoryStateManager) CountStuckTransfers(ctx context.Context, status types.TransferStatus, before time.Time) (int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	count := 0
+	for id, transfer := range m.transfers {
+		if transfer.Status == status && m.changed[id].Before(before) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// LeaseTransfers leases up to limit transfers in a status for owner, oldest first
+func (m *MemoryStateManager) LeaseTransfers(ctx context.Context, status types.TransferStatus, owner string, ttl time.Duration, limit int) ([]types.Transfer, error) {
+	return m.lease(status, "", owner, ttl, limit)
+}
+
+// LeaseUnsignedTransfers leases up to limit transfers in a status that the
+// relayer address has not signed, oldest first
+func (m *MemoryStateManager) LeaseUnsignedTransfers(ctx context.Context, status types.TransferStatus, relayerAddress, owner string, ttl time.Duration, limit int) ([]types.Transfer, error) {
+	if relayerAddress == "" {
+		return nil, fmt.Errorf("relayer address is required")
+	}
+	return m.lease(status, relayerAddress, owner, ttl, limit)
+}
+
+// lease leases transfers in a status, skipping those signed by
+// relayerAddress unless it is empty
+func (m *MemoryStateManager) lease(status types.TransferStatus, relayerAddress, owner string, ttl time.Duration, limit int) ([]types.Transfer, error) {
+	if err := validateLease(owner, ttl); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	candidates := []types.Transfer{}
+	for id, transfer := range m.transfers {
+		if transfer.Status != status {
+			continue
+		}
+		if lease, leased := m.leases[id]; leased && lease.expiresAt.After(now) {
+			continue
+		}
+		if relayerAddress != "" && m.signedBy(id, relayerAddress) {
+			continue
+		}
+		candidates = append(candidates, copyTransfer(*transfer))
+	}
+
+	sortTransfersOldestFirst(candidates)
+	if len(candidates) > limit {
+		candidates = candidates[:max(limit, 0)]
+	}
+
+	for _, transfer := range candidates {
+		m.leases[transfer.ID] = transferLease{owner: owner, expiresAt: now.Add(ttl)}
+	}
+
+	return candidates, nil
+}
+
+// RenewLease extends a lease still held by owner
+func (m *MemoryStateManager) RenewLease(ctx context.Context, transferID, owner string, ttl time.Duration) error {
+	if err := validateLease(owner, ttl); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	lease, leased := m.leases[transferID]
+	if !leased || lease.owner != owner || !lease.expiresAt.After(now) {
+		return fmt.Errorf("failed to renew lease: transfer %s is not leased by %s", transferID, owner)
+	}
+
+	m.leases[transferID] = transferLease{owner: owner, expiresAt: now.Add(ttl)}
+	return nil
+}
+
+// ReleaseLease gives up a lease held by owner
+func (m *MemoryStateManager) ReleaseLease(ctx context.Context, transferID, owner string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if lease, leased := m.leases[transferID]; leased && lease.owner == owner {
+		delete(m.leases, transferID)
+	}
+
+	return nil
+}
+
+// UpdateConfirmations updates the confirmation count for a transfer
+func (m *MemoryStateManager) UpdateConfirmations(ctx context.Context, transferID string, confirmations uint64) error {
+	return m.update(transferID, func(transfer *types.Transfer) {
+		transfer.Confirmations = confirmations
+	})
+}
+
+// AddSupportedToken registers a new supported token
+func (m *MemoryStateManager) AddSupportedToken(ctx context.Context, token *types.SupportedToken, audit AuditFunc) error {
+	if err := validateSupportedToken(token); err != nil {
+		return fmt.Errorf("token validation failed: %w", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, existing := range m.tokens {
+		if existing.ChainID == token.ChainID && existing.TokenAddress == token.TokenAddress {
+			return fmt.Errorf("failed to create supported token: duplicate token chain=%d, address=%s", token.ChainID, token.TokenAddress)
+		}
+	}
+
+	token.ID = m.nextTokenID
+	if err := m.recordAudit(audit, token); err != nil {
+		token.ID = 0
+		return err
+	}
+	m.nextTokenID++
+	m.tokens = append(m.tokens, *token)
+
+	return nil
+}
+
+// IsTokenSupported checks if a token is supported on a specific chain
+func (m *MemoryStateManager) IsTokenSupported(ctx context.Context, chainID types.ChainID, tokenAddress string) (bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, token := range m.tokens {
+		if token.ChainID == chainID && token.TokenAddress == tokenAddress && token.Enabled {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// GetSupportedTokens returns all enabled supported tokens for a chain, ordered by name
+func (m *MemoryStateManager) GetSupportedTokens(ctx context.Context, chainID types.ChainID) ([]types.SupportedToken, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var tokens []types.SupportedToken
+	for _, token := range m.tokens {
+		if token.ChainID == chainID && token.Enabled {
+			tokens = append(tokens, token)
+		}
+	}
+
+	sort.SliceStable(tokens, func(i, j int) bool {
+		return tokens[i].Name < tokens[j].Name
+	})
+
+	return tokens, nil
+}
+
+// GetSupportedToken returns a supported token by ID
+func (m *MemoryStateManager) GetSupportedToken(ctx context.Context, id int) (*types.SupportedToken, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, token := range m.tokens {
+		if token.ID == id {
+			return &token, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %d", ErrTokenNotFound, id)
+}
+
+// ListSupportedTokens returns every supported token, ordered by chain and name
+func (m *MemoryStateManager) ListSupportedTokens(ctx context.Context) ([]types.SupportedToken, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	tokens := append([]types.SupportedToken(nil), m.tokens...)
+	sort.SliceStable(tokens, func(i, j int) bool {
+		if tokens[i].ChainID != tokens[j].ChainID {
+			return tokens[i].ChainID < tokens[j].ChainID
+		}
+		return tokens[i].Name < tokens[j].Name
+	})
+
+	return tokens, nil
+}
+
+// SetTokenEnabled enables or disables a supported token
+func (m *MemoryStateManager) SetTokenEnabled(ctx context.Context, id int, enabled bool, audit AuditFunc) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := range m.tokens {
+		if m.tokens[i].ID == id {
+			updated := m.tokens[i]
+			updated.Enabled = enabled
+			if err := m.recordAudit(audit, &updated); err != nil {
+				return err
+			}
+			m.tokens[i] = updated
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %d", ErrTokenNotFound, id)
+}
+
+// DeleteSupportedToken removes a supported token
+func (m *MemoryStateManager) DeleteSupportedToken(ctx context.Context, id int, audit AuditFunc) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := range m.tokens {
+		if m.tokens[i].ID == id {
+			if err := m.recordAudit(audit, nil); err != nil {
+				return err
+			}
+			m.tokens = append(m.tokens[:i], m.tokens[i+1:]...)
+			limits := m.limits[:0]
+			for _, limit := range m.limits {
+				if limit.TokenID != id {
+					limits = append(limits, limit)
+				}
+			}
+			m.limits = limits
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %d", ErrTokenNotFound, id)
+}
+
+// RecordAudit appends an entry to the audit log
+func (m *MemoryStateManager) RecordAudit(ctx context.Context, entry *AuditEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.appendAudit(entry)
+}
+
+// recordAudit appends the entry audit builds for a change. Callers make the
+// change only once it succeeds, and must hold the lock.
+func (m *MemoryStateManager) recordAudit(audit AuditFunc, changed interface{}) error {
+	if audit == nil {
+		return nil
+	}
+
+	entry, err := audit(changed)
+	if err == nil {
+		err = m.appendAudit(entry)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrAuditFailed, err)
+	}
+	return nil
+}
+
+// appendAudit appends an entry to the audit log. The caller must hold the
+// lock.
+func (m *MemoryStateManager) appendAudit(entry *AuditEntry) error {
+	if err := validateAuditEntry(entry); err != nil {
+		return fmt.Errorf("audit entry validation failed: %w", err)
+	}
+
+	entry.ID = len(m.audit) + 1
+	entry.PerformedAt = time.Now()
+	if len(entry.OldValues) == 0 {
+		entry.OldValues = json.RawMessage("null")
+	}
+	if len(entry.NewValues) == 0 {
+		entry.NewValues = json.RawMessage("null")
+	}
+	m.audit = append(m.audit, *entry)
+
+	return nil
+}
+
+// GetAuditTrail returns the audit trail of an entity, oldest first
+func (m *MemoryStateManager) GetAuditTrail(ctx context.Context, entityType, entityID string) ([]AuditEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entries := []AuditEntry{}
+	for _, entry := range m.audit {
+		if entry.EntityType == entityType && entry.EntityID == entityID {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
+
+// HasRelayerSigned checks if a relayer has already signed a transfer
+func (m *MemoryStateManager) HasRelayerSigned(ctx context.Context, transferID, relayerAddress string) (bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.signedBy(transferID, relayerAddress), nil
+}
+
+// signedBy reports whether a relayer signed a transfer. The caller must hold
+// the lock.
+func (m *MemoryStateManager) signedBy(transferID, relayerAddress string) bool {
+	for _, signature := range m.signatures[transferID] {
+		if signature.RelayerAddress == relayerAddress {
+			return true
+		}
+	}
+	return false
+}
+
+// GetSignatureCount returns the number of signatures for a transfer
+func (m *MemoryStateManager) GetSignatureCount(ctx context.Context, transferID string) (int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return len(m.signatures[transferID]), nil
+}
+
+// CreateWebhook registers a webhook
+func (m *MemoryStateManager) CreateWebhook(ctx context.Context, webhook *Webhook, audit AuditFunc) error {
+	if err := webhook.Validate(); err != nil {
+		return fmt.Errorf("webhook validation failed: %w", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	created := copyWebhook(*webhook)
+	created.ID = m.nextWebhook
+	if created.Events == nil {
+		created.Events = []string{}
+	}
+	created.CreatedAt = now
+	created.UpdatedAt = now
+	original := *webhook
+	*webhook = created
+	if err := m.recordAudit(audit, &created); err != nil {
+		*webhook = original
+		return err
+	}
+	m.nextWebhook++
+	m.webhooks = append(m.webhooks, copyWebhook(*webhook))
+
+	return nil
+}
+
+// GetWebhook returns a webhook by ID
+func (m *MemoryStateManager) GetWebhook(ctx context.Context, id int) (*Webhook, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, webhook := range m.webhooks {
+		if webhook.ID == id {
+			found := copyWebhook(webhook)
+			return &found, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %d", ErrWebhookNotFound, id)
+}
+
+// ListWebhooks returns every webhook in creation order
+func (m *MemoryStateManager) ListWebhooks(ctx context.Context) ([]Webhook, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	webhooks := make([]Webhook, 0, len(m.webhooks))
+	for _, webhook := range m.webhooks {
+		webhooks = append(webhooks, copyWebhook(webhook))
+	}
+
+	return webhooks, nil
+}
+
+// DeleteWebhook removes a webhook and its deliveries
+func (m *MemoryStateManager) DeleteWebhook(ctx context.Context, id int, audit AuditFunc) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := range m.webhooks {
+		if m.webhooks[i].ID != id {
+			continue
+		}
+		if err := m.recordAudit(audit, nil); err != nil {
+			return err
+		}
+		m.webhooks = append(m.webhooks[:i], m.webhooks[i+1:]...)
+
+		kept := m.deliveries[:0]
+		for _, delivery := range m.deliveries {
+			if delivery.WebhookID != id {
+				kept = append(kept, delivery)
+			}
+		}
+		m.deliveries = kept
+		return nil
+	}
+
+	return fmt.Errorf("%w: %d", ErrWebhookNotFound, id)
+}
+
+// ClaimDeliveries leases up to limit due deliveries of enabled webhooks
+func (m *MemoryStateManager) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]WebhookDelivery, error) {
+	if lease <= 0 {
+		return nil, fmt.Errorf("lease duration must be positive")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	enabled := make(map[int]bool, len(m.webhooks))
+	for _, webhook := range m.webhooks {
+		enabled[webhook.ID] = webhook.Enabled
+	}
+
+	now := time.Now()
+	due := []*WebhookDelivery{}
+	for _, delivery := range m.deliveries {
+		if delivery.Status == DeliveryPending && !delivery.NextAttemptAt.After(now) && enabled[delivery.WebhookID] {
+			due = append(due, delivery)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].NextAttemptAt.Before(due[j].NextAttemptAt)
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	claimed := make([]WebhookDelivery, 0, len(due))
+	for _, delivery := range due {
+		delivery.NextAttemptAt = now.Add(lease)
+		claimed = append(claimed, copyDelivery(*delivery))
+	}
+	sort.Slice(claimed, func(i, j int) bool { return claimed[i].ID < claimed[j].ID })
+
+	return claimed, nil
+}
+
+// MarkDelivered records a successful attempt
+func (m *MemoryStateManager) MarkDelivered(ctx context.Context, id int64, statusCode int) error {
+	return m.updateDelivery(id, func(delivery *WebhookDelivery) {
+		deliveredAt := time.Now()
+		delivery.Status = DeliveryDelivered
+		delivery.Attempts++
+		delivery.LastStatusCode = statusCode
+		delivery.LastError = ""
+		delivery.DeliveredAt = &deliveredAt
+	})
+}
+
+// MarkDeliveryFailed records a failed attempt and schedules a retry, or
+// dead-letters the delivery if retryAt is zero
+func (m *MemoryStateManager) MarkDeliveryFailed(ctx context.Context, id int64, statusCode int, reason string, retryAt time.Time) error {
+	return m.updateDelivery(id, func(delivery *WebhookDelivery) {
+		delivery.Status = DeliveryPending
+		delivery.NextAttemptAt = retryAt
+		if retryAt.IsZero() {
+			delivery.Status = DeliveryDead
+			delivery.NextAttemptAt = time.Now()
+		}
+		delivery.Attempts++
+		delivery.LastStatusCode = statusCode
+		delivery.LastError = reason
+	})
+}
+
+// ListDeliveries returns a webhook's most recent deliveries, newest first
+func (m *MemoryStateManager) ListDeliveries(ctx context.Context, webhookID int, status DeliveryStatus, limit int) ([]WebhookDelivery, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	deliveries := []WebhookDelivery{}
+	for i := len(m.deliveries) - 1; i >= 0 && len(deliveries) < limit; i-- {
+		delivery := m.deliveries[i]
+		if delivery.WebhookID == webhookID && (status == "" || delivery.Status == status) {
+			deliveries = append(deliveries, copyDelivery(*delivery))
+		}
+	}
+
+	return deliveries, nil
+}
+
+// ReplayDelivery resets a delivery of a webhook so it is sent again at once
+func (m *MemoryStateManager) ReplayDelivery(ctx context.Context, webhookID int, id int64, audit AuditFunc) (*WebhookDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, delivery := range m.deliveries {
+		if delivery.ID != id || delivery.WebhookID != webhookID {
+			continue
+		}
+		replayed := copyDelivery(*delivery)
+		replayed.Status = DeliveryPending
+		replayed.Attempts = 0
+		replayed.NextAttemptAt = time.Now()
+		replayed.LastError = ""
+		replayed.LastStatusCode = 0
+		replayed.DeliveredAt = nil
+		if err := m.recordAudit(audit, &replayed); err != nil {
+			return nil, err
+		}
+
+		*delivery = copyDelivery(replayed)
+		return &replayed, nil
+	}
+
+	return nil, fmt.Errorf("%w: %d", ErrDeliveryNotFound, id)
+}
+
+// enqueueWebhookDeliveries queues a delivery to every webhook matching a
+// transfer entering its current status, as the enqueue_webhook_deliveries
+// trigger does. The caller must hold the lock.
+func (m *MemoryStateManager) enqueueWebhookDeliveries(transfer types.Transfer) {
+	event := TransferEvent(transfer.Status)
+	payload, err := json.Marshal(webhookTransfer{
+		ID:                transfer.ID,
+		SourceChain:       transfer.SourceChain,
+		DestinationChain:  transfer.DestinationChain,
+		Token:             transfer.Token,
+		Amount:            transfer.Amount,
+		Sender:            transfer.Sender,
+		Recipient:         transfer.Recipient,
+		Status:            transfer.Status,
+		SourceTxHash:      transfer.SourceTxHash,
+		DestinationTxHash: transfer.DestinationTxHash,
+		BlockNumber:       transfer.BlockNumber,
+		Confirmations:     transfer.Confirmations,
+		Fee:               transfer.Fee,
+	})
+	if err != nil {
+		fmt.Printf("Error encoding webhook payload for transfer %s: %v\n", transfer.ID, err)
+		return
+	}
+
+	now := time.Now()
+	for _, webhook := range m.webhooks {
+		if !webhook.Matches(transfer, event) {
+			continue
+		}
+		m.deliveries = append(m.deliveries, &WebhookDelivery{
+			ID:            m.nextDelivery,
+			WebhookID:     webhook.ID,
+			TransferID:    transfer.ID,
+			EventType:     event,
+			Payload:       payload,
+			Status:        DeliveryPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		})
+		m.nextDelivery++
+	}
+}
+
+// ListOpenReviews returns every open review with its votes, oldest first
+func (m *MemoryStateManager) ListOpenReviews(ctx context.Context) ([]TransferReview, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	reviews := []TransferReview{}
+	for _, review := range m.reviews {
+		if review.Status == ReviewOpen {
+			reviews = append(reviews, copyReview(*review))
+		}
+	}
+	sortReviewsOldestFirst(reviews)
+
+	return reviews, nil
+}
+
+// GetOpenReview returns the open review of a transfer
+func (m *MemoryStateManager) GetOpenReview(ctx context.Context, transferID string) (*TransferReview, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	review := m.openReview(transferID)
+	if review == nil {
+		return nil, fmt.Errorf("%w: %s", ErrReviewNotFound, transferID)
+	}
+
+	result := copyReview(*review)
+	return &result, nil
+}
+
+// VoteOnReview records an operator's vote and resolves the review once
+// quorum operators agree
+func (m *MemoryStateManager) VoteOnReview(ctx context.Context, transferID string, vote ReviewVote, quorum int, audit AuditFunc) (*TransferReview, error) {
+	if err := vote.Validate(); err != nil {
+		return nil, fmt.Errorf("vote validation failed: %w", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	review := m.openReview(transferID)
+	if review == nil {
+		return nil, fmt.Errorf("%w: %s", ErrReviewNotFound, transferID)
+	}
+	for _, existing := range review.Votes {
+		if existing.Operator == vote.Operator {
+			return nil, fmt.Errorf("%w: %s", ErrAlreadyVoted, vote.Operator)
+		}
+	}
+
+	now := time.Now()
+	vote.ReviewID = review.ID
+	vote.CreatedAt = now
+	updated := copyReview(*review)
+	updated.Votes = append(updated.Votes, vote)
+
+	decision, decided := updated.tally(quorum)
+	var status types.TransferStatus
+	if decided {
+		status = updated.resolve(decision, vote.Reason, now)
+	}
+
+	result := copyReview(updated)
+	if err := m.recordAudit(audit, &result); err != nil {
+		return nil, err
+	}
+
+	*review = updated
+	if transfer, exists := m.transfers[transferID]; decided && exists {
+		transfer.Status = status
+		transfer.UpdatedAt = now
+		m.changed[transfer.ID] = now
+		m.enqueueWebhookDeliveries(*transfer)
+	}
+
+	return &result, nil
+}
+
+// GetApprovedReviewReason returns the reason the latest review of a transfer
+// was opened for, if operators approved it
+func (m *MemoryStateManager) GetApprovedReviewReason(ctx context.Context, transferID string) (string, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for i := len(m.reviews) - 1; i >= 0; i-- {
+		if m.reviews[i].TransferID != transferID {
+			continue
+		}
+		if m.reviews[i].Status != ReviewApproved {
+			return "", false, nil
+		}
+		return m.reviews[i].Reason, true, nil
+	}
+	return "", false, nil
+}
+
+// openReview returns the open review of a transfer, if any. The caller
+// must hold the lock.
+func (m *MemoryStateManager) openReview(transferID string) *TransferReview {
+	for _, review := range m.reviews {
+		if review.TransferID == transferID && review.Status == ReviewOpen {
+			return review
+		}
+	}
+	return nil
+}
+
+// DelayTransfer moves a transfer to the delayed status and records when it
+// is released, unless the transfer was delayed before
+func (m *MemoryStateManager) DelayTransfer(ctx context.Context, transferID string, releaseAt time.Time) (*TransferDelay, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	transfer, exists := m.transfers[transferID]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrTransferNotFound, transferID)
+	}
+	if delay := m.delay(transferID); delay != nil {
+		result := copyDelay(*delay)
+		return &result, nil
+	}
+
+	now := time.Now()
+	delay := &TransferDelay{
+		ID:             m.nextDelay,
+		TransferID:     transferID,
+		PreviousStatus: transfer.Status,
+		Status:         DelayWaiting,
+		ReleaseAt:      releaseAt,
+		CreatedAt:      now,
+	}
+	m.nextDelay++
+	m.delays = append(m.delays, delay)
+
+	transfer.Status = types.StatusDelayed
+	transfer.UpdatedAt = now
+	m.changed[transfer.ID] = now
+	m.enqueueWebhookDeliveries(*transfer)
+
+	result := copyDelay(*delay)
+	return &result, nil
+}
+
+// GetTransferDelay returns the delay of a transfer
+func (m *MemoryStateManager) GetTransferDelay(ctx context.Context, transferID string) (*TransferDelay, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	delay := m.delay(transferID)
+	if delay == nil {
+		return nil, fmt.Errorf("%w: %s", ErrDelayNotFound, transferID)
+	}
+
+	result := copyDelay(*delay)
+	return &result, nil
+}
+
+// ListWaitingDelays returns the waiting delays, soonest release first
+func (m *MemoryStateManager) ListWaitingDelays(ctx context.Context) ([]TransferDelay, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	delays := []TransferDelay{}
+	for _, delay := range m.delays {
+		if delay.Status == DelayWaiting {
+			delays = append(delays, copyDelay(*delay))
+		}
+	}
+	sortDelaysByRelease(delays)
+
+	return delays, nil
+}
+
+// ReleaseDueDelays releases the waiting delays due at a time
+func (m *MemoryStateManager) ReleaseDueDelays(ctx context.Context, now time.Time) ([]TransferDelay, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	resolvedAt := time.Now()
+	released := []TransferDelay{}
+	for _, delay := range m.delays {
+		if delay.Status != DelayWaiting || delay.ReleaseAt.After(now) {
+			continue
+		}
+		delay.Status = DelayReleased
+		delay.ResolvedAt = &resolvedAt
+		released = append(released, copyDelay(*delay))
+
+		if transfer, exists := m.transfers[delay.TransferID]; exists && transfer.Status == types.StatusDelayed {
+			transfer.Status = delay.PreviousStatus
+			transfer.UpdatedAt = resolvedAt
+			m.changed[transfer.ID] = resolvedAt
+			m.enqueueWebhookDeliveries(*transfer)
+		}
+	}
+	sortDelaysByRelease(released)
+
+	return released, nil
+}
+
+// CancelTransferDelay cancels the waiting delay of a transfer and fails the
+// transfer
+func (m *MemoryStateManager) CancelTransferDelay(ctx context.Context, transferID, operator, reason string, audit AuditFunc) (*TransferDelay, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delay := m.delay(transferID)
+	if delay == nil {
+		return nil, fmt.Errorf("%w: %s", ErrDelayNotFound, transferID)
+	}
+	if delay.Status != DelayWaiting {
+		return nil, fmt.Errorf("%w: %s was %s", ErrDelayNotFound, transferID, delay.Status)
+	}
+
+	now := time.Now()
+	cancelled := copyDelay(*delay)
+	cancelled.cancel(operator, reason, now)
+	result := copyDelay(cancelled)
+	if err := m.recordAudit(audit, &result); err != nil {
+		return nil, err
+	}
+
+	*delay = cancelled
+	if transfer, exists := m.transfers[transferID]; exists {
+		transfer.Status = types.StatusFailed
+		transfer.UpdatedAt = now
+		m.changed[transfer.ID] = now
+		m.enqueueWebhookDeliveries(*transfer)
+	}
+
+	return &result, nil
+}
+
+// delay returns the delay of a transfer, if any. The caller must hold the
+// lock.
+func (m *MemoryStateManager) delay(transferID string) *TransferDelay {
+	for _, delay := range m.delays {
+		if delay.TransferID == transferID {
+			return delay
+		}
+	}
+	return nil
+}
+
+// CreateAPIKey stores a new API key, enforcing unique hashes
+func (m *MemoryStateManager) CreateAPIKey(ctx context.Context, key *APIKey, audit AuditFunc) error {
+	if err := key.Validate(); err != nil {
+		return fmt.Errorf("API key validation failed: %w", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, existing := range m.apiKeys {
+		if existing.KeyHash == key.KeyHash {
+			return fmt.Errorf("failed to create API key: duplicate key hash")
+		}
+	}
+
+	created := *key
+	created.ID = m.nextAPIKey
+	created.CreatedAt = time.Now()
+	original := *key
+	*key = created
+	if err := m.recordAudit(audit, &created); err != nil {
+		*key = original
+		return err
+	}
+	m.nextAPIKey++
+	m.apiKeys = append(m.apiKeys, created)
+
+	return nil
+}
+
+// GetAPIKeyByHash returns the active API key with a hash
+func (m *MemoryStateManager) GetAPIKeyByHash(ctx context.Context, keyHash string) (*APIKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, key := range m.apiKeys {
+		if key.KeyHash == keyHash && key.RevokedAt == nil {
+			return &key, nil
+		}
+	}
+
+	return nil, ErrAPIKeyNotFound
+}
+
+// ListAPIKeys returns every API key in creation order
+func (m *MemoryStateManager) ListAPIKeys(ctx context.Context) ([]APIKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return append([]APIKey{}, m.apiKeys...), nil
+}
+
+// RevokeAPIKey disables an active API key
+func (m *MemoryStateManager) RevokeAPIKey(ctx context.Context, id int, audit AuditFunc) (*APIKey, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := range m.apiKeys {
+		if m.apiKeys[i].ID == id && m.apiKeys[i].RevokedAt == nil {
+			now := time.Now()
+			key := m.apiKeys[i]
+			key.RevokedAt = &now
+			if err := m.recordAudit(audit, &key); err != nil {
+				return nil, err
+			}
+			m.apiKeys[i] = key
+			return &key, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %d", ErrAPIKeyNotFound, id)
+}
+
+// updateDelivery applies a change to a pending delivery
+func (m *MemoryStateManager) updateDelivery(id int64, apply func(delivery *WebhookDelivery)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, delivery := range m.deliveries {
+		if delivery.ID == id && delivery.Status == DeliveryPending {
+			apply(delivery)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %d", ErrDeliveryNotFound, id)
+}
+
+// update applies a mutation to a stored transfer and bumps its updated_at.
+// Status changes queue webhook deliveries.
+func (m *MemoryStateManager) update(transferID string, apply func(transfer *types.Transfer)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	transfer, exists := m.transfers[transferID]
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrTransferNotFound, transferID)
+	}
+
+	previous := transfer.Status
+	apply(transfer)
+	transfer.UpdatedAt = time.Now()
+	if transfer.Status != previous {
+		m.changed[transferID] = transfer.UpdatedAt
+		m.enqueueWebhookDeliveries(*transfer)
+	}
+
+	return nil
+}
+
+// collect returns copies of all stored transfers accepted by keep
+func (m *MemoryStateManager) collect(keep func(transfer *types.Transfer) bool) []types.Transfer {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	transfers := []types.Transfer{}
+	for _, transfer := range m.transfers {
+		if keep(transfer) {
+			transfers = append(transfers, copyTransfer(*transfer))
+		}
+	}
+
+	return transfers
+}
+
+// transferBefore reports whether a transfer sorts after the (createdAt, id)
+// keyset position in newest-first order
+func transferBefore(transfer types.Transfer, createdAt time.Time, id string) bool {
+	if transfer.CreatedAt.Equal(createdAt) {
+		return strings.Compare(transfer.ID, id) < 0
+	}
+	return transfer.CreatedAt.Before(createdAt)
+}
+
+// copyTransfer returns a transfer that shares no mutable state with the original
+func copyTransfer(transfer types.Transfer) types.Transfer {
+	if transfer.Amount != nil {
+		transfer.Amount = types.NewBigInt(transfer.Amount.Int)
+	}
+	if transfer.Fee != nil {
+		transfer.Fee = types.NewBigInt(transfer.Fee.Int)
+	}
+	return transfer
+}
+
+// copyWebhook returns a webhook that shares no mutable state with the original
+func copyWebhook(webhook Webhook) Webhook {
+	webhook.Events = append([]string{}, webhook.Events...)
+	return webhook
+}
+
+// copyDelivery returns a delivery that shares no mutable state with the original
+func copyDelivery(delivery WebhookDelivery) WebhookDelivery {
+	delivery.Payload = append(json.RawMessage(nil), delivery.Payload...)
+	if delivery.DeliveredAt != nil {
+		deliveredAt := *delivery.DeliveredAt
+		delivery.DeliveredAt = &deliveredAt
+	}
+	return delivery
+}
+
+// SetTokenLimit creates or replaces the limit of a token for a route
+func (m *MemoryStateManager) SetTokenLimit(ctx context.Context, limit *TokenLimit, audit AuditFunc) error {
+	if err := limit.Validate(); err != nil {
+		return fmt.Errorf("token limit validation failed: %w", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	exists := false
+	for _, token := range m.tokens {
+		exists = exists || token.ID == limit.TokenID
+	}
+	if !exists {
+		return fmt.Errorf("%w: %d", ErrTokenNotFound, limit.TokenID)
+	}
+
+	stored := copyTokenLimit(*limit)
+	stored.UpdatedAt = time.Now()
+	replaced := -1
+	stored.ID = m.nextLimit
+	for i := range m.limits {
+		if m.limits[i].TokenID == limit.TokenID && m.limits[i].DestinationChain == limit.DestinationChain {
+			replaced = i
+			stored.ID = m.limits[i].ID
+		}
+	}
+
+	// Like the repository, the limit carries its ID by the time it is audited
+	previousID, previousUpdatedAt := limit.ID, limit.UpdatedAt
+	limit.ID = stored.ID
+	limit.UpdatedAt = stored.UpdatedAt
+	changed := copyTokenLimit(stored)
+	if err := m.recordAudit(audit, &changed); err != nil {
+		limit.ID, limit.UpdatedAt = previousID, previousUpdatedAt
+		return err
+	}
+
+	if replaced >= 0 {
+		m.limits[replaced] = stored
+		return nil
+	}
+	m.nextLimit++
+	m.limits = append(m.limits, stored)
+	return nil
+}
+
+// ListTokenLimits returns the limits of a token ordered by destination chain
+func (m *MemoryStateManager) ListTokenLimits(ctx context.Context, tokenID int) ([]TokenLimit, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	limits := []TokenLimit{}
+	for _, limit := range m.limits {
+		if limit.TokenID == tokenID {
+			limits = append(limits, copyTokenLimit(limit))
+		}
+	}
+	sortTokenLimits(limits)
+
+	return limits, nil
+}
+
+// DeleteTokenLimit removes the limit of a token for a route
+func (m *MemoryStateManager) DeleteTokenLimit(ctx context.Context, tokenID int, destination types.ChainID, audit AuditFunc) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, limit := range m.limits {
+		if limit.TokenID == tokenID && limit.DestinationChain == destination {
+			if err := m.recordAudit(audit, nil); err != nil {
+				return err
+			}
+			m.limits = append(m.limits[:i], m.limits[i+1:]...)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: token %d to %s", ErrTokenLimitNotFound, tokenID, destination)
+}
+
+// GetTransferLimits returns the limits of a transfer's token on its source
+// chain that apply to its route
+func (m *MemoryStateManager) GetTransferLimits(ctx context.Context, transfer types.Transfer) ([]TokenLimit, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	limits := []TokenLimit{}
+	for _, token := range m.tokens {
+		if token.ChainID != transfer.SourceChain || !strings.EqualFold(token.TokenAddress, transfer.Token) {
+			continue
+		}
+		for _, limit := range m.limits {
+			if limit.TokenID == token.ID && (limit.DestinationChain == 0 || limit.DestinationChain == transfer.DestinationChain) {
+				limits = append(limits, copyTokenLimit(limit))
+			}
+		}
+	}
+	sortTokenLimits(limits)
+
+	return limits, nil
+}
+
+// SignedVolume sums the transfers matching a query. A transfer counts from
+// its first signature.
+func (m *MemoryStateManager) SignedVolume(ctx context.Context, query VolumeQuery) (*big.Int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	volume := new(big.Int)
+	for id, transfer := range m.transfers {
+		signatures := m.signatures[id]
+		if len(signatures) == 0 || transfer.Amount == nil || transfer.Amount.Int == nil {
+			continue
+		}
+		signedAt := signatures[0].CreatedAt
+		for _, signature := range signatures[1:] {
+			if signature.CreatedAt.Before(signedAt) {
+				signedAt = signature.CreatedAt
+			}
+		}
+
+		if transfer.SourceChain == query.SourceChain &&
+			strings.EqualFold(transfer.Token, query.Token) &&
+			(query.DestinationChain == 0 || transfer.DestinationChain == query.DestinationChain) &&
+			transfer.Status != types.StatusFailed &&
+			transfer.ID != query.ExcludeTransfer &&
+			!signedAt.Before(query.Since) {
+			volume.Add(volume, transfer.Amount.Int)
+		}
+	}
+
+	return volume, nil
+}
+
+// LockTokenVolume serializes volume checks of a token out of a chain
+func (m *MemoryStateManager) LockTokenVolume(ctx context.Context, sourceChain types.ChainID, token string) (func(), error) {
+	key := volumeLockKey(sourceChain, token)
+
+	m.mu.Lock()
+	lock, exists := m.volumeLocks[key]
+	if !exists {
+		lock = &sync.Mutex{}
+		m.volumeLocks[key] = lock
+	}
+	m.mu.Unlock()
+
+	lock.Lock()
+	return lock.Unlock, nil
+}
+
+// sortTokenLimits orders limits by destination chain, token-wide ones first
+func sortTokenLimits(limits []TokenLimit) {
+	sort.SliceStable(limits, func(i, j int) bool {
+		return limits[i].DestinationChain < limits[j].DestinationChain
+	})
+}
+
+// copyTokenLimit returns a copy of a limit sharing no amounts with it
+func copyTokenLimit(limit TokenLimit) TokenLimit {
+	for _, amount := range []**types.BigInt{&limit.MinAmount, &limit.MaxAmount, &limit.HourlyCap, &limit.DailyCap, &limit.DelayThreshold} {
+		if isSet(*amount) {
+			*amount = types.NewBigInt((*amount).Int)
+		}
+	}
+	return limit
+}
+
+// GetRouteTotals sums the transfers of a token along a route
+func (m *MemoryStateManager) GetRouteTotals(ctx context.Context, source, destination types.ChainID, token string) (*RouteTotals, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	totals := &RouteTotals{Recorded: new(big.Int), Completed: new(big.Int)}
+	for _, transfer := range m.transfers {
+		if transfer.SourceChain != source || transfer.DestinationChain != destination ||
+			!strings.EqualFold(transfer.Token, token) || transfer.Amount == nil || transfer.Amount.Int == nil {
+			continue
+		}
+		totals.Recorded.Add(totals.Recorded, transfer.Amount.Int)
+		if transfer.Status == types.StatusCompleted {
+			totals.Completed.Add(totals.Completed, transfer.Amount.Int)
+		}
+	}
+
+	return totals, nil
+}
+
+// ListRelayerConfigs returns every known relayer, ordered by address
+func (m *MemoryStateManager) ListRelayerConfigs(ctx context.Context) ([]RelayerConfig, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.relayerConfigs(), nil
+}
+
+// SetActiveRelayers makes exactly the given relayers active
+func (m *MemoryStateManager) SetActiveRelayers(ctx context.Context, addresses []string) ([]RelayerConfig, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	active := make(map[string]bool)
+	for _, address := range normalizeAddresses(addresses) {
+		active[address] = true
+	}
+
+	now := time.Now()
+	for i := range m.relayers {
+		relayer := &m.relayers[i]
+		address := strings.ToLower(relayer.Address)
+		if relayer.IsActive != active[address] {
+			relayer.IsActive = active[address]
+			relayer.UpdatedAt = now
+		}
+		delete(active, address)
+	}
+	for _, address := range normalizeAddresses(addresses) {
+		if !active[address] {
+			continue
+		}
+		m.relayers = append(m.relayers, RelayerConfig{
+			ID:              m.nextRelayer,
+			Address:         address,
+			PublicKey:       []byte{},
+			IsActive:        true,
+			ThresholdWeight: 1,
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		})
+		m.nextRelayer++
+	}
+
+	return m.relayerConfigs(), nil
+}
+
+// relayerConfigs copies the relayers ordered by address. The caller must
+// hold the lock.
+func (m *MemoryStateManager) relayerConfigs() []RelayerConfig {
+	relayers := make([]RelayerConfig, len(m.relayers))
+	for i, relayer := range m.relayers {
+		relayer.PublicKey = append([]byte{}, relayer.PublicKey...)
+		relayers[i] = relayer
+	}
+	sort.Slice(relayers, func(i, j int) bool {
+		return strings.ToLower(relayers[i].Address) < strings.ToLower(relayers[j].Address)
+	})
+	return relayers
+}
+
+// RecordScannedBlock advances the scanned block of a chain
+func (m *MemoryStateManager) RecordScannedBlock(ctx context.Context, chainID types.ChainID, block uint64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if current, scanned := m.scanned[chainID]; !scanned || block > current {
+		m.scanned[chainID] = block
+	}
+	return nil
+}
+
+// GetScannedBlock returns the last block scanned on a chain
+func (m *MemoryStateManager) GetScannedBlock(ctx context.Context, chainID types.ChainID) (uint64, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	block, scanned := m.scanned[chainID]
+	return block, scanned, nil
+}
@@ -0,0 +1,172 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"nexus-bridge/pkg/types"
+)
+
+// MemoryTxOutbox is a thread-safe in-memory TxOutbox
+type MemoryTxOutbox struct {
+	mu      sync.Mutex
+	entries []*OutboxEntry
+	nextID  int64
+}
+
+// NewMemoryTxOutbox creates an empty in-memory transaction outbox
+func NewMemoryTxOutbox() *MemoryTxOutbox {
+	return &MemoryTxOutbox{nextID: 1}
+}
+
+var _ TxOutbox = (*MemoryTxOutbox)(nil)
+
+// Enqueue persists a signed transaction for a transfer
+func (m *MemoryTxOutbox) Enqueue(ctx context.Context, transferID string, signed types.SignedTransaction) (*OutboxEntry, error) {
+	if err := validateSignedTransaction(transferID, signed); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, existing := range m.entries {
+		if existing.TxHash == signed.TxHash {
+			return nil, fmt.Errorf("failed to enqueue transaction: duplicate tx hash %s", signed.TxHash)
+		}
+		if !existing.IsLive() {
+			continue
+		}
+		if existing.TransferID == transferID {
+			return nil, fmt.Errorf("failed to enqueue transaction: transfer %s already has a live transaction", transferID)
+		}
+		if existing.ChainID == signed.ChainID && strings.EqualFold(existing.FromAddress, signed.From) && existing.Nonce == signed.Nonce {
+			return nil, fmt.Errorf("failed to enqueue transaction: nonce %d already in use", signed.Nonce)
+		}
+	}
+
+	now := time.Now()
+	entry := &OutboxEntry{
+		ID:          m.nextID,
+		TransferID:  transferID,
+		ChainID:     signed.ChainID,
+		FromAddress: signed.From,
+		Nonce:       signed.Nonce,
+		TxHash:      signed.TxHash,
+		RawTx:       append([]byte(nil), signed.Raw...),
+		Status:      OutboxStatusPending,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	m.nextID++
+	m.entries = append(m.entries, entry)
+
+	result := *entry
+	return &result, nil
+}
+
+// GetLiveByTransfer returns the live transaction for a transfer, or nil
+func (m *MemoryTxOutbox) GetLiveByTransfer(ctx context.Context, transferID string) (*OutboxEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, entry := range m.entries {
+		if entry.TransferID == transferID && entry.IsLive() {
+			result := *entry
+			return &result, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// NextNonce returns one past the highest live nonce for a sender, or zero
+func (m *MemoryTxOutbox) NextNonce(ctx context.Context, chainID types.ChainID, from string) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var next uint64
+	for _, entry := range m.entries {
+		if entry.ChainID == chainID && strings.EqualFold(entry.FromAddress, from) &&
+			entry.Status != OutboxStatusDropped && entry.Nonce+1 > next {
+			next = entry.Nonce + 1
+		}
+	}
+
+	return next, nil
+}
+
+// ListOutstanding returns pending and broadcast entries for a chain, by nonce
+func (m *MemoryTxOutbox) ListOutstanding(ctx context.Context, chainID types.ChainID) ([]OutboxEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var entries []OutboxEntry
+	for _, entry := range m.entries {
+		if entry.ChainID == chainID &&
+			(entry.Status == OutboxStatusPending || entry.Status == OutboxStatusBroadcast) {
+			entries = append(entries, *entry)
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Nonce < entries[j].Nonce
+	})
+
+	return entries, nil
+}
+
+// MarkBroadcast records a broadcast attempt
+func (m *MemoryTxOutbox) MarkBroadcast(ctx context.Context, id int64, broadcastErr error) error {
+	return m.update(id, "mark transaction broadcast", func(entry *OutboxEntry) {
+		entry.Attempts++
+		if broadcastErr != nil {
+			entry.LastError = broadcastErr.Error()
+			return
+		}
+		entry.Status = OutboxStatusBroadcast
+		entry.LastError = ""
+	})
+}
+
+// MarkMined records the mined result of a transaction
+func (m *MemoryTxOutbox) MarkMined(ctx context.Context, id int64, result types.TxResult) error {
+	return m.update(id, "mark transaction mined", func(entry *OutboxEntry) {
+		entry.Status = OutboxStatusConfirmed
+		if !result.Status {
+			entry.Status = OutboxStatusReverted
+		}
+		entry.BlockNumber = result.BlockNumber
+	})
+}
+
+// MarkDropped records that a transaction will never be mined
+func (m *MemoryTxOutbox) MarkDropped(ctx context.Context, id int64) error {
+	return m.update(id, "mark transaction dropped", func(entry *OutboxEntry) {
+		entry.Status = OutboxStatusDropped
+	})
+}
+
+// update mutates an outstanding entry
+func (m *MemoryTxOutbox) update(id int64, action string, apply func(entry *OutboxEntry)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, entry := range m.entries {
+		if entry.ID != id {
+			continue
+		}
+		if entry.Status != OutboxStatusPending && entry.Status != OutboxStatusBroadcast {
+			break
+		}
+		apply(entry)
+		entry.UpdatedAt = time.Now()
+		return nil
+	}
+
+	return fmt.Errorf("failed to %s: outstanding transaction not found", action)
+}
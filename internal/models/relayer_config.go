@@ -0,0 +1,126 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// RelayerConfig is a relayer of the bridge network. Relayers are active
+// while the bridge contracts authorize them.
+type RelayerConfig struct {
+	ID              int       `json:"id" db:"id"`
+	Address         string    `json:"address" db:"relayer_address"`
+	PublicKey       []byte    `json:"public_key,omitempty" db:"public_key"`
+	IsActive        bool      `json:"is_active" db:"is_active"`
+	ThresholdWeight int       `json:"threshold_weight" db:"threshold_weight"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// RelayerConfigStore persists the relayer set
+type RelayerConfigStore interface {
+	// ListRelayerConfigs returns every known relayer, ordered by address
+	ListRelayerConfigs(ctx context.Context) ([]RelayerConfig, error)
+
+	// SetActiveRelayers makes exactly the given relayers active, adding
+	// those not known yet with a weight of 1. Other relayers are kept but
+	// deactivated. Addresses are compared without case.
+	SetActiveRelayers(ctx context.Context, addresses []string) ([]RelayerConfig, error)
+}
+
+// RelayerConfigRepository handles database operations for the relayer set
+type RelayerConfigRepository struct {
+	db *sqlx.DB
+}
+
+// NewRelayerConfigRepository creates a new relayer config repository
+func NewRelayerConfigRepository(db *sqlx.DB) *RelayerConfigRepository {
+	return &RelayerConfigRepository{db: db}
+}
+
+const relayerConfigColumns = `
+	id, relayer_address, public_key, COALESCE(is_active, FALSE) AS is_active,
+	COALESCE(threshold_weight, 1) AS threshold_weight, created_at, updated_at`
+
+// List returns every relayer, ordered by address
+func (r *RelayerConfigRepository) List(ctx context.Context) ([]RelayerConfig, error) {
+	return listRelayerConfigs(ctx, r.db)
+}
+
+// SetActive makes exactly the given relayers active
+func (r *RelayerConfigRepository) SetActive(ctx context.Context, addresses []string) ([]RelayerConfig, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	active := normalizeAddresses(addresses)
+	_, err = tx.ExecContext(ctx, `
+		UPDATE relayer_config SET is_active = FALSE
+		WHERE is_active AND NOT (LOWER(relayer_address) = ANY($1))`, pq.Array(active))
+	if err != nil {
+		return nil, fmt.Errorf("failed to deactivate relayers: %w", err)
+	}
+
+	for _, address := range active {
+		result, err := tx.ExecContext(ctx, `
+			UPDATE relayer_config SET is_active = TRUE
+			WHERE LOWER(relayer_address) = $1`, address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to activate relayer %s: %w", address, err)
+		}
+		if rows, err := result.RowsAffected(); err != nil || rows > 0 {
+			continue
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO relayer_config (relayer_address, public_key, is_active, threshold_weight)
+			VALUES ($1, $2, TRUE, 1)`, address, []byte{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to add relayer %s: %w", address, err)
+		}
+	}
+
+	relayers, err := listRelayerConfigs(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit relayer set: %w", err)
+	}
+	return relayers, nil
+}
+
+// listRelayerConfigs loads every relayer, ordered by address
+func listRelayerConfigs(ctx context.Context, q sqlx.QueryerContext) ([]RelayerConfig, error) {
+	relayers := []RelayerConfig{}
+	query := `SELECT ` + relayerConfigColumns + ` FROM relayer_config ORDER BY LOWER(relayer_address) ASC`
+
+	if err := sqlx.SelectContext(ctx, q, &relayers, query); err != nil {
+		return nil, fmt.Errorf("failed to list relayers: %w", err)
+	}
+	return relayers, nil
+}
+
+// normalizeAddresses lowercases and deduplicates addresses, sorted
+func normalizeAddresses(addresses []string) []string {
+	seen := make(map[string]bool, len(addresses))
+	normalized := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		address = strings.ToLower(strings.TrimSpace(address))
+		if address == "" || seen[address] {
+			continue
+		}
+		seen[address] = true
+		normalized = append(normalized, address)
+	}
+	sort.Strings(normalized)
+	return normalized
+}
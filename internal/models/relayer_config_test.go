@@ -0,0 +1,94 @@
+package models
+
+import (
+	"context"
+	"testing"
+
+	"nexus-bridge/internal/models/testutil"
+)
+
+func TestStateManager_RelayerConfigContract(t *testing.T) {
+	runRelayerConfigContract(t, func(t *testing.T) RelayerConfigStore {
+		db := testutil.SetupTestDB(t)
+		t.Cleanup(func() { testutil.CleanupTestDB(t, db) })
+		return NewStateManager(db)
+	})
+}
+
+func TestMemoryStateManager_RelayerConfigContract(t *testing.T) {
+	runRelayerConfigContract(t, func(t *testing.T) RelayerConfigStore {
+		return NewMemoryStateManager()
+	})
+}
+
+const (
+	relayerA = "0x00000000000000000000000000000000000000a1"
+	relayerB = "0x00000000000000000000000000000000000000b2"
+	relayerC = "0x00000000000000000000000000000000000000c3"
+)
+
+// activeAddresses returns the addresses of the active relayers
+func activeAddresses(relayers []RelayerConfig) []string {
+	var active []string
+	for _, relayer := range relayers {
+		if relayer.IsActive {
+			active = append(active, relayer.Address)
+		}
+	}
+	return active
+}
+
+func runRelayerConfigContract(t *testing.T, newStore func(t *testing.T) RelayerConfigStore) {
+	ctx := context.Background()
+
+	t.Run("SetActive", func(t *testing.T) {
+		store := newStore(t)
+
+		relayers, err := store.ListRelayerConfigs(ctx)
+		if err != nil || len(relayers) != 0 {
+			t.Fatalf("Expected no relayers, got %+v/%v", relayers, err)
+		}
+
+		// Addresses are stored lowercase, once each
+		relayers, err = store.SetActiveRelayers(ctx, []string{"0x00000000000000000000000000000000000000B2", relayerA, relayerB})
+		if err != nil {
+			t.Fatalf("Failed to set relayers: %v", err)
+		}
+		if len(relayers) != 2 || relayers[0].Address != relayerA || relayers[1].Address != relayerB {
+			t.Fatalf("Expected both relayers in address order, got %+v", relayers)
+		}
+		if !relayers[0].IsActive || relayers[0].ThresholdWeight != 1 || relayers[0].ID == 0 {
+			t.Errorf("Unexpected new relayer: %+v", relayers[0])
+		}
+
+		relayers, err = store.SetActiveRelayers(ctx, []string{relayerB, relayerC})
+		if err != nil {
+			t.Fatalf("Failed to set relayers: %v", err)
+		}
+		if len(relayers) != 3 {
+			t.Fatalf("Expected removed relayers to be kept, got %+v", relayers)
+		}
+		active := activeAddresses(relayers)
+		if len(active) != 2 || active[0] != relayerB || active[1] != relayerC {
+			t.Errorf("Expected b and c active, got %v", active)
+		}
+
+		// A relayer added back keeps its row
+		first := relayers[0]
+		relayers, err = store.SetActiveRelayers(ctx, []string{relayerA})
+		if err != nil {
+			t.Fatalf("Failed to set relayers: %v", err)
+		}
+		if relayers[0].ID != first.ID || !relayers[0].IsActive {
+			t.Errorf("Expected relayer a reactivated, got %+v", relayers[0])
+		}
+		if active := activeAddresses(relayers); len(active) != 1 {
+			t.Errorf("Expected only a active, got %v", active)
+		}
+
+		listed, err := store.ListRelayerConfigs(ctx)
+		if err != nil || len(listed) != 3 {
+			t.Errorf("Expected three relayers, got %+v/%v", listed, err)
+		}
+	})
+}
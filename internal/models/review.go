@@ -0,0 +1,393 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"nexus-bridge/pkg/types"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrReviewNotFound is returned when a transfer has no open review
+	ErrReviewNotFound = errors.New("transfer is not under review")
+	// ErrAlreadyVoted is returned when an operator votes twice on a review
+	ErrAlreadyVoted = errors.New("operator has already voted on this review")
+)
+
+// ReviewStatus represents the lifecycle of a transfer review
+type ReviewStatus string
+
+const (
+	// ReviewOpen is waiting for operator decisions
+	ReviewOpen ReviewStatus = "open"
+	// ReviewApproved returned the transfer to the status it was flagged in
+	ReviewApproved ReviewStatus = "approved"
+	// ReviewRejected failed the transfer
+	ReviewRejected ReviewStatus = "rejected"
+)
+
+// ReviewDecision is an operator's vote on a review
+type ReviewDecision string
+
+const (
+	DecisionApprove ReviewDecision = "approve"
+	DecisionReject  ReviewDecision = "reject"
+)
+
+// IsValid reports whether the decision is approve or reject
+func (d ReviewDecision) IsValid() bool {
+	return d == DecisionApprove || d == DecisionReject
+}
+
+// TransferReview records why a transfer was held for manual review and how
+// operators decided it
+type TransferReview struct {
+	ID             int                  `json:"id" db:"id"`
+	TransferID     string               `json:"transfer_id" db:"transfer_id"`
+	Reason         string               `json:"reason" db:"reason"`
+	PreviousStatus types.TransferStatus `json:"previous_status" db:"previous_status"`
+	Status         ReviewStatus         `json:"status" db:"status"`
+	Resolution     string               `json:"resolution,omitempty" db:"resolution"`
+	Votes          []ReviewVote         `json:"votes" db:"-"`
+	CreatedAt      time.Time            `json:"created_at" db:"created_at"`
+	ResolvedAt     *time.Time           `json:"resolved_at,omitempty" db:"resolved_at"`
+}
+
+// ReviewVote is one operator's decision on a review
+type ReviewVote struct {
+	ReviewID  int            `json:"-" db:"review_id"`
+	Operator  string         `json:"operator" db:"operator"`
+	Decision  ReviewDecision `json:"decision" db:"decision"`
+	Reason    string         `json:"reason,omitempty" db:"reason"`
+	CreatedAt time.Time      `json:"created_at" db:"created_at"`
+}
+
+// Validate validates a vote before it is recorded. Rejections must give a
+// reason, since it is recorded as the transfer's failure.
+func (v *ReviewVote) Validate() error {
+	if v.Operator == "" {
+		return fmt.Errorf("operator is required")
+	}
+	if !v.Decision.IsValid() {
+		return fmt.Errorf("invalid decision: %s", v.Decision)
+	}
+	if v.Decision == DecisionReject && v.Reason == "" {
+		return fmt.Errorf("a reason is required to reject a transfer")
+	}
+	return nil
+}
+
+// ReviewStore manages the queue of transfers held for manual review.
+// Transfers enter it through MarkTransferForReview.
+type ReviewStore interface {
+	// ListOpenReviews returns every open review with its votes, oldest first
+	ListOpenReviews(ctx context.Context) ([]TransferReview, error)
+
+	// GetOpenReview returns the open review of a transfer
+	GetOpenReview(ctx context.Context, transferID string) (*TransferReview, error)
+
+	// VoteOnReview records an operator's vote on a transfer's open review.
+	// Once quorum distinct operators agree, the review is resolved: approval
+	// returns the transfer to the status it was flagged in, and rejection
+	// fails it.
+	VoteOnReview(ctx context.Context, transferID string, vote ReviewVote, quorum int, audit AuditFunc) (*TransferReview, error)
+
+	// GetApprovedReviewReason returns the reason the latest review of a
+	// transfer was opened for, if operators approved it
+	GetApprovedReviewReason(ctx context.Context, transferID string) (string, bool, error)
+}
+
+// tally returns the decision reached by quorum distinct operators, if any
+func (r *TransferReview) tally(quorum int) (ReviewDecision, bool) {
+	if quorum < 1 {
+		quorum = 1
+	}
+
+	counts := make(map[ReviewDecision]int)
+	for _, vote := range r.Votes {
+		counts[vote.Decision]++
+		if counts[vote.Decision] >= quorum {
+			return vote.Decision, true
+		}
+	}
+	return "", false
+}
+
+// resolve closes the review with a decision, returning the status the
+// transfer moves to
+func (r *TransferReview) resolve(decision ReviewDecision, resolution string, at time.Time) types.TransferStatus {
+	r.Resolution = resolution
+	r.ResolvedAt = &at
+	if decision == DecisionApprove {
+		r.Status = ReviewApproved
+		return r.PreviousStatus
+	}
+	r.Status = ReviewRejected
+	return types.StatusFailed
+}
+
+// ReviewRepository handles database operations for transfer reviews
+type ReviewRepository struct {
+	db *sqlx.DB
+}
+
+// NewReviewRepository creates a new review repository
+func NewReviewRepository(db *sqlx.DB) *ReviewRepository {
+	return &ReviewRepository{db: db}
+}
+
+// Open holds a transfer for review, recording the reason and the status to
+// return it to if it is approved. A transfer already under review keeps its
+// open review.
+func (r *ReviewRepository) Open(ctx context.Context, transferID, reason string) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var status types.TransferStatus
+	err = tx.GetContext(ctx, &status, `SELECT status FROM transfers WHERE id = $1 FOR UPDATE`, transferID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: %s", ErrTransferNotFound, transferID)
+		}
+		return fmt.Errorf("failed to mark transfer for review: %w", err)
+	}
+	if status == types.StatusUnderReview {
+		return nil
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE transfers SET status = $1, updated_at = $2 WHERE id = $3`,
+		types.StatusUnderReview, time.Now(), transferID)
+	if err != nil {
+		return fmt.Errorf("failed to mark transfer for review: %w", err)
+	}
+
+	query := `
+		INSERT INTO transfer_reviews (transfer_id, reason, previous_status)
+		VALUES ($1, $2, $3)`
+
+	if _, err := tx.ExecContext(ctx, query, transferID, reason, status); err != nil {
+		return fmt.Errorf("failed to create review: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit review: %w", err)
+	}
+	return nil
+}
+
+// ListOpen returns every open review with its votes, oldest first
+func (r *ReviewRepository) ListOpen(ctx context.Context) ([]TransferReview, error) {
+	reviews := []TransferReview{}
+	query := `
+		SELECT id, transfer_id, reason, previous_status, status, COALESCE(resolution, '') AS resolution,
+			   created_at, resolved_at
+		FROM transfer_reviews
+		WHERE status = $1
+		ORDER BY created_at ASC, id ASC`
+
+	if err := r.db.SelectContext(ctx, &reviews, query, ReviewOpen); err != nil {
+		return nil, fmt.Errorf("failed to list reviews: %w", err)
+	}
+
+	ids := make([]int64, len(reviews))
+	for i, review := range reviews {
+		ids[i] = int64(review.ID)
+	}
+	votes, err := selectReviewVotes(ctx, r.db, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range reviews {
+		reviews[i].Votes = votes[reviews[i].ID]
+	}
+
+	return reviews, nil
+}
+
+// GetOpen returns the open review of a transfer
+func (r *ReviewRepository) GetOpen(ctx context.Context, transferID string) (*TransferReview, error) {
+	return getOpenReview(ctx, r.db, transferID, false)
+}
+
+// Vote records an operator's vote and resolves the review once quorum
+// operators agree. The review row is locked so concurrent votes are
+// tallied one at a time.
+func (r *ReviewRepository) Vote(ctx context.Context, transferID string, vote ReviewVote, quorum int, audit AuditFunc) (*TransferReview, error) {
+	if err := vote.Validate(); err != nil {
+		return nil, fmt.Errorf("vote validation failed: %w", err)
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	review, err := getOpenReview(ctx, tx, transferID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO transfer_review_votes (review_id, operator, decision, reason)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (review_id, operator) DO NOTHING`
+
+	result, err := tx.ExecContext(ctx, query, review.ID, vote.Operator, vote.Decision, nullableString(vote.Reason))
+	if err != nil {
+		return nil, fmt.Errorf("failed to record vote: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrAlreadyVoted, vote.Operator)
+	}
+
+	votes, err := selectReviewVotes(ctx, tx, []int64{int64(review.ID)})
+	if err != nil {
+		return nil, err
+	}
+	review.Votes = votes[review.ID]
+
+	if decision, decided := review.tally(quorum); decided {
+		now := time.Now()
+		status := review.resolve(decision, vote.Reason, now)
+
+		_, err = tx.ExecContext(ctx, `UPDATE transfer_reviews SET status = $1, resolution = $2, resolved_at = $3 WHERE id = $4`,
+			review.Status, nullableString(review.Resolution), now, review.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve review: %w", err)
+		}
+		_, err = tx.ExecContext(ctx, `UPDATE transfers SET status = $1, updated_at = $2 WHERE id = $3`,
+			status, now, transferID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update reviewed transfer: %w", err)
+		}
+	}
+
+	if err := recordAudit(ctx, tx, audit, review); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit vote: %w", err)
+	}
+	return review, nil
+}
+
+// GetApprovedReason returns the reason the latest review of a transfer was
+// opened for, if it was approved
+func (r *ReviewRepository) GetApprovedReason(ctx context.Context, transferID string) (string, bool, error) {
+	var review struct {
+		Reason string       `db:"reason"`
+		Status ReviewStatus `db:"status"`
+	}
+	query := `
+		SELECT reason, status
+		FROM transfer_reviews
+		WHERE transfer_id = $1
+		ORDER BY id DESC
+		LIMIT 1`
+
+	err := r.db.GetContext(ctx, &review, query, transferID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("failed to get review status: %w", err)
+	}
+
+	if review.Status != ReviewApproved {
+		return "", false, nil
+	}
+	return review.Reason, true, nil
+}
+
+// getOpenReview loads the open review of a transfer with its votes,
+// optionally locking it for the rest of a transaction
+func getOpenReview(ctx context.Context, q sqlx.QueryerContext, transferID string, lock bool) (*TransferReview, error) {
+	query := `
+		SELECT id, transfer_id, reason, previous_status, status, COALESCE(resolution, '') AS resolution,
+			   created_at, resolved_at
+		FROM transfer_reviews
+		WHERE transfer_id = $1 AND status = $2`
+	if lock {
+		query += ` FOR UPDATE`
+	}
+
+	var review TransferReview
+	if err := sqlx.GetContext(ctx, q, &review, query, transferID, ReviewOpen); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", ErrReviewNotFound, transferID)
+		}
+		return nil, fmt.Errorf("failed to get review: %w", err)
+	}
+
+	votes, err := selectReviewVotes(ctx, q, []int64{int64(review.ID)})
+	if err != nil {
+		return nil, err
+	}
+	review.Votes = votes[review.ID]
+
+	return &review, nil
+}
+
+// selectReviewVotes returns the votes on reviews keyed by review ID, each
+// in the order they were cast
+func selectReviewVotes(ctx context.Context, q sqlx.QueryerContext, reviewIDs []int64) (map[int][]ReviewVote, error) {
+	votes := make(map[int][]ReviewVote, len(reviewIDs))
+	for _, id := range reviewIDs {
+		votes[int(id)] = []ReviewVote{}
+	}
+	if len(reviewIDs) == 0 {
+		return votes, nil
+	}
+
+	var rows []ReviewVote
+	query := `
+		SELECT review_id, operator, decision, COALESCE(reason, '') AS reason, created_at
+		FROM transfer_review_votes
+		WHERE review_id = ANY($1)
+		ORDER BY created_at ASC, operator ASC`
+
+	if err := sqlx.SelectContext(ctx, q, &rows, query, pq.Array(reviewIDs)); err != nil {
+		return nil, fmt.Errorf("failed to get review votes: %w", err)
+	}
+	for _, vote := range rows {
+		votes[vote.ReviewID] = append(votes[vote.ReviewID], vote)
+	}
+
+	return votes, nil
+}
+
+// copyReview returns a deep copy of a review
+func copyReview(review TransferReview) TransferReview {
+	review.Votes = append([]ReviewVote{}, review.Votes...)
+	if review.ResolvedAt != nil {
+		resolvedAt := *review.ResolvedAt
+		review.ResolvedAt = &resolvedAt
+	}
+	return review
+}
+
+// sortReviewsOldestFirst orders reviews by (created_at, id) ascending
+func sortReviewsOldestFirst(reviews []TransferReview) {
+	sort.SliceStable(reviews, func(i, j int) bool {
+		if reviews[i].CreatedAt.Equal(reviews[j].CreatedAt) {
+			return reviews[i].ID < reviews[j].ID
+		}
+		return reviews[i].CreatedAt.Before(reviews[j].CreatedAt)
+	})
+}
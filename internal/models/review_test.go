@@ -0,0 +1,206 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"nexus-bridge/internal/models/testutil"
+	"nexus-bridge/pkg/types"
+)
+
+// reviewStateStore is a Store that also manages the review queue
+type reviewStateStore interface {
+	Store
+	ReviewStore
+}
+
+func TestStateManager_ReviewContract(t *testing.T) {
+	runReviewContract(t, func(t *testing.T) reviewStateStore {
+		db := testutil.SetupTestDB(t)
+		t.Cleanup(func() { testutil.CleanupTestDB(t, db) })
+		return NewStateManager(db)
+	})
+}
+
+func TestMemoryStateManager_ReviewContract(t *testing.T) {
+	runReviewContract(t, func(t *testing.T) reviewStateStore {
+		return NewMemoryStateManager()
+	})
+}
+
+func TestReviewVote_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		vote  ReviewVote
+		valid bool
+	}{
+		{"Approve", ReviewVote{Operator: "alice", Decision: DecisionApprove}, true},
+		{"RejectWithReason", ReviewVote{Operator: "alice", Decision: DecisionReject, Reason: "sanctioned"}, true},
+		{"MissingOperator", ReviewVote{Decision: DecisionApprove}, false},
+		{"UnknownDecision", ReviewVote{Operator: "alice", Decision: "abstain"}, false},
+		{"RejectWithoutReason", ReviewVote{Operator: "alice", Decision: DecisionReject}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.vote.Validate()
+			if tt.valid && err != nil {
+				t.Errorf("Expected valid vote, got %v", err)
+			}
+			if !tt.valid && err == nil {
+				t.Error("Expected validation error")
+			}
+		})
+	}
+}
+
+func mustFlag(t *testing.T, store Store, transferID, reason string) {
+	t.Helper()
+	if err := store.MarkTransferForReview(context.Background(), transferID, reason); err != nil {
+		t.Fatalf("Failed to mark transfer for review: %v", err)
+	}
+}
+
+func mustVote(t *testing.T, store ReviewStore, transferID, operator string, decision ReviewDecision, reason string, quorum int) *TransferReview {
+	t.Helper()
+	vote := ReviewVote{Operator: operator, Decision: decision, Reason: reason}
+	review, err := store.VoteOnReview(context.Background(), transferID, vote, quorum, nil)
+	if err != nil {
+		t.Fatalf("Failed to vote on review: %v", err)
+	}
+	return review
+}
+
+func assertTransferStatus(t *testing.T, store Store, transferID string, expected types.TransferStatus) {
+	t.Helper()
+	transfer, err := store.GetTransfer(context.Background(), transferID)
+	if err != nil {
+		t.Fatalf("Failed to get transfer: %v", err)
+	}
+	if transfer.Status != expected {
+		t.Errorf("Expected transfer %s to be %s, got %s", transferID, expected, transfer.Status)
+	}
+}
+
+func runReviewContract(t *testing.T, newStore func(t *testing.T) reviewStateStore) {
+	ctx := context.Background()
+
+	t.Run("QueueKeepsReasons", func(t *testing.T) {
+		store := newStore(t)
+		first, second := contractTransfer(1), contractTransfer(2)
+		mustRecord(t, store, first)
+		mustRecord(t, store, second)
+		if err := store.UpdateTransferStatus(ctx, second.ID, types.StatusSigned); err != nil {
+			t.Fatalf("Failed to update status: %v", err)
+		}
+
+		mustFlag(t, store, first.ID, "fee too low")
+		mustFlag(t, store, second.ID, "destination transaction reverted")
+		// Flagging again keeps the original review
+		mustFlag(t, store, first.ID, "fee still too low")
+
+		if err := store.MarkTransferForReview(ctx, contractTransfer(3).ID, "missing"); !errors.Is(err, ErrTransferNotFound) {
+			t.Errorf("Expected ErrTransferNotFound, got %v", err)
+		}
+
+		reviews, err := store.ListOpenReviews(ctx)
+		if err != nil {
+			t.Fatalf("Failed to list reviews: %v", err)
+		}
+		if len(reviews) != 2 || reviews[0].TransferID != first.ID || reviews[1].TransferID != second.ID {
+			t.Fatalf("Expected both reviews oldest first, got %+v", reviews)
+		}
+		if reviews[0].Reason != "fee too low" || reviews[0].PreviousStatus != types.StatusPending ||
+			reviews[0].Status != ReviewOpen || reviews[0].Votes == nil || len(reviews[0].Votes) != 0 {
+			t.Errorf("Unexpected review: %+v", reviews[0])
+		}
+		if reviews[1].PreviousStatus != types.StatusSigned {
+			t.Errorf("Expected previous status signed, got %s", reviews[1].PreviousStatus)
+		}
+
+		review, err := store.GetOpenReview(ctx, second.ID)
+		if err != nil || review.ID != reviews[1].ID {
+			t.Errorf("Expected the second review, got %+v/%v", review, err)
+		}
+		if _, err := store.GetOpenReview(ctx, contractTransfer(3).ID); !errors.Is(err, ErrReviewNotFound) {
+			t.Errorf("Expected ErrReviewNotFound, got %v", err)
+		}
+	})
+
+	t.Run("ApprovalRestoresStatus", func(t *testing.T) {
+		store := newStore(t)
+		transfer := contractTransfer(1)
+		mustRecord(t, store, transfer)
+		if err := store.UpdateTransferStatus(ctx, transfer.ID, types.StatusConfirming); err != nil {
+			t.Fatalf("Failed to update status: %v", err)
+		}
+		mustFlag(t, store, transfer.ID, "fee too low")
+
+		if _, approved, err := store.GetApprovedReviewReason(ctx, transfer.ID); err != nil || approved {
+			t.Errorf("Expected an open review not to count as approved, got %v/%v", approved, err)
+		}
+
+		review := mustVote(t, store, transfer.ID, "alice", DecisionApprove, "fee waived", 1)
+		if review.Status != ReviewApproved || review.Resolution != "fee waived" || review.ResolvedAt == nil ||
+			len(review.Votes) != 1 || review.Votes[0].Operator != "alice" {
+			t.Errorf("Expected an approved review, got %+v", review)
+		}
+		assertTransferStatus(t, store, transfer.ID, types.StatusConfirming)
+
+		if reason, approved, err := store.GetApprovedReviewReason(ctx, transfer.ID); err != nil || !approved || reason != "fee too low" {
+			t.Errorf("Expected the transfer to be approved for its fee, got %q/%v/%v", reason, approved, err)
+		}
+		if _, err := store.VoteOnReview(ctx, transfer.ID, ReviewVote{Operator: "bob", Decision: DecisionApprove}, 1, nil); !errors.Is(err, ErrReviewNotFound) {
+			t.Errorf("Expected ErrReviewNotFound once resolved, got %v", err)
+		}
+
+		// A later review supersedes the approval
+		mustFlag(t, store, transfer.ID, "destination transaction reverted")
+		if _, approved, err := store.GetApprovedReviewReason(ctx, transfer.ID); err != nil || approved {
+			t.Errorf("Expected a new review to supersede the approval, got %v/%v", approved, err)
+		}
+	})
+
+	t.Run("QuorumOfDistinctOperators", func(t *testing.T) {
+		store := newStore(t)
+		transfer := contractTransfer(1)
+		mustRecord(t, store, transfer)
+		mustFlag(t, store, transfer.ID, "recipient is screened")
+
+		review := mustVote(t, store, transfer.ID, "alice", DecisionReject, "sanctioned recipient", 2)
+		if review.Status != ReviewOpen || len(review.Votes) != 1 {
+			t.Errorf("Expected the review to stay open after one vote, got %+v", review)
+		}
+		assertTransferStatus(t, store, transfer.ID, types.StatusUnderReview)
+
+		duplicate := ReviewVote{Operator: "alice", Decision: DecisionReject, Reason: "again"}
+		if _, err := store.VoteOnReview(ctx, transfer.ID, duplicate, 2, nil); !errors.Is(err, ErrAlreadyVoted) {
+			t.Errorf("Expected ErrAlreadyVoted, got %v", err)
+		}
+		invalid := ReviewVote{Operator: "bob", Decision: DecisionReject}
+		if _, err := store.VoteOnReview(ctx, transfer.ID, invalid, 2, nil); err == nil {
+			t.Error("Expected a rejection without a reason to fail")
+		}
+
+		// Disagreeing votes do not add up
+		review = mustVote(t, store, transfer.ID, "bob", DecisionApprove, "", 2)
+		if review.Status != ReviewOpen || len(review.Votes) != 2 {
+			t.Errorf("Expected the review to stay open on a split vote, got %+v", review)
+		}
+
+		review = mustVote(t, store, transfer.ID, "carol", DecisionReject, "confirmed sanctioned", 2)
+		if review.Status != ReviewRejected || review.Resolution != "confirmed sanctioned" || len(review.Votes) != 3 {
+			t.Errorf("Expected a rejected review, got %+v", review)
+		}
+		assertTransferStatus(t, store, transfer.ID, types.StatusFailed)
+
+		reviews, err := store.ListOpenReviews(ctx)
+		if err != nil || len(reviews) != 0 {
+			t.Errorf("Expected an empty queue, got %+v/%v", reviews, err)
+		}
+		if _, approved, err := store.GetApprovedReviewReason(ctx, transfer.ID); err != nil || approved {
+			t.Errorf("Expected a rejected transfer not to be approved, got %v/%v", approved, err)
+		}
+	})
+}
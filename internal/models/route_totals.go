@@ -0,0 +1,47 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"nexus-bridge/pkg/types"
+)
+
+// RouteTotals are the amounts of a token moved along a route, in the token's
+// base units
+type RouteTotals struct {
+	// Recorded is the amount of every transfer seen on the source chain,
+	// where the bridge locked or burned it
+	Recorded *big.Int
+	// Completed is the amount of the transfers executed on the destination
+	// chain, where the bridge unlocked or minted it
+	Completed *big.Int
+}
+
+// RouteTotaler sums transfers by route, to reconcile the bridge contracts'
+// balances with the transfers the relayers have seen
+type RouteTotaler interface {
+	// GetRouteTotals sums the transfers of a token, given by its address on
+	// its own chain, from a source to a destination chain
+	GetRouteTotals(ctx context.Context, source, destination types.ChainID, token string) (*RouteTotals, error)
+}
+
+// RouteTotals sums the transfers of a token along a route
+func (r *TransferRepository) RouteTotals(ctx context.Context, source, destination types.ChainID, token string) (*RouteTotals, error) {
+	var row struct {
+		Recorded  types.BigInt `db:"recorded"`
+		Completed types.BigInt `db:"completed"`
+	}
+	query := `
+		SELECT COALESCE(SUM(amount), 0)::TEXT AS recorded,
+			   COALESCE(SUM(amount) FILTER (WHERE status = 'completed'), 0)::TEXT AS completed
+		FROM transfers
+		WHERE source_chain = $1 AND destination_chain = $2 AND LOWER(token) = LOWER($3)`
+
+	if err := r.db.GetContext(ctx, &row, query, source, destination, token); err != nil {
+		return nil, fmt.Errorf("failed to sum route totals: %w", err)
+	}
+
+	return &RouteTotals{Recorded: row.Recorded.Int, Completed: row.Completed.Int}, nil
+}
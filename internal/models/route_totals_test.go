@@ -0,0 +1,64 @@
+package models
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"nexus-bridge/internal/models/testutil"
+	"nexus-bridge/pkg/types"
+)
+
+// routeTotalStateStore is a Store that also sums transfers by route
+type routeTotalStateStore interface {
+	Store
+	RouteTotaler
+}
+
+func TestStateManager_RouteTotalsContract(t *testing.T) {
+	runRouteTotalsContract(t, func(t *testing.T) routeTotalStateStore {
+		db := testutil.SetupTestDB(t)
+		t.Cleanup(func() { testutil.CleanupTestDB(t, db) })
+		return NewStateManager(db)
+	})
+}
+
+func TestMemoryStateManager_RouteTotalsContract(t *testing.T) {
+	runRouteTotalsContract(t, func(t *testing.T) routeTotalStateStore {
+		return NewMemoryStateManager()
+	})
+}
+
+func runRouteTotalsContract(t *testing.T, newStore func(t *testing.T) routeTotalStateStore) {
+	ctx := context.Background()
+	store := newStore(t)
+
+	back := contractTransfer(3)
+	back.SourceChain, back.DestinationChain = types.ChainPolygon, types.ChainEthereum
+	other := contractTransfer(4)
+	other.Token = "0x00000000000000000000000000000000000000b1"
+	for _, transfer := range []types.Transfer{contractTransfer(1), contractTransfer(2), back, other} {
+		mustRecord(t, store, transfer)
+	}
+	if err := store.UpdateTransferStatus(ctx, contractTransfer(1).ID, types.StatusCompleted); err != nil {
+		t.Fatalf("Failed to update status: %v", err)
+	}
+
+	// Tokens are matched whatever the case of their address
+	totals, err := store.GetRouteTotals(ctx, types.ChainEthereum, types.ChainPolygon, "0xa0b86a33e6441e6c7d3e4c2c4c6c6c6c6c6c6c6c")
+	if err != nil {
+		t.Fatalf("Failed to get route totals: %v", err)
+	}
+	amount := contractTransfer(1).Amount.Int
+	if totals.Recorded.Cmp(new(big.Int).Mul(amount, big.NewInt(2))) != 0 || totals.Completed.Cmp(amount) != 0 {
+		t.Errorf("Expected two transfers recorded and one completed, got %s/%s", totals.Recorded, totals.Completed)
+	}
+
+	totals, err = store.GetRouteTotals(ctx, types.ChainEthereum, types.ChainCosmos, contractTransfer(1).Token)
+	if err != nil {
+		t.Fatalf("Failed to get route totals: %v", err)
+	}
+	if totals.Recorded.Sign() != 0 || totals.Completed.Sign() != 0 {
+		t.Errorf("Expected no totals on an unused route, got %s/%s", totals.Recorded, totals.Completed)
+	}
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"nexus-bridge/pkg/types"
+)
+
+// ScanProgressStore persists how far the relayer has scanned each chain for
+// events, so processes that do not scan can report the relayer's lag
+type ScanProgressStore interface {
+	// RecordScannedBlock advances the scanned block of a chain. It never
+	// moves backwards.
+	RecordScannedBlock(ctx context.Context, chainID types.ChainID, block uint64) error
+
+	// GetScannedBlock returns the last block scanned on a chain, and false
+	// if the chain was never scanned
+	GetScannedBlock(ctx context.Context, chainID types.ChainID) (uint64, bool, error)
+}
+
+// ScanProgressRepository handles database operations for chain scan progress
+type ScanProgressRepository struct {
+	db *sqlx.DB
+}
+
+// NewScanProgressRepository creates a new scan progress repository
+func NewScanProgressRepository(db *sqlx.DB) *ScanProgressRepository {
+	return &ScanProgressRepository{db: db}
+}
+
+// Record advances the scanned block of a chain
+func (r *ScanProgressRepository) Record(ctx context.Context, chainID types.ChainID, block uint64) error {
+	query := `
+		INSERT INTO chain_scan_progress (chain_id, scanned_block, updated_at)
+		VALUES ($1, $2, CURRENT_TIMESTAMP)
+		ON CONFLICT (chain_id) DO UPDATE
+		SET scanned_block = GREATEST(chain_scan_progress.scanned_block, EXCLUDED.scanned_block),
+		    updated_at = CURRENT_TIMESTAMP`
+
+	if _, err := r.db.ExecContext(ctx, query, chainID, block); err != nil {
+		return fmt.Errorf("failed to record scanned block: %w", err)
+	}
+	return nil
+}
+
+// Get returns the last block scanned on a chain
+func (r *ScanProgressRepository) Get(ctx context.Context, chainID types.ChainID) (uint64, bool, error) {
+	var block uint64
+	err := r.db.GetContext(ctx, &block, `SELECT scanned_block FROM chain_scan_progress WHERE chain_id = $1`, chainID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, false, nil
+		}
+		return 0, false, fmt.Errorf("failed to get scanned block: %w", err)
+	}
+	return block, true, nil
+}
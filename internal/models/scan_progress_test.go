@@ -0,0 +1,60 @@
+package models
+
+import (
+	"context"
+	"testing"
+
+	"nexus-bridge/internal/models/testutil"
+	"nexus-bridge/pkg/types"
+)
+
+func TestStateManager_ScanProgressContract(t *testing.T) {
+	runScanProgressContract(t, func(t *testing.T) ScanProgressStore {
+		db := testutil.SetupTestDB(t)
+		t.Cleanup(func() { testutil.CleanupTestDB(t, db) })
+		return NewStateManager(db)
+	})
+}
+
+func TestMemoryStateManager_ScanProgressContract(t *testing.T) {
+	runScanProgressContract(t, func(t *testing.T) ScanProgressStore {
+		return NewMemoryStateManager()
+	})
+}
+
+func runScanProgressContract(t *testing.T, newStore func(t *testing.T) ScanProgressStore) {
+	ctx := context.Background()
+	store := newStore(t)
+
+	if _, scanned, err := store.GetScannedBlock(ctx, types.ChainEthereum); err != nil || scanned {
+		t.Fatalf("Expected an unscanned chain, got %v/%v", scanned, err)
+	}
+
+	if err := store.RecordScannedBlock(ctx, types.ChainEthereum, 120); err != nil {
+		t.Fatalf("Failed to record scanned block: %v", err)
+	}
+	if err := store.RecordScannedBlock(ctx, types.ChainPolygon, 900); err != nil {
+		t.Fatalf("Failed to record scanned block: %v", err)
+	}
+
+	// Progress never moves backwards
+	if err := store.RecordScannedBlock(ctx, types.ChainEthereum, 100); err != nil {
+		t.Fatalf("Failed to record scanned block: %v", err)
+	}
+	block, scanned, err := store.GetScannedBlock(ctx, types.ChainEthereum)
+	if err != nil || !scanned || block != 120 {
+		t.Fatalf("Expected block 120, got %d/%v/%v", block, scanned, err)
+	}
+
+	if err := store.RecordScannedBlock(ctx, types.ChainEthereum, 130); err != nil {
+		t.Fatalf("Failed to record scanned block: %v", err)
+	}
+	block, _, _ = store.GetScannedBlock(ctx, types.ChainEthereum)
+	if block != 130 {
+		t.Fatalf("Expected block 130, got %d", block)
+	}
+	block, _, _ = store.GetScannedBlock(ctx, types.ChainPolygon)
+	if block != 900 {
+		t.Fatalf("Expected block 900, got %d", block)
+	}
+}
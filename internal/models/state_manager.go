@@ -3,17 +3,50 @@ package models
 import (
 	"context"
 	"fmt"
+	"math/big"
+	"time"
 
 	"nexus-bridge/pkg/types"
 
 	"github.com/jmoiron/sqlx"
 )
 
+// Store is the full set of state operations shared by the database-backed
+// StateManager and the in-memory MemoryStateManager
+type Store interface {
+	types.StateManager
+
+	GetTransfer(ctx context.Context, transferID string) (*types.Transfer, error)
+	GetTransfersByStatus(ctx context.Context, status types.TransferStatus) ([]types.Transfer, error)
+	SearchTransfers(ctx context.Context, filter TransferFilter) (*TransferPage, error)
+	CountStuckTransfers(ctx context.Context, status types.TransferStatus, before time.Time) (int, error)
+	UpdateConfirmations(ctx context.Context, transferID string, confirmations uint64) error
+	AddSupportedToken(ctx context.Context, token *types.SupportedToken, audit AuditFunc) error
+	IsTokenSupported(ctx context.Context, chainID types.ChainID, tokenAddress string) (bool, error)
+	GetSupportedTokens(ctx context.Context, chainID types.ChainID) ([]types.SupportedToken, error)
+	GetSupportedToken(ctx context.Context, id int) (*types.SupportedToken, error)
+	ListSupportedTokens(ctx context.Context) ([]types.SupportedToken, error)
+	SetTokenEnabled(ctx context.Context, id int, enabled bool, audit AuditFunc) error
+	DeleteSupportedToken(ctx context.Context, id int, audit AuditFunc) error
+	RecordAudit(ctx context.Context, entry *AuditEntry) error
+	GetAuditTrail(ctx context.Context, entityType, entityID string) ([]AuditEntry, error)
+	HasRelayerSigned(ctx context.Context, transferID, relayerAddress string) (bool, error)
+	GetSignatureCount(ctx context.Context, transferID string) (int, error)
+}
+
 // StateManager implements the StateManager interface using database repositories
 type StateManager struct {
 	transferRepo  *TransferRepository
 	signatureRepo *SignatureRepository
 	tokenRepo     *SupportedTokenRepository
+	auditRepo     *AuditLogRepository
+	webhookRepo   *WebhookRepository
+	reviewRepo    *ReviewRepository
+	apiKeyRepo    *APIKeyRepository
+	limitRepo     *TokenLimitRepository
+	delayRepo     *DelayRepository
+	relayerRepo   *RelayerConfigRepository
+	scanRepo      *ScanProgressRepository
 }
 
 // NewStateManager creates a new state manager with database repositories
@@ -22,6 +55,14 @@ func NewStateManager(db *sqlx.DB) *StateManager {
 		transferRepo:  NewTransferRepository(db),
 		signatureRepo: NewSignatureRepository(db),
 		tokenRepo:     NewSupportedTokenRepository(db),
+		auditRepo:     NewAuditLogRepository(db),
+		webhookRepo:   NewWebhookRepository(db),
+		reviewRepo:    NewReviewRepository(db),
+		apiKeyRepo:    NewAPIKeyRepository(db),
+		limitRepo:     NewTokenLimitRepository(db),
+		delayRepo:     NewDelayRepository(db),
+		relayerRepo:   NewRelayerConfigRepository(db),
+		scanRepo:      NewScanProgressRepository(db),
 	}
 }
 
@@ -83,9 +124,9 @@ func (sm *StateManager) GetSignatures(ctx context.Context, transferID string) ([
 	return sm.signatureRepo.GetByTransferID(ctx, transferID)
 }
 
-// MarkTransferForReview marks a transfer for manual review
+// MarkTransferForReview holds a transfer for manual review
 func (sm *StateManager) MarkTransferForReview(ctx context.Context, transferID string, reason string) error {
-	return sm.transferRepo.MarkForReview(ctx, transferID, reason)
+	return sm.reviewRepo.Open(ctx, transferID, reason)
 }
 
 // GetTransfer returns a transfer by ID
@@ -98,11 +139,47 @@ func (sm *StateManager) GetTransfersByStatus(ctx context.Context, status types.T
 	return sm.transferRepo.GetByStatus(ctx, status)
 }
 
+// SearchTransfers returns a page of transfers matching the filter
+func (sm *StateManager) SearchTransfers(ctx context.Context, filter TransferFilter) (*TransferPage, error) {
+	return sm.transferRepo.Search(ctx, filter)
+}
+
+// CountStuckTransfers counts transfers in a status they entered before a time
+func (sm *StateManager) CountStuckTransfers(ctx context.Context, status types.TransferStatus, before time.Time) (int, error) {
+	return sm.transferRepo.CountStuck(ctx, status, before)
+}
+
+// LeaseTransfers leases up to limit transfers in a status for owner
+func (sm *StateManager) LeaseTransfers(ctx context.Context, status types.TransferStatus, owner string, ttl time.Duration, limit int) ([]types.Transfer, error) {
+	return sm.transferRepo.Lease(ctx, status, owner, ttl, limit)
+}
+
+// LeaseUnsignedTransfers leases up to limit transfers in a status that the
+// relayer address has not signed
+func (sm *StateManager) LeaseUnsignedTransfers(ctx context.Context, status types.TransferStatus, relayerAddress, owner string, ttl time.Duration, limit int) ([]types.Transfer, error) {
+	return sm.transferRepo.LeaseUnsigned(ctx, status, relayerAddress, owner, ttl, limit)
+}
+
+// RenewLease extends a lease still held by owner
+func (sm *StateManager) RenewLease(ctx context.Context, transferID, owner string, ttl time.Duration) error {
+	return sm.transferRepo.RenewLease(ctx, transferID, owner, ttl)
+}
+
+// ReleaseLease gives up a lease held by owner
+func (sm *StateManager) ReleaseLease(ctx context.Context, transferID, owner string) error {
+	return sm.transferRepo.ReleaseLease(ctx, transferID, owner)
+}
+
 // UpdateConfirmations updates the confirmation count for a transfer
 func (sm *StateManager) UpdateConfirmations(ctx context.Context, transferID string, confirmations uint64) error {
 	return sm.transferRepo.UpdateConfirmations(ctx, transferID, confirmations)
 }
 
+// AddSupportedToken registers a new supported token
+func (sm *StateManager) AddSupportedToken(ctx context.Context, token *types.SupportedToken, audit AuditFunc) error {
+	return sm.tokenRepo.Create(ctx, token, audit)
+}
+
 // IsTokenSupported checks if a token is supported on a specific chain
 func (sm *StateManager) IsTokenSupported(ctx context.Context, chainID types.ChainID, tokenAddress string) (bool, error) {
 	return sm.tokenRepo.IsSupported(ctx, chainID, tokenAddress)
@@ -113,6 +190,36 @@ func (sm *StateManager) GetSupportedTokens(ctx context.Context, chainID types.Ch
 	return sm.tokenRepo.GetByChain(ctx, chainID)
 }
 
+// GetSupportedToken returns a supported token by ID
+func (sm *StateManager) GetSupportedToken(ctx context.Context, id int) (*types.SupportedToken, error) {
+	return sm.tokenRepo.GetByID(ctx, id)
+}
+
+// ListSupportedTokens returns every supported token, including disabled ones
+func (sm *StateManager) ListSupportedTokens(ctx context.Context) ([]types.SupportedToken, error) {
+	return sm.tokenRepo.GetAll(ctx)
+}
+
+// SetTokenEnabled enables or disables a supported token
+func (sm *StateManager) SetTokenEnabled(ctx context.Context, id int, enabled bool, audit AuditFunc) error {
+	return sm.tokenRepo.UpdateEnabled(ctx, id, enabled, audit)
+}
+
+// DeleteSupportedToken removes a supported token
+func (sm *StateManager) DeleteSupportedToken(ctx context.Context, id int, audit AuditFunc) error {
+	return sm.tokenRepo.Delete(ctx, id, audit)
+}
+
+// RecordAudit appends an entry to the audit log
+func (sm *StateManager) RecordAudit(ctx context.Context, entry *AuditEntry) error {
+	return sm.auditRepo.Create(ctx, entry)
+}
+
+// GetAuditTrail returns the audit trail of an entity, oldest first
+func (sm *StateManager) GetAuditTrail(ctx context.Context, entityType, entityID string) ([]AuditEntry, error) {
+	return sm.auditRepo.GetByEntity(ctx, entityType, entityID)
+}
+
 // HasRelayerSigned checks if a relayer has already signed a transfer
 func (sm *StateManager) HasRelayerSigned(ctx context.Context, transferID, relayerAddress string) (bool, error) {
 	return sm.signatureRepo.HasSignature(ctx, transferID, relayerAddress)
@@ -121,4 +228,183 @@ func (sm *StateManager) HasRelayerSigned(ctx context.Context, transferID, relaye
 // GetSignatureCount returns the number of signatures for a transfer
 func (sm *StateManager) GetSignatureCount(ctx context.Context, transferID string) (int, error) {
 	return sm.signatureRepo.CountByTransferID(ctx, transferID)
-}
\ No newline at end of file
+}
+
+// CreateWebhook registers a webhook
+func (sm *StateManager) CreateWebhook(ctx context.Context, webhook *Webhook, audit AuditFunc) error {
+	return sm.webhookRepo.CreateWebhook(ctx, webhook, audit)
+}
+
+// GetWebhook returns a webhook by ID
+func (sm *StateManager) GetWebhook(ctx context.Context, id int) (*Webhook, error) {
+	return sm.webhookRepo.GetWebhook(ctx, id)
+}
+
+// ListWebhooks returns every webhook
+func (sm *StateManager) ListWebhooks(ctx context.Context) ([]Webhook, error) {
+	return sm.webhookRepo.ListWebhooks(ctx)
+}
+
+// DeleteWebhook removes a webhook and its deliveries
+func (sm *StateManager) DeleteWebhook(ctx context.Context, id int, audit AuditFunc) error {
+	return sm.webhookRepo.DeleteWebhook(ctx, id, audit)
+}
+
+// ClaimDeliveries leases due webhook deliveries
+func (sm *StateManager) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]WebhookDelivery, error) {
+	return sm.webhookRepo.ClaimDeliveries(ctx, limit, lease)
+}
+
+// MarkDelivered records a successful webhook delivery
+func (sm *StateManager) MarkDelivered(ctx context.Context, id int64, statusCode int) error {
+	return sm.webhookRepo.MarkDelivered(ctx, id, statusCode)
+}
+
+// MarkDeliveryFailed records a failed webhook delivery attempt
+func (sm *StateManager) MarkDeliveryFailed(ctx context.Context, id int64, statusCode int, reason string, retryAt time.Time) error {
+	return sm.webhookRepo.MarkDeliveryFailed(ctx, id, statusCode, reason, retryAt)
+}
+
+// ListDeliveries returns a webhook's most recent deliveries
+func (sm *StateManager) ListDeliveries(ctx context.Context, webhookID int, status DeliveryStatus, limit int) ([]WebhookDelivery, error) {
+	return sm.webhookRepo.ListDeliveries(ctx, webhookID, status, limit)
+}
+
+// ReplayDelivery queues a webhook delivery to be sent again
+func (sm *StateManager) ReplayDelivery(ctx context.Context, webhookID int, id int64, audit AuditFunc) (*WebhookDelivery, error) {
+	return sm.webhookRepo.ReplayDelivery(ctx, webhookID, id, audit)
+}
+
+// ListOpenReviews returns every open transfer review
+func (sm *StateManager) ListOpenReviews(ctx context.Context) ([]TransferReview, error) {
+	return sm.reviewRepo.ListOpen(ctx)
+}
+
+// GetOpenReview returns the open review of a transfer
+func (sm *StateManager) GetOpenReview(ctx context.Context, transferID string) (*TransferReview, error) {
+	return sm.reviewRepo.GetOpen(ctx, transferID)
+}
+
+// VoteOnReview records an operator's vote on a transfer's open review
+func (sm *StateManager) VoteOnReview(ctx context.Context, transferID string, vote ReviewVote, quorum int, audit AuditFunc) (*TransferReview, error) {
+	return sm.reviewRepo.Vote(ctx, transferID, vote, quorum, audit)
+}
+
+// GetApprovedReviewReason returns the reason the latest review of a transfer
+// was opened for, if operators approved it
+func (sm *StateManager) GetApprovedReviewReason(ctx context.Context, transferID string) (string, bool, error) {
+	return sm.reviewRepo.GetApprovedReason(ctx, transferID)
+}
+
+// CreateAPIKey stores a new API key
+func (sm *StateManager) CreateAPIKey(ctx context.Context, key *APIKey, audit AuditFunc) error {
+	return sm.apiKeyRepo.Create(ctx, key, audit)
+}
+
+// GetAPIKeyByHash returns the active API key with a hash
+func (sm *StateManager) GetAPIKeyByHash(ctx context.Context, keyHash string) (*APIKey, error) {
+	return sm.apiKeyRepo.GetByHash(ctx, keyHash)
+}
+
+// ListAPIKeys returns every API key
+func (sm *StateManager) ListAPIKeys(ctx context.Context) ([]APIKey, error) {
+	return sm.apiKeyRepo.List(ctx)
+}
+
+// RevokeAPIKey disables an API key
+func (sm *StateManager) RevokeAPIKey(ctx context.Context, id int, audit AuditFunc) (*APIKey, error) {
+	return sm.apiKeyRepo.Revoke(ctx, id, audit)
+}
+
+// SetTokenLimit creates or replaces the limit of a token for a route
+func (sm *StateManager) SetTokenLimit(ctx context.Context, limit *TokenLimit, audit AuditFunc) error {
+	return sm.limitRepo.Set(ctx, limit, audit)
+}
+
+// ListTokenLimits returns the limits of a token
+func (sm *StateManager) ListTokenLimits(ctx context.Context, tokenID int) ([]TokenLimit, error) {
+	return sm.limitRepo.ListByToken(ctx, tokenID)
+}
+
+// DeleteTokenLimit removes the limit of a token for a route
+func (sm *StateManager) DeleteTokenLimit(ctx context.Context, tokenID int, destination types.ChainID, audit AuditFunc) error {
+	return sm.limitRepo.Delete(ctx, tokenID, destination, audit)
+}
+
+// GetTransferLimits returns the limits applying to a transfer
+func (sm *StateManager) GetTransferLimits(ctx context.Context, transfer types.Transfer) ([]TokenLimit, error) {
+	return sm.limitRepo.ForTransfer(ctx, transfer)
+}
+
+// SignedVolume sums the transfers counted against a volume cap
+func (sm *StateManager) SignedVolume(ctx context.Context, query VolumeQuery) (*big.Int, error) {
+	return sm.limitRepo.SignedVolume(ctx, query)
+}
+
+// LockTokenVolume serializes volume checks of a token out of a chain
+func (sm *StateManager) LockTokenVolume(ctx context.Context, sourceChain types.ChainID, token string) (func(), error) {
+	return sm.limitRepo.LockTokenVolume(ctx, sourceChain, token)
+}
+
+// GetRouteTotals sums the transfers of a token along a route
+func (sm *StateManager) GetRouteTotals(ctx context.Context, source, destination types.ChainID, token string) (*RouteTotals, error) {
+	return sm.transferRepo.RouteTotals(ctx, source, destination, token)
+}
+
+// DelayTransfer holds a transfer in the delayed status until releaseAt
+func (sm *StateManager) DelayTransfer(ctx context.Context, transferID string, releaseAt time.Time) (*TransferDelay, error) {
+	return sm.delayRepo.Delay(ctx, transferID, releaseAt)
+}
+
+// GetTransferDelay returns the delay of a transfer
+func (sm *StateManager) GetTransferDelay(ctx context.Context, transferID string) (*TransferDelay, error) {
+	return sm.delayRepo.Get(ctx, transferID)
+}
+
+// ListWaitingDelays returns the delays still waiting
+func (sm *StateManager) ListWaitingDelays(ctx context.Context) ([]TransferDelay, error) {
+	return sm.delayRepo.ListWaiting(ctx)
+}
+
+// ReleaseDueDelays releases the waiting delays due at a time
+func (sm *StateManager) ReleaseDueDelays(ctx context.Context, now time.Time) ([]TransferDelay, error) {
+	return sm.delayRepo.ReleaseDue(ctx, now)
+}
+
+// CancelTransferDelay cancels the waiting delay of a transfer
+func (sm *StateManager) CancelTransferDelay(ctx context.Context, transferID, operator, reason string, audit AuditFunc) (*TransferDelay, error) {
+	return sm.delayRepo.Cancel(ctx, transferID, operator, reason, audit)
+}
+
+// ListRelayerConfigs returns every known relayer
+func (sm *StateManager) ListRelayerConfigs(ctx context.Context) ([]RelayerConfig, error) {
+	return sm.relayerRepo.List(ctx)
+}
+
+// SetActiveRelayers makes exactly the given relayers active
+func (sm *StateManager) SetActiveRelayers(ctx context.Context, addresses []string) ([]RelayerConfig, error) {
+	return sm.relayerRepo.SetActive(ctx, addresses)
+}
+
+// RecordScannedBlock advances the scanned block of a chain
+func (sm *StateManager) RecordScannedBlock(ctx context.Context, chainID types.ChainID, block uint64) error {
+	return sm.scanRepo.Record(ctx, chainID, block)
+}
+
+// GetScannedBlock returns the last block scanned on a chain
+func (sm *StateManager) GetScannedBlock(ctx context.Context, chainID types.ChainID) (uint64, bool, error) {
+	return sm.scanRepo.Get(ctx, chainID)
+}
+
+var (
+	_ Store              = (*StateManager)(nil)
+	_ TransferLeaser     = (*StateManager)(nil)
+	_ WebhookStore       = (*StateManager)(nil)
+	_ ReviewStore        = (*StateManager)(nil)
+	_ APIKeyStore        = (*StateManager)(nil)
+	_ TokenLimitStore    = (*StateManager)(nil)
+	_ RouteTotaler       = (*StateManager)(nil)
+	_ DelayStore         = (*StateManager)(nil)
+	_ RelayerConfigStore = (*StateManager)(nil)
+	_ ScanProgressStore  = (*StateManager)(nil)
+)
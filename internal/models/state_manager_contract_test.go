@@ -0,0 +1,560 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"nexus-bridge/internal/models/testutil"
+	"nexus-bridge/pkg/types"
+)
+
+// storeFactory returns a fresh, empty Store for a single contract case
+type storeFactory func(t *testing.T) Store
+
+func TestStateManager_Contract(t *testing.T) {
+	runStoreContract(t, func(t *testing.T) Store {
+		db := testutil.SetupTestDB(t)
+		t.Cleanup(func() { testutil.CleanupTestDB(t, db) })
+		return NewStateManager(db)
+	})
+}
+
+func TestMemoryStateManager_Contract(t *testing.T) {
+	runStoreContract(t, func(t *testing.T) Store {
+		return NewMemoryStateManager()
+	})
+}
+
+// runStoreContract exercises the behaviour every Store backend must share
+func runStoreContract(t *testing.T, newStore storeFactory) {
+	cases := []struct {
+		name string
+		run  func(t *testing.T, store Store)
+	}{
+		{"RecordAndGetTransfer", contractRecordAndGetTransfer},
+		{"RejectsInvalidTransfers", contractRejectsInvalidTransfers},
+		{"RejectsDuplicateTransfer", contractRejectsDuplicateTransfer},
+		{"UpdateStatus", contractUpdateStatus},
+		{"MarkComplete", contractMarkComplete},
+		{"MarkForReview", contractMarkForReview},
+		{"IsTransferProcessed", contractIsTransferProcessed},
+		{"BlockRange", contractBlockRange},
+		{"Signatures", contractSignatures},
+		{"UniqueRelayerPerTransfer", contractUniqueRelayerPerTransfer},
+		{"SearchPagination", contractSearchPagination},
+		{"CountStuckTransfers", contractCountStuckTransfers},
+		{"SupportedTokens", contractSupportedTokens},
+		{"TokenAdministration", contractTokenAdministration},
+		{"AuditTrail", contractAuditTrail},
+		{"AuditedChanges", contractAuditedChanges},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.run(t, newStore(t))
+		})
+	}
+}
+
+func contractTransfer(n int) types.Transfer {
+	return types.Transfer{
+		ID:               fmt.Sprintf("0x%064x", n),
+		SourceChain:      types.ChainEthereum,
+		DestinationChain: types.ChainPolygon,
+		Token:            "0xA0b86a33E6441E6C7D3E4C2C4C6C6C6C6C6C6C6C",
+		Amount:           types.NewBigInt(big.NewInt(1000000000000000000)),
+		Sender:           "0x742d35Cc6634C0532925a3b8D4C9db96590C4C4C",
+		Recipient:        "0x8ba1f109551bD432803012645Hac136c22C4C4C",
+		Status:           types.StatusPending,
+		BlockNumber:      uint64(100 * n),
+	}
+}
+
+func mustRecord(t *testing.T, store Store, transfer types.Transfer) {
+	t.Helper()
+	if err := store.RecordTransfer(context.Background(), transfer); err != nil {
+		t.Fatalf("Failed to record transfer: %v", err)
+	}
+}
+
+func contractRecordAndGetTransfer(t *testing.T, store Store) {
+	transfer := contractTransfer(1)
+	mustRecord(t, store, transfer)
+
+	retrieved, err := store.GetTransfer(context.Background(), transfer.ID)
+	if err != nil {
+		t.Fatalf("Failed to get transfer: %v", err)
+	}
+	if retrieved.Amount.Cmp(transfer.Amount.Int) != 0 {
+		t.Errorf("Expected amount %s, got %s", transfer.Amount.String(), retrieved.Amount.String())
+	}
+	if retrieved.CreatedAt.IsZero() {
+		t.Error("Expected CreatedAt to be set")
+	}
+
+	if _, err := store.GetTransfer(context.Background(), contractTransfer(2).ID); err == nil {
+		t.Error("Expected error for unknown transfer")
+	}
+}
+
+func contractRejectsInvalidTransfers(t *testing.T, store Store) {
+	sameChain := contractTransfer(1)
+	sameChain.DestinationChain = sameChain.SourceChain
+	if err := store.RecordTransfer(context.Background(), sameChain); err == nil {
+		t.Error("Expected error for same source and destination chain")
+	}
+
+	zeroAmount := contractTransfer(2)
+	zeroAmount.Amount = types.NewBigInt(big.NewInt(0))
+	if err := store.RecordTransfer(context.Background(), zeroAmount); err == nil {
+		t.Error("Expected error for zero amount")
+	}
+
+	negativeAmount := contractTransfer(3)
+	negativeAmount.Amount = types.NewBigInt(big.NewInt(-1))
+	if err := store.RecordTransfer(context.Background(), negativeAmount); err == nil {
+		t.Error("Expected error for negative amount")
+	}
+}
+
+func contractRejectsDuplicateTransfer(t *testing.T, store Store) {
+	transfer := contractTransfer(1)
+	mustRecord(t, store, transfer)
+
+	if err := store.RecordTransfer(context.Background(), transfer); err == nil {
+		t.Error("Expected error for duplicate transfer ID")
+	}
+}
+
+func contractCountStuckTransfers(t *testing.T, store Store) {
+	ctx := context.Background()
+	mustRecord(t, store, contractTransfer(1))
+	mustRecord(t, store, contractTransfer(2))
+	if err := store.UpdateTransferStatus(ctx, contractTransfer(2).ID, types.StatusSigned); err != nil {
+		t.Fatalf("Failed to update status: %v", err)
+	}
+
+	count, err := store.CountStuckTransfers(ctx, types.StatusPending, time.Now().Add(time.Minute))
+	if err != nil || count != 1 {
+		t.Errorf("Expected 1 stuck pending transfer, got %d/%v", count, err)
+	}
+
+	count, err = store.CountStuckTransfers(ctx, types.StatusPending, time.Now().Add(-time.Minute))
+	if err != nil || count != 0 {
+		t.Errorf("Expected no transfers stuck a minute ago, got %d/%v", count, err)
+	}
+}
+
+func contractUpdateStatus(t *testing.T, store Store) {
+	transfer := contractTransfer(1)
+	mustRecord(t, store, transfer)
+
+	if err := store.UpdateTransferStatus(context.Background(), transfer.ID, types.StatusSigned); err != nil {
+		t.Fatalf("Failed to update status: %v", err)
+	}
+
+	status, err := store.GetTransferStatus(context.Background(), transfer.ID)
+	if err != nil {
+		t.Fatalf("Failed to get status: %v", err)
+	}
+	if *status != types.StatusSigned {
+		t.Errorf("Expected status %s, got %s", types.StatusSigned, *status)
+	}
+
+	if err := store.UpdateTransferStatus(context.Background(), transfer.ID, "bogus"); err == nil {
+		t.Error("Expected error for invalid status")
+	}
+	if err := store.UpdateTransferStatus(context.Background(), contractTransfer(2).ID, types.StatusSigned); err == nil {
+		t.Error("Expected error for unknown transfer")
+	}
+}
+
+func contractMarkComplete(t *testing.T, store Store) {
+	transfer := contractTransfer(1)
+	mustRecord(t, store, transfer)
+
+	txHash := "0xabcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890"
+	if err := store.MarkTransferComplete(context.Background(), transfer.ID, txHash); err != nil {
+		t.Fatalf("Failed to mark complete: %v", err)
+	}
+
+	retrieved, err := store.GetTransfer(context.Background(), transfer.ID)
+	if err != nil {
+		t.Fatalf("Failed to get transfer: %v", err)
+	}
+	if retrieved.Status != types.StatusCompleted || retrieved.DestinationTxHash != txHash {
+		t.Errorf("Expected completed transfer with hash %s, got %s/%s", txHash, retrieved.Status, retrieved.DestinationTxHash)
+	}
+}
+
+func contractMarkForReview(t *testing.T, store Store) {
+	transfer := contractTransfer(1)
+	mustRecord(t, store, transfer)
+
+	if err := store.MarkTransferForReview(context.Background(), transfer.ID, "large amount"); err != nil {
+		t.Fatalf("Failed to mark for review: %v", err)
+	}
+
+	queue, err := store.GetTransfersByStatus(context.Background(), types.StatusUnderReview)
+	if err != nil {
+		t.Fatalf("Failed to get transfers by status: %v", err)
+	}
+	if len(queue) != 1 || queue[0].ID != transfer.ID {
+		t.Errorf("Expected transfer %s under review, got %v", transfer.ID, queue)
+	}
+}
+
+func contractIsTransferProcessed(t *testing.T, store Store) {
+	transfer := contractTransfer(1)
+
+	processed, err := store.IsTransferProcessed(context.Background(), transfer.ID)
+	if err != nil || processed {
+		t.Fatalf("Expected unknown transfer to be unprocessed, got %v/%v", processed, err)
+	}
+
+	mustRecord(t, store, transfer)
+	if err := store.UpdateTransferStatus(context.Background(), transfer.ID, types.StatusFailed); err != nil {
+		t.Fatalf("Failed to update status: %v", err)
+	}
+
+	processed, err = store.IsTransferProcessed(context.Background(), transfer.ID)
+	if err != nil || !processed {
+		t.Errorf("Expected failed transfer to be processed, got %v/%v", processed, err)
+	}
+}
+
+func contractBlockRange(t *testing.T, store Store) {
+	for n := 1; n <= 3; n++ {
+		mustRecord(t, store, contractTransfer(n))
+	}
+
+	transfers, err := store.GetTransfersInBlockRange(context.Background(), types.ChainEthereum, 150, 300)
+	if err != nil {
+		t.Fatalf("Failed to get block range: %v", err)
+	}
+	if len(transfers) != 2 {
+		t.Fatalf("Expected 2 transfers, got %d", len(transfers))
+	}
+	if transfers[0].BlockNumber > transfers[1].BlockNumber {
+		t.Error("Expected transfers ordered by block number")
+	}
+
+	transfers, err = store.GetTransfersInBlockRange(context.Background(), types.ChainPolygon, 0, 1000)
+	if err != nil {
+		t.Fatalf("Failed to get block range: %v", err)
+	}
+	if len(transfers) != 0 {
+		t.Errorf("Expected no transfers from polygon, got %d", len(transfers))
+	}
+}
+
+func contractSignatures(t *testing.T, store Store) {
+	transfer := contractTransfer(1)
+	mustRecord(t, store, transfer)
+
+	relayers := []string{
+		"0x742d35Cc6634C0532925a3b8D4C9db96590C4C4C",
+		"0x8ba1f109551bD432803012645Hac136c22C4C4C",
+	}
+	for i, relayer := range relayers {
+		signature := types.Signature{RelayerAddress: relayer, Signature: []byte(fmt.Sprintf("signature%d", i))}
+		if err := store.RecordSignature(context.Background(), transfer.ID, signature); err != nil {
+			t.Fatalf("Failed to record signature: %v", err)
+		}
+	}
+
+	signatures, err := store.GetSignatures(context.Background(), transfer.ID)
+	if err != nil {
+		t.Fatalf("Failed to get signatures: %v", err)
+	}
+	if len(signatures) != 2 || signatures[0].RelayerAddress != relayers[0] {
+		t.Errorf("Expected 2 signatures in insertion order, got %v", signatures)
+	}
+
+	count, err := store.GetSignatureCount(context.Background(), transfer.ID)
+	if err != nil || count != 2 {
+		t.Errorf("Expected signature count 2, got %d/%v", count, err)
+	}
+
+	signed, err := store.HasRelayerSigned(context.Background(), transfer.ID, relayers[1])
+	if err != nil || !signed {
+		t.Errorf("Expected relayer to have signed, got %v/%v", signed, err)
+	}
+
+	orphan := types.Signature{RelayerAddress: relayers[0], Signature: []byte("orphan")}
+	if err := store.RecordSignature(context.Background(), contractTransfer(2).ID, orphan); err == nil {
+		t.Error("Expected error for signature on unknown transfer")
+	}
+	if err := store.RecordSignature(context.Background(), transfer.ID, types.Signature{RelayerAddress: relayers[0]}); err == nil {
+		t.Error("Expected error for empty signature data")
+	}
+}
+
+func contractUniqueRelayerPerTransfer(t *testing.T, store Store) {
+	transfer := contractTransfer(1)
+	mustRecord(t, store, transfer)
+
+	signature := types.Signature{RelayerAddress: "0x742d35Cc6634C0532925a3b8D4C9db96590C4C4C", Signature: []byte("signature")}
+	if err := store.RecordSignature(context.Background(), transfer.ID, signature); err != nil {
+		t.Fatalf("Failed to record signature: %v", err)
+	}
+	if err := store.RecordSignature(context.Background(), transfer.ID, signature); err == nil {
+		t.Error("Expected error for duplicate relayer signature")
+	}
+}
+
+func contractSearchPagination(t *testing.T, store Store) {
+	for n := 1; n <= 5; n++ {
+		transfer := contractTransfer(n)
+		if n%2 == 0 {
+			transfer.Recipient = "0x0000000000000000000000000000000000000001"
+		}
+		mustRecord(t, store, transfer)
+	}
+
+	filter := TransferFilter{Recipient: "0x8BA1F109551BD432803012645HAC136C22C4C4C", Limit: 2}
+	var seen []string
+	for {
+		page, err := store.SearchTransfers(context.Background(), filter)
+		if err != nil {
+			t.Fatalf("Failed to search transfers: %v", err)
+		}
+		for _, transfer := range page.Transfers {
+			seen = append(seen, transfer.ID)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		filter.Cursor = page.NextCursor
+	}
+
+	expected := []string{contractTransfer(5).ID, contractTransfer(3).ID, contractTransfer(1).ID}
+	if fmt.Sprint(seen) != fmt.Sprint(expected) {
+		t.Errorf("Expected %v newest first, got %v", expected, seen)
+	}
+
+	if _, err := store.SearchTransfers(context.Background(), TransferFilter{Cursor: "%%%"}); err == nil {
+		t.Error("Expected error for invalid cursor")
+	}
+}
+
+func contractSupportedTokens(t *testing.T, store Store) {
+	tokens := []*types.SupportedToken{
+		{ChainID: types.ChainEthereum, TokenAddress: "0x00000000000000000000000000000000000000b1", Name: "Beta", Symbol: "BETA", Decimals: 18, Enabled: true},
+		{ChainID: types.ChainEthereum, TokenAddress: "0x00000000000000000000000000000000000000a1", Name: "Alpha", Symbol: "ALPHA", Decimals: 6, Enabled: true},
+		{ChainID: types.ChainEthereum, TokenAddress: "0x00000000000000000000000000000000000000c1", Name: "Gamma", Symbol: "GAMMA", Decimals: 18, Enabled: false},
+	}
+	for _, token := range tokens {
+		if err := store.AddSupportedToken(context.Background(), token, nil); err != nil {
+			t.Fatalf("Failed to add token: %v", err)
+		}
+		if token.ID == 0 {
+			t.Error("Expected token ID to be assigned")
+		}
+	}
+
+	duplicate := *tokens[0]
+	if err := store.AddSupportedToken(context.Background(), &duplicate, nil); err == nil {
+		t.Error("Expected error for duplicate token")
+	}
+	invalid := &types.SupportedToken{ChainID: types.ChainEthereum, TokenAddress: "0x01", Name: "Bad", Symbol: "BAD", Decimals: 19}
+	if err := store.AddSupportedToken(context.Background(), invalid, nil); err == nil {
+		t.Error("Expected error for decimals above 18")
+	}
+
+	enabled, err := store.GetSupportedTokens(context.Background(), types.ChainEthereum)
+	if err != nil {
+		t.Fatalf("Failed to get tokens: %v", err)
+	}
+	if len(enabled) != 2 || enabled[0].Symbol != "ALPHA" {
+		t.Errorf("Expected enabled tokens ordered by name, got %v", enabled)
+	}
+
+	supported, err := store.IsTokenSupported(context.Background(), types.ChainEthereum, tokens[2].TokenAddress)
+	if err != nil || supported {
+		t.Errorf("Expected disabled token to be unsupported, got %v/%v", supported, err)
+	}
+}
+
+func contractTokenAdministration(t *testing.T, store Store) {
+	ctx := context.Background()
+	tokens := []*types.SupportedToken{
+		{ChainID: types.ChainPolygon, TokenAddress: "0x00000000000000000000000000000000000000a2", Name: "Alpha", Symbol: "ALPHA", Decimals: 18, Enabled: true},
+		{ChainID: types.ChainEthereum, TokenAddress: "0x00000000000000000000000000000000000000b1", Name: "Beta", Symbol: "BETA", Decimals: 18, Enabled: false},
+		{ChainID: types.ChainEthereum, TokenAddress: "0x00000000000000000000000000000000000000a1", Name: "Alpha", Symbol: "ALPHA", Decimals: 6, Enabled: true},
+	}
+	for _, token := range tokens {
+		if err := store.AddSupportedToken(ctx, token, nil); err != nil {
+			t.Fatalf("Failed to add token: %v", err)
+		}
+	}
+
+	all, err := store.ListSupportedTokens(ctx)
+	if err != nil {
+		t.Fatalf("Failed to list tokens: %v", err)
+	}
+	if len(all) != 3 || all[0].ID != tokens[2].ID || all[1].ID != tokens[1].ID || all[2].ID != tokens[0].ID {
+		t.Errorf("Expected all tokens ordered by chain and name, got %v", all)
+	}
+
+	token, err := store.GetSupportedToken(ctx, tokens[1].ID)
+	if err != nil || token.Symbol != "BETA" || token.Enabled {
+		t.Fatalf("Expected disabled BETA token, got %+v/%v", token, err)
+	}
+
+	if err := store.SetTokenEnabled(ctx, tokens[1].ID, true, nil); err != nil {
+		t.Fatalf("Failed to enable token: %v", err)
+	}
+	supported, err := store.IsTokenSupported(ctx, types.ChainEthereum, tokens[1].TokenAddress)
+	if err != nil || !supported {
+		t.Errorf("Expected enabled token to be supported, got %v/%v", supported, err)
+	}
+
+	if err := store.DeleteSupportedToken(ctx, tokens[1].ID, nil); err != nil {
+		t.Fatalf("Failed to delete token: %v", err)
+	}
+	if _, err := store.GetSupportedToken(ctx, tokens[1].ID); !errors.Is(err, ErrTokenNotFound) {
+		t.Errorf("Expected ErrTokenNotFound after delete, got %v", err)
+	}
+	if err := store.SetTokenEnabled(ctx, tokens[1].ID, false, nil); !errors.Is(err, ErrTokenNotFound) {
+		t.Errorf("Expected ErrTokenNotFound enabling a deleted token, got %v", err)
+	}
+	if err := store.DeleteSupportedToken(ctx, tokens[1].ID, nil); !errors.Is(err, ErrTokenNotFound) {
+		t.Errorf("Expected ErrTokenNotFound deleting twice, got %v", err)
+	}
+}
+
+func contractAuditTrail(t *testing.T, store Store) {
+	ctx := context.Background()
+	token := &types.SupportedToken{ChainID: types.ChainEthereum, TokenAddress: "0x00000000000000000000000000000000000000a1", Name: "Alpha", Symbol: "ALPHA", Decimals: 6, Enabled: true}
+
+	created, err := NewAuditEntry(AuditTokenCreated, AuditEntitySupportedToken, "1", "alice", nil, token)
+	if err != nil {
+		t.Fatalf("Failed to build audit entry: %v", err)
+	}
+	if err := store.RecordAudit(ctx, created); err != nil {
+		t.Fatalf("Failed to record audit entry: %v", err)
+	}
+	if created.ID == 0 || created.PerformedAt.IsZero() {
+		t.Errorf("Expected ID and timestamp to be assigned, got %+v", created)
+	}
+
+	disabled := *token
+	disabled.Enabled = false
+	changed, _ := NewAuditEntry(AuditTokenDisabled, AuditEntitySupportedToken, "1", "bob", token, disabled)
+	changed.Description = "paused by operator"
+	if err := store.RecordAudit(ctx, changed); err != nil {
+		t.Fatalf("Failed to record audit entry: %v", err)
+	}
+	other, _ := NewAuditEntry(AuditTokenCreated, AuditEntitySupportedToken, "2", "alice", nil, token)
+	if err := store.RecordAudit(ctx, other); err != nil {
+		t.Fatalf("Failed to record audit entry: %v", err)
+	}
+
+	if err := store.RecordAudit(ctx, &AuditEntry{EventType: AuditTokenCreated, EntityType: AuditEntitySupportedToken, EntityID: "1"}); err == nil {
+		t.Error("Expected error for audit entry without actor")
+	}
+	long, _ := NewAuditEntry(AuditTokenCreated, AuditEntitySupportedToken, "1", strings.Repeat("x", MaxActorLength+1), nil, token)
+	if err := store.RecordAudit(ctx, long); err == nil {
+		t.Error("Expected error for audit actor over the column length")
+	}
+
+	trail, err := store.GetAuditTrail(ctx, AuditEntitySupportedToken, "1")
+	if err != nil {
+		t.Fatalf("Failed to get audit trail: %v", err)
+	}
+	if len(trail) != 2 || trail[0].EventType != AuditTokenCreated || trail[1].PerformedBy != "bob" {
+		t.Fatalf("Expected two entries oldest first, got %+v", trail)
+	}
+	if string(trail[0].OldValues) != "null" || trail[1].Description != "paused by operator" {
+		t.Errorf("Unexpected audit values: %+v", trail)
+	}
+
+	var before types.SupportedToken
+	if err := json.Unmarshal(trail[1].OldValues, &before); err != nil || !before.Enabled {
+		t.Errorf("Expected old values to hold the enabled token, got %s/%v", trail[1].OldValues, err)
+	}
+}
+
+func contractAuditedChanges(t *testing.T, store Store) {
+	ctx := context.Background()
+	audit := func(eventType string) AuditFunc {
+		return func(changed interface{}) (*AuditEntry, error) {
+			token := changed.(*types.SupportedToken)
+			return NewAuditEntry(eventType, AuditEntitySupportedToken, fmt.Sprint(token.ID), "alice", nil, changed)
+		}
+	}
+	failing := func(changed interface{}) (*AuditEntry, error) {
+		return nil, errors.New("audit unavailable")
+	}
+
+	token := &types.SupportedToken{ChainID: types.ChainEthereum, TokenAddress: "0x00000000000000000000000000000000000000a1", Name: "Alpha", Symbol: "ALPHA", Decimals: 6, Enabled: true}
+	if err := store.AddSupportedToken(ctx, token, audit(AuditTokenCreated)); err != nil {
+		t.Fatalf("Failed to add token: %v", err)
+	}
+	trail, err := store.GetAuditTrail(ctx, AuditEntitySupportedToken, fmt.Sprint(token.ID))
+	if err != nil || len(trail) != 1 || trail[0].EventType != AuditTokenCreated {
+		t.Fatalf("Expected the creation to be audited with the assigned ID, got %+v/%v", trail, err)
+	}
+
+	// A change whose audit entry cannot be recorded is rolled back
+	other := &types.SupportedToken{ChainID: types.ChainPolygon, TokenAddress: "0x00000000000000000000000000000000000000a2", Name: "Beta", Symbol: "BETA", Decimals: 18, Enabled: true}
+	if err := store.AddSupportedToken(ctx, other, failing); !errors.Is(err, ErrAuditFailed) {
+		t.Errorf("Expected ErrAuditFailed, got %v", err)
+	}
+	if supported, err := store.IsTokenSupported(ctx, other.ChainID, other.TokenAddress); err != nil || supported {
+		t.Errorf("Expected the unaudited token not to be added, got %v/%v", supported, err)
+	}
+
+	if err := store.SetTokenEnabled(ctx, token.ID, false, failing); !errors.Is(err, ErrAuditFailed) {
+		t.Errorf("Expected ErrAuditFailed, got %v", err)
+	}
+	if err := store.DeleteSupportedToken(ctx, token.ID, failing); !errors.Is(err, ErrAuditFailed) {
+		t.Errorf("Expected ErrAuditFailed, got %v", err)
+	}
+	stored, err := store.GetSupportedToken(ctx, token.ID)
+	if err != nil || !stored.Enabled {
+		t.Errorf("Expected the token to be left enabled, got %+v/%v", stored, err)
+	}
+
+	trail, err = store.GetAuditTrail(ctx, AuditEntitySupportedToken, fmt.Sprint(token.ID))
+	if err != nil || len(trail) != 1 {
+		t.Errorf("Expected no entries for rolled back changes, got %+v/%v", trail, err)
+	}
+}
+
+func TestMemoryStateManager_ConcurrentSignatures(t *testing.T) {
+	store := NewMemoryStateManager()
+	transfer := contractTransfer(1)
+	mustRecord(t, store, transfer)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			signature := types.Signature{RelayerAddress: "0x742d35Cc6634C0532925a3b8D4C9db96590C4C4C", Signature: []byte("signature")}
+			errs <- store.RecordSignature(context.Background(), transfer.ID, signature)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	succeeded := 0
+	for err := range errs {
+		if err == nil {
+			succeeded++
+		}
+	}
+	if succeeded != 1 {
+		t.Errorf("Expected exactly one signature to be recorded, got %d", succeeded)
+	}
+}
@@ -3,6 +3,7 @@ package models
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 
 	"nexus-bridge/pkg/types"
@@ -10,6 +11,9 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
+// ErrTokenNotFound is returned when a supported token does not exist
+var ErrTokenNotFound = errors.New("token not found")
+
 // SupportedTokenRepository handles database operations for supported tokens
 type SupportedTokenRepository struct {
 	db *sqlx.DB
@@ -20,9 +24,10 @@ func NewSupportedTokenRepository(db *sqlx.DB) *SupportedTokenRepository {
 	return &SupportedTokenRepository{db: db}
 }
 
-// Create inserts a new supported token into the database
-func (r *SupportedTokenRepository) Create(ctx context.Context, token *types.SupportedToken) error {
-	if err := r.validateToken(token); err != nil {
+// Create inserts a new supported token into the database, recording its
+// audit entry in the same transaction
+func (r *SupportedTokenRepository) Create(ctx context.Context, token *types.SupportedToken, audit AuditFunc) error {
+	if err := validateSupportedToken(token); err != nil {
 		return fmt.Errorf("token validation failed: %w", err)
 	}
 
@@ -31,14 +36,15 @@ func (r *SupportedTokenRepository) Create(ctx context.Context, token *types.Supp
 		VALUES ($1, $2, $3, $4, $5, $6, $7)
 		RETURNING id`
 
-	err := r.db.GetContext(ctx, &token.ID, query,
-		token.ChainID, token.TokenAddress, token.Name, token.Symbol,
-		token.Decimals, token.IsNative, token.Enabled)
-	if err != nil {
-		return fmt.Errorf("failed to create supported token: %w", err)
-	}
-
-	return nil
+	return inAuditedTx(ctx, r.db, audit, func(tx *sqlx.Tx) (interface{}, error) {
+		err := tx.GetContext(ctx, &token.ID, query,
+			token.ChainID, token.TokenAddress, token.Name, token.Symbol,
+			token.Decimals, token.IsNative, token.Enabled)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create supported token: %w", err)
+		}
+		return token, nil
+	})
 }
 
 // GetByChainAndAddress retrieves a supported token by chain ID and token address
@@ -52,7 +58,26 @@ func (r *SupportedTokenRepository) GetByChainAndAddress(ctx context.Context, cha
 	err := r.db.GetContext(ctx, &token, query, chainID, tokenAddress)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("token not found: chain=%d, address=%s", chainID, tokenAddress)
+			return nil, fmt.Errorf("%w: chain=%d, address=%s", ErrTokenNotFound, chainID, tokenAddress)
+		}
+		return nil, fmt.Errorf("failed to get supported token: %w", err)
+	}
+
+	return &token, nil
+}
+
+// GetByID retrieves a supported token by ID
+func (r *SupportedTokenRepository) GetByID(ctx context.Context, id int) (*types.SupportedToken, error) {
+	var token types.SupportedToken
+	query := `
+		SELECT id, chain_id, token_address, name, symbol, decimals, is_native, enabled
+		FROM supported_tokens
+		WHERE id = $1`
+
+	err := r.db.GetContext(ctx, &token, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: %d", ErrTokenNotFound, id)
 		}
 		return nil, fmt.Errorf("failed to get supported token: %w", err)
 	}
@@ -93,25 +118,23 @@ func (r *SupportedTokenRepository) GetAll(ctx context.Context) ([]types.Supporte
 	return tokens, nil
 }
 
-// UpdateEnabled updates the enabled status of a token
-func (r *SupportedTokenRepository) UpdateEnabled(ctx context.Context, id int, enabled bool) error {
-	query := `UPDATE supported_tokens SET enabled = $1 WHERE id = $2`
-
-	result, err := r.db.ExecContext(ctx, query, enabled, id)
-	if err != nil {
-		return fmt.Errorf("failed to update token enabled status: %w", err)
-	}
-
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
-	}
-
-	if rowsAffected == 0 {
-		return fmt.Errorf("token not found: %d", id)
-	}
-
-	return nil
+// UpdateEnabled updates the enabled status of a token, recording its audit
+// entry in the same transaction
+func (r *SupportedTokenRepository) UpdateEnabled(ctx context.Context, id int, enabled bool, audit AuditFunc) error {
+	query := `
+		UPDATE supported_tokens SET enabled = $1 WHERE id = $2
+		RETURNING id, chain_id, token_address, name, symbol, decimals, is_native, enabled`
+
+	return inAuditedTx(ctx, r.db, audit, func(tx *sqlx.Tx) (interface{}, error) {
+		var token types.SupportedToken
+		if err := tx.GetContext(ctx, &token, query, enabled, id); err != nil {
+			if err == sql.ErrNoRows {
+				return nil, fmt.Errorf("%w: %d", ErrTokenNotFound, id)
+			}
+			return nil, fmt.Errorf("failed to update token enabled status: %w", err)
+		}
+		return &token, nil
+	})
 }
 
 // IsSupported checks if a token is supported on a specific chain
@@ -130,29 +153,32 @@ func (r *SupportedTokenRepository) IsSupported(ctx context.Context, chainID type
 	return count > 0, nil
 }
 
-// Delete removes a supported token
-func (r *SupportedTokenRepository) Delete(ctx context.Context, id int) error {
+// Delete removes a supported token, recording its audit entry in the same
+// transaction
+func (r *SupportedTokenRepository) Delete(ctx context.Context, id int, audit AuditFunc) error {
 	query := `DELETE FROM supported_tokens WHERE id = $1`
 
-	result, err := r.db.ExecContext(ctx, query, id)
-	if err != nil {
-		return fmt.Errorf("failed to delete supported token: %w", err)
-	}
+	return inAuditedTx(ctx, r.db, audit, func(tx *sqlx.Tx) (interface{}, error) {
+		result, err := tx.ExecContext(ctx, query, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete supported token: %w", err)
+		}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
-	}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get rows affected: %w", err)
+		}
 
-	if rowsAffected == 0 {
-		return fmt.Errorf("token not found: %d", id)
-	}
+		if rowsAffected == 0 {
+			return nil, fmt.Errorf("%w: %d", ErrTokenNotFound, id)
+		}
 
-	return nil
+		return nil, nil
+	})
 }
 
-// validateToken validates the supported token data
-func (r *SupportedTokenRepository) validateToken(token *types.SupportedToken) error {
+// validateSupportedToken validates the supported token data
+func validateSupportedToken(token *types.SupportedToken) error {
 	if token.ChainID == 0 {
 		return fmt.Errorf("chain ID is required")
 	}
@@ -24,7 +24,7 @@ func TestSupportedTokenRepository_Create(t *testing.T) {
 		Enabled:      true,
 	}
 
-	err := repo.Create(context.Background(), token)
+	err := repo.Create(context.Background(), token, nil)
 	if err != nil {
 		t.Fatalf("Failed to create supported token: %v", err)
 	}
@@ -120,7 +120,7 @@ func TestSupportedTokenRepository_CreateInvalidToken(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := repo.Create(context.Background(), tt.token)
+			err := repo.Create(context.Background(), tt.token, nil)
 			if tt.expectErr && err == nil {
 				t.Error("Expected error but got none")
 			}
@@ -174,7 +174,7 @@ func TestSupportedTokenRepository_GetByChain(t *testing.T) {
 	}
 
 	for _, token := range tokens {
-		err := repo.Create(context.Background(), token)
+		err := repo.Create(context.Background(), token, nil)
 		if err != nil {
 			t.Fatalf("Failed to create token: %v", err)
 		}
@@ -226,7 +226,7 @@ func TestSupportedTokenRepository_IsSupported(t *testing.T) {
 	}
 
 	// Create the token
-	err = repo.Create(context.Background(), token)
+	err = repo.Create(context.Background(), token, nil)
 	if err != nil {
 		t.Fatalf("Failed to create token: %v", err)
 	}
@@ -241,7 +241,7 @@ func TestSupportedTokenRepository_IsSupported(t *testing.T) {
 	}
 
 	// Disable the token
-	err = repo.UpdateEnabled(context.Background(), token.ID, false)
+	err = repo.UpdateEnabled(context.Background(), token.ID, false, nil)
 	if err != nil {
 		t.Fatalf("Failed to disable token: %v", err)
 	}
@@ -271,13 +271,13 @@ func TestSupportedTokenRepository_UpdateEnabled(t *testing.T) {
 		Enabled:      true,
 	}
 
-	err := repo.Create(context.Background(), token)
+	err := repo.Create(context.Background(), token, nil)
 	if err != nil {
 		t.Fatalf("Failed to create token: %v", err)
 	}
 
 	// Disable the token
-	err = repo.UpdateEnabled(context.Background(), token.ID, false)
+	err = repo.UpdateEnabled(context.Background(), token.ID, false, nil)
 	if err != nil {
 		t.Fatalf("Failed to update enabled status: %v", err)
 	}
@@ -293,7 +293,7 @@ func TestSupportedTokenRepository_UpdateEnabled(t *testing.T) {
 	}
 
 	// Re-enable the token
-	err = repo.UpdateEnabled(context.Background(), token.ID, true)
+	err = repo.UpdateEnabled(context.Background(), token.ID, true, nil)
 	if err != nil {
 		t.Fatalf("Failed to update enabled status: %v", err)
 	}
@@ -324,13 +324,13 @@ func TestSupportedTokenRepository_Delete(t *testing.T) {
 		Enabled:      true,
 	}
 
-	err := repo.Create(context.Background(), token)
+	err := repo.Create(context.Background(), token, nil)
 	if err != nil {
 		t.Fatalf("Failed to create token: %v", err)
 	}
 
 	// Delete the token
-	err = repo.Delete(context.Background(), token.ID)
+	err = repo.Delete(context.Background(), token.ID, nil)
 	if err != nil {
 		t.Fatalf("Failed to delete token: %v", err)
 	}
@@ -0,0 +1,263 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"nexus-bridge/pkg/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ErrTokenLimitNotFound is returned when a token has no limit for a route
+var ErrTokenLimitNotFound = errors.New("token limit not found")
+
+// TokenLimit bounds the transfers of a supported token out of its chain,
+// in the token's base units. A limit with no destination chain applies to
+// every route of the token, and one with a destination only to that route;
+// a transfer must satisfy both. Unset amounts are unlimited.
+type TokenLimit struct {
+	ID               int           `json:"id" db:"id"`
+	TokenID          int           `json:"token_id" db:"token_id"`
+	DestinationChain types.ChainID `json:"destination_chain,omitempty" db:"destination_chain"`
+	MinAmount        *types.BigInt `json:"min_amount,omitempty" db:"min_amount"`
+	MaxAmount        *types.BigInt `json:"max_amount,omitempty" db:"max_amount"`
+	// HourlyCap and DailyCap bound the volume signed over the last hour and
+	// the last 24 hours
+	HourlyCap *types.BigInt `json:"hourly_cap,omitempty" db:"hourly_cap"`
+	DailyCap  *types.BigInt `json:"daily_cap,omitempty" db:"daily_cap"`
+	// DelayThreshold is the amount above which signed transfers are
+	// delayed before execution
+	DelayThreshold *types.BigInt `json:"delay_threshold,omitempty" db:"delay_threshold"`
+	UpdatedAt      time.Time     `json:"updated_at" db:"updated_at"`
+}
+
+// Validate validates a limit before it is stored
+func (l *TokenLimit) Validate() error {
+	if l.TokenID <= 0 {
+		return fmt.Errorf("token ID is required")
+	}
+	if l.MinAmount == nil && l.MaxAmount == nil && l.HourlyCap == nil && l.DailyCap == nil && l.DelayThreshold == nil {
+		return fmt.Errorf("at least one amount, cap or delay threshold is required")
+	}
+	if isSet(l.MinAmount) && l.MinAmount.Sign() < 0 {
+		return fmt.Errorf("minimum amount cannot be negative")
+	}
+	for name, amount := range map[string]*types.BigInt{"maximum amount": l.MaxAmount, "hourly cap": l.HourlyCap, "daily cap": l.DailyCap} {
+		if amount != nil && (amount.Int == nil || amount.Sign() <= 0) {
+			return fmt.Errorf("%s must be positive", name)
+		}
+	}
+	if l.DelayThreshold != nil && (l.DelayThreshold.Int == nil || l.DelayThreshold.Sign() < 0) {
+		return fmt.Errorf("delay threshold cannot be negative")
+	}
+	if isSet(l.MinAmount) && isSet(l.MaxAmount) && l.MinAmount.Cmp(l.MaxAmount.Int) > 0 {
+		return fmt.Errorf("minimum amount cannot exceed maximum amount")
+	}
+	return nil
+}
+
+// isSet reports whether an optional amount is set
+func isSet(amount *types.BigInt) bool {
+	return amount != nil && amount.Int != nil
+}
+
+// VolumeQuery selects the transfers counted against a volume cap: those of
+// a token out of its chain that relayers started signing since a time
+type VolumeQuery struct {
+	SourceChain types.ChainID
+	Token       string
+	// DestinationChain narrows the query to a route when set
+	DestinationChain types.ChainID
+	Since            time.Time
+	// ExcludeTransfer leaves out the transfer being checked
+	ExcludeTransfer string
+}
+
+// TokenLimitStore persists per-token and per-route transfer limits
+type TokenLimitStore interface {
+	// SetTokenLimit creates or replaces the limit of a token for a route
+	SetTokenLimit(ctx context.Context, limit *TokenLimit, audit AuditFunc) error
+
+	// ListTokenLimits returns the limits of a token, token-wide limit first
+	ListTokenLimits(ctx context.Context, tokenID int) ([]TokenLimit, error)
+
+	// DeleteTokenLimit removes the limit of a token for a route
+	DeleteTokenLimit(ctx context.Context, tokenID int, destination types.ChainID, audit AuditFunc) error
+
+	// GetTransferLimits returns the limits applying to a transfer: those of
+	// its token on the source chain, token-wide and for its route
+	GetTransferLimits(ctx context.Context, transfer types.Transfer) ([]TokenLimit, error)
+
+	// SignedVolume returns the total amount of the transfers matching a
+	// query, excluding failed transfers
+	SignedVolume(ctx context.Context, query VolumeQuery) (*big.Int, error)
+
+	// LockTokenVolume serializes volume checks of a token out of a chain
+	// until the returned unlock is called, so concurrent relayers cannot both
+	// fit a transfer in the same remaining cap
+	LockTokenVolume(ctx context.Context, sourceChain types.ChainID, token string) (func(), error)
+}
+
+// TokenLimitRepository handles database operations for token limits
+type TokenLimitRepository struct {
+	db *sqlx.DB
+}
+
+// NewTokenLimitRepository creates a new token limit repository
+func NewTokenLimitRepository(db *sqlx.DB) *TokenLimitRepository {
+	return &TokenLimitRepository{db: db}
+}
+
+const tokenLimitColumns = `
+	id, token_id, destination_chain, min_amount, max_amount, hourly_cap, daily_cap, delay_threshold, updated_at`
+
+// Set upserts the limit of a token for a route
+func (r *TokenLimitRepository) Set(ctx context.Context, limit *TokenLimit, audit AuditFunc) error {
+	if err := limit.Validate(); err != nil {
+		return fmt.Errorf("token limit validation failed: %w", err)
+	}
+
+	query := `
+		INSERT INTO token_limits (token_id, destination_chain, min_amount, max_amount, hourly_cap, daily_cap, delay_threshold, updated_at)
+		SELECT $1, $2, $3, $4, $5, $6, $7, $8
+		WHERE EXISTS (SELECT 1 FROM supported_tokens WHERE id = $1)
+		ON CONFLICT (token_id, destination_chain) DO UPDATE
+		SET min_amount = EXCLUDED.min_amount, max_amount = EXCLUDED.max_amount,
+			hourly_cap = EXCLUDED.hourly_cap, daily_cap = EXCLUDED.daily_cap,
+			delay_threshold = EXCLUDED.delay_threshold, updated_at = EXCLUDED.updated_at
+		RETURNING id, updated_at`
+
+	return inAuditedTx(ctx, r.db, audit, func(tx *sqlx.Tx) (interface{}, error) {
+		err := tx.QueryRowxContext(ctx, query,
+			limit.TokenID, limit.DestinationChain,
+			nullableAmount(limit.MinAmount), nullableAmount(limit.MaxAmount),
+			nullableAmount(limit.HourlyCap), nullableAmount(limit.DailyCap), nullableAmount(limit.DelayThreshold), time.Now(),
+		).Scan(&limit.ID, &limit.UpdatedAt)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, fmt.Errorf("%w: %d", ErrTokenNotFound, limit.TokenID)
+			}
+			return nil, fmt.Errorf("failed to set token limit: %w", err)
+		}
+		return limit, nil
+	})
+}
+
+// ListByToken returns the limits of a token ordered by destination chain
+func (r *TokenLimitRepository) ListByToken(ctx context.Context, tokenID int) ([]TokenLimit, error) {
+	limits := []TokenLimit{}
+	query := `SELECT ` + tokenLimitColumns + ` FROM token_limits WHERE token_id = $1 ORDER BY destination_chain ASC`
+
+	if err := r.db.SelectContext(ctx, &limits, query, tokenID); err != nil {
+		return nil, fmt.Errorf("failed to list token limits: %w", err)
+	}
+
+	return limits, nil
+}
+
+// Delete removes the limit of a token for a route
+func (r *TokenLimitRepository) Delete(ctx context.Context, tokenID int, destination types.ChainID, audit AuditFunc) error {
+	query := `DELETE FROM token_limits WHERE token_id = $1 AND destination_chain = $2`
+
+	return inAuditedTx(ctx, r.db, audit, func(tx *sqlx.Tx) (interface{}, error) {
+		result, err := tx.ExecContext(ctx, query, tokenID, destination)
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete token limit: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if rowsAffected == 0 {
+			return nil, fmt.Errorf("%w: token %d to %s", ErrTokenLimitNotFound, tokenID, destination)
+		}
+
+		return nil, nil
+	})
+}
+
+// ForTransfer returns the limits of a transfer's token on its source chain
+// that apply to its route
+func (r *TokenLimitRepository) ForTransfer(ctx context.Context, transfer types.Transfer) ([]TokenLimit, error) {
+	limits := []TokenLimit{}
+	query := `
+		SELECT l.id, l.token_id, l.destination_chain, l.min_amount, l.max_amount, l.hourly_cap, l.daily_cap,
+			l.delay_threshold, l.updated_at
+		FROM token_limits l
+		JOIN supported_tokens t ON t.id = l.token_id
+		WHERE t.chain_id = $1 AND LOWER(t.token_address) = LOWER($2)
+			AND l.destination_chain IN (0, $3)
+		ORDER BY l.destination_chain ASC`
+
+	if err := r.db.SelectContext(ctx, &limits, query, transfer.SourceChain, transfer.Token, transfer.DestinationChain); err != nil {
+		return nil, fmt.Errorf("failed to get transfer limits: %w", err)
+	}
+
+	return limits, nil
+}
+
+// SignedVolume sums the transfers matching a query. A transfer counts from
+// its first signature, so only transfers signed within the window and not
+// before it are summed; the window is scanned on the signatures' created_at.
+func (r *TokenLimitRepository) SignedVolume(ctx context.Context, query VolumeQuery) (*big.Int, error) {
+	var volume types.BigInt
+	sqlQuery := `
+		SELECT COALESCE(SUM(t.amount), 0)::TEXT
+		FROM transfers t
+		WHERE t.id IN (SELECT s.transfer_id FROM signatures s WHERE s.created_at >= $4)
+			AND NOT EXISTS (SELECT 1 FROM signatures s WHERE s.transfer_id = t.id AND s.created_at < $4)
+			AND t.source_chain = $1 AND LOWER(t.token) = LOWER($2)
+			AND ($3 = 0 OR t.destination_chain = $3)
+			AND t.status != 'failed' AND t.id != $5`
+
+	err := r.db.GetContext(ctx, &volume, sqlQuery,
+		query.SourceChain, query.Token, query.DestinationChain, query.Since, query.ExcludeTransfer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum signed volume: %w", err)
+	}
+
+	return volume.Int, nil
+}
+
+// LockTokenVolume takes a session advisory lock on the volume of a token,
+// held on a dedicated connection until unlocked. The lock covers every route
+// of the token, since token-wide caps count all of them.
+func (r *TokenLimitRepository) LockTokenVolume(ctx context.Context, sourceChain types.ChainID, token string) (func(), error) {
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection: %w", err)
+	}
+
+	key := volumeLockKey(sourceChain, token)
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock(hashtextextended($1, 0))`, key); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to lock token volume: %w", err)
+	}
+
+	return func() {
+		if _, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock(hashtextextended($1, 0))`, key); err != nil {
+			fmt.Printf("Error unlocking token volume %s: %v\n", key, err)
+		}
+		conn.Close()
+	}, nil
+}
+
+// volumeLockKey identifies the volume of a token out of a chain
+func volumeLockKey(sourceChain types.ChainID, token string) string {
+	return fmt.Sprintf("token_volume:%d:%s", sourceChain, strings.ToLower(token))
+}
+
+// nullableAmount stores unset amounts as NULL
+func nullableAmount(amount *types.BigInt) interface{} {
+	if !isSet(amount) {
+		return nil
+	}
+	return amount.String()
+}
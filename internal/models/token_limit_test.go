@@ -0,0 +1,268 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"nexus-bridge/internal/models/testutil"
+	"nexus-bridge/pkg/types"
+)
+
+// tokenLimitStateStore is a Store that also manages token limits
+type tokenLimitStateStore interface {
+	Store
+	TokenLimitStore
+}
+
+func TestStateManager_TokenLimitContract(t *testing.T) {
+	runTokenLimitContract(t, func(t *testing.T) tokenLimitStateStore {
+		db := testutil.SetupTestDB(t)
+		t.Cleanup(func() { testutil.CleanupTestDB(t, db) })
+		return NewStateManager(db)
+	})
+}
+
+func TestMemoryStateManager_TokenLimitContract(t *testing.T) {
+	runTokenLimitContract(t, func(t *testing.T) tokenLimitStateStore {
+		return NewMemoryStateManager()
+	})
+}
+
+func TestTokenLimit_Validate(t *testing.T) {
+	amount := func(n int64) *types.BigInt { return types.NewBigInt(big.NewInt(n)) }
+
+	tests := []struct {
+		name  string
+		limit TokenLimit
+		valid bool
+	}{
+		{"MinAndMax", TokenLimit{TokenID: 1, MinAmount: amount(10), MaxAmount: amount(100)}, true},
+		{"CapsOnly", TokenLimit{TokenID: 1, HourlyCap: amount(100), DailyCap: amount(1000)}, true},
+		{"ZeroMinimum", TokenLimit{TokenID: 1, MinAmount: amount(0)}, true},
+		{"DelayOnly", TokenLimit{TokenID: 1, DelayThreshold: amount(0)}, true},
+		{"MissingToken", TokenLimit{MaxAmount: amount(100)}, false},
+		{"Empty", TokenLimit{TokenID: 1}, false},
+		{"NegativeMinimum", TokenLimit{TokenID: 1, MinAmount: amount(-1)}, false},
+		{"ZeroMaximum", TokenLimit{TokenID: 1, MaxAmount: amount(0)}, false},
+		{"ZeroCap", TokenLimit{TokenID: 1, DailyCap: amount(0)}, false},
+		{"NegativeDelay", TokenLimit{TokenID: 1, DelayThreshold: amount(-1)}, false},
+		{"MinAboveMax", TokenLimit{TokenID: 1, MinAmount: amount(101), MaxAmount: amount(100)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.limit.Validate()
+			if tt.valid && err != nil {
+				t.Errorf("Expected valid limit, got %v", err)
+			}
+			if !tt.valid && err == nil {
+				t.Error("Expected validation error")
+			}
+		})
+	}
+}
+
+// mustAddLimitedToken registers the token of contract transfers
+func mustAddLimitedToken(t *testing.T, store Store) *types.SupportedToken {
+	t.Helper()
+	token := &types.SupportedToken{
+		ChainID:      types.ChainEthereum,
+		TokenAddress: "0xa0b86a33e6441e6c7d3e4c2c4c6c6c6c6c6c6c6c",
+		Name:         "USD Coin",
+		Symbol:       "USDC",
+		Decimals:     18,
+		Enabled:      true,
+	}
+	if err := store.AddSupportedToken(context.Background(), token, nil); err != nil {
+		t.Fatalf("Failed to add token: %v", err)
+	}
+	return token
+}
+
+func runTokenLimitContract(t *testing.T, newStore func(t *testing.T) tokenLimitStateStore) {
+	ctx := context.Background()
+	ether := func(n int64) *types.BigInt {
+		return types.NewBigInt(new(big.Int).Mul(big.NewInt(n), big.NewInt(1e18)))
+	}
+
+	t.Run("SetListDelete", func(t *testing.T) {
+		store := newStore(t)
+		token := mustAddLimitedToken(t, store)
+
+		route := &TokenLimit{TokenID: token.ID, DestinationChain: types.ChainPolygon, MaxAmount: ether(5)}
+		wide := &TokenLimit{TokenID: token.ID, MinAmount: ether(1), DailyCap: ether(100), DelayThreshold: ether(50)}
+		for _, limit := range []*TokenLimit{route, wide} {
+			if err := store.SetTokenLimit(ctx, limit, nil); err != nil {
+				t.Fatalf("Failed to set limit: %v", err)
+			}
+			if limit.ID == 0 || limit.UpdatedAt.IsZero() {
+				t.Errorf("Expected ID and update time to be set, got %+v", limit)
+			}
+		}
+
+		// Setting a route again replaces its limit
+		replacement := &TokenLimit{TokenID: token.ID, DestinationChain: types.ChainPolygon, HourlyCap: ether(10)}
+		if err := store.SetTokenLimit(ctx, replacement, nil); err != nil {
+			t.Fatalf("Failed to replace limit: %v", err)
+		}
+		if replacement.ID != route.ID {
+			t.Errorf("Expected limit %d to be replaced, got %d", route.ID, replacement.ID)
+		}
+
+		limits, err := store.ListTokenLimits(ctx, token.ID)
+		if err != nil {
+			t.Fatalf("Failed to list limits: %v", err)
+		}
+		if len(limits) != 2 || limits[0].DestinationChain != 0 || limits[1].DestinationChain != types.ChainPolygon {
+			t.Fatalf("Expected the token-wide limit first, got %+v", limits)
+		}
+		if limits[0].MinAmount.Cmp(ether(1).Int) != 0 || limits[0].MaxAmount != nil || limits[0].DailyCap.Cmp(ether(100).Int) != 0 ||
+			limits[0].DelayThreshold.Cmp(ether(50).Int) != 0 {
+			t.Errorf("Unexpected token-wide limit: %+v", limits[0])
+		}
+		if limits[1].MaxAmount != nil || limits[1].HourlyCap.Cmp(ether(10).Int) != 0 {
+			t.Errorf("Unexpected route limit: %+v", limits[1])
+		}
+
+		if err := store.DeleteTokenLimit(ctx, token.ID, types.ChainPolygon, nil); err != nil {
+			t.Fatalf("Failed to delete limit: %v", err)
+		}
+		if err := store.DeleteTokenLimit(ctx, token.ID, types.ChainPolygon, nil); !errors.Is(err, ErrTokenLimitNotFound) {
+			t.Errorf("Expected ErrTokenLimitNotFound, got %v", err)
+		}
+
+		if err := store.SetTokenLimit(ctx, &TokenLimit{TokenID: token.ID + 100, MaxAmount: ether(1)}, nil); !errors.Is(err, ErrTokenNotFound) {
+			t.Errorf("Expected ErrTokenNotFound, got %v", err)
+		}
+		if err := store.SetTokenLimit(ctx, &TokenLimit{TokenID: token.ID}, nil); err == nil {
+			t.Error("Expected an empty limit to be rejected")
+		}
+
+		// Deleting a token removes its limits
+		if err := store.DeleteSupportedToken(ctx, token.ID, nil); err != nil {
+			t.Fatalf("Failed to delete token: %v", err)
+		}
+		limits, err = store.ListTokenLimits(ctx, token.ID)
+		if err != nil || len(limits) != 0 {
+			t.Errorf("Expected no limits, got %+v/%v", limits, err)
+		}
+	})
+
+	t.Run("TransferLimits", func(t *testing.T) {
+		store := newStore(t)
+		token := mustAddLimitedToken(t, store)
+		for _, limit := range []*TokenLimit{
+			{TokenID: token.ID, MaxAmount: ether(50)},
+			{TokenID: token.ID, DestinationChain: types.ChainPolygon, MaxAmount: ether(5)},
+			{TokenID: token.ID, DestinationChain: types.ChainCosmos, MaxAmount: ether(1)},
+		} {
+			if err := store.SetTokenLimit(ctx, limit, nil); err != nil {
+				t.Fatalf("Failed to set limit: %v", err)
+			}
+		}
+
+		// Transfers carry checksummed addresses
+		limits, err := store.GetTransferLimits(ctx, contractTransfer(1))
+		if err != nil {
+			t.Fatalf("Failed to get transfer limits: %v", err)
+		}
+		if len(limits) != 2 || limits[0].DestinationChain != 0 || limits[1].DestinationChain != types.ChainPolygon {
+			t.Errorf("Expected the token-wide and Polygon limits, got %+v", limits)
+		}
+
+		other := contractTransfer(2)
+		other.SourceChain = types.ChainPolygon
+		limits, err = store.GetTransferLimits(ctx, other)
+		if err != nil || len(limits) != 0 {
+			t.Errorf("Expected no limits on another chain, got %+v/%v", limits, err)
+		}
+	})
+
+	t.Run("SignedVolume", func(t *testing.T) {
+		store := newStore(t)
+		signature := types.Signature{RelayerAddress: "0x742d35Cc6634C0532925a3b8D4C9db96590C4C4C", Signature: []byte("signature")}
+		cosmos := contractTransfer(3)
+		cosmos.DestinationChain = types.ChainCosmos
+		for _, transfer := range []types.Transfer{contractTransfer(1), contractTransfer(2), cosmos, contractTransfer(4), contractTransfer(5)} {
+			mustRecord(t, store, transfer)
+			// The fifth transfer is not signed yet
+			if transfer.ID == contractTransfer(5).ID {
+				continue
+			}
+			if err := store.RecordSignature(ctx, transfer.ID, signature); err != nil {
+				t.Fatalf("Failed to record signature: %v", err)
+			}
+		}
+		if err := store.UpdateTransferStatus(ctx, contractTransfer(4).ID, types.StatusFailed); err != nil {
+			t.Fatalf("Failed to update status: %v", err)
+		}
+
+		query := VolumeQuery{
+			SourceChain: types.ChainEthereum,
+			Token:       "0xa0b86a33e6441e6c7d3e4c2c4c6c6c6c6c6c6c6c",
+			Since:       time.Now().Add(-time.Hour),
+		}
+		volume, err := store.SignedVolume(ctx, query)
+		if err != nil {
+			t.Fatalf("Failed to get volume: %v", err)
+		}
+		if volume.Cmp(ether(3).Int) != 0 {
+			t.Errorf("Expected a volume of 3 tokens, got %s", volume)
+		}
+
+		query.DestinationChain = types.ChainPolygon
+		query.ExcludeTransfer = contractTransfer(1).ID
+		volume, err = store.SignedVolume(ctx, query)
+		if err != nil || volume.Cmp(ether(1).Int) != 0 {
+			t.Errorf("Expected a route volume of 1 token, got %s/%v", volume, err)
+		}
+
+		query.Since = time.Now().Add(time.Hour)
+		volume, err = store.SignedVolume(ctx, query)
+		if err != nil || volume.Sign() != 0 {
+			t.Errorf("Expected no volume in the future, got %s/%v", volume, err)
+		}
+	})
+
+	t.Run("LockTokenVolume", func(t *testing.T) {
+		store := newStore(t)
+		unlock, err := store.LockTokenVolume(ctx, types.ChainEthereum, "0xA0b86a33E6441E6C7D3E4C2C4C6C6C6C6C6C6C6C")
+		if err != nil {
+			t.Fatalf("Failed to lock token volume: %v", err)
+		}
+
+		// Other tokens are not locked
+		other, err := store.LockTokenVolume(ctx, types.ChainPolygon, "0xA0b86a33E6441E6C7D3E4C2C4C6C6C6C6C6C6C6C")
+		if err != nil {
+			t.Fatalf("Failed to lock another token volume: %v", err)
+		}
+		other()
+
+		locked := make(chan func())
+		go func() {
+			second, err := store.LockTokenVolume(ctx, types.ChainEthereum, "0xa0b86a33e6441e6c7d3e4c2c4c6c6c6c6c6c6c6c")
+			if err != nil {
+				t.Errorf("Failed to lock token volume again: %v", err)
+				second = func() {}
+			}
+			locked <- second
+		}()
+
+		select {
+		case <-locked:
+			t.Fatal("Expected the token volume to stay locked")
+		case <-time.After(50 * time.Millisecond):
+		}
+		unlock()
+
+		select {
+		case second := <-locked:
+			second()
+		case <-time.After(5 * time.Second):
+			t.Fatal("Expected the lock to be taken once released")
+		}
+	})
+}
@@ -3,6 +3,7 @@ package models
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
@@ -11,6 +12,9 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
+// ErrTransferNotFound is returned when a transfer does not exist
+var ErrTransferNotFound = errors.New("transfer not found")
+
 // TransferRepository handles database operations for transfers
 type TransferRepository struct {
 	db *sqlx.DB
@@ -62,7 +66,7 @@ func (r *TransferRepository) GetByID(ctx context.Context, id string) (*types.Tra
 	err := r.db.GetContext(ctx, &transfer, query, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("transfer not found: %s", id)
+			return nil, fmt.Errorf("%w: %s", ErrTransferNotFound, id)
 		}
 		return nil, fmt.Errorf("failed to get transfer: %w", err)
 	}
@@ -88,7 +92,7 @@ func (r *TransferRepository) UpdateStatus(ctx context.Context, id string, status
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("transfer not found: %s", id)
+		return fmt.Errorf("%w: %s", ErrTransferNotFound, id)
 	}
 
 	return nil
@@ -112,7 +116,7 @@ func (r *TransferRepository) UpdateDestinationTxHash(ctx context.Context, id str
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("transfer not found: %s", id)
+		return fmt.Errorf("%w: %s", ErrTransferNotFound, id)
 	}
 
 	return nil
@@ -136,7 +140,7 @@ func (r *TransferRepository) UpdateConfirmations(ctx context.Context, id string,
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("transfer not found: %s", id)
+		return fmt.Errorf("%w: %s", ErrTransferNotFound, id)
 	}
 
 	return nil
@@ -180,6 +184,23 @@ func (r *TransferRepository) List(ctx context.Context, limit, offset int) ([]typ
 	return transfers, nil
 }
 
+// Search retrieves transfers matching a filter using keyset pagination over
+// (created_at, id), newest first
+func (r *TransferRepository) Search(ctx context.Context, filter TransferFilter) (*TransferPage, error) {
+	query, args, err := filter.buildSearchQuery()
+	if err != nil {
+		return nil, err
+	}
+
+	var transfers []types.Transfer
+	err = r.db.SelectContext(ctx, &transfers, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search transfers: %w", err)
+	}
+
+	return newTransferPage(transfers, filter.pageSize()), nil
+}
+
 // GetByStatus retrieves transfers by status
 func (r *TransferRepository) GetByStatus(ctx context.Context, status types.TransferStatus) ([]types.Transfer, error) {
 	var transfers []types.Transfer
@@ -199,27 +220,19 @@ func (r *TransferRepository) GetByStatus(ctx context.Context, status types.Trans
 	return transfers, nil
 }
 
-// MarkForReview marks a transfer for manual review
-func (r *TransferRepository) MarkForReview(ctx context.Context, id string, reason string) error {
+// CountStuck counts transfers in a status they entered before a time. Only
+// status changes count, so leasing a transfer does not hide it.
+func (r *TransferRepository) CountStuck(ctx context.Context, status types.TransferStatus, before time.Time) (int, error) {
+	var count int
 	query := `
-		UPDATE transfers 
-		SET status = $1, updated_at = $2
-		WHERE id = $3`
-
-	result, err := r.db.ExecContext(ctx, query, types.StatusUnderReview, time.Now(), id)
-	if err != nil {
-		return fmt.Errorf("failed to mark transfer for review: %w", err)
-	}
+		SELECT COUNT(*)
+		FROM transfers
+		WHERE status = $1 AND status_changed_at < $2`
 
-	rowsAffected, err := result.RowsAffected()
+	err := r.db.GetContext(ctx, &count, query, status, before)
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
-	}
-
-	if rowsAffected == 0 {
-		return fmt.Errorf("transfer not found: %s", id)
+		return 0, fmt.Errorf("failed to count stuck transfers: %w", err)
 	}
 
-	// TODO: Log the reason for review in a separate audit table
-	return nil
-}
\ No newline at end of file
+	return count, nil
+}
@@ -0,0 +1,220 @@
+package models
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"nexus-bridge/pkg/types"
+)
+
+const (
+	// DefaultTransferPageSize is used when a filter does not specify a limit
+	DefaultTransferPageSize = 50
+	// MaxTransferPageSize caps the number of transfers returned per page
+	MaxTransferPageSize = 500
+)
+
+// TransferFilter describes a transfer search. Zero-valued fields are ignored.
+type TransferFilter struct {
+	Status           types.TransferStatus
+	Sender           string
+	Recipient        string
+	Token            string
+	SourceChain      types.ChainID
+	DestinationChain types.ChainID
+	// Chain matches either the source or the destination chain
+	Chain types.ChainID
+	// TxHash matches either the source or the destination transaction hash
+	TxHash        string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+
+	// Cursor is the opaque NextCursor of a previous page
+	Cursor string
+	Limit  int
+}
+
+// TransferPage is a single page of search results, newest first
+type TransferPage struct {
+	Transfers  []types.Transfer `json:"transfers"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+}
+
+// transferCursor is the keyset position of the last transfer on a page
+type transferCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// encodeTransferCursor encodes the keyset position of a transfer
+func encodeTransferCursor(transfer types.Transfer) string {
+	raw := fmt.Sprintf("%d:%s", transfer.CreatedAt.UnixNano(), transfer.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeTransferCursor decodes a cursor produced by encodeTransferCursor
+func decodeTransferCursor(cursor string) (*transferCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	parts := strings.SplitN(string(raw), ":", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return nil, fmt.Errorf("invalid cursor: malformed position")
+	}
+
+	nanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	return &transferCursor{CreatedAt: time.Unix(0, nanos).UTC(), ID: parts[1]}, nil
+}
+
+// pageSize returns the effective page size for the filter
+func (f TransferFilter) pageSize() int {
+	if f.Limit <= 0 {
+		return DefaultTransferPageSize
+	}
+	if f.Limit > MaxTransferPageSize {
+		return MaxTransferPageSize
+	}
+	return f.Limit
+}
+
+// Validate checks that the filter's cursor and time range are well formed
+func (f TransferFilter) Validate() error {
+	if f.Cursor != "" {
+		if _, err := decodeTransferCursor(f.Cursor); err != nil {
+			return err
+		}
+	}
+	if !f.CreatedAfter.IsZero() && !f.CreatedBefore.IsZero() && !f.CreatedAfter.Before(f.CreatedBefore) {
+		return fmt.Errorf("invalid time range: created_after must be before created_before")
+	}
+	return nil
+}
+
+// Matches reports whether a transfer satisfies every criterion of the filter,
+// ignoring the cursor and limit
+func (f TransferFilter) Matches(transfer types.Transfer) bool {
+	if f.Status != "" && transfer.Status != f.Status {
+		return false
+	}
+	if f.Sender != "" && !strings.EqualFold(transfer.Sender, f.Sender) {
+		return false
+	}
+	if f.Recipient != "" && !strings.EqualFold(transfer.Recipient, f.Recipient) {
+		return false
+	}
+	if f.Token != "" && !strings.EqualFold(transfer.Token, f.Token) {
+		return false
+	}
+	if f.SourceChain != 0 && transfer.SourceChain != f.SourceChain {
+		return false
+	}
+	if f.DestinationChain != 0 && transfer.DestinationChain != f.DestinationChain {
+		return false
+	}
+	if f.Chain != 0 && transfer.SourceChain != f.Chain && transfer.DestinationChain != f.Chain {
+		return false
+	}
+	if f.TxHash != "" &&
+		!strings.EqualFold(transfer.SourceTxHash, f.TxHash) &&
+		!strings.EqualFold(transfer.DestinationTxHash, f.TxHash) {
+		return false
+	}
+	if !f.CreatedAfter.IsZero() && transfer.CreatedAt.Before(f.CreatedAfter) {
+		return false
+	}
+	if !f.CreatedBefore.IsZero() && !transfer.CreatedAt.Before(f.CreatedBefore) {
+		return false
+	}
+	return true
+}
+
+// buildSearchQuery builds the SQL query and arguments for a filter. One extra
+// row is requested so the caller can tell whether a further page exists.
+func (f TransferFilter) buildSearchQuery() (string, []interface{}, error) {
+	var conditions []string
+	var args []interface{}
+
+	addCondition := func(format string, values ...interface{}) {
+		placeholders := make([]interface{}, len(values))
+		for i, value := range values {
+			args = append(args, value)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		conditions = append(conditions, fmt.Sprintf(format, placeholders...))
+	}
+
+	if f.Status != "" {
+		addCondition("status = %s", f.Status)
+	}
+	if f.Sender != "" {
+		addCondition("LOWER(sender) = LOWER(%s)", f.Sender)
+	}
+	if f.Recipient != "" {
+		addCondition("LOWER(recipient) = LOWER(%s)", f.Recipient)
+	}
+	if f.Token != "" {
+		addCondition("LOWER(token) = LOWER(%s)", f.Token)
+	}
+	if f.SourceChain != 0 {
+		addCondition("source_chain = %s", f.SourceChain)
+	}
+	if f.DestinationChain != 0 {
+		addCondition("destination_chain = %s", f.DestinationChain)
+	}
+	if f.Chain != 0 {
+		addCondition("(source_chain = %s OR destination_chain = %s)", f.Chain, f.Chain)
+	}
+	if f.TxHash != "" {
+		addCondition("(LOWER(source_tx_hash) = LOWER(%s) OR LOWER(destination_tx_hash) = LOWER(%s))", f.TxHash, f.TxHash)
+	}
+	if !f.CreatedAfter.IsZero() {
+		addCondition("created_at >= %s", f.CreatedAfter)
+	}
+	if !f.CreatedBefore.IsZero() {
+		addCondition("created_at < %s", f.CreatedBefore)
+	}
+	if f.Cursor != "" {
+		cursor, err := decodeTransferCursor(f.Cursor)
+		if err != nil {
+			return "", nil, err
+		}
+		addCondition("(created_at, id) < (%s, %s)", cursor.CreatedAt, cursor.ID)
+	}
+
+	query := `
+		SELECT id, source_chain, destination_chain, token, amount, sender, recipient,
+			   status, source_tx_hash, destination_tx_hash, block_number, confirmations,
+			   fee, created_at, updated_at
+		FROM transfers`
+	if len(conditions) > 0 {
+		query += "\n\t\tWHERE " + strings.Join(conditions, " AND ")
+	}
+
+	args = append(args, f.pageSize()+1)
+	query += fmt.Sprintf("\n\t\tORDER BY created_at DESC, id DESC\n\t\tLIMIT $%d", len(args))
+
+	return query, args, nil
+}
+
+// newTransferPage trims an over-fetched result set to the page size and sets
+// the cursor for the next page
+func newTransferPage(transfers []types.Transfer, pageSize int) *TransferPage {
+	page := &TransferPage{Transfers: transfers}
+	if len(transfers) > pageSize {
+		page.Transfers = transfers[:pageSize]
+		page.NextCursor = encodeTransferCursor(page.Transfers[pageSize-1])
+	}
+	if page.Transfers == nil {
+		page.Transfers = []types.Transfer{}
+	}
+	return page
+}
@@ -0,0 +1,165 @@
+package models
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"nexus-bridge/pkg/types"
+)
+
+func TestTransferCursor_RoundTrip(t *testing.T) {
+	transfer := types.Transfer{
+		ID:        "0x1234567890abcdef1234567890abcdef12345678901234567890abcdef123456",
+		CreatedAt: time.Date(2025, 1, 30, 12, 0, 0, 123456000, time.UTC),
+	}
+
+	cursor, err := decodeTransferCursor(encodeTransferCursor(transfer))
+	if err != nil {
+		t.Fatalf("Failed to decode cursor: %v", err)
+	}
+
+	if cursor.ID != transfer.ID {
+		t.Errorf("Expected ID %s, got %s", transfer.ID, cursor.ID)
+	}
+	if !cursor.CreatedAt.Equal(transfer.CreatedAt) {
+		t.Errorf("Expected CreatedAt %v, got %v", transfer.CreatedAt, cursor.CreatedAt)
+	}
+}
+
+func TestTransferCursor_Invalid(t *testing.T) {
+	cursors := []string{"not base64!", "bm9jb2xvbg", "YWJjOjB4MTIz"}
+
+	for _, cursor := range cursors {
+		if _, err := decodeTransferCursor(cursor); err == nil {
+			t.Errorf("Expected error for cursor %q", cursor)
+		}
+	}
+}
+
+func TestTransferFilter_Matches(t *testing.T) {
+	created := time.Date(2025, 1, 30, 12, 0, 0, 0, time.UTC)
+	transfer := types.Transfer{
+		ID:                "0x1111111111111111111111111111111111111111111111111111111111111111",
+		SourceChain:       types.ChainEthereum,
+		DestinationChain:  types.ChainPolygon,
+		Token:             "0xA0b86a33E6441E6C7D3E4C2C4C6C6C6C6C6C6C6C",
+		Amount:            types.NewBigInt(big.NewInt(1000000000000000000)),
+		Sender:            "0x742d35Cc6634C0532925a3b8D4C9db96590C4C4C",
+		Recipient:         "0x8ba1f109551bD432803012645Hac136c22C4C4C",
+		Status:            types.StatusCompleted,
+		SourceTxHash:      "0xaaaa",
+		DestinationTxHash: "0xBBBB",
+		CreatedAt:         created,
+	}
+
+	tests := []struct {
+		name     string
+		filter   TransferFilter
+		expected bool
+	}{
+		{"empty filter", TransferFilter{}, true},
+		{"status match", TransferFilter{Status: types.StatusCompleted}, true},
+		{"status mismatch", TransferFilter{Status: types.StatusPending}, false},
+		{"sender case-insensitive", TransferFilter{Sender: strings.ToLower(transfer.Sender)}, true},
+		{"recipient mismatch", TransferFilter{Recipient: "0x0000000000000000000000000000000000000000"}, false},
+		{"route match", TransferFilter{SourceChain: types.ChainEthereum, DestinationChain: types.ChainPolygon}, true},
+		{"route mismatch", TransferFilter{SourceChain: types.ChainPolygon}, false},
+		{"either chain source", TransferFilter{Chain: types.ChainEthereum}, true},
+		{"either chain destination", TransferFilter{Chain: types.ChainPolygon}, true},
+		{"either chain mismatch", TransferFilter{Chain: types.ChainCosmos}, false},
+		{"source tx hash", TransferFilter{TxHash: "0xAAAA"}, true},
+		{"destination tx hash", TransferFilter{TxHash: "0xbbbb"}, true},
+		{"unknown tx hash", TransferFilter{TxHash: "0xcccc"}, false},
+		{"created after", TransferFilter{CreatedAfter: created}, true},
+		{"created before is exclusive", TransferFilter{CreatedBefore: created}, false},
+		{"inside time range", TransferFilter{CreatedAfter: created.Add(-time.Hour), CreatedBefore: created.Add(time.Hour)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Matches(transfer); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestTransferFilter_BuildSearchQuery(t *testing.T) {
+	cursor := encodeTransferCursor(types.Transfer{ID: "0xabc", CreatedAt: time.Now()})
+	filter := TransferFilter{
+		Status: types.StatusPending,
+		Sender: "0x742d35Cc6634C0532925a3b8D4C9db96590C4C4C",
+		TxHash: "0xaaaa",
+		Cursor: cursor,
+		Limit:  10,
+	}
+
+	query, args, err := filter.buildSearchQuery()
+	if err != nil {
+		t.Fatalf("Failed to build query: %v", err)
+	}
+
+	// status, sender, tx hash twice, cursor pair and limit
+	if len(args) != 7 {
+		t.Fatalf("Expected 7 args, got %d", len(args))
+	}
+	if args[len(args)-1] != 11 {
+		t.Errorf("Expected limit arg 11, got %v", args[len(args)-1])
+	}
+	for _, fragment := range []string{"status = $1", "LOWER(sender) = LOWER($2)", "(created_at, id) < ($5, $6)", "LIMIT $7"} {
+		if !strings.Contains(query, fragment) {
+			t.Errorf("Expected query to contain %q, got %s", fragment, query)
+		}
+	}
+
+	filter.Cursor = "%%%"
+	if _, _, err := filter.buildSearchQuery(); err == nil {
+		t.Error("Expected error for invalid cursor")
+	}
+}
+
+func TestTransferFilter_Validate(t *testing.T) {
+	now := time.Now()
+	if err := (TransferFilter{CreatedAfter: now, CreatedBefore: now.Add(time.Hour)}).Validate(); err != nil {
+		t.Errorf("Expected valid filter, got %v", err)
+	}
+	if err := (TransferFilter{CreatedAfter: now, CreatedBefore: now}).Validate(); err == nil {
+		t.Error("Expected error for empty time range")
+	}
+	if err := (TransferFilter{Cursor: "%%%"}).Validate(); err == nil {
+		t.Error("Expected error for invalid cursor")
+	}
+}
+
+func TestTransferFilter_PageSize(t *testing.T) {
+	if size := (TransferFilter{}).pageSize(); size != DefaultTransferPageSize {
+		t.Errorf("Expected default page size %d, got %d", DefaultTransferPageSize, size)
+	}
+	if size := (TransferFilter{Limit: MaxTransferPageSize + 1}).pageSize(); size != MaxTransferPageSize {
+		t.Errorf("Expected max page size %d, got %d", MaxTransferPageSize, size)
+	}
+}
+
+func TestNewTransferPage(t *testing.T) {
+	transfers := []types.Transfer{{ID: "0x3"}, {ID: "0x2"}, {ID: "0x1"}}
+
+	page := newTransferPage(transfers, 2)
+	if len(page.Transfers) != 2 {
+		t.Fatalf("Expected 2 transfers, got %d", len(page.Transfers))
+	}
+	if page.NextCursor == "" {
+		t.Error("Expected next cursor when more results exist")
+	}
+
+	page = newTransferPage(transfers, 3)
+	if page.NextCursor != "" {
+		t.Error("Expected no next cursor on the last page")
+	}
+
+	page = newTransferPage(nil, 3)
+	if page.Transfers == nil {
+		t.Error("Expected empty slice rather than nil")
+	}
+}
@@ -0,0 +1,152 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"nexus-bridge/pkg/types"
+)
+
+// TransferLeaser hands out time-limited leases on transfers so several relayer
+// processes can drain the same status queue without working on the same
+// transfer. A lease held by a crashed process expires and the transfer
+// becomes available again.
+type TransferLeaser interface {
+	// LeaseTransfers leases up to limit transfers in a status that are not
+	// leased or whose lease has expired, oldest first
+	LeaseTransfers(ctx context.Context, status types.TransferStatus, owner string, ttl time.Duration, limit int) ([]types.Transfer, error)
+
+	// LeaseUnsignedTransfers leases transfers like LeaseTransfers, skipping
+	// those the relayer address has already signed
+	LeaseUnsignedTransfers(ctx context.Context, status types.TransferStatus, relayerAddress, owner string, ttl time.Duration, limit int) ([]types.Transfer, error)
+
+	// RenewLease extends a lease still held by owner
+	RenewLease(ctx context.Context, transferID, owner string, ttl time.Duration) error
+
+	// ReleaseLease gives up a lease held by owner
+	ReleaseLease(ctx context.Context, transferID, owner string) error
+}
+
+// Lease leases up to limit transfers in a status. Rows locked by a concurrent
+// lease are skipped rather than waited on. Expiry follows the database clock,
+// so replicas with skewed clocks agree on when a lease ends.
+func (r *TransferRepository) Lease(ctx context.Context, status types.TransferStatus, owner string, ttl time.Duration, limit int) ([]types.Transfer, error) {
+	return r.lease(ctx, status, "", owner, ttl, limit)
+}
+
+// LeaseUnsigned leases up to limit transfers in a status that the relayer
+// address has not signed
+func (r *TransferRepository) LeaseUnsigned(ctx context.Context, status types.TransferStatus, relayerAddress, owner string, ttl time.Duration, limit int) ([]types.Transfer, error) {
+	if relayerAddress == "" {
+		return nil, fmt.Errorf("relayer address is required")
+	}
+	return r.lease(ctx, status, relayerAddress, owner, ttl, limit)
+}
+
+// lease leases transfers in a status, skipping those signed by
+// relayerAddress unless it is empty
+func (r *TransferRepository) lease(ctx context.Context, status types.TransferStatus, relayerAddress, owner string, ttl time.Duration, limit int) ([]types.Transfer, error) {
+	if err := validateLease(owner, ttl); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		return []types.Transfer{}, nil
+	}
+
+	var transfers []types.Transfer
+	query := `
+		UPDATE transfers
+		SET lease_owner = $1, lease_expires_at = NOW() + $2 * INTERVAL '1 microsecond'
+		WHERE id IN (
+			SELECT id
+			FROM transfers
+			WHERE status = $3 AND (lease_expires_at IS NULL OR lease_expires_at <= NOW())
+			  AND ($5 = '' OR NOT EXISTS (
+				SELECT 1 FROM signatures
+				WHERE signatures.transfer_id = transfers.id AND signatures.relayer_address = $5
+			  ))
+			ORDER BY created_at ASC, id ASC
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, source_chain, destination_chain, token, amount, sender, recipient,
+			   status, source_tx_hash, destination_tx_hash, block_number, confirmations,
+			   fee, created_at, updated_at`
+
+	err := r.db.SelectContext(ctx, &transfers, query, owner, ttl.Microseconds(), status, limit, relayerAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lease transfers: %w", err)
+	}
+
+	// RETURNING does not preserve the subquery order
+	sortTransfersOldestFirst(transfers)
+
+	return transfers, nil
+}
+
+// RenewLease extends a lease still held by owner
+func (r *TransferRepository) RenewLease(ctx context.Context, id, owner string, ttl time.Duration) error {
+	if err := validateLease(owner, ttl); err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE transfers
+		SET lease_expires_at = NOW() + $1 * INTERVAL '1 microsecond'
+		WHERE id = $2 AND lease_owner = $3 AND lease_expires_at > NOW()`
+
+	result, err := r.db.ExecContext(ctx, query, ttl.Microseconds(), id, owner)
+	if err != nil {
+		return fmt.Errorf("failed to renew lease: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("failed to renew lease: transfer %s is not leased by %s", id, owner)
+	}
+
+	return nil
+}
+
+// ReleaseLease gives up a lease held by owner. Releasing a lease that has
+// already been taken over is not an error.
+func (r *TransferRepository) ReleaseLease(ctx context.Context, id, owner string) error {
+	query := `
+		UPDATE transfers
+		SET lease_owner = NULL, lease_expires_at = NULL
+		WHERE id = $1 AND lease_owner = $2`
+
+	_, err := r.db.ExecContext(ctx, query, id, owner)
+	if err != nil {
+		return fmt.Errorf("failed to release lease: %w", err)
+	}
+
+	return nil
+}
+
+// validateLease validates the lease owner and duration
+func validateLease(owner string, ttl time.Duration) error {
+	if owner == "" {
+		return fmt.Errorf("lease owner is required")
+	}
+	if ttl <= 0 {
+		return fmt.Errorf("lease duration must be positive")
+	}
+	return nil
+}
+
+// sortTransfersOldestFirst orders transfers by (created_at, id) ascending
+func sortTransfersOldestFirst(transfers []types.Transfer) {
+	sort.SliceStable(transfers, func(i, j int) bool {
+		if transfers[i].CreatedAt.Equal(transfers[j].CreatedAt) {
+			return transfers[i].ID < transfers[j].ID
+		}
+		return transfers[i].CreatedAt.Before(transfers[j].CreatedAt)
+	})
+}
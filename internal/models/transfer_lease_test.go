@@ -0,0 +1,205 @@
+package models
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"nexus-bridge/internal/models/testutil"
+	"nexus-bridge/pkg/types"
+)
+
+// leasingStore is a Store whose transfers can also be leased
+type leasingStore interface {
+	Store
+	TransferLeaser
+}
+
+func TestStateManager_LeaseContract(t *testing.T) {
+	runLeaseContract(t, func(t *testing.T) leasingStore {
+		db := testutil.SetupTestDB(t)
+		t.Cleanup(func() { testutil.CleanupTestDB(t, db) })
+		return NewStateManager(db)
+	})
+}
+
+func TestMemoryStateManager_LeaseContract(t *testing.T) {
+	runLeaseContract(t, func(t *testing.T) leasingStore {
+		return NewMemoryStateManager()
+	})
+}
+
+func recordSignedTransfers(t *testing.T, store leasingStore, count int) {
+	t.Helper()
+	for n := 1; n <= count; n++ {
+		transfer := contractTransfer(n)
+		transfer.Status = types.StatusSigned
+		mustRecord(t, store, transfer)
+	}
+}
+
+func runLeaseContract(t *testing.T, newStore func(t *testing.T) leasingStore) {
+	ctx := context.Background()
+
+	t.Run("LeasesOldestFirstAndSkipsLeased", func(t *testing.T) {
+		store := newStore(t)
+		recordSignedTransfers(t, store, 3)
+		mustRecord(t, store, contractTransfer(4)) // pending, never leased
+
+		first, err := store.LeaseTransfers(ctx, types.StatusSigned, "relayer-a", time.Minute, 2)
+		if err != nil {
+			t.Fatalf("Failed to lease: %v", err)
+		}
+		if len(first) != 2 || first[0].ID != contractTransfer(1).ID || first[1].ID != contractTransfer(2).ID {
+			t.Fatalf("Expected the two oldest transfers, got %+v", first)
+		}
+
+		second, err := store.LeaseTransfers(ctx, types.StatusSigned, "relayer-b", time.Minute, 10)
+		if err != nil {
+			t.Fatalf("Failed to lease: %v", err)
+		}
+		if len(second) != 1 || second[0].ID != contractTransfer(3).ID {
+			t.Fatalf("Expected only the unleased transfer, got %+v", second)
+		}
+	})
+
+	t.Run("ConcurrentLeasesAreDisjoint", func(t *testing.T) {
+		store := newStore(t)
+		recordSignedTransfers(t, store, 10)
+
+		var mu sync.Mutex
+		seen := make(map[string]int)
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func(owner string) {
+				defer wg.Done()
+				leased, err := store.LeaseTransfers(ctx, types.StatusSigned, owner, time.Minute, 4)
+				if err != nil {
+					t.Errorf("Failed to lease: %v", err)
+					return
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				for _, transfer := range leased {
+					seen[transfer.ID]++
+				}
+			}(string(rune('a' + i)))
+		}
+		wg.Wait()
+
+		if len(seen) != 10 {
+			t.Errorf("Expected all 10 transfers leased, got %d", len(seen))
+		}
+		for id, count := range seen {
+			if count != 1 {
+				t.Errorf("Transfer %s leased %d times", id, count)
+			}
+		}
+	})
+
+	t.Run("ExpiredLeasesAreReclaimed", func(t *testing.T) {
+		store := newStore(t)
+		recordSignedTransfers(t, store, 1)
+		transferID := contractTransfer(1).ID
+
+		if _, err := store.LeaseTransfers(ctx, types.StatusSigned, "crashed", 50*time.Millisecond, 1); err != nil {
+			t.Fatalf("Failed to lease: %v", err)
+		}
+		time.Sleep(100 * time.Millisecond)
+
+		reclaimed, err := store.LeaseTransfers(ctx, types.StatusSigned, "survivor", time.Minute, 1)
+		if err != nil || len(reclaimed) != 1 {
+			t.Fatalf("Expected expired lease to be reclaimed, got %d/%v", len(reclaimed), err)
+		}
+
+		if err := store.RenewLease(ctx, transferID, "crashed", time.Minute); err == nil {
+			t.Error("Expected the previous owner to have lost the lease")
+		}
+		if err := store.RenewLease(ctx, transferID, "survivor", time.Minute); err != nil {
+			t.Errorf("Expected the new owner to renew, got %v", err)
+		}
+	})
+
+	t.Run("ReleaseMakesTransferAvailable", func(t *testing.T) {
+		store := newStore(t)
+		recordSignedTransfers(t, store, 1)
+		transferID := contractTransfer(1).ID
+
+		if _, err := store.LeaseTransfers(ctx, types.StatusSigned, "relayer-a", time.Minute, 1); err != nil {
+			t.Fatalf("Failed to lease: %v", err)
+		}
+
+		// Only the owner can release
+		if err := store.ReleaseLease(ctx, transferID, "relayer-b"); err != nil {
+			t.Fatalf("Failed to release: %v", err)
+		}
+		leased, _ := store.LeaseTransfers(ctx, types.StatusSigned, "relayer-b", time.Minute, 1)
+		if len(leased) != 0 {
+			t.Fatal("Expected lease to survive release by another owner")
+		}
+
+		if err := store.ReleaseLease(ctx, transferID, "relayer-a"); err != nil {
+			t.Fatalf("Failed to release: %v", err)
+		}
+		leased, err := store.LeaseTransfers(ctx, types.StatusSigned, "relayer-b", time.Minute, 1)
+		if err != nil || len(leased) != 1 {
+			t.Errorf("Expected released transfer to be leased again, got %d/%v", len(leased), err)
+		}
+	})
+
+	t.Run("UnsignedLeasesSkipTransfersSignedByRelayer", func(t *testing.T) {
+		store := newStore(t)
+		recordSignedTransfers(t, store, 2)
+		relayer := "0x742d35cc6634c0532925a3b8d4c9db96590c4c4c"
+		signature := types.Signature{RelayerAddress: relayer, Signature: []byte("signature")}
+		if err := store.RecordSignature(ctx, contractTransfer(1).ID, signature); err != nil {
+			t.Fatalf("Failed to record signature: %v", err)
+		}
+
+		leased, err := store.LeaseUnsignedTransfers(ctx, types.StatusSigned, relayer, "relayer-a", time.Minute, 10)
+		if err != nil {
+			t.Fatalf("Failed to lease: %v", err)
+		}
+		if len(leased) != 1 || leased[0].ID != contractTransfer(2).ID {
+			t.Fatalf("Expected only the transfer the relayer has not signed, got %+v", leased)
+		}
+
+		if _, err := store.LeaseUnsignedTransfers(ctx, types.StatusSigned, "", "relayer-a", time.Minute, 10); err == nil {
+			t.Error("Expected error for missing relayer address")
+		}
+	})
+
+	t.Run("LeasesDoNotResetStuckTime", func(t *testing.T) {
+		store := newStore(t)
+		recordSignedTransfers(t, store, 1)
+		time.Sleep(50 * time.Millisecond)
+		before := time.Now()
+
+		if _, err := store.LeaseTransfers(ctx, types.StatusSigned, "relayer-a", time.Minute, 1); err != nil {
+			t.Fatalf("Failed to lease: %v", err)
+		}
+		if err := store.ReleaseLease(ctx, contractTransfer(1).ID, "relayer-a"); err != nil {
+			t.Fatalf("Failed to release: %v", err)
+		}
+		if err := store.UpdateConfirmations(ctx, contractTransfer(1).ID, 12); err != nil {
+			t.Fatalf("Failed to update confirmations: %v", err)
+		}
+
+		count, err := store.CountStuckTransfers(ctx, types.StatusSigned, before)
+		if err != nil || count != 1 {
+			t.Errorf("Expected the leased transfer to stay stuck, got %d/%v", count, err)
+		}
+	})
+
+	t.Run("RejectsInvalidLeases", func(t *testing.T) {
+		store := newStore(t)
+		if _, err := store.LeaseTransfers(ctx, types.StatusSigned, "", time.Minute, 1); err == nil {
+			t.Error("Expected error for missing owner")
+		}
+		if _, err := store.LeaseTransfers(ctx, types.StatusSigned, "relayer-a", 0, 1); err == nil {
+			t.Error("Expected error for zero duration")
+		}
+	})
+}
@@ -248,4 +248,53 @@ func TestTransfer_Validate(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestTransferRepository_Search(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := NewTransferRepository(db)
+
+	ids := []string{
+		"0x1111111111111111111111111111111111111111111111111111111111111111",
+		"0x2222222222222222222222222222222222222222222222222222222222222222",
+		"0x3333333333333333333333333333333333333333333333333333333333333333",
+	}
+	for i, id := range ids {
+		transfer := &types.Transfer{
+			ID:               id,
+			SourceChain:      types.ChainEthereum,
+			DestinationChain: types.ChainPolygon,
+			Token:            "0xA0b86a33E6441E6C7D3E4C2C4C6C6C6C6C6C6C6C",
+			Amount:           types.NewBigInt(big.NewInt(int64(i + 1))),
+			Sender:           "0x742d35Cc6634C0532925a3b8D4C9db96590C4C4C",
+			Recipient:        "0x8ba1f109551bD432803012645Hac136c22C4C4C",
+			Status:           types.StatusPending,
+		}
+		if err := repo.Create(context.Background(), transfer); err != nil {
+			t.Fatalf("Failed to create transfer: %v", err)
+		}
+	}
+
+	filter := TransferFilter{Sender: "0x742d35cc6634c0532925a3b8d4c9db96590c4c4c", Limit: 2}
+	page, err := repo.Search(context.Background(), filter)
+	if err != nil {
+		t.Fatalf("Failed to search transfers: %v", err)
+	}
+	if len(page.Transfers) != 2 || page.NextCursor == "" {
+		t.Fatalf("Expected a full first page with a cursor, got %d transfers", len(page.Transfers))
+	}
+
+	filter.Cursor = page.NextCursor
+	page, err = repo.Search(context.Background(), filter)
+	if err != nil {
+		t.Fatalf("Failed to search transfers: %v", err)
+	}
+	if len(page.Transfers) != 1 || page.NextCursor != "" {
+		t.Fatalf("Expected a final page with 1 transfer, got %d", len(page.Transfers))
+	}
+	if page.Transfers[0].ID != ids[0] {
+		t.Errorf("Expected oldest transfer %s last, got %s", ids[0], page.Transfers[0].ID)
+	}
+}
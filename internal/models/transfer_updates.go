@@ -0,0 +1,131 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"nexus-bridge/pkg/types"
+
+	"github.com/lib/pq"
+)
+
+// TransferUpdatesChannel is the NOTIFY channel transfer changes are published
+// on by the notify_transfers_update trigger
+const TransferUpdatesChannel = "transfer_updates"
+
+const (
+	// listenerMinReconnect and listenerMaxReconnect bound the backoff used
+	// when the listening connection drops
+	listenerMinReconnect = time.Second
+	listenerMaxReconnect = time.Minute
+	// listenerPingInterval is how often an idle listening connection is checked
+	listenerPingInterval = 90 * time.Second
+)
+
+// TransferUpdate is a change to a transfer's status, confirmations or
+// destination transaction
+type TransferUpdate struct {
+	TransferID        string               `json:"transfer_id"`
+	SourceChain       types.ChainID        `json:"source_chain"`
+	DestinationChain  types.ChainID        `json:"destination_chain"`
+	Sender            string               `json:"sender"`
+	Recipient         string               `json:"recipient"`
+	Status            types.TransferStatus `json:"status"`
+	Confirmations     uint64               `json:"confirmations"`
+	DestinationTxHash string               `json:"destination_tx_hash"`
+	UpdatedAt         time.Time            `json:"updated_at"`
+}
+
+// UpdateFromTransfer describes the current state of a transfer as an update
+func UpdateFromTransfer(transfer types.Transfer) TransferUpdate {
+	return TransferUpdate{
+		TransferID:        transfer.ID,
+		SourceChain:       transfer.SourceChain,
+		DestinationChain:  transfer.DestinationChain,
+		Sender:            transfer.Sender,
+		Recipient:         transfer.Recipient,
+		Status:            transfer.Status,
+		Confirmations:     transfer.Confirmations,
+		DestinationTxHash: transfer.DestinationTxHash,
+		UpdatedAt:         transfer.UpdatedAt,
+	}
+}
+
+// TransferUpdateHandler receives transfer updates. A nil update means the
+// listening connection was re-established and updates may have been missed.
+type TransferUpdateHandler func(update *TransferUpdate)
+
+// TransferUpdateListener receives the transfer updates written by any
+// process sharing the database, using LISTEN/NOTIFY rather than polling
+type TransferUpdateListener struct {
+	listener *pq.Listener
+}
+
+// NewTransferUpdateListener opens a dedicated listening connection
+func NewTransferUpdateListener(databaseURL string) (*TransferUpdateListener, error) {
+	listener := pq.NewListener(databaseURL, listenerMinReconnect, listenerMaxReconnect,
+		func(event pq.ListenerEventType, err error) {
+			if err != nil {
+				fmt.Printf("Error on transfer update listener: %v\n", err)
+			}
+		})
+
+	if err := listener.Listen(TransferUpdatesChannel); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to listen for transfer updates: %w", err)
+	}
+
+	return &TransferUpdateListener{listener: listener}, nil
+}
+
+// Run passes every update to the handler until the context ends
+func (l *TransferUpdateListener) Run(ctx context.Context, handler TransferUpdateHandler) error {
+	ticker := time.NewTicker(listenerPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case notification := <-l.listener.Notify:
+			// pq sends nil after reconnecting
+			if notification == nil {
+				handler(nil)
+				continue
+			}
+
+			update, err := parseTransferUpdate(notification.Extra)
+			if err != nil {
+				fmt.Printf("Error parsing transfer update: %v\n", err)
+				continue
+			}
+			handler(update)
+		case <-ticker.C:
+			if err := l.listener.Ping(); err != nil {
+				fmt.Printf("Error pinging transfer update listener: %v\n", err)
+			}
+		}
+	}
+}
+
+// Close closes the listening connection
+func (l *TransferUpdateListener) Close() error {
+	return l.listener.Close()
+}
+
+// parseTransferUpdate decodes a notification payload, stamping it with the
+// time it was received
+func parseTransferUpdate(payload string) (*TransferUpdate, error) {
+	var update TransferUpdate
+	if err := json.Unmarshal([]byte(payload), &update); err != nil {
+		return nil, fmt.Errorf("failed to decode transfer update: %w", err)
+	}
+	if update.TransferID == "" {
+		return nil, fmt.Errorf("transfer update has no transfer ID")
+	}
+
+	update.UpdatedAt = time.Now().UTC()
+	return &update, nil
+}
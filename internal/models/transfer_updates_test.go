@@ -0,0 +1,81 @@
+package models
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/internal/models/testutil"
+	"nexus-bridge/pkg/types"
+)
+
+func TestParseTransferUpdate(t *testing.T) {
+	update, err := parseTransferUpdate(`{
+		"transfer_id": "0xabc",
+		"source_chain": 1,
+		"destination_chain": 137,
+		"sender": "0x742d35Cc6634C0532925a3b8D4C9db96590C4C4C",
+		"recipient": "0x8ba1f109551bD432803012645Hac136c22C4C4C",
+		"status": "confirming",
+		"confirmations": 12,
+		"destination_tx_hash": ""
+	}`)
+	require.NoError(t, err)
+	assert.Equal(t, "0xabc", update.TransferID)
+	assert.Equal(t, types.ChainEthereum, update.SourceChain)
+	assert.Equal(t, types.ChainPolygon, update.DestinationChain)
+	assert.Equal(t, types.StatusConfirming, update.Status)
+	assert.Equal(t, uint64(12), update.Confirmations)
+	assert.False(t, update.UpdatedAt.IsZero())
+
+	_, err = parseTransferUpdate(`{"status": "confirming"}`)
+	assert.Error(t, err)
+
+	_, err = parseTransferUpdate(`not json`)
+	assert.Error(t, err)
+}
+
+func TestTransferUpdateListener(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	t.Cleanup(func() { testutil.CleanupTestDB(t, db) })
+
+	listener, err := NewTransferUpdateListener(testutil.DatabaseURL())
+	require.NoError(t, err)
+	defer listener.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	updates := make(chan TransferUpdate, 10)
+	go listener.Run(ctx, func(update *TransferUpdate) {
+		if update != nil {
+			updates <- *update
+		}
+	})
+
+	next := func() TransferUpdate {
+		select {
+		case update := <-updates:
+			return update
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for transfer update")
+			return TransferUpdate{}
+		}
+	}
+
+	store := NewStateManager(db)
+	transfer := contractTransfer(1)
+	mustRecord(t, store, transfer)
+
+	update := next()
+	assert.Equal(t, transfer.ID, update.TransferID)
+	assert.Equal(t, types.StatusPending, update.Status)
+
+	require.NoError(t, store.UpdateTransferStatus(ctx, transfer.ID, types.StatusConfirming))
+	update = next()
+	assert.Equal(t, types.StatusConfirming, update.Status)
+	assert.Equal(t, transfer.Sender, update.Sender)
+}
@@ -0,0 +1,267 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"nexus-bridge/pkg/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// OutboxStatus represents the lifecycle of a destination transaction
+type OutboxStatus string
+
+const (
+	// OutboxStatusPending is signed and persisted but not known to be broadcast
+	OutboxStatusPending OutboxStatus = "pending"
+	// OutboxStatusBroadcast has been accepted by a node
+	OutboxStatusBroadcast OutboxStatus = "broadcast"
+	// OutboxStatusConfirmed was mined successfully
+	OutboxStatusConfirmed OutboxStatus = "confirmed"
+	// OutboxStatusReverted was mined but reverted
+	OutboxStatusReverted OutboxStatus = "reverted"
+	// OutboxStatusDropped will never be mined because its nonce was consumed
+	OutboxStatusDropped OutboxStatus = "dropped"
+)
+
+// OutboxEntry is a signed destination-chain transaction for a transfer
+type OutboxEntry struct {
+	ID          int64         `json:"id" db:"id"`
+	TransferID  string        `json:"transfer_id" db:"transfer_id"`
+	ChainID     types.ChainID `json:"chain_id" db:"chain_id"`
+	FromAddress string        `json:"from_address" db:"from_address"`
+	Nonce       uint64        `json:"nonce" db:"nonce"`
+	TxHash      string        `json:"tx_hash" db:"tx_hash"`
+	RawTx       []byte        `json:"raw_tx" db:"raw_tx"`
+	Status      OutboxStatus  `json:"status" db:"status"`
+	Attempts    int           `json:"attempts" db:"attempts"`
+	LastError   string        `json:"last_error" db:"last_error"`
+	BlockNumber uint64        `json:"block_number" db:"block_number"`
+	CreatedAt   time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at" db:"updated_at"`
+}
+
+// IsLive reports whether the entry still occupies its nonce and transfer
+func (e *OutboxEntry) IsLive() bool {
+	return e.Status == OutboxStatusPending || e.Status == OutboxStatusBroadcast || e.Status == OutboxStatusConfirmed
+}
+
+// SignedTransaction returns the entry as a signed transaction ready for broadcast
+func (e *OutboxEntry) SignedTransaction() types.SignedTransaction {
+	return types.SignedTransaction{
+		ChainID: e.ChainID,
+		From:    e.FromAddress,
+		Nonce:   e.Nonce,
+		TxHash:  e.TxHash,
+		Raw:     e.RawTx,
+	}
+}
+
+// TxOutbox persists signed destination transactions before they are broadcast
+type TxOutbox interface {
+	// Enqueue persists a signed transaction for a transfer. It fails if the
+	// transfer already has a live transaction or the nonce is in use.
+	Enqueue(ctx context.Context, transferID string, signed types.SignedTransaction) (*OutboxEntry, error)
+
+	// GetLiveByTransfer returns the live transaction for a transfer, or nil
+	GetLiveByTransfer(ctx context.Context, transferID string) (*OutboxEntry, error)
+
+	// NextNonce returns one past the highest live nonce for a sender, or zero
+	NextNonce(ctx context.Context, chainID types.ChainID, from string) (uint64, error)
+
+	// ListOutstanding returns pending and broadcast entries, oldest first
+	ListOutstanding(ctx context.Context, chainID types.ChainID) ([]OutboxEntry, error)
+
+	// MarkBroadcast records a broadcast attempt and its error, if any
+	MarkBroadcast(ctx context.Context, id int64, broadcastErr error) error
+
+	// MarkMined records the mined result of a transaction
+	MarkMined(ctx context.Context, id int64, result types.TxResult) error
+
+	// MarkDropped records that a transaction's nonce was consumed by another one
+	MarkDropped(ctx context.Context, id int64) error
+}
+
+// TxOutboxRepository handles database operations for the transaction outbox
+type TxOutboxRepository struct {
+	db *sqlx.DB
+}
+
+// NewTxOutboxRepository creates a new transaction outbox repository
+func NewTxOutboxRepository(db *sqlx.DB) *TxOutboxRepository {
+	return &TxOutboxRepository{db: db}
+}
+
+var _ TxOutbox = (*TxOutboxRepository)(nil)
+
+// Enqueue inserts a signed transaction into the outbox
+func (r *TxOutboxRepository) Enqueue(ctx context.Context, transferID string, signed types.SignedTransaction) (*OutboxEntry, error) {
+	if err := validateSignedTransaction(transferID, signed); err != nil {
+		return nil, err
+	}
+
+	entry := &OutboxEntry{
+		TransferID:  transferID,
+		ChainID:     signed.ChainID,
+		FromAddress: signed.From,
+		Nonce:       signed.Nonce,
+		TxHash:      signed.TxHash,
+		RawTx:       signed.Raw,
+		Status:      OutboxStatusPending,
+	}
+
+	query := `
+		INSERT INTO tx_outbox (transfer_id, chain_id, from_address, nonce, tx_hash, raw_tx, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRowxContext(ctx, query,
+		entry.TransferID, entry.ChainID, entry.FromAddress, entry.Nonce,
+		entry.TxHash, entry.RawTx, entry.Status,
+	).Scan(&entry.ID, &entry.CreatedAt, &entry.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to enqueue transaction: %w", err)
+	}
+
+	return entry, nil
+}
+
+// GetLiveByTransfer returns the live transaction for a transfer, or nil
+func (r *TxOutboxRepository) GetLiveByTransfer(ctx context.Context, transferID string) (*OutboxEntry, error) {
+	var entry OutboxEntry
+	query := `
+		SELECT id, transfer_id, chain_id, from_address, nonce, tx_hash, raw_tx, status,
+			   attempts, COALESCE(last_error, '') AS last_error,
+			   COALESCE(block_number, 0) AS block_number, created_at, updated_at
+		FROM tx_outbox
+		WHERE transfer_id = $1 AND status IN ($2, $3, $4)`
+
+	err := r.db.GetContext(ctx, &entry, query, transferID,
+		OutboxStatusPending, OutboxStatusBroadcast, OutboxStatusConfirmed)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get outbox entry: %w", err)
+	}
+
+	return &entry, nil
+}
+
+// NextNonce returns one past the highest live nonce for a sender
+func (r *TxOutboxRepository) NextNonce(ctx context.Context, chainID types.ChainID, from string) (uint64, error) {
+	var next uint64
+	query := `
+		SELECT COALESCE(MAX(nonce) + 1, 0)
+		FROM tx_outbox
+		WHERE chain_id = $1 AND from_address = $2 AND status <> $3`
+
+	err := r.db.GetContext(ctx, &next, query, chainID, from, OutboxStatusDropped)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get next nonce: %w", err)
+	}
+
+	return next, nil
+}
+
+// ListOutstanding returns pending and broadcast entries for a chain, oldest first
+func (r *TxOutboxRepository) ListOutstanding(ctx context.Context, chainID types.ChainID) ([]OutboxEntry, error) {
+	var entries []OutboxEntry
+	query := `
+		SELECT id, transfer_id, chain_id, from_address, nonce, tx_hash, raw_tx, status,
+			   attempts, COALESCE(last_error, '') AS last_error,
+			   COALESCE(block_number, 0) AS block_number, created_at, updated_at
+		FROM tx_outbox
+		WHERE chain_id = $1 AND status IN ($2, $3)
+		ORDER BY nonce ASC, id ASC`
+
+	err := r.db.SelectContext(ctx, &entries, query, chainID, OutboxStatusPending, OutboxStatusBroadcast)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list outstanding transactions: %w", err)
+	}
+
+	return entries, nil
+}
+
+// MarkBroadcast records a broadcast attempt
+func (r *TxOutboxRepository) MarkBroadcast(ctx context.Context, id int64, broadcastErr error) error {
+	if broadcastErr != nil {
+		query := `
+			UPDATE tx_outbox
+			SET attempts = attempts + 1, last_error = $1
+			WHERE id = $2 AND status IN ($3, $4)`
+		return r.update(ctx, "record broadcast failure", query,
+			broadcastErr.Error(), id, OutboxStatusPending, OutboxStatusBroadcast)
+	}
+
+	query := `
+		UPDATE tx_outbox
+		SET status = $1, attempts = attempts + 1, last_error = NULL
+		WHERE id = $2 AND status IN ($3, $4)`
+	return r.update(ctx, "mark transaction broadcast", query,
+		OutboxStatusBroadcast, id, OutboxStatusPending, OutboxStatusBroadcast)
+}
+
+// MarkMined records the mined result of a transaction
+func (r *TxOutboxRepository) MarkMined(ctx context.Context, id int64, result types.TxResult) error {
+	status := OutboxStatusConfirmed
+	if !result.Status {
+		status = OutboxStatusReverted
+	}
+
+	query := `
+		UPDATE tx_outbox
+		SET status = $1, block_number = $2
+		WHERE id = $3 AND status IN ($4, $5)`
+	return r.update(ctx, "mark transaction mined", query,
+		status, result.BlockNumber, id, OutboxStatusPending, OutboxStatusBroadcast)
+}
+
+// MarkDropped records that a transaction will never be mined
+func (r *TxOutboxRepository) MarkDropped(ctx context.Context, id int64) error {
+	query := `
+		UPDATE tx_outbox
+		SET status = $1
+		WHERE id = $2 AND status IN ($3, $4)`
+	return r.update(ctx, "mark transaction dropped", query,
+		OutboxStatusDropped, id, OutboxStatusPending, OutboxStatusBroadcast)
+}
+
+// update runs a status update that must affect exactly one outstanding entry
+func (r *TxOutboxRepository) update(ctx context.Context, action, query string, args ...interface{}) error {
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to %s: %w", action, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("failed to %s: outstanding transaction not found", action)
+	}
+
+	return nil
+}
+
+// validateSignedTransaction validates a transaction before it is enqueued
+func validateSignedTransaction(transferID string, signed types.SignedTransaction) error {
+	if transferID == "" {
+		return fmt.Errorf("transfer ID is required")
+	}
+	if signed.From == "" {
+		return fmt.Errorf("sender address is required")
+	}
+	if signed.TxHash == "" {
+		return fmt.Errorf("transaction hash is required")
+	}
+	if len(signed.Raw) == 0 {
+		return fmt.Errorf("raw transaction is required")
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"nexus-bridge/internal/models/testutil"
+	"nexus-bridge/pkg/types"
+)
+
+func TestTxOutboxRepository_Contract(t *testing.T) {
+	runTxOutboxContract(t, func(t *testing.T) TxOutbox {
+		db := testutil.SetupTestDB(t)
+		t.Cleanup(func() { testutil.CleanupTestDB(t, db) })
+
+		// Outbox entries reference transfers
+		sm := NewStateManager(db)
+		for n := 1; n <= 3; n++ {
+			if err := sm.RecordTransfer(context.Background(), contractTransfer(n)); err != nil {
+				t.Fatalf("Failed to record transfer: %v", err)
+			}
+		}
+		return NewTxOutboxRepository(db)
+	})
+}
+
+func TestMemoryTxOutbox_Contract(t *testing.T) {
+	runTxOutboxContract(t, func(t *testing.T) TxOutbox {
+		return NewMemoryTxOutbox()
+	})
+}
+
+func signedTx(nonce uint64) types.SignedTransaction {
+	hash := fmt.Sprintf("0x%064x", nonce+100)
+	return types.SignedTransaction{
+		ChainID: types.ChainPolygon,
+		From:    "0x742d35Cc6634C0532925a3b8D4C9db96590C4C4C",
+		Nonce:   nonce,
+		TxHash:  hash,
+		Raw:     []byte(hash),
+	}
+}
+
+func runTxOutboxContract(t *testing.T, newOutbox func(t *testing.T) TxOutbox) {
+	ctx := context.Background()
+
+	t.Run("EnqueueAndGetLive", func(t *testing.T) {
+		outbox := newOutbox(t)
+		transferID := contractTransfer(1).ID
+
+		entry, err := outbox.Enqueue(ctx, transferID, signedTx(0))
+		if err != nil {
+			t.Fatalf("Failed to enqueue: %v", err)
+		}
+		if entry.ID == 0 || entry.Status != OutboxStatusPending {
+			t.Errorf("Expected pending entry with ID, got %+v", entry)
+		}
+
+		live, err := outbox.GetLiveByTransfer(ctx, transferID)
+		if err != nil || live == nil || live.TxHash != entry.TxHash {
+			t.Fatalf("Expected live entry %s, got %+v/%v", entry.TxHash, live, err)
+		}
+
+		missing, err := outbox.GetLiveByTransfer(ctx, contractTransfer(2).ID)
+		if err != nil || missing != nil {
+			t.Errorf("Expected no live entry, got %+v/%v", missing, err)
+		}
+	})
+
+	t.Run("OneLiveTransactionPerTransfer", func(t *testing.T) {
+		outbox := newOutbox(t)
+		transferID := contractTransfer(1).ID
+
+		if _, err := outbox.Enqueue(ctx, transferID, signedTx(0)); err != nil {
+			t.Fatalf("Failed to enqueue: %v", err)
+		}
+		if _, err := outbox.Enqueue(ctx, transferID, signedTx(1)); err == nil {
+			t.Error("Expected error for second live transaction")
+		}
+	})
+
+	t.Run("NonceUniquePerSender", func(t *testing.T) {
+		outbox := newOutbox(t)
+
+		if _, err := outbox.Enqueue(ctx, contractTransfer(1).ID, signedTx(0)); err != nil {
+			t.Fatalf("Failed to enqueue: %v", err)
+		}
+		reused := signedTx(0)
+		reused.TxHash = fmt.Sprintf("0x%064x", 999)
+		if _, err := outbox.Enqueue(ctx, contractTransfer(2).ID, reused); err == nil {
+			t.Error("Expected error for reused nonce")
+		}
+	})
+
+	t.Run("DroppedEntriesFreeNonceAndTransfer", func(t *testing.T) {
+		outbox := newOutbox(t)
+		transferID := contractTransfer(1).ID
+
+		entry, err := outbox.Enqueue(ctx, transferID, signedTx(4))
+		if err != nil {
+			t.Fatalf("Failed to enqueue: %v", err)
+		}
+		next, err := outbox.NextNonce(ctx, types.ChainPolygon, entry.FromAddress)
+		if err != nil || next != 5 {
+			t.Errorf("Expected next nonce 5, got %d/%v", next, err)
+		}
+
+		if err := outbox.MarkDropped(ctx, entry.ID); err != nil {
+			t.Fatalf("Failed to mark dropped: %v", err)
+		}
+		next, err = outbox.NextNonce(ctx, types.ChainPolygon, entry.FromAddress)
+		if err != nil || next != 0 {
+			t.Errorf("Expected next nonce 0 after drop, got %d/%v", next, err)
+		}
+
+		replacement := signedTx(4)
+		replacement.TxHash = fmt.Sprintf("0x%064x", 999)
+		if _, err := outbox.Enqueue(ctx, transferID, replacement); err != nil {
+			t.Errorf("Expected replacement to be accepted, got %v", err)
+		}
+	})
+
+	t.Run("Lifecycle", func(t *testing.T) {
+		outbox := newOutbox(t)
+		first, err := outbox.Enqueue(ctx, contractTransfer(1).ID, signedTx(1))
+		if err != nil {
+			t.Fatalf("Failed to enqueue: %v", err)
+		}
+		second, err := outbox.Enqueue(ctx, contractTransfer(2).ID, signedTx(0))
+		if err != nil {
+			t.Fatalf("Failed to enqueue: %v", err)
+		}
+
+		if err := outbox.MarkBroadcast(ctx, first.ID, errors.New("connection refused")); err != nil {
+			t.Fatalf("Failed to record broadcast failure: %v", err)
+		}
+		if err := outbox.MarkBroadcast(ctx, second.ID, nil); err != nil {
+			t.Fatalf("Failed to mark broadcast: %v", err)
+		}
+
+		outstanding, err := outbox.ListOutstanding(ctx, types.ChainPolygon)
+		if err != nil {
+			t.Fatalf("Failed to list outstanding: %v", err)
+		}
+		if len(outstanding) != 2 || outstanding[0].Nonce != 0 {
+			t.Fatalf("Expected 2 outstanding entries ordered by nonce, got %+v", outstanding)
+		}
+		if outstanding[0].Status != OutboxStatusBroadcast || outstanding[1].Status != OutboxStatusPending {
+			t.Errorf("Unexpected statuses %s/%s", outstanding[0].Status, outstanding[1].Status)
+		}
+		if outstanding[1].LastError == "" || outstanding[1].Attempts != 1 {
+			t.Errorf("Expected broadcast failure recorded, got %+v", outstanding[1])
+		}
+
+		if err := outbox.MarkMined(ctx, second.ID, types.TxResult{BlockNumber: 10, Status: true}); err != nil {
+			t.Fatalf("Failed to mark mined: %v", err)
+		}
+		if err := outbox.MarkMined(ctx, first.ID, types.TxResult{BlockNumber: 11, Status: false}); err != nil {
+			t.Fatalf("Failed to mark mined: %v", err)
+		}
+
+		outstanding, err = outbox.ListOutstanding(ctx, types.ChainPolygon)
+		if err != nil || len(outstanding) != 0 {
+			t.Errorf("Expected no outstanding entries, got %d/%v", len(outstanding), err)
+		}
+
+		// A reverted transaction no longer blocks the transfer
+		live, err := outbox.GetLiveByTransfer(ctx, contractTransfer(1).ID)
+		if err != nil || live != nil {
+			t.Errorf("Expected reverted entry not to be live, got %+v/%v", live, err)
+		}
+
+		if err := outbox.MarkDropped(ctx, second.ID); err == nil {
+			t.Error("Expected error updating a settled entry")
+		}
+	})
+
+	t.Run("RejectsIncompleteTransactions", func(t *testing.T) {
+		outbox := newOutbox(t)
+		incomplete := signedTx(0)
+		incomplete.Raw = nil
+		if _, err := outbox.Enqueue(ctx, contractTransfer(1).ID, incomplete); err == nil {
+			t.Error("Expected error for missing raw transaction")
+		}
+	})
+}
@@ -0,0 +1,438 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"nexus-bridge/pkg/types"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrWebhookNotFound is returned when a webhook does not exist
+	ErrWebhookNotFound = errors.New("webhook not found")
+	// ErrDeliveryNotFound is returned when a webhook delivery does not exist
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+)
+
+// DeliveryStatus represents the lifecycle of a webhook delivery
+type DeliveryStatus string
+
+const (
+	// DeliveryPending is waiting for its next attempt
+	DeliveryPending DeliveryStatus = "pending"
+	// DeliveryDelivered was accepted by the webhook's endpoint
+	DeliveryDelivered DeliveryStatus = "delivered"
+	// DeliveryDead ran out of attempts and will only be retried by a replay
+	DeliveryDead DeliveryStatus = "dead"
+)
+
+// IsValid reports whether the status is one of the known delivery statuses
+func (s DeliveryStatus) IsValid() bool {
+	return s == DeliveryPending || s == DeliveryDelivered || s == DeliveryDead
+}
+
+// TransferEvent names the webhook event sent when a transfer enters a status
+func TransferEvent(status types.TransferStatus) string {
+	return "transfer." + string(status)
+}
+
+// Webhook is an endpoint notified of the lifecycle events of transfers in
+// its scope. Empty scope fields match every transfer, and an empty event
+// list matches every event. Owner is the integrator managing the webhook,
+// and is empty for webhooks registered by operators.
+type Webhook struct {
+	ID               int            `json:"id" db:"id"`
+	URL              string         `json:"url" db:"url"`
+	Secret           string         `json:"-" db:"secret"`
+	Address          string         `json:"address,omitempty" db:"address"`
+	Token            string         `json:"token,omitempty" db:"token"`
+	SourceChain      types.ChainID  `json:"source_chain,omitempty" db:"source_chain"`
+	DestinationChain types.ChainID  `json:"destination_chain,omitempty" db:"destination_chain"`
+	Events           pq.StringArray `json:"events" db:"events"`
+	Enabled          bool           `json:"enabled" db:"enabled"`
+	CreatedBy        string         `json:"created_by" db:"created_by"`
+	Owner            string         `json:"owner,omitempty" db:"owner"`
+	CreatedAt        time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at" db:"updated_at"`
+}
+
+// Validate validates a webhook before it is created
+func (w *Webhook) Validate() error {
+	endpoint, err := url.Parse(w.URL)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return fmt.Errorf("webhook URL must be an absolute http or https URL")
+	}
+	if w.Secret == "" {
+		return fmt.Errorf("webhook secret is required")
+	}
+	if w.SourceChain != 0 && w.SourceChain == w.DestinationChain {
+		return fmt.Errorf("source and destination chains must be different")
+	}
+	for _, event := range w.Events {
+		status, found := strings.CutPrefix(event, "transfer.")
+		if !found || !types.TransferStatus(status).IsValid() {
+			return fmt.Errorf("unknown webhook event: %s", event)
+		}
+	}
+	return nil
+}
+
+// Matches reports whether a transfer event is in the webhook's scope. It
+// mirrors the matching done by the enqueue_webhook_deliveries trigger.
+func (w *Webhook) Matches(transfer types.Transfer, event string) bool {
+	if !w.Enabled {
+		return false
+	}
+	if w.Address != "" && !strings.EqualFold(w.Address, transfer.Sender) && !strings.EqualFold(w.Address, transfer.Recipient) {
+		return false
+	}
+	if w.Token != "" && !strings.EqualFold(w.Token, transfer.Token) {
+		return false
+	}
+	if w.SourceChain != 0 && w.SourceChain != transfer.SourceChain {
+		return false
+	}
+	if w.DestinationChain != 0 && w.DestinationChain != transfer.DestinationChain {
+		return false
+	}
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, subscribed := range w.Events {
+		if subscribed == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery is one event queued for delivery to a webhook
+type WebhookDelivery struct {
+	ID             int64           `json:"id" db:"id"`
+	WebhookID      int             `json:"webhook_id" db:"webhook_id"`
+	TransferID     string          `json:"transfer_id" db:"transfer_id"`
+	EventType      string          `json:"event_type" db:"event_type"`
+	Payload        json.RawMessage `json:"payload" db:"payload"`
+	Status         DeliveryStatus  `json:"status" db:"status"`
+	Attempts       int             `json:"attempts" db:"attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at" db:"next_attempt_at"`
+	LastError      string          `json:"last_error,omitempty" db:"last_error"`
+	LastStatusCode int             `json:"last_status_code,omitempty" db:"last_status_code"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty" db:"delivered_at"`
+	CreatedAt      time.Time       `json:"created_at" db:"created_at"`
+}
+
+// webhookTransfer is the state of a transfer captured with a webhook event.
+// Its encoding matches the object built by the enqueue_webhook_deliveries
+// trigger.
+type webhookTransfer struct {
+	ID                string               `json:"id"`
+	SourceChain       types.ChainID        `json:"source_chain"`
+	DestinationChain  types.ChainID        `json:"destination_chain"`
+	Token             string               `json:"token"`
+	Amount            *types.BigInt        `json:"amount"`
+	Sender            string               `json:"sender"`
+	Recipient         string               `json:"recipient"`
+	Status            types.TransferStatus `json:"status"`
+	SourceTxHash      string               `json:"source_tx_hash"`
+	DestinationTxHash string               `json:"destination_tx_hash"`
+	BlockNumber       uint64               `json:"block_number"`
+	Confirmations     uint64               `json:"confirmations"`
+	Fee               *types.BigInt        `json:"fee"`
+}
+
+// WebhookStore persists webhooks and their delivery queue. Deliveries are
+// queued by the store itself whenever a transfer is created or changes
+// status.
+type WebhookStore interface {
+	CreateWebhook(ctx context.Context, webhook *Webhook, audit AuditFunc) error
+	GetWebhook(ctx context.Context, id int) (*Webhook, error)
+	ListWebhooks(ctx context.Context) ([]Webhook, error)
+	DeleteWebhook(ctx context.Context, id int, audit AuditFunc) error
+
+	// ClaimDeliveries returns up to limit due pending deliveries of enabled
+	// webhooks, oldest first, and holds them for lease so concurrent workers
+	// skip them
+	ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]WebhookDelivery, error)
+
+	// MarkDelivered records a successful attempt
+	MarkDelivered(ctx context.Context, id int64, statusCode int) error
+
+	// MarkDeliveryFailed records a failed attempt. The delivery is retried at
+	// retryAt, or moved to the dead-letter state if retryAt is zero.
+	MarkDeliveryFailed(ctx context.Context, id int64, statusCode int, reason string, retryAt time.Time) error
+
+	// ListDeliveries returns a webhook's most recent deliveries, newest
+	// first, optionally only those in a status
+	ListDeliveries(ctx context.Context, webhookID int, status DeliveryStatus, limit int) ([]WebhookDelivery, error)
+
+	// ReplayDelivery queues a delivery to be sent again immediately with a
+	// fresh set of attempts
+	ReplayDelivery(ctx context.Context, webhookID int, id int64, audit AuditFunc) (*WebhookDelivery, error)
+}
+
+// WebhookRepository handles database operations for webhooks and deliveries
+type WebhookRepository struct {
+	db *sqlx.DB
+}
+
+// NewWebhookRepository creates a new webhook repository
+func NewWebhookRepository(db *sqlx.DB) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+var _ WebhookStore = (*WebhookRepository)(nil)
+
+const webhookColumns = `
+	id, url, secret, COALESCE(address, '') AS address, COALESCE(token, '') AS token,
+	COALESCE(source_chain, 0) AS source_chain, COALESCE(destination_chain, 0) AS destination_chain,
+	events, enabled, COALESCE(created_by, '') AS created_by, COALESCE(owner, '') AS owner,
+	created_at, updated_at`
+
+const deliveryColumns = `
+	id, webhook_id, transfer_id, event_type, payload, status, attempts, next_attempt_at,
+	COALESCE(last_error, '') AS last_error, COALESCE(last_status_code, 0) AS last_status_code,
+	delivered_at, created_at`
+
+// CreateWebhook inserts a new webhook, recording its audit entry in the same
+// transaction
+func (r *WebhookRepository) CreateWebhook(ctx context.Context, webhook *Webhook, audit AuditFunc) error {
+	if err := webhook.Validate(); err != nil {
+		return fmt.Errorf("webhook validation failed: %w", err)
+	}
+	if webhook.Events == nil {
+		webhook.Events = pq.StringArray{}
+	}
+
+	query := `
+		INSERT INTO webhooks (url, secret, address, token, source_chain, destination_chain, events, enabled, created_by, owner)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, created_at, updated_at`
+
+	return inAuditedTx(ctx, r.db, audit, func(tx *sqlx.Tx) (interface{}, error) {
+		err := tx.QueryRowxContext(ctx, query,
+			webhook.URL, webhook.Secret, nullableString(webhook.Address), nullableString(webhook.Token),
+			nullableChain(webhook.SourceChain), nullableChain(webhook.DestinationChain),
+			webhook.Events, webhook.Enabled, nullableString(webhook.CreatedBy), nullableString(webhook.Owner),
+		).Scan(&webhook.ID, &webhook.CreatedAt, &webhook.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create webhook: %w", err)
+		}
+		return webhook, nil
+	})
+}
+
+// GetWebhook returns a webhook by ID
+func (r *WebhookRepository) GetWebhook(ctx context.Context, id int) (*Webhook, error) {
+	var webhook Webhook
+	query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE id = $1`
+
+	if err := r.db.GetContext(ctx, &webhook, query, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: %d", ErrWebhookNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+
+	return &webhook, nil
+}
+
+// ListWebhooks returns every webhook in creation order
+func (r *WebhookRepository) ListWebhooks(ctx context.Context) ([]Webhook, error) {
+	webhooks := []Webhook{}
+	query := `SELECT ` + webhookColumns + ` FROM webhooks ORDER BY id ASC`
+
+	if err := r.db.SelectContext(ctx, &webhooks, query); err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+// DeleteWebhook removes a webhook and its deliveries, recording its audit
+// entry in the same transaction
+func (r *WebhookRepository) DeleteWebhook(ctx context.Context, id int, audit AuditFunc) error {
+	return inAuditedTx(ctx, r.db, audit, func(tx *sqlx.Tx) (interface{}, error) {
+		result, err := tx.ExecContext(ctx, `DELETE FROM webhooks WHERE id = $1`, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete webhook: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			return nil, fmt.Errorf("%w: %d", ErrWebhookNotFound, id)
+		}
+
+		return nil, nil
+	})
+}
+
+// ClaimDeliveries leases due deliveries of enabled webhooks by pushing back
+// their next attempt. Rows locked by a concurrent claim are skipped.
+func (r *WebhookRepository) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]WebhookDelivery, error) {
+	if lease <= 0 {
+		return nil, fmt.Errorf("lease duration must be positive")
+	}
+	if limit <= 0 {
+		return []WebhookDelivery{}, nil
+	}
+
+	now := time.Now()
+	var deliveries []WebhookDelivery
+	query := `
+		UPDATE webhook_deliveries
+		SET next_attempt_at = $1
+		WHERE id IN (
+			SELECT d.id
+			FROM webhook_deliveries d
+			JOIN webhooks w ON w.id = d.webhook_id
+			WHERE d.status = $2 AND d.next_attempt_at <= $3 AND w.enabled
+			ORDER BY d.next_attempt_at ASC, d.id ASC
+			LIMIT $4
+			FOR UPDATE OF d SKIP LOCKED
+		)
+		RETURNING ` + deliveryColumns
+
+	err := r.db.SelectContext(ctx, &deliveries, query, now.Add(lease), DeliveryPending, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+
+	// RETURNING does not preserve the subquery order
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].ID < deliveries[j].ID })
+
+	return deliveries, nil
+}
+
+// MarkDelivered records a successful attempt
+func (r *WebhookRepository) MarkDelivered(ctx context.Context, id int64, statusCode int) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $1, attempts = attempts + 1, last_status_code = $2, last_error = NULL,
+			delivered_at = $3
+		WHERE id = $4 AND status = $5`
+
+	return r.updateDelivery(ctx, "mark webhook delivered", query,
+		DeliveryDelivered, statusCode, time.Now(), id, DeliveryPending)
+}
+
+// MarkDeliveryFailed records a failed attempt and schedules a retry, or
+// dead-letters the delivery if retryAt is zero
+func (r *WebhookRepository) MarkDeliveryFailed(ctx context.Context, id int64, statusCode int, reason string, retryAt time.Time) error {
+	status := DeliveryPending
+	if retryAt.IsZero() {
+		status = DeliveryDead
+		retryAt = time.Now()
+	}
+
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $1, attempts = attempts + 1, last_status_code = $2, last_error = $3,
+			next_attempt_at = $4
+		WHERE id = $5 AND status = $6`
+
+	return r.updateDelivery(ctx, "record webhook failure", query,
+		status, nullableStatusCode(statusCode), reason, retryAt, id, DeliveryPending)
+}
+
+// ListDeliveries returns a webhook's most recent deliveries, newest first
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, webhookID int, status DeliveryStatus, limit int) ([]WebhookDelivery, error) {
+	deliveries := []WebhookDelivery{}
+	query := `
+		SELECT ` + deliveryColumns + `
+		FROM webhook_deliveries
+		WHERE webhook_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY id DESC
+		LIMIT $3`
+
+	if err := r.db.SelectContext(ctx, &deliveries, query, webhookID, status, limit); err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// ReplayDelivery resets a delivery of a webhook so it is sent again at once,
+// recording its audit entry in the same transaction
+func (r *WebhookRepository) ReplayDelivery(ctx context.Context, webhookID int, id int64, audit AuditFunc) (*WebhookDelivery, error) {
+	var delivery WebhookDelivery
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $1, attempts = 0, next_attempt_at = $2, last_error = NULL,
+			last_status_code = NULL, delivered_at = NULL
+		WHERE id = $3 AND webhook_id = $4
+		RETURNING ` + deliveryColumns
+
+	err := inAuditedTx(ctx, r.db, audit, func(tx *sqlx.Tx) (interface{}, error) {
+		err := tx.GetContext(ctx, &delivery, query, DeliveryPending, time.Now(), id, webhookID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, fmt.Errorf("%w: %d", ErrDeliveryNotFound, id)
+			}
+			return nil, fmt.Errorf("failed to replay webhook delivery: %w", err)
+		}
+		return &delivery, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+// updateDelivery runs an update that must affect exactly one pending delivery
+func (r *WebhookRepository) updateDelivery(ctx context.Context, action, query string, args ...interface{}) error {
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to %s: %w", action, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("failed to %s: %w", action, ErrDeliveryNotFound)
+	}
+
+	return nil
+}
+
+// nullableString stores empty strings as SQL NULL
+func nullableString(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+// nullableChain stores an unset chain as SQL NULL
+func nullableChain(chainID types.ChainID) interface{} {
+	if chainID == 0 {
+		return nil
+	}
+	return chainID
+}
+
+// nullableStatusCode stores a missing HTTP status code as SQL NULL
+func nullableStatusCode(statusCode int) interface{} {
+	if statusCode == 0 {
+		return nil
+	}
+	return statusCode
+}
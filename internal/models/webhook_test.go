@@ -0,0 +1,264 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"nexus-bridge/internal/models/testutil"
+	"nexus-bridge/pkg/types"
+)
+
+// webhookStateStore is a Store that also queues webhook deliveries
+type webhookStateStore interface {
+	Store
+	WebhookStore
+}
+
+func TestStateManager_WebhookContract(t *testing.T) {
+	runWebhookContract(t, func(t *testing.T) webhookStateStore {
+		db := testutil.SetupTestDB(t)
+		t.Cleanup(func() { testutil.CleanupTestDB(t, db) })
+		return NewStateManager(db)
+	})
+}
+
+func TestMemoryStateManager_WebhookContract(t *testing.T) {
+	runWebhookContract(t, func(t *testing.T) webhookStateStore {
+		return NewMemoryStateManager()
+	})
+}
+
+func TestWebhook_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		webhook Webhook
+		valid   bool
+	}{
+		{"Valid", Webhook{URL: "https://example.com/hook", Secret: "s", Events: []string{"transfer.completed"}}, true},
+		{"RelativeURL", Webhook{URL: "/hook", Secret: "s"}, false},
+		{"UnsupportedScheme", Webhook{URL: "ftp://example.com", Secret: "s"}, false},
+		{"MissingSecret", Webhook{URL: "https://example.com"}, false},
+		{"SameChains", Webhook{URL: "https://example.com", Secret: "s", SourceChain: 1, DestinationChain: 1}, false},
+		{"UnknownEvent", Webhook{URL: "https://example.com", Secret: "s", Events: []string{"transfer.done"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.webhook.Validate()
+			if tt.valid && err != nil {
+				t.Errorf("Expected valid webhook, got %v", err)
+			}
+			if !tt.valid && err == nil {
+				t.Error("Expected validation error")
+			}
+		})
+	}
+}
+
+func mustCreateWebhook(t *testing.T, store WebhookStore, webhook Webhook) *Webhook {
+	t.Helper()
+	if webhook.URL == "" {
+		webhook.URL = "https://example.com/hook"
+	}
+	webhook.Secret = "secret"
+	if err := store.CreateWebhook(context.Background(), &webhook, nil); err != nil {
+		t.Fatalf("Failed to create webhook: %v", err)
+	}
+	return &webhook
+}
+
+func mustClaim(t *testing.T, store WebhookStore, limit int) []WebhookDelivery {
+	t.Helper()
+	deliveries, err := store.ClaimDeliveries(context.Background(), limit, time.Minute)
+	if err != nil {
+		t.Fatalf("Failed to claim deliveries: %v", err)
+	}
+	return deliveries
+}
+
+func runWebhookContract(t *testing.T, newStore func(t *testing.T) webhookStateStore) {
+	ctx := context.Background()
+
+	t.Run("CreateGetListDelete", func(t *testing.T) {
+		store := newStore(t)
+
+		invalid := Webhook{URL: "not a url", Secret: "secret", Enabled: true}
+		if err := store.CreateWebhook(ctx, &invalid, nil); err == nil {
+			t.Error("Expected invalid webhook to be rejected")
+		}
+
+		first := mustCreateWebhook(t, store, Webhook{Address: contractTransfer(1).Recipient, Enabled: true})
+		second := mustCreateWebhook(t, store, Webhook{
+			Token:            contractTransfer(1).Token,
+			SourceChain:      types.ChainEthereum,
+			DestinationChain: types.ChainPolygon,
+			Events:           []string{TransferEvent(types.StatusCompleted)},
+			Enabled:          true,
+			CreatedBy:        "alice",
+			Owner:            "key:acme",
+		})
+		if first.ID == 0 || second.ID == first.ID {
+			t.Fatalf("Expected distinct webhook IDs, got %d and %d", first.ID, second.ID)
+		}
+
+		got, err := store.GetWebhook(ctx, second.ID)
+		if err != nil {
+			t.Fatalf("Failed to get webhook: %v", err)
+		}
+		if got.Secret != "secret" || got.SourceChain != types.ChainEthereum || got.CreatedBy != "alice" ||
+			got.Owner != "key:acme" || len(got.Events) != 1 || got.Events[0] != "transfer.completed" {
+			t.Errorf("Unexpected webhook: %+v", got)
+		}
+
+		all, err := store.ListWebhooks(ctx)
+		if err != nil {
+			t.Fatalf("Failed to list webhooks: %v", err)
+		}
+		if len(all) != 2 || all[0].ID != first.ID || all[1].ID != second.ID {
+			t.Errorf("Expected webhooks in creation order, got %+v", all)
+		}
+		if all[0].Events == nil || len(all[0].Events) != 0 {
+			t.Errorf("Expected an empty event list, got %#v", all[0].Events)
+		}
+
+		if err := store.DeleteWebhook(ctx, first.ID, nil); err != nil {
+			t.Fatalf("Failed to delete webhook: %v", err)
+		}
+		if _, err := store.GetWebhook(ctx, first.ID); !errors.Is(err, ErrWebhookNotFound) {
+			t.Errorf("Expected ErrWebhookNotFound after delete, got %v", err)
+		}
+		if err := store.DeleteWebhook(ctx, first.ID, nil); !errors.Is(err, ErrWebhookNotFound) {
+			t.Errorf("Expected ErrWebhookNotFound deleting twice, got %v", err)
+		}
+	})
+
+	t.Run("QueuesMatchingStatusChanges", func(t *testing.T) {
+		store := newStore(t)
+		transfer := contractTransfer(1)
+
+		// Addresses match regardless of case
+		byRecipient := mustCreateWebhook(t, store, Webhook{Address: "0x" + strings.ToUpper(transfer.Recipient[2:]), Enabled: true})
+		completedOnly := mustCreateWebhook(t, store, Webhook{
+			Token:   transfer.Token,
+			Events:  []string{TransferEvent(types.StatusCompleted)},
+			Enabled: true,
+		})
+		otherRoute := mustCreateWebhook(t, store, Webhook{SourceChain: types.ChainPolygon, Enabled: true})
+		disabled := mustCreateWebhook(t, store, Webhook{Enabled: false})
+
+		mustRecord(t, store, transfer)
+		if err := store.UpdateConfirmations(ctx, transfer.ID, 5); err != nil {
+			t.Fatalf("Failed to update confirmations: %v", err)
+		}
+		if err := store.MarkTransferComplete(ctx, transfer.ID, "0xdest"); err != nil {
+			t.Fatalf("Failed to complete transfer: %v", err)
+		}
+
+		recipientDeliveries, err := store.ListDeliveries(ctx, byRecipient.ID, "", 10)
+		if err != nil {
+			t.Fatalf("Failed to list deliveries: %v", err)
+		}
+		if len(recipientDeliveries) != 2 ||
+			recipientDeliveries[0].EventType != "transfer.completed" ||
+			recipientDeliveries[1].EventType != "transfer.pending" {
+			t.Fatalf("Expected completed and pending events newest first, got %+v", recipientDeliveries)
+		}
+
+		var payload map[string]interface{}
+		if err := json.Unmarshal(recipientDeliveries[0].Payload, &payload); err != nil {
+			t.Fatalf("Failed to decode payload: %v", err)
+		}
+		if payload["id"] != transfer.ID || payload["status"] != "completed" ||
+			payload["amount"] != "1000000000000000000" || payload["destination_tx_hash"] != "0xdest" ||
+			payload["confirmations"] != float64(5) || payload["fee"] != nil {
+			t.Errorf("Unexpected payload: %s", recipientDeliveries[0].Payload)
+		}
+
+		completed, err := store.ListDeliveries(ctx, completedOnly.ID, "", 10)
+		if err != nil || len(completed) != 1 || completed[0].EventType != "transfer.completed" {
+			t.Errorf("Expected only the completed event, got %+v/%v", completed, err)
+		}
+		for _, id := range []int{otherRoute.ID, disabled.ID} {
+			deliveries, err := store.ListDeliveries(ctx, id, "", 10)
+			if err != nil || len(deliveries) != 0 {
+				t.Errorf("Expected no deliveries for webhook %d, got %+v/%v", id, deliveries, err)
+			}
+		}
+	})
+
+	t.Run("RetriesDeadLettersAndReplays", func(t *testing.T) {
+		store := newStore(t)
+		webhook := mustCreateWebhook(t, store, Webhook{Enabled: true})
+		mustRecord(t, store, contractTransfer(1))
+		mustRecord(t, store, contractTransfer(2))
+
+		claimed := mustClaim(t, store, 10)
+		if len(claimed) != 2 || claimed[0].TransferID != contractTransfer(1).ID {
+			t.Fatalf("Expected both deliveries oldest first, got %+v", claimed)
+		}
+		if again := mustClaim(t, store, 10); len(again) != 0 {
+			t.Fatalf("Expected claimed deliveries to be held, got %+v", again)
+		}
+
+		// A failure scheduled in the past is due again at once
+		first, second := claimed[0], claimed[1]
+		if err := store.MarkDeliveryFailed(ctx, first.ID, 500, "server error", time.Now().Add(-time.Second)); err != nil {
+			t.Fatalf("Failed to record failure: %v", err)
+		}
+		retried := mustClaim(t, store, 10)
+		if len(retried) != 1 || retried[0].ID != first.ID || retried[0].Attempts != 1 ||
+			retried[0].LastStatusCode != 500 || retried[0].LastError != "server error" {
+			t.Fatalf("Expected the failed delivery to be retried, got %+v", retried)
+		}
+
+		// Without a retry time the delivery is dead-lettered
+		if err := store.MarkDeliveryFailed(ctx, first.ID, 0, "connection refused", time.Time{}); err != nil {
+			t.Fatalf("Failed to dead-letter delivery: %v", err)
+		}
+		if err := store.MarkDelivered(ctx, second.ID, 204); err != nil {
+			t.Fatalf("Failed to mark delivered: %v", err)
+		}
+		if err := store.MarkDelivered(ctx, second.ID, 204); !errors.Is(err, ErrDeliveryNotFound) {
+			t.Errorf("Expected ErrDeliveryNotFound for a delivered delivery, got %v", err)
+		}
+
+		dead, err := store.ListDeliveries(ctx, webhook.ID, DeliveryDead, 10)
+		if err != nil || len(dead) != 1 || dead[0].ID != first.ID || dead[0].Attempts != 2 || dead[0].LastStatusCode != 0 {
+			t.Fatalf("Expected one dead delivery, got %+v/%v", dead, err)
+		}
+		delivered, err := store.ListDeliveries(ctx, webhook.ID, DeliveryDelivered, 10)
+		if err != nil || len(delivered) != 1 || delivered[0].DeliveredAt == nil || delivered[0].LastStatusCode != 204 {
+			t.Fatalf("Expected one delivered delivery, got %+v/%v", delivered, err)
+		}
+		if due := mustClaim(t, store, 10); len(due) != 0 {
+			t.Fatalf("Expected nothing due, got %+v", due)
+		}
+
+		replayed, err := store.ReplayDelivery(ctx, webhook.ID, first.ID, nil)
+		if err != nil {
+			t.Fatalf("Failed to replay delivery: %v", err)
+		}
+		if replayed.Status != DeliveryPending || replayed.Attempts != 0 || replayed.LastError != "" {
+			t.Errorf("Expected a fresh pending delivery, got %+v", replayed)
+		}
+		if due := mustClaim(t, store, 10); len(due) != 1 || due[0].ID != first.ID {
+			t.Fatalf("Expected the replayed delivery to be due, got %+v", due)
+		}
+
+		if _, err := store.ReplayDelivery(ctx, webhook.ID+1, first.ID, nil); !errors.Is(err, ErrDeliveryNotFound) {
+			t.Errorf("Expected ErrDeliveryNotFound replaying under another webhook, got %v", err)
+		}
+
+		// Deleting a webhook deletes its deliveries
+		if err := store.DeleteWebhook(ctx, webhook.ID, nil); err != nil {
+			t.Fatalf("Failed to delete webhook: %v", err)
+		}
+		if _, err := store.ReplayDelivery(ctx, webhook.ID, first.ID, nil); !errors.Is(err, ErrDeliveryNotFound) {
+			t.Errorf("Expected ErrDeliveryNotFound after deleting the webhook, got %v", err)
+		}
+	})
+}
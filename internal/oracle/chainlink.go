@@ -0,0 +1,148 @@
+package oracle
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"nexus-bridge/internal/adapters"
+	"nexus-bridge/pkg/types"
+)
+
+// nativeDecimals is the number of decimals of the native currency of the
+// EVM chains Chainlink feeds are read from
+const nativeDecimals = 18
+
+// RoundReader reads Chainlink aggregators on a chain
+type RoundReader interface {
+	LatestRoundData(ctx context.Context, feed string) (*adapters.PriceRound, error)
+}
+
+var _ RoundReader = (*adapters.EthereumAdapter)(nil)
+
+// chainlinkSource is the aggregator pricing an asset in USD
+type chainlinkSource struct {
+	reader   RoundReader
+	address  string
+	decimals uint8 // decimals of the asset, not of the answer
+}
+
+// ChainlinkFeed is a PriceOracle reading Chainlink AggregatorV3 USD feeds
+// through chain adapters
+type ChainlinkFeed struct {
+	sources map[Asset]chainlinkSource
+	mu      sync.RWMutex
+}
+
+var _ PriceOracle = (*ChainlinkFeed)(nil)
+
+// NewChainlinkFeed creates a feed without aggregators
+func NewChainlinkFeed() *ChainlinkFeed {
+	return &ChainlinkFeed{
+		sources: make(map[Asset]chainlinkSource),
+	}
+}
+
+// AddFeed prices an asset with the aggregator at an address, read through a
+// chain's reader. Decimals are those of the asset.
+func (f *ChainlinkFeed) AddFeed(asset Asset, reader RoundReader, address string, decimals uint8) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sources[normalize(asset)] = chainlinkSource{reader: reader, address: address, decimals: decimals}
+}
+
+// Price reads the latest answer of the asset's aggregator. Answers carried
+// over from an earlier round are reported as stale.
+func (f *ChainlinkFeed) Price(ctx context.Context, asset Asset) (Price, error) {
+	f.mu.RLock()
+	source, exists := f.sources[normalize(asset)]
+	f.mu.RUnlock()
+	if !exists {
+		return Price{}, fmt.Errorf("%w: %s", ErrNoPrice, asset)
+	}
+
+	round, err := source.reader.LatestRoundData(ctx, source.address)
+	if err != nil {
+		return Price{}, fmt.Errorf("failed to read %s feed: %w", asset, err)
+	}
+	if round.Answer == nil || round.Answer.Sign() <= 0 {
+		return Price{}, fmt.Errorf("invalid %s price from %s: answer %v", asset, source.address, round.Answer)
+	}
+	if round.AnsweredInRound != nil && round.RoundID != nil && round.AnsweredInRound.Cmp(round.RoundID) < 0 {
+		return Price{}, fmt.Errorf("%w: %s feed answered in round %s of %s", ErrStalePrice, asset, round.AnsweredInRound, round.RoundID)
+	}
+
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(round.Decimals)), nil)
+	return Price{
+		USD:       new(big.Rat).SetFrac(round.Answer, scale),
+		Decimals:  source.decimals,
+		UpdatedAt: round.UpdatedAt,
+		Source:    "chainlink:" + source.address,
+	}, nil
+}
+
+// FeedConfig is a Chainlink aggregator configured for an asset
+type FeedConfig struct {
+	// Chain is the chain the aggregator is read on
+	Chain    types.ChainID
+	Asset    Asset
+	Decimals uint8
+	Address  string
+}
+
+// ParseChainlinkFeeds parses aggregators written as comma-separated
+// "chain:asset=feed" entries. The chain is the one the aggregator is read
+// on. The asset is "native" for that chain's native currency, or a token
+// address with its decimals as "0xA0b8.../6".
+func ParseChainlinkFeeds(spec string) ([]FeedConfig, error) {
+	var feeds []FeedConfig
+
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		pair, address, found := strings.Cut(entry, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid feed %q: expected chain:asset=feed", entry)
+		}
+		chain, asset, found := strings.Cut(pair, ":")
+		if !found {
+			return nil, fmt.Errorf("invalid feed %q: expected chain:asset=feed", entry)
+		}
+
+		chainID, err := types.ParseChainID(strings.TrimSpace(chain))
+		if err != nil {
+			return nil, fmt.Errorf("invalid feed %q: %w", entry, err)
+		}
+		address = strings.TrimSpace(address)
+		if !common.IsHexAddress(address) {
+			return nil, fmt.Errorf("invalid feed %q: bad feed address", entry)
+		}
+
+		feed := FeedConfig{Chain: chainID, Address: address}
+		asset = strings.TrimSpace(asset)
+		if asset == "native" {
+			feed.Asset = Native(chainID)
+			feed.Decimals = nativeDecimals
+		} else {
+			token, decimals, found := strings.Cut(asset, "/")
+			parsed, err := strconv.ParseUint(decimals, 10, 8)
+			if !found || err != nil || !common.IsHexAddress(token) {
+				return nil, fmt.Errorf("invalid feed %q: expected native or token/decimals", entry)
+			}
+			feed.Asset = Token(token)
+			feed.Decimals = uint8(parsed)
+		}
+
+		feeds = append(feeds, feed)
+	}
+
+	return feeds, nil
+}
@@ -0,0 +1,91 @@
+package oracle
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/internal/adapters"
+	"nexus-bridge/pkg/types"
+)
+
+const testFeed = "0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419"
+
+// fakeRounds is a RoundReader answering from a map of feeds
+type fakeRounds map[string]*adapters.PriceRound
+
+func (f fakeRounds) LatestRoundData(ctx context.Context, feed string) (*adapters.PriceRound, error) {
+	round, exists := f[feed]
+	if !exists {
+		return nil, errors.New("execution reverted")
+	}
+	return round, nil
+}
+
+func TestChainlinkFeed(t *testing.T) {
+	updatedAt := time.Unix(1760000000, 0)
+	rounds := fakeRounds{testFeed: {
+		RoundID:         big.NewInt(7),
+		Answer:          big.NewInt(300012345678),
+		Decimals:        8,
+		UpdatedAt:       updatedAt,
+		AnsweredInRound: big.NewInt(7),
+	}}
+	feed := NewChainlinkFeed()
+	feed.AddFeed(Native(types.ChainEthereum), rounds, testFeed, 18)
+
+	price, err := feed.Price(context.Background(), Native(types.ChainEthereum))
+	require.NoError(t, err)
+	assert.Equal(t, "3000.12345678", price.USD.FloatString(8))
+	assert.Equal(t, uint8(18), price.Decimals)
+	assert.Equal(t, updatedAt, price.UpdatedAt)
+	assert.Equal(t, "chainlink:"+testFeed, price.Source)
+
+	_, err = feed.Price(context.Background(), Token(testToken))
+	assert.ErrorIs(t, err, ErrNoPrice)
+
+	// Answers carried over from an earlier round are stale
+	rounds[testFeed].AnsweredInRound = big.NewInt(6)
+	_, err = feed.Price(context.Background(), Native(types.ChainEthereum))
+	assert.ErrorIs(t, err, ErrStalePrice)
+
+	rounds[testFeed].AnsweredInRound = big.NewInt(7)
+	rounds[testFeed].Answer = big.NewInt(0)
+	_, err = feed.Price(context.Background(), Native(types.ChainEthereum))
+	assert.Error(t, err)
+
+	delete(rounds, testFeed)
+	_, err = feed.Price(context.Background(), Native(types.ChainEthereum))
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrNoPrice)
+}
+
+func TestParseChainlinkFeeds(t *testing.T) {
+	feeds, err := ParseChainlinkFeeds("ethereum:native=" + testFeed + ", 1:" + testToken + "/6=" + testFeed + ",")
+	require.NoError(t, err)
+	require.Len(t, feeds, 2)
+	assert.Equal(t, FeedConfig{Chain: types.ChainEthereum, Asset: Native(types.ChainEthereum), Decimals: 18, Address: testFeed}, feeds[0])
+	assert.Equal(t, FeedConfig{Chain: types.ChainEthereum, Asset: Token(testToken), Decimals: 6, Address: testFeed}, feeds[1])
+
+	feeds, err = ParseChainlinkFeeds("")
+	require.NoError(t, err)
+	assert.Empty(t, feeds)
+
+	for _, spec := range []string{
+		"ethereum:native",
+		"ethereumnative=" + testFeed,
+		"mars:native=" + testFeed,
+		"ethereum:native=0x123",
+		"ethereum:" + testToken + "=" + testFeed,
+		"ethereum:" + testToken + "/300=" + testFeed,
+		"ethereum:usdc/6=" + testFeed,
+	} {
+		_, err := ParseChainlinkFeeds(spec)
+		assert.Error(t, err, spec)
+	}
+}
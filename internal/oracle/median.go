@@ -0,0 +1,122 @@
+package oracle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Median is a PriceOracle taking the median of the fresh prices of several
+// sources, so one bad or lagging source cannot move the price on its own
+type Median struct {
+	sources []PriceOracle
+	// MaxAge is how old a price may be before it is ignored, where zero
+	// accepts prices of any age
+	MaxAge time.Duration
+	// MinSources is how many fresh prices are needed for a median
+	MinSources int
+	now        func() time.Time
+}
+
+var _ PriceOracle = (*Median)(nil)
+
+// NewMedian creates a medianizer over sources accepting prices up to maxAge
+// old
+func NewMedian(maxAge time.Duration, sources ...PriceOracle) *Median {
+	return &Median{
+		sources:    sources,
+		MaxAge:     maxAge,
+		MinSources: 1,
+		now:        time.Now,
+	}
+}
+
+// Price asks every source for an asset's price and returns the median of
+// the fresh ones. Sources not pricing the asset are skipped, and failing
+// sources logged and skipped. The median is as old as the oldest price it
+// was taken from.
+func (m *Median) Price(ctx context.Context, asset Asset) (Price, error) {
+	prices := make([]Price, len(m.sources))
+	errs := make([]error, len(m.sources))
+	var wg sync.WaitGroup
+	for i, source := range m.sources {
+		wg.Add(1)
+		go func(i int, source PriceOracle) {
+			defer wg.Done()
+			prices[i], errs[i] = source.Price(ctx, asset)
+			if errs[i] == nil {
+				errs[i] = prices[i].validate(asset)
+			}
+		}(i, source)
+	}
+	wg.Wait()
+
+	var fresh []Price
+	var stale int
+	var failure error
+	now := m.now()
+	for i, price := range prices {
+		switch {
+		case errors.Is(errs[i], ErrNoPrice):
+		case errors.Is(errs[i], ErrStalePrice):
+			stale++
+		case errs[i] != nil:
+			fmt.Printf("Error getting %s price: %v\n", asset, errs[i])
+			failure = errs[i]
+		case m.MaxAge > 0 && now.Sub(price.UpdatedAt) > m.MaxAge:
+			stale++
+		default:
+			fresh = append(fresh, price)
+		}
+	}
+
+	required := m.MinSources
+	if required < 1 {
+		required = 1
+	}
+	if len(fresh) < required {
+		switch {
+		case stale > 0:
+			return Price{}, fmt.Errorf("%w: %s has %d fresh of %d required prices", ErrStalePrice, asset, len(fresh), required)
+		case failure != nil:
+			return Price{}, fmt.Errorf("failed to price %s: %w", asset, failure)
+		case len(fresh) == 0:
+			return Price{}, fmt.Errorf("%w: %s", ErrNoPrice, asset)
+		default:
+			return Price{}, fmt.Errorf("%w: %s has %d of %d required prices", ErrNoPrice, asset, len(fresh), required)
+		}
+	}
+
+	return median(asset, fresh)
+}
+
+// median returns the median of prices of an asset, averaging the middle two
+// of an even number
+func median(asset Asset, prices []Price) (Price, error) {
+	result := Price{Decimals: prices[0].Decimals, UpdatedAt: prices[0].UpdatedAt, Source: "median"}
+	for _, price := range prices {
+		if price.Decimals != result.Decimals {
+			return Price{}, fmt.Errorf("sources disagree on %s decimals: %d and %d", asset, result.Decimals, price.Decimals)
+		}
+		if price.UpdatedAt.Before(result.UpdatedAt) {
+			result.UpdatedAt = price.UpdatedAt
+		}
+	}
+
+	sort.Slice(prices, func(i, j int) bool {
+		return prices[i].USD.Cmp(prices[j].USD) < 0
+	})
+	middle := len(prices) / 2
+	if len(prices)%2 == 1 {
+		result.USD = new(big.Rat).Set(prices[middle].USD)
+	} else {
+		sum := new(big.Rat).Add(prices[middle-1].USD, prices[middle].USD)
+		result.USD = sum.Quo(sum, big.NewRat(2, 1))
+	}
+
+	return result, nil
+}
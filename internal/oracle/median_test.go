@@ -0,0 +1,85 @@
+package oracle
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/pkg/types"
+)
+
+// failingOracle is a PriceOracle that always fails
+type failingOracle struct{}
+
+func (failingOracle) Price(ctx context.Context, asset Asset) (Price, error) {
+	return Price{}, errors.New("rpc unavailable")
+}
+
+func staticPrice(usd int64, updatedAt time.Time) *StaticFeed {
+	feed := NewStaticFeed()
+	feed.SetPrice(Native(types.ChainEthereum), Price{USD: big.NewRat(usd, 1), Decimals: 18, UpdatedAt: updatedAt})
+	return feed
+}
+
+func TestMedian(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	median := NewMedian(time.Hour,
+		staticPrice(3000, now.Add(-time.Minute)),
+		staticPrice(9000, now.Add(-2*time.Minute)),
+		staticPrice(3100, now.Add(-3*time.Minute)),
+		staticPrice(1, now.Add(-2*time.Hour)), // stale, ignored
+		failingOracle{},
+		NewStaticFeed(), // does not price the asset
+	)
+	median.now = func() time.Time { return now }
+
+	price, err := median.Price(context.Background(), Native(types.ChainEthereum))
+	require.NoError(t, err)
+	assert.Equal(t, "3100", price.USD.RatString(), "an outlier cannot move the median")
+	assert.Equal(t, now.Add(-3*time.Minute), price.UpdatedAt, "the median is as old as its oldest price")
+	assert.Equal(t, uint8(18), price.Decimals)
+
+	// Even counts average the middle two
+	median = NewMedian(time.Hour, staticPrice(3000, now), staticPrice(3100, now))
+	median.now = func() time.Time { return now }
+	price, err = median.Price(context.Background(), Native(types.ChainEthereum))
+	require.NoError(t, err)
+	assert.Equal(t, "3050", price.USD.RatString())
+}
+
+func TestMedian_NotEnoughPrices(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	asset := Native(types.ChainEthereum)
+
+	median := NewMedian(time.Hour, staticPrice(3000, now), staticPrice(3100, now.Add(-2*time.Hour)))
+	median.now = func() time.Time { return now }
+	median.MinSources = 2
+	_, err := median.Price(context.Background(), asset)
+	assert.ErrorIs(t, err, ErrStalePrice)
+
+	median = NewMedian(time.Hour, failingOracle{}, NewStaticFeed())
+	_, err = median.Price(context.Background(), asset)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrNoPrice)
+
+	median = NewMedian(time.Hour, NewStaticFeed())
+	_, err = median.Price(context.Background(), asset)
+	assert.ErrorIs(t, err, ErrNoPrice)
+
+	// Without a maximum age any price is fresh
+	median = NewMedian(0, staticPrice(3000, now.Add(-24*time.Hour)))
+	_, err = median.Price(context.Background(), asset)
+	assert.NoError(t, err)
+
+	// Sources must agree on what a unit is
+	other := NewStaticFeed()
+	other.SetPrice(asset, Price{USD: big.NewRat(3000, 1), Decimals: 6})
+	median = NewMedian(0, staticPrice(3000, now), other)
+	_, err = median.Price(context.Background(), asset)
+	assert.Error(t, err)
+}
@@ -0,0 +1,79 @@
+package oracle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"nexus-bridge/pkg/types"
+)
+
+var (
+	// ErrNoPrice is returned when a source does not price an asset
+	ErrNoPrice = errors.New("no price")
+	// ErrStalePrice is returned when the prices of an asset are too old to use
+	ErrStalePrice = errors.New("stale price")
+)
+
+// Asset is something priced by an oracle: the native currency of Chain when
+// Token is empty, and otherwise a token. Bridged tokens keep the address
+// they have on their original chain, so tokens are priced by address alone.
+type Asset struct {
+	Chain types.ChainID
+	Token string
+}
+
+// Native returns the asset of a chain's native currency
+func Native(chain types.ChainID) Asset {
+	return Asset{Chain: chain}
+}
+
+// Token returns the asset of a token address
+func Token(address string) Asset {
+	return Asset{Token: strings.ToLower(address)}
+}
+
+func (a Asset) String() string {
+	if a.Token == "" {
+		return a.Chain.String() + " native"
+	}
+	return a.Token
+}
+
+// Price is the USD value of one whole unit of an asset
+type Price struct {
+	USD *big.Rat
+	// Decimals is the number of decimals of the asset, relating whole units
+	// to base units such as wei
+	Decimals  uint8
+	UpdatedAt time.Time
+	// Source names where the price came from
+	Source string
+}
+
+// PerBaseUnit returns the USD value of one base unit of the asset
+func (p Price) PerBaseUnit() *big.Rat {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(p.Decimals)), nil)
+	return new(big.Rat).Quo(p.USD, new(big.Rat).SetInt(scale))
+}
+
+// PriceOracle prices assets in USD
+type PriceOracle interface {
+	// Price returns the latest price of an asset. It returns an error
+	// wrapping ErrNoPrice if the oracle does not price the asset.
+	Price(ctx context.Context, asset Asset) (Price, error)
+}
+
+// validate checks a price is positive and timestamped
+func (p Price) validate(asset Asset) error {
+	if p.USD == nil || p.USD.Sign() <= 0 {
+		return fmt.Errorf("invalid %s price from %s: must be positive", asset, p.Source)
+	}
+	if p.UpdatedAt.IsZero() {
+		return fmt.Errorf("invalid %s price from %s: no update time", asset, p.Source)
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package oracle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"nexus-bridge/pkg/types"
+)
+
+// StaticFeed is a PriceOracle with prices set in process
+type StaticFeed struct {
+	prices map[Asset]Price
+	mu     sync.RWMutex
+}
+
+var _ PriceOracle = (*StaticFeed)(nil)
+
+// NewStaticFeed creates a feed without prices
+func NewStaticFeed() *StaticFeed {
+	return &StaticFeed{
+		prices: make(map[Asset]Price),
+	}
+}
+
+// SetPrice sets the price of an asset. Prices without an update time are
+// stamped with the current time, and prices without a source as "static".
+func (f *StaticFeed) SetPrice(asset Asset, price Price) {
+	if price.UpdatedAt.IsZero() {
+		price.UpdatedAt = time.Now()
+	}
+	if price.Source == "" {
+		price.Source = "static"
+	}
+	price.USD = new(big.Rat).Set(price.USD)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.prices[normalize(asset)] = price
+}
+
+// Price returns the price last set for an asset
+func (f *StaticFeed) Price(ctx context.Context, asset Asset) (Price, error) {
+	f.mu.RLock()
+	price, exists := f.prices[normalize(asset)]
+	f.mu.RUnlock()
+
+	if !exists {
+		return Price{}, fmt.Errorf("%w: %s", ErrNoPrice, asset)
+	}
+	return price, nil
+}
+
+// normalize keys tokens by lowercase address whatever chain they were given
+// with
+func normalize(asset Asset) Asset {
+	if asset.Token == "" {
+		return asset
+	}
+	return Token(asset.Token)
+}
+
+// priceFile is the JSON document read by a FileFeed
+type priceFile struct {
+	// UpdatedAt is when the prices were taken, defaulting to the time the
+	// file was last modified
+	UpdatedAt time.Time   `json:"updated_at"`
+	Prices    []filePrice `json:"prices"`
+}
+
+// filePrice prices either the native currency of a chain or a token
+type filePrice struct {
+	Chain    string `json:"chain,omitempty"` // name or numeric ID
+	Token    string `json:"token,omitempty"`
+	USD      string `json:"usd"`
+	Decimals uint8  `json:"decimals"`
+}
+
+// FileFeed is a PriceOracle reading prices from a JSON file, for prices
+// published by an external job. The file is read again whenever it changes;
+// if it becomes invalid, the prices read last are kept.
+type FileFeed struct {
+	path     string
+	feed     *StaticFeed
+	modified time.Time
+	mu       sync.Mutex
+}
+
+var _ PriceOracle = (*FileFeed)(nil)
+
+// NewFileFeed creates a feed from a price file, which must be valid
+func NewFileFeed(path string) (*FileFeed, error) {
+	f := &FileFeed{path: path}
+	if err := f.reload(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Price returns the price of an asset from the latest valid file
+func (f *FileFeed) Price(ctx context.Context, asset Asset) (Price, error) {
+	f.mu.Lock()
+	if err := f.reload(); err != nil {
+		fmt.Printf("Error reloading price file %s: %v\n", f.path, err)
+	}
+	feed := f.feed
+	f.mu.Unlock()
+
+	return feed.Price(ctx, asset)
+}
+
+// reload reads the file if it changed since it was last read
+func (f *FileFeed) reload() error {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return fmt.Errorf("failed to read price file: %w", err)
+	}
+	if f.feed != nil && info.ModTime().Equal(f.modified) {
+		return nil
+	}
+
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return fmt.Errorf("failed to read price file: %w", err)
+	}
+	feed, err := parsePriceFile(data, info.ModTime(), "file:"+f.path)
+	if err != nil {
+		return err
+	}
+
+	f.feed = feed
+	f.modified = info.ModTime()
+	return nil
+}
+
+// parsePriceFile parses a price file into a feed
+func parsePriceFile(data []byte, modified time.Time, source string) (*StaticFeed, error) {
+	var file priceFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid price file: %w", err)
+	}
+	updatedAt := file.UpdatedAt
+	if updatedAt.IsZero() {
+		updatedAt = modified
+	}
+
+	feed := NewStaticFeed()
+	for i, entry := range file.Prices {
+		var asset Asset
+		switch {
+		case entry.Chain != "" && entry.Token == "":
+			chain, err := types.ParseChainID(entry.Chain)
+			if err != nil {
+				return nil, fmt.Errorf("invalid price %d: %w", i, err)
+			}
+			asset = Native(chain)
+		case entry.Token != "" && entry.Chain == "":
+			asset = Token(strings.TrimSpace(entry.Token))
+		default:
+			return nil, fmt.Errorf("invalid price %d: expected either chain or token", i)
+		}
+
+		usd, ok := new(big.Rat).SetString(strings.TrimSpace(entry.USD))
+		if !ok || usd.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid price %d: bad usd %q", i, entry.USD)
+		}
+
+		feed.SetPrice(asset, Price{USD: usd, Decimals: entry.Decimals, UpdatedAt: updatedAt, Source: source})
+	}
+
+	return feed, nil
+}
@@ -0,0 +1,96 @@
+package oracle
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/pkg/types"
+)
+
+const testToken = "0xA0b86a33E6441E6C7D3E4C2C4C6C6C6C6C6C6C6C"
+
+func TestStaticFeed(t *testing.T) {
+	feed := NewStaticFeed()
+	feed.SetPrice(Native(types.ChainEthereum), Price{USD: big.NewRat(3000, 1), Decimals: 18})
+	// Tokens are priced by address, whatever chain they are given with
+	feed.SetPrice(Asset{Chain: types.ChainPolygon, Token: testToken}, Price{USD: big.NewRat(1, 1), Decimals: 6})
+
+	price, err := feed.Price(context.Background(), Native(types.ChainEthereum))
+	require.NoError(t, err)
+	assert.Equal(t, "3000", price.USD.RatString())
+	assert.Equal(t, "static", price.Source)
+	assert.False(t, price.UpdatedAt.IsZero())
+	assert.Equal(t, "3/1000000000000000", price.PerBaseUnit().RatString())
+
+	price, err = feed.Price(context.Background(), Token("0xa0b86a33e6441e6c7d3e4c2c4c6c6c6c6c6c6c6c"))
+	require.NoError(t, err)
+	assert.Equal(t, uint8(6), price.Decimals)
+
+	_, err = feed.Price(context.Background(), Native(types.ChainPolygon))
+	assert.ErrorIs(t, err, ErrNoPrice)
+}
+
+func writePriceFile(t *testing.T, path, content string, modified time.Time) {
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	require.NoError(t, os.Chtimes(path, modified, modified))
+}
+
+func TestFileFeed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prices.json")
+	modified := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	writePriceFile(t, path, `{"prices": [
+		{"chain": "polygon", "usd": "0.5", "decimals": 18},
+		{"token": "`+testToken+`", "usd": "1.0002", "decimals": 6}
+	]}`, modified)
+
+	feed, err := NewFileFeed(path)
+	require.NoError(t, err)
+
+	price, err := feed.Price(context.Background(), Native(types.ChainPolygon))
+	require.NoError(t, err)
+	assert.Equal(t, "1/2", price.USD.RatString())
+	assert.True(t, modified.Equal(price.UpdatedAt), "prices default to the file's modification time")
+	assert.Equal(t, "file:"+path, price.Source)
+
+	// Changes are picked up, and an explicit update time wins
+	writePriceFile(t, path, `{"updated_at": "2026-10-18T13:00:00Z", "prices": [{"chain": "137", "usd": "0.6", "decimals": 18}]}`, modified.Add(time.Hour))
+	price, err = feed.Price(context.Background(), Native(types.ChainPolygon))
+	require.NoError(t, err)
+	assert.Equal(t, "3/5", price.USD.RatString())
+	assert.Equal(t, modified.Add(time.Hour), price.UpdatedAt.UTC())
+	_, err = feed.Price(context.Background(), Token(testToken))
+	assert.ErrorIs(t, err, ErrNoPrice)
+
+	// An invalid file keeps the last prices
+	writePriceFile(t, path, `{"prices": [`, modified.Add(2*time.Hour))
+	price, err = feed.Price(context.Background(), Native(types.ChainPolygon))
+	require.NoError(t, err)
+	assert.Equal(t, "3/5", price.USD.RatString())
+}
+
+func TestNewFileFeed_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	_, err := NewFileFeed(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+
+	for _, content := range []string{
+		`{"prices": [{"usd": "1"}]}`,
+		`{"prices": [{"chain": "ethereum", "token": "` + testToken + `", "usd": "1"}]}`,
+		`{"prices": [{"chain": "mars", "usd": "1"}]}`,
+		`{"prices": [{"token": "` + testToken + `", "usd": "abc"}]}`,
+		`{"prices": [{"token": "` + testToken + `", "usd": "0"}]}`,
+		`[]`,
+	} {
+		path := filepath.Join(dir, "prices.json")
+		writePriceFile(t, path, content, time.Now())
+		_, err := NewFileFeed(path)
+		assert.Error(t, err, content)
+	}
+}
@@ -0,0 +1,50 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Limit is a token bucket refilled at Rate tokens per second up to Burst
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// PerMinute returns a limit allowing n requests a minute, all of which may
+// be spent at once
+func PerMinute(n int) Limit {
+	return Limit{Rate: float64(n) / 60, Burst: n}
+}
+
+// Result is the outcome of taking a token from a bucket
+type Result struct {
+	Allowed    bool
+	Remaining  int
+	RetryAfter time.Duration
+}
+
+// Limiter takes tokens from per-key buckets
+type Limiter interface {
+	// Allow takes a token from the bucket of a key, creating it full if it
+	// does not exist
+	Allow(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// take refills a bucket holding tokens last updated elapsed ago and takes a
+// token from it if it can, returning the tokens left and the result
+func take(tokens float64, elapsed time.Duration, limit Limit) (float64, Result) {
+	tokens = math.Min(float64(limit.Burst), tokens+elapsed.Seconds()*limit.Rate)
+	if tokens >= 1 {
+		tokens--
+		return tokens, Result{Allowed: true, Remaining: int(tokens)}
+	}
+
+	return tokens, Result{Allowed: false, RetryAfter: retryAfter(tokens, limit)}
+}
+
+// retryAfter returns how long a bucket holding tokens takes to refill one
+func retryAfter(tokens float64, limit Limit) time.Duration {
+	return time.Duration((1 - tokens) / limit.Rate * float64(time.Second))
+}
@@ -0,0 +1,114 @@
+package ratelimit
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryLimiter_Contract(t *testing.T) {
+	runLimiterContract(t, func(t *testing.T) Limiter {
+		return NewMemoryLimiter()
+	})
+}
+
+func TestRedisLimiter_Contract(t *testing.T) {
+	runLimiterContract(t, func(t *testing.T) Limiter {
+		url := os.Getenv("TEST_REDIS_URL")
+		if url == "" {
+			url = "redis://localhost:6379/15"
+		}
+		options, err := redis.ParseURL(url)
+		require.NoError(t, err)
+
+		client := redis.NewClient(options)
+		t.Cleanup(func() { client.Close() })
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if err := client.Ping(ctx).Err(); err != nil {
+			t.Skipf("Skipping Redis tests: %v", err)
+		}
+
+		prefix := "ratelimit-test:" + t.Name() + ":"
+		t.Cleanup(func() {
+			keys, _ := client.Keys(context.Background(), prefix+"*").Result()
+			if len(keys) > 0 {
+				client.Del(context.Background(), keys...)
+			}
+		})
+		return NewRedisLimiter(client, prefix)
+	})
+}
+
+// runLimiterContract exercises the behaviour every Limiter must share
+func runLimiterContract(t *testing.T, newLimiter func(t *testing.T) Limiter) {
+	ctx := context.Background()
+
+	t.Run("SpendsBurstThenRefuses", func(t *testing.T) {
+		limiter := newLimiter(t)
+		limit := PerMinute(3)
+
+		for remaining := 2; remaining >= 0; remaining-- {
+			result, err := limiter.Allow(ctx, "alice", limit)
+			require.NoError(t, err)
+			assert.True(t, result.Allowed)
+			assert.Equal(t, remaining, result.Remaining)
+		}
+
+		result, err := limiter.Allow(ctx, "alice", limit)
+		require.NoError(t, err)
+		assert.False(t, result.Allowed)
+		assert.InDelta(t, 20*time.Second, result.RetryAfter, float64(time.Second))
+
+		// Buckets are per key
+		result, err = limiter.Allow(ctx, "bob", limit)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+	})
+
+	t.Run("Refills", func(t *testing.T) {
+		limiter := newLimiter(t)
+		limit := Limit{Rate: 20, Burst: 1}
+
+		result, err := limiter.Allow(ctx, "alice", limit)
+		require.NoError(t, err)
+		require.True(t, result.Allowed)
+		result, err = limiter.Allow(ctx, "alice", limit)
+		require.NoError(t, err)
+		require.False(t, result.Allowed)
+
+		time.Sleep(100 * time.Millisecond)
+		result, err = limiter.Allow(ctx, "alice", limit)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+	})
+}
+
+func TestMemoryLimiter_SweepsFullBuckets(t *testing.T) {
+	limiter := NewMemoryLimiter()
+	now := time.Now()
+	limiter.now = func() time.Time { return now }
+	limit := PerMinute(60)
+
+	_, err := limiter.Allow(context.Background(), "idle", limit)
+	require.NoError(t, err)
+	for i := 0; i < 60; i++ {
+		_, err = limiter.Allow(context.Background(), "busy", limit)
+		require.NoError(t, err)
+	}
+
+	// A second later the idle bucket is full again but the busy one is not
+	limiter.mu.Lock()
+	limiter.sweep(now.Add(time.Second))
+	_, idle := limiter.buckets["idle"]
+	_, busy := limiter.buckets["busy"]
+	limiter.mu.Unlock()
+
+	assert.False(t, idle)
+	assert.True(t, busy)
+}
@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often idle buckets are dropped
+const sweepInterval = time.Minute
+
+// bucket is the state of a single in-process token bucket
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+	limit     Limit
+}
+
+// MemoryLimiter keeps token buckets in process. Each API replica enforces
+// its own limits.
+type MemoryLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryLimiter creates an in-process limiter
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+// Allow takes a token from the bucket of a key
+func (l *MemoryLimiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Sub(l.lastSweep) >= sweepInterval {
+		l.sweep(now)
+	}
+
+	b, exists := l.buckets[key]
+	if !exists {
+		b = &bucket{tokens: float64(limit.Burst), updatedAt: now}
+		l.buckets[key] = b
+	}
+
+	var result Result
+	b.tokens, result = take(b.tokens, now.Sub(b.updatedAt), limit)
+	b.updatedAt = now
+	b.limit = limit
+
+	return result, nil
+}
+
+// sweep drops buckets that have refilled completely, since a new bucket
+// starts full anyway. The caller must hold the lock.
+func (l *MemoryLimiter) sweep(now time.Time) {
+	for key, b := range l.buckets {
+		missing := float64(b.limit.Burst) - b.tokens
+		if now.Sub(b.updatedAt).Seconds()*b.limit.Rate >= missing {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
@@ -0,0 +1,70 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// takeScript refills and takes from a bucket stored as a hash of tokens and
+// the millisecond time it was last updated. The server clock is used so
+// every API replica agrees on elapsed time. Buckets expire once they would
+// have refilled completely.
+var takeScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local clock = redis.call('TIME')
+local now = tonumber(clock[1]) * 1000 + math.floor(tonumber(clock[2]) / 1000)
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'updated_at')
+local tokens = tonumber(state[1]) or burst
+local updated_at = tonumber(state[2]) or now
+
+tokens = math.min(burst, tokens + math.max(0, now - updated_at) / 1000 * rate)
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'updated_at', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst / rate * 1000) + 1000)
+return {allowed, tostring(tokens)}
+`)
+
+// RedisLimiter keeps token buckets in Redis, so limits are shared by every
+// API replica
+type RedisLimiter struct {
+	client redis.Scripter
+	prefix string
+}
+
+// NewRedisLimiter creates a limiter storing buckets under a key prefix
+func NewRedisLimiter(client redis.Scripter, prefix string) *RedisLimiter {
+	return &RedisLimiter{client: client, prefix: prefix}
+}
+
+// Allow takes a token from the bucket of a key
+func (l *RedisLimiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	values, err := takeScript.Run(ctx, l.client, []string{l.prefix + key}, limit.Rate, limit.Burst).Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to take rate limit token: %w", err)
+	}
+	if len(values) != 2 {
+		return Result{}, fmt.Errorf("unexpected rate limit reply: %v", values)
+	}
+
+	allowed, _ := values[0].(int64)
+	remaining, _ := values[1].(string)
+	tokens, err := strconv.ParseFloat(remaining, 64)
+	if err != nil {
+		return Result{}, fmt.Errorf("unexpected rate limit reply: %v", values)
+	}
+
+	if allowed == 1 {
+		return Result{Allowed: true, Remaining: int(tokens)}, nil
+	}
+	return Result{Allowed: false, RetryAfter: retryAfter(tokens, limit)}, nil
+}
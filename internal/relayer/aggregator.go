@@ -0,0 +1,218 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+const (
+	// DefaultLagTimeout is how long after a transfer's first signature a
+	// relayer that did not sign it is lagging
+	DefaultLagTimeout = 2 * time.Minute
+	// DefaultHealthWindow is how long a relayer that lagged stays unhealthy
+	DefaultHealthWindow = 15 * time.Minute
+)
+
+// SignatureSource provides the signatures collected for a transfer
+type SignatureSource interface {
+	GetSignatures(ctx context.Context, transferID string) ([]types.Signature, error)
+}
+
+// RelayerConfigLister provides the relayer set with its weights
+type RelayerConfigLister interface {
+	ListRelayerConfigs(ctx context.Context) ([]models.RelayerConfig, error)
+}
+
+// DestinationRelayers reports the relayers a destination bridge authorizes
+// and the signatures it requires
+type DestinationRelayers interface {
+	RequiredSignatures(chainID types.ChainID) uint64
+	IsAuthorized(chainID types.ChainID, address string) bool
+}
+
+var _ DestinationRelayers = (*RelayerSync)(nil)
+
+// Aggregation is the signature collection of a transfer
+type Aggregation struct {
+	TransferID string
+	// Ready reports whether enough distinct relayers signed, carrying
+	// enough weight
+	Ready          bool
+	Required       uint64
+	RequiredWeight int
+	// Signatures are the signatures to submit once ready, or every counted
+	// signature before then
+	Signatures []types.Signature
+	// Weight is the threshold_weight the relayers of Signatures sum to
+	Weight int
+	// Lagging are the active relayers that have not signed the transfer
+	// LagTimeout after its first signature
+	Lagging []string
+}
+
+// SignatureAggregator decides when a transfer has collected enough
+// signatures for its destination bridge. Only signatures of relayers active
+// in relayer_config and authorized by the destination bridge count. A
+// transfer is ready once they number the bridge's requiredSignatures and
+// their threshold_weight sums to RequiredWeight, so a relayer with a weight
+// of 0 signs without vouching for the transfer. Ready transfers get the
+// fewest signatures meeting both, so the destination call carries no more
+// calldata than needed. Relayers that have not lagged on any transfer over
+// the last HealthWindow are preferred, then those with a higher
+// threshold_weight.
+type SignatureAggregator struct {
+	signatures   SignatureSource
+	relayers     RelayerConfigLister
+	destinations DestinationRelayers
+	LagTimeout   time.Duration
+	HealthWindow time.Duration
+	// RequiredWeight is the threshold_weight ready signatures must sum to.
+	// Zero requires as much weight as the destination requires signatures,
+	// which relayers of the default weight of 1 carry one each.
+	RequiredWeight int
+	now            func() time.Time
+
+	mu     sync.Mutex
+	lagged map[string]time.Time
+}
+
+// NewSignatureAggregator creates an aggregator over the collected
+// signatures and the relayer set
+func NewSignatureAggregator(signatures SignatureSource, relayers RelayerConfigLister, destinations DestinationRelayers) *SignatureAggregator {
+	return &SignatureAggregator{
+		signatures:   signatures,
+		relayers:     relayers,
+		destinations: destinations,
+		LagTimeout:   DefaultLagTimeout,
+		HealthWindow: DefaultHealthWindow,
+		now:          time.Now,
+		lagged:       make(map[string]time.Time),
+	}
+}
+
+// Aggregate weighs the signatures of a transfer and selects those to
+// submit, reporting the relayers lagging on it
+func (a *SignatureAggregator) Aggregate(ctx context.Context, transfer types.Transfer) (*Aggregation, error) {
+	required := a.destinations.RequiredSignatures(transfer.DestinationChain)
+	if required == 0 {
+		return nil, fmt.Errorf("required signatures not known for chain: %s", transfer.DestinationChain)
+	}
+
+	configs, err := a.relayers.ListRelayerConfigs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list relayers: %w", err)
+	}
+	weights := make(map[string]int, len(configs))
+	for _, config := range configs {
+		address := strings.ToLower(config.Address)
+		if !config.IsActive || !a.destinations.IsAuthorized(transfer.DestinationChain, address) {
+			continue
+		}
+		weights[address] = config.ThresholdWeight
+	}
+
+	signatures, err := a.signatures.GetSignatures(ctx, transfer.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signatures: %w", err)
+	}
+
+	requiredWeight := a.RequiredWeight
+	if requiredWeight <= 0 {
+		requiredWeight = int(required)
+	}
+	aggregation := &Aggregation{TransferID: transfer.ID, Required: required, RequiredWeight: requiredWeight}
+	signed := make(map[string]bool, len(signatures))
+	var counted []types.Signature
+	var firstSigned time.Time
+	for _, signature := range signatures {
+		if firstSigned.IsZero() || signature.CreatedAt.Before(firstSigned) {
+			firstSigned = signature.CreatedAt
+		}
+		address := strings.ToLower(signature.RelayerAddress)
+		if signed[address] {
+			continue
+		}
+		signed[address] = true
+		if _, active := weights[address]; active {
+			counted = append(counted, signature)
+		}
+	}
+
+	now := a.now()
+	if !firstSigned.IsZero() && now.Sub(firstSigned) >= a.LagTimeout {
+		aggregation.Lagging = a.recordLagging(transfer.ID, weights, signed, now)
+	}
+
+	a.mu.Lock()
+	sort.SliceStable(counted, func(i, j int) bool {
+		left, right := strings.ToLower(counted[i].RelayerAddress), strings.ToLower(counted[j].RelayerAddress)
+		if leftHealthy := a.healthyAt(left, now); leftHealthy != a.healthyAt(right, now) {
+			return leftHealthy
+		}
+		if weights[left] != weights[right] {
+			return weights[left] > weights[right]
+		}
+		return left < right
+	})
+	a.mu.Unlock()
+
+	weight := 0
+	for i, signature := range counted {
+		weight += weights[strings.ToLower(signature.RelayerAddress)]
+		if uint64(i+1) >= required && weight >= requiredWeight {
+			aggregation.Ready = true
+			counted = counted[:i+1]
+			break
+		}
+	}
+	aggregation.Signatures = counted
+	aggregation.Weight = weight
+
+	return aggregation, nil
+}
+
+// IsHealthy reports whether a relayer has not lagged over the last
+// HealthWindow
+func (a *SignatureAggregator) IsHealthy(address string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.healthyAt(strings.ToLower(address), a.now())
+}
+
+// healthyAt reports whether a relayer is healthy at a time. The caller
+// holds the lock.
+func (a *SignatureAggregator) healthyAt(address string, now time.Time) bool {
+	lagged, exists := a.lagged[address]
+	return !exists || now.Sub(lagged) >= a.HealthWindow
+}
+
+// recordLagging returns the active relayers missing from a transfer's
+// signatures and marks them unhealthy
+func (a *SignatureAggregator) recordLagging(transferID string, weights map[string]int, signed map[string]bool, now time.Time) []string {
+	var lagging []string
+	for address := range weights {
+		if !signed[address] {
+			lagging = append(lagging, address)
+		}
+	}
+	if len(lagging) == 0 {
+		return nil
+	}
+	sort.Strings(lagging)
+
+	a.mu.Lock()
+	for _, address := range lagging {
+		a.lagged[address] = now
+	}
+	a.mu.Unlock()
+
+	fmt.Printf("Relayers lagging on transfer %s: %s\n", transferID, strings.Join(lagging, ", "))
+	return lagging
+}
@@ -0,0 +1,190 @@
+package relayer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+const relayerD = "0x00000000000000000000000000000000000000d4"
+
+// fixedRelayers is a RelayerConfigLister over a fixed relayer set
+type fixedRelayers []models.RelayerConfig
+
+func (f fixedRelayers) ListRelayerConfigs(ctx context.Context) ([]models.RelayerConfig, error) {
+	return f, nil
+}
+
+// fixedDestination is a DestinationRelayers for a single bridge
+type fixedDestination struct {
+	required   uint64
+	authorized map[string]bool
+}
+
+func (f *fixedDestination) RequiredSignatures(chainID types.ChainID) uint64 {
+	if chainID != types.ChainPolygon {
+		return 0
+	}
+	return f.required
+}
+
+func (f *fixedDestination) IsAuthorized(chainID types.ChainID, address string) bool {
+	return chainID == types.ChainPolygon && f.authorized[address]
+}
+
+func newTestAggregator(relayers fixedRelayers, required uint64) (*SignatureAggregator, *models.MemoryStateManager, *fixedDestination) {
+	state := models.NewMemoryStateManager()
+	destination := &fixedDestination{required: required, authorized: make(map[string]bool)}
+	for _, relayer := range relayers {
+		destination.authorized[relayer.Address] = true
+	}
+	return NewSignatureAggregator(state, relayers, destination), state, destination
+}
+
+func recordSignatures(t *testing.T, state *models.MemoryStateManager, transferID string, relayers ...string) {
+	for _, relayer := range relayers {
+		require.NoError(t, state.RecordSignature(context.Background(), transferID,
+			types.Signature{RelayerAddress: relayer, Signature: make([]byte, 65)}))
+	}
+}
+
+// signers returns the relayers of signatures, in order
+func signers(signatures []types.Signature) []string {
+	addresses := make([]string, len(signatures))
+	for i, signature := range signatures {
+		addresses[i] = signature.RelayerAddress
+	}
+	return addresses
+}
+
+func TestSignatureAggregator_CountsActiveRelayers(t *testing.T) {
+	aggregator, state, destination := newTestAggregator(fixedRelayers{
+		{Address: relayerA, IsActive: true, ThresholdWeight: 1},
+		{Address: relayerB, IsActive: false, ThresholdWeight: 1},
+		{Address: relayerC, IsActive: true, ThresholdWeight: 1},
+		{Address: relayerD, IsActive: true, ThresholdWeight: 1},
+	}, 2)
+	destination.authorized[relayerD] = false
+	transfer := createSignedTransfer(t, state, 1)
+
+	// Inactive relayers and those the bridge does not authorize do not count
+	recordSignatures(t, state, transfer.ID, relayerA, relayerB, relayerD)
+	aggregation, err := aggregator.Aggregate(context.Background(), transfer)
+	require.NoError(t, err)
+	assert.False(t, aggregation.Ready)
+	assert.Equal(t, uint64(2), aggregation.Required)
+	assert.Equal(t, []string{relayerA}, signers(aggregation.Signatures))
+
+	recordSignatures(t, state, transfer.ID, relayerC)
+	aggregation, err = aggregator.Aggregate(context.Background(), transfer)
+	require.NoError(t, err)
+	assert.True(t, aggregation.Ready)
+	assert.Equal(t, []string{relayerA, relayerC}, signers(aggregation.Signatures))
+}
+
+func TestSignatureAggregator_SelectsMinimalSet(t *testing.T) {
+	aggregator, state, _ := newTestAggregator(fixedRelayers{
+		{Address: relayerA, IsActive: true, ThresholdWeight: 1},
+		{Address: relayerB, IsActive: true, ThresholdWeight: 1},
+		{Address: relayerC, IsActive: true, ThresholdWeight: 2},
+		{Address: relayerD, IsActive: true, ThresholdWeight: 0},
+	}, 3)
+	transfer := createSignedTransfer(t, state, 1)
+	recordSignatures(t, state, transfer.ID, relayerA, relayerB, relayerD, relayerC)
+
+	// The bridge counts signers, so a third is needed past the weight
+	aggregation, err := aggregator.Aggregate(context.Background(), transfer)
+	require.NoError(t, err)
+	assert.True(t, aggregation.Ready)
+	assert.Equal(t, []string{relayerC, relayerA, relayerB}, signers(aggregation.Signatures))
+
+	transfer = createSignedTransfer(t, state, 2)
+	recordSignatures(t, state, transfer.ID, relayerC, relayerD)
+	aggregation, err = aggregator.Aggregate(context.Background(), transfer)
+	require.NoError(t, err)
+	assert.False(t, aggregation.Ready, "two signers do not satisfy three required signatures")
+}
+
+func TestSignatureAggregator_RequiresWeight(t *testing.T) {
+	aggregator, state, _ := newTestAggregator(fixedRelayers{
+		{Address: relayerA, IsActive: true, ThresholdWeight: 1},
+		{Address: relayerB, IsActive: true, ThresholdWeight: 0},
+		{Address: relayerC, IsActive: true, ThresholdWeight: 2},
+	}, 2)
+	transfer := createSignedTransfer(t, state, 1)
+
+	// Relayers of weight 0 count as signers but not towards the weight
+	recordSignatures(t, state, transfer.ID, relayerA, relayerB)
+	aggregation, err := aggregator.Aggregate(context.Background(), transfer)
+	require.NoError(t, err)
+	assert.False(t, aggregation.Ready)
+	assert.Equal(t, 1, aggregation.Weight)
+	assert.Equal(t, 2, aggregation.RequiredWeight)
+
+	recordSignatures(t, state, transfer.ID, relayerC)
+	aggregation, err = aggregator.Aggregate(context.Background(), transfer)
+	require.NoError(t, err)
+	assert.True(t, aggregation.Ready)
+	assert.Equal(t, []string{relayerC, relayerA}, signers(aggregation.Signatures))
+	assert.Equal(t, 3, aggregation.Weight)
+
+	// A configured weight can ask for more signatures than the bridge
+	aggregator.RequiredWeight = 4
+	aggregation, err = aggregator.Aggregate(context.Background(), transfer)
+	require.NoError(t, err)
+	assert.False(t, aggregation.Ready)
+	assert.Len(t, aggregation.Signatures, 3)
+}
+
+func TestSignatureAggregator_ReportsLaggingRelayers(t *testing.T) {
+	aggregator, state, _ := newTestAggregator(fixedRelayers{
+		{Address: relayerA, IsActive: true, ThresholdWeight: 1},
+		{Address: relayerB, IsActive: true, ThresholdWeight: 1},
+		{Address: relayerC, IsActive: true, ThresholdWeight: 1},
+	}, 2)
+	lagged := createSignedTransfer(t, state, 1)
+	recordSignatures(t, state, lagged.ID, relayerB, relayerC)
+	now := time.Now()
+	aggregator.now = func() time.Time { return now }
+
+	// Relayers are not lagging before the timeout
+	aggregation, err := aggregator.Aggregate(context.Background(), lagged)
+	require.NoError(t, err)
+	assert.Empty(t, aggregation.Lagging)
+	assert.True(t, aggregator.IsHealthy(relayerA))
+
+	now = now.Add(DefaultLagTimeout)
+	aggregation, err = aggregator.Aggregate(context.Background(), lagged)
+	require.NoError(t, err)
+	assert.Equal(t, []string{relayerA}, aggregation.Lagging)
+	assert.False(t, aggregator.IsHealthy(relayerA))
+
+	// Signatures of healthy relayers are preferred
+	transfer := createSignedTransfer(t, state, 2)
+	recordSignatures(t, state, transfer.ID, relayerA, relayerB, relayerC)
+	aggregation, err = aggregator.Aggregate(context.Background(), transfer)
+	require.NoError(t, err)
+	assert.True(t, aggregation.Ready)
+	assert.Equal(t, []string{relayerB, relayerC}, signers(aggregation.Signatures))
+
+	now = now.Add(DefaultHealthWindow)
+	assert.True(t, aggregator.IsHealthy(relayerA))
+	aggregation, err = aggregator.Aggregate(context.Background(), transfer)
+	require.NoError(t, err)
+	assert.Equal(t, []string{relayerA, relayerB}, signers(aggregation.Signatures))
+}
+
+func TestSignatureAggregator_UnknownDestination(t *testing.T) {
+	aggregator, state, _ := newTestAggregator(nil, 2)
+	transfer := createSignedTransfer(t, state, 1)
+	transfer.DestinationChain = types.ChainEthereum
+
+	_, err := aggregator.Aggregate(context.Background(), transfer)
+	assert.Error(t, err)
+}
@@ -0,0 +1,81 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+
+	"nexus-bridge/pkg/types"
+)
+
+// ConfirmationCounter counts the confirmations of a transaction on a chain
+type ConfirmationCounter interface {
+	GetBlockConfirmations(ctx context.Context, txHash string) (uint64, error)
+}
+
+// ConfirmationStore records the confirmations of transfers and promotes
+// those confirmed
+type ConfirmationStore interface {
+	UpdateConfirmations(ctx context.Context, transferID string, confirmations uint64) error
+	UpdateTransferStatus(ctx context.Context, transferID string, status types.TransferStatus) error
+}
+
+// sourceChain is a chain transfers are locked on and the confirmations
+// their lock transaction needs
+type sourceChain struct {
+	counter  ConfirmationCounter
+	required uint64
+}
+
+// ConfirmationTracker is a TransferExecutor draining the confirming queue.
+// A transfer moves to pending once its lock transaction has the
+// confirmations its source chain requires, so nothing is signed for a lock
+// a reorg could still undo. A lock transaction that is no longer found
+// keeps its transfer confirming.
+type ConfirmationTracker struct {
+	state  ConfirmationStore
+	chains map[types.ChainID]sourceChain
+}
+
+var _ TransferExecutor = (*ConfirmationTracker)(nil)
+
+// NewConfirmationTracker creates a tracker recording confirmations in the
+// state manager
+func NewConfirmationTracker(state ConfirmationStore) *ConfirmationTracker {
+	return &ConfirmationTracker{
+		state:  state,
+		chains: make(map[types.ChainID]sourceChain),
+	}
+}
+
+// AddChain registers a source chain and the confirmations lock transactions
+// need on it
+func (t *ConfirmationTracker) AddChain(chainID types.ChainID, counter ConfirmationCounter, required uint64) {
+	t.chains[chainID] = sourceChain{counter: counter, required: required}
+}
+
+// Execute records the confirmations of a transfer's lock transaction and
+// moves the transfer to pending once there are enough
+func (t *ConfirmationTracker) Execute(ctx context.Context, transfer types.Transfer) error {
+	chain, exists := t.chains[transfer.SourceChain]
+	if !exists {
+		return fmt.Errorf("no chain registered for: %s", transfer.SourceChain)
+	}
+
+	confirmations, err := chain.counter.GetBlockConfirmations(ctx, transfer.SourceTxHash)
+	if err != nil {
+		return fmt.Errorf("failed to count confirmations: %w", err)
+	}
+	if confirmations != transfer.Confirmations {
+		if err := t.state.UpdateConfirmations(ctx, transfer.ID, confirmations); err != nil {
+			return fmt.Errorf("failed to record confirmations: %w", err)
+		}
+	}
+	if confirmations < chain.required {
+		return nil
+	}
+
+	if err := t.state.UpdateTransferStatus(ctx, transfer.ID, types.StatusPending); err != nil {
+		return fmt.Errorf("failed to mark transfer pending: %w", err)
+	}
+	return nil
+}
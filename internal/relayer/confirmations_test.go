@@ -0,0 +1,63 @@
+package relayer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+// fakeCounter reports a fixed number of confirmations
+type fakeCounter struct {
+	confirmations uint64
+	err           error
+}
+
+func (f *fakeCounter) GetBlockConfirmations(ctx context.Context, txHash string) (uint64, error) {
+	return f.confirmations, f.err
+}
+
+func newTestConfirmationTracker(t *testing.T) (*ConfirmationTracker, *fakeCounter, *models.MemoryStateManager, types.Transfer) {
+	state := models.NewMemoryStateManager()
+	counter := &fakeCounter{}
+	tracker := NewConfirmationTracker(state)
+	tracker.AddChain(types.ChainEthereum, counter, 12)
+
+	transfer := createValidatorTransfer()
+	transfer.Status = types.StatusConfirming
+	transfer.SourceTxHash = "0x1111111111111111111111111111111111111111111111111111111111111111"
+	require.NoError(t, state.RecordTransfer(context.Background(), transfer))
+	return tracker, counter, state, transfer
+}
+
+func TestConfirmationTracker_PromotesConfirmedTransfer(t *testing.T) {
+	tracker, counter, state, transfer := newTestConfirmationTracker(t)
+
+	counter.confirmations = 11
+	require.NoError(t, tracker.Execute(context.Background(), transfer))
+	assertStatus(t, state, transfer.ID, types.StatusConfirming)
+
+	counter.confirmations = 12
+	require.NoError(t, tracker.Execute(context.Background(), transfer))
+	assertStatus(t, state, transfer.ID, types.StatusPending)
+
+	recorded, err := state.GetTransfer(context.Background(), transfer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(12), recorded.Confirmations)
+}
+
+func TestConfirmationTracker_MissingLockStaysConfirming(t *testing.T) {
+	tracker, counter, state, transfer := newTestConfirmationTracker(t)
+	counter.err = errors.New("not found")
+
+	assert.Error(t, tracker.Execute(context.Background(), transfer))
+	assertStatus(t, state, transfer.ID, types.StatusConfirming)
+
+	transfer.SourceChain = types.ChainID(56)
+	assert.Error(t, tracker.Execute(context.Background(), transfer))
+}
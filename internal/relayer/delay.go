@@ -0,0 +1,132 @@
+package relayer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+// DefaultTransferDelay is how long transfers above a delay threshold wait
+// before execution
+const DefaultTransferDelay = time.Hour
+
+// ErrTransferDelayed is returned when a transfer is held by a delay that
+// was not released
+var ErrTransferDelayed = errors.New("transfer is delayed")
+
+// DelayGateStore provides the delay thresholds of transfers and persists
+// their delays
+type DelayGateStore interface {
+	GetTransferLimits(ctx context.Context, transfer types.Transfer) ([]models.TokenLimit, error)
+	DelayTransfer(ctx context.Context, transferID string, releaseAt time.Time) (*models.TransferDelay, error)
+	GetTransferDelay(ctx context.Context, transferID string) (*models.TransferDelay, error)
+	ReleaseDueDelays(ctx context.Context, now time.Time) ([]models.TransferDelay, error)
+}
+
+// DelayGate is a TransferExecutor holding signed transfers above a delay
+// threshold of their token for Delay before the next executor submits them.
+// Delays are persisted, so every relayer enforces them across restarts: a
+// delayed transfer leaves the signed status until Run releases it, and is
+// only passed on once its delay was released. Operators may cancel it in
+// the meantime, which fails it.
+type DelayGate struct {
+	store        DelayGateStore
+	next         TransferExecutor
+	Delay        time.Duration
+	PollInterval time.Duration
+	now          func() time.Time
+}
+
+var _ TransferExecutor = (*DelayGate)(nil)
+
+// NewDelayGate creates a gate in front of the executor submitting transfers
+func NewDelayGate(store DelayGateStore, next TransferExecutor) *DelayGate {
+	return &DelayGate{
+		store:        store,
+		next:         next,
+		Delay:        DefaultTransferDelay,
+		PollInterval: DefaultPollInterval,
+		now:          time.Now,
+	}
+}
+
+// Execute delays a transfer above its threshold, and passes on any other
+// transfer or one whose delay was released
+func (g *DelayGate) Execute(ctx context.Context, transfer types.Transfer) error {
+	delay, err := g.store.GetTransferDelay(ctx, transfer.ID)
+	if err == nil {
+		return g.afterDelay(ctx, transfer, delay)
+	}
+	if !errors.Is(err, models.ErrDelayNotFound) {
+		return fmt.Errorf("failed to get transfer delay: %w", err)
+	}
+
+	limits, err := g.store.GetTransferLimits(ctx, transfer)
+	if err != nil {
+		return fmt.Errorf("failed to get transfer limits: %w", err)
+	}
+	threshold := models.DelayThreshold(limits)
+	if threshold == nil || transfer.Amount == nil || transfer.Amount.Int == nil || transfer.Amount.Cmp(threshold) <= 0 {
+		return g.next.Execute(ctx, transfer)
+	}
+
+	delay, err = g.store.DelayTransfer(ctx, transfer.ID, g.now().Add(g.Delay))
+	if err != nil {
+		return fmt.Errorf("failed to delay transfer: %w", err)
+	}
+	if delay.Status != models.DelayWaiting {
+		// Another relayer delayed it first
+		return g.afterDelay(ctx, transfer, delay)
+	}
+
+	fmt.Printf("Delayed transfer %s of %s above the threshold of %s until %s\n",
+		transfer.ID, transfer.Amount, threshold, delay.ReleaseAt.Format(time.RFC3339))
+	return nil
+}
+
+// afterDelay passes on a transfer whose delay was released
+func (g *DelayGate) afterDelay(ctx context.Context, transfer types.Transfer, delay *models.TransferDelay) error {
+	switch delay.Status {
+	case models.DelayReleased:
+		return g.next.Execute(ctx, transfer)
+	case models.DelayCancelled:
+		return fmt.Errorf("%w: delay cancelled by %s", ErrTransferDelayed, delay.CancelledBy)
+	default:
+		return fmt.Errorf("%w: until %s", ErrTransferDelayed, delay.ReleaseAt.Format(time.RFC3339))
+	}
+}
+
+// Run releases due delays until the context ends, returning their
+// transfers to the signed queue
+func (g *DelayGate) Run(ctx context.Context) error {
+	ticker := time.NewTicker(g.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := g.ReleaseDue(ctx); err != nil && ctx.Err() == nil {
+			fmt.Printf("Error releasing delayed transfers: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// ReleaseDue releases the delays that are due and returns them
+func (g *DelayGate) ReleaseDue(ctx context.Context) ([]models.TransferDelay, error) {
+	released, err := g.store.ReleaseDueDelays(ctx, g.now())
+	if err != nil {
+		return nil, err
+	}
+	for _, delay := range released {
+		fmt.Printf("Released delayed transfer %s\n", delay.TransferID)
+	}
+	return released, nil
+}
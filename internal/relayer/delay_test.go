@@ -0,0 +1,105 @@
+package relayer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+func newTestDelayGate(t *testing.T, limit models.TokenLimit) (*DelayGate, *recordingExecutor, *models.MemoryStateManager) {
+	state := models.NewMemoryStateManager()
+	setupLimitedToken(t, state, limit)
+	next := newRecordingExecutor(state)
+	return NewDelayGate(state, next), next, state
+}
+
+func assertStatus(t *testing.T, state *models.MemoryStateManager, transferID string, status types.TransferStatus) {
+	transfer, err := state.GetTransfer(context.Background(), transferID)
+	require.NoError(t, err)
+	assert.Equal(t, status, transfer.Status)
+}
+
+func TestDelayGate_SmallTransferPassesThrough(t *testing.T) {
+	gate, next, state := newTestDelayGate(t, models.TokenLimit{DelayThreshold: ether(5)})
+	transfer := createSignedTransfer(t, state, 1)
+
+	require.NoError(t, gate.Execute(context.Background(), transfer))
+	assert.Equal(t, 1, next.Executed()[transfer.ID])
+	assertStatus(t, state, transfer.ID, types.StatusExecuting)
+}
+
+func TestDelayGate_LargeTransferWaitsForRelease(t *testing.T) {
+	gate, next, state := newTestDelayGate(t, models.TokenLimit{DelayThreshold: ether(5)})
+	now := time.Now()
+	gate.now = func() time.Time { return now }
+
+	transfer := createSignedTransfer(t, state, 1)
+	transfer.Amount = ether(6)
+
+	require.NoError(t, gate.Execute(context.Background(), transfer))
+	assert.Empty(t, next.Executed())
+	assertStatus(t, state, transfer.ID, types.StatusDelayed)
+
+	delay, err := state.GetTransferDelay(context.Background(), transfer.ID)
+	require.NoError(t, err)
+	assert.True(t, delay.ReleaseAt.Equal(now.Add(DefaultTransferDelay)))
+
+	// Another relayer picking up the transfer is held by the same delay
+	assert.ErrorIs(t, gate.Execute(context.Background(), transfer), ErrTransferDelayed)
+	assert.Empty(t, next.Executed())
+
+	released, err := gate.ReleaseDue(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, released)
+
+	now = now.Add(DefaultTransferDelay)
+	released, err = gate.ReleaseDue(context.Background())
+	require.NoError(t, err)
+	require.Len(t, released, 1)
+	assertStatus(t, state, transfer.ID, types.StatusSigned)
+
+	require.NoError(t, gate.Execute(context.Background(), transfer))
+	assert.Equal(t, 1, next.Executed()[transfer.ID])
+}
+
+func TestDelayGate_CancelledTransferIsNotExecuted(t *testing.T) {
+	gate, next, state := newTestDelayGate(t, models.TokenLimit{DelayThreshold: ether(5)})
+	transfer := createSignedTransfer(t, state, 1)
+	transfer.Amount = ether(6)
+
+	require.NoError(t, gate.Execute(context.Background(), transfer))
+	_, err := state.CancelTransferDelay(context.Background(), transfer.ID, "alice", "recipient is an exploit contract", nil)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, gate.Execute(context.Background(), transfer), ErrTransferDelayed)
+	assert.Empty(t, next.Executed())
+	assertStatus(t, state, transfer.ID, types.StatusFailed)
+}
+
+func TestDelayGate_LowestThresholdApplies(t *testing.T) {
+	gate, next, state := newTestDelayGate(t, models.TokenLimit{DelayThreshold: ether(50)})
+	route := models.TokenLimit{
+		TokenID:          1,
+		DestinationChain: types.ChainPolygon,
+		DelayThreshold:   ether(5),
+	}
+	require.NoError(t, state.SetTokenLimit(context.Background(), &route, nil))
+
+	transfer := createSignedTransfer(t, state, 1)
+	transfer.Amount = ether(6)
+	require.NoError(t, gate.Execute(context.Background(), transfer))
+	assertStatus(t, state, transfer.ID, types.StatusDelayed)
+
+	// Routes without their own threshold use the token's
+	other := createSignedTransfer(t, state, 2)
+	other.Amount = ether(6)
+	other.DestinationChain = types.ChainCosmos
+	require.NoError(t, gate.Execute(context.Background(), other))
+	assert.Equal(t, 1, next.Executed()[other.ID])
+}
@@ -0,0 +1,97 @@
+package relayer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"nexus-bridge/internal/adapters"
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+// Aggregator selects the signatures a transfer is submitted with
+type Aggregator interface {
+	Aggregate(ctx context.Context, transfer types.Transfer) (*Aggregation, error)
+}
+
+// TransferSubmitter submits the destination transaction of a transfer
+type TransferSubmitter interface {
+	Submit(ctx context.Context, transferID string, chainID types.ChainID, tx types.Transaction) (*models.OutboxEntry, error)
+}
+
+var (
+	_ Aggregator        = (*SignatureAggregator)(nil)
+	_ TransferSubmitter = (*Submitter)(nil)
+)
+
+// destinationBridge is the bridge completing transfers on a chain
+type destinationBridge struct {
+	contract string
+	method   string
+}
+
+// DestinationExecutor is a TransferExecutor completing signed transfers on
+// their destination bridge once enough relayers signed them. Transfers
+// still collecting signatures stay signed and are tried again on a later
+// lease.
+type DestinationExecutor struct {
+	aggregator Aggregator
+	submitter  TransferSubmitter
+	bridges    map[types.ChainID]destinationBridge
+}
+
+var _ TransferExecutor = (*DestinationExecutor)(nil)
+
+// NewDestinationExecutor creates an executor submitting aggregated
+// signatures through a submitter
+func NewDestinationExecutor(aggregator Aggregator, submitter TransferSubmitter) *DestinationExecutor {
+	return &DestinationExecutor{
+		aggregator: aggregator,
+		submitter:  submitter,
+		bridges:    make(map[types.ChainID]destinationBridge),
+	}
+}
+
+// AddChain registers the bridge contract of a destination chain and the
+// method completing transfers on it, unlockTokens or mintTokens
+func (e *DestinationExecutor) AddChain(chainID types.ChainID, contract, method string) {
+	e.bridges[chainID] = destinationBridge{contract: contract, method: method}
+}
+
+// Execute submits a transfer with its selected signatures once it is ready
+func (e *DestinationExecutor) Execute(ctx context.Context, transfer types.Transfer) error {
+	bridge, exists := e.bridges[transfer.DestinationChain]
+	if !exists {
+		return fmt.Errorf("no bridge registered for chain: %s", transfer.DestinationChain)
+	}
+
+	aggregation, err := e.aggregator.Aggregate(ctx, transfer)
+	if err != nil {
+		return err
+	}
+	if len(aggregation.Lagging) > 0 {
+		fmt.Printf("Relayers lagging on transfer %s: %v\n", transfer.ID, aggregation.Lagging)
+	}
+	if !aggregation.Ready {
+		return nil
+	}
+
+	signatures := make([][]byte, len(aggregation.Signatures))
+	for i, signature := range aggregation.Signatures {
+		signatures[i] = signature.Signature
+	}
+	data, err := adapters.PackExecuteCall(bridge.method, transfer, signatures)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s call: %w", bridge.method, err)
+	}
+
+	_, err = e.submitter.Submit(ctx, transfer.ID, transfer.DestinationChain, types.Transaction{
+		To:   bridge.contract,
+		Data: data,
+	})
+	if err != nil && !errors.Is(err, ErrTransferProcessed) {
+		return fmt.Errorf("failed to submit transfer: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package relayer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/internal/adapters"
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+const testBridge = "0x5FbDB2315678afecb367f032d93F642f64180aa3"
+
+func newTestDestinationExecutor(t *testing.T, required uint64) (*DestinationExecutor, *models.MemoryTxOutbox, *models.MemoryStateManager, types.Transfer) {
+	state := models.NewMemoryStateManager()
+	relayers := fixedRelayers{
+		{Address: relayerA, IsActive: true, ThresholdWeight: 1},
+		{Address: relayerB, IsActive: true, ThresholdWeight: 1},
+	}
+	destination := &fixedDestination{required: required, authorized: map[string]bool{relayerA: true, relayerB: true}}
+	aggregator := NewSignatureAggregator(state, relayers, destination)
+
+	outbox := models.NewMemoryTxOutbox()
+	submitter := NewSubmitter(outbox, state)
+	submitter.RegisterSender(types.ChainPolygon, newFakeSender())
+
+	executor := NewDestinationExecutor(aggregator, submitter)
+	executor.AddChain(types.ChainPolygon, testBridge, adapters.MethodMintTokens)
+
+	transfer := createValidatorTransfer()
+	transfer.Status = types.StatusSigned
+	require.NoError(t, state.RecordTransfer(context.Background(), transfer))
+	return executor, outbox, state, transfer
+}
+
+func TestDestinationExecutor_WaitsForSignatures(t *testing.T) {
+	executor, outbox, state, transfer := newTestDestinationExecutor(t, 2)
+	recordSignatures(t, state, transfer.ID, relayerA)
+
+	require.NoError(t, executor.Execute(context.Background(), transfer))
+	entry, err := outbox.GetLiveByTransfer(context.Background(), transfer.ID)
+	require.NoError(t, err)
+	assert.Nil(t, entry)
+	assertStatus(t, state, transfer.ID, types.StatusSigned)
+}
+
+func TestDestinationExecutor_SubmitsReadyTransfer(t *testing.T) {
+	executor, outbox, state, transfer := newTestDestinationExecutor(t, 2)
+	recordSignatures(t, state, transfer.ID, relayerA, relayerB)
+
+	require.NoError(t, executor.Execute(context.Background(), transfer))
+	entry, err := outbox.GetLiveByTransfer(context.Background(), transfer.ID)
+	require.NoError(t, err)
+	require.NotNil(t, entry)
+	assert.Equal(t, types.ChainPolygon, entry.ChainID)
+	assertStatus(t, state, transfer.ID, types.StatusExecuting)
+}
+
+func TestDestinationExecutor_UnknownChain(t *testing.T) {
+	executor, _, state, transfer := newTestDestinationExecutor(t, 1)
+	recordSignatures(t, state, transfer.ID, relayerA)
+
+	transfer.DestinationChain = types.ChainID(56)
+	assert.Error(t, executor.Execute(context.Background(), transfer))
+}
@@ -0,0 +1,243 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+const (
+	// DefaultMaxEventAttempts is how many times a failing event is retried
+	DefaultMaxEventAttempts = 5
+	// DefaultStaleClaimTimeout is how long an event may stay claimed before
+	// it is assumed abandoned by a crashed process
+	DefaultStaleClaimTimeout = 5 * time.Minute
+	// DefaultRetryInterval is how often Run looks for failed events to retry
+	DefaultRetryInterval = 10 * time.Second
+	// DefaultEventBaseBackoff is the delay before a failed event is first
+	// retried. Each further failure doubles it.
+	DefaultEventBaseBackoff = 5 * time.Second
+	// DefaultEventMaxBackoff caps the delay between retries of an event
+	DefaultEventMaxBackoff = 5 * time.Minute
+)
+
+// Dispatcher routes observed events to their handlers through the event
+// inbox, so each event is handled once even when it is observed again by an
+// overlapping scan or after a restart
+type Dispatcher struct {
+	inbox             models.EventInbox
+	handlers          map[types.EventType]types.EventHandler
+	mu                sync.RWMutex
+	now               func() time.Time
+	retries           map[inboxRetryKey]eventRetry
+	retriesMu         sync.Mutex
+	MaxAttempts       int
+	StaleClaimTimeout time.Duration
+	RetryInterval     time.Duration
+	BaseBackoff       time.Duration
+	MaxBackoff        time.Duration
+}
+
+// inboxRetryKey identifies an event by its position on chain, like the inbox
+type inboxRetryKey struct {
+	chainID  types.ChainID
+	txHash   string
+	logIndex uint
+}
+
+// eventRetry records when a failed event may next be retried by this process
+type eventRetry struct {
+	failures int
+	retryAt  time.Time
+}
+
+// NewDispatcher creates a new dispatcher backed by an event inbox
+func NewDispatcher(inbox models.EventInbox) *Dispatcher {
+	return &Dispatcher{
+		inbox:             inbox,
+		handlers:          make(map[types.EventType]types.EventHandler),
+		now:               time.Now,
+		retries:           make(map[inboxRetryKey]eventRetry),
+		MaxAttempts:       DefaultMaxEventAttempts,
+		StaleClaimTimeout: DefaultStaleClaimTimeout,
+		RetryInterval:     DefaultRetryInterval,
+		BaseBackoff:       DefaultEventBaseBackoff,
+		MaxBackoff:        DefaultEventMaxBackoff,
+	}
+}
+
+// RegisterHandler registers the handler for an event type
+func (d *Dispatcher) RegisterHandler(eventType types.EventType, handler types.EventHandler) error {
+	if handler == nil {
+		return fmt.Errorf("handler is required")
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, exists := d.handlers[eventType]; exists {
+		return fmt.Errorf("handler already registered for event type: %s", eventType)
+	}
+
+	d.handlers[eventType] = handler
+	return nil
+}
+
+// Dispatch records an event in the inbox and, if it has not been handled
+// yet, invokes its handler
+func (d *Dispatcher) Dispatch(ctx context.Context, event types.Event) error {
+	if _, err := d.inbox.Record(ctx, event); err != nil {
+		return fmt.Errorf("failed to record event %s: %w", event.ID, err)
+	}
+
+	return d.process(ctx, event)
+}
+
+// Run dispatches events from a channel until it is closed or the context ends.
+// Every RetryInterval it also retries failed events whose backoff has
+// elapsed, so a transient handler failure does not wait for a restart.
+func (d *Dispatcher) Run(ctx context.Context, events <-chan types.Event) error {
+	ticker := time.NewTicker(d.RetryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if err := d.Dispatch(ctx, event); err != nil {
+				fmt.Printf("Error dispatching event %s: %v\n", event.ID, err)
+			}
+		case <-ticker.C:
+			if _, err := d.RetryFailed(ctx); err != nil && ctx.Err() == nil {
+				fmt.Printf("Error retrying events: %v\n", err)
+			}
+		}
+	}
+}
+
+// RetryFailed reprocesses pending events whose backoff has elapsed,
+// returning how many were attempted. Events that failed in this process wait
+// out their backoff; any other pending event is retried straight away.
+func (d *Dispatcher) RetryFailed(ctx context.Context) (int, error) {
+	pending, err := d.inbox.ListPending(ctx, d.MaxAttempts, 1000)
+	if err != nil {
+		return 0, err
+	}
+
+	attempted := 0
+	now := d.now()
+	for _, event := range pending {
+		d.retriesMu.Lock()
+		retry, failed := d.retries[retryKeyForEvent(event)]
+		d.retriesMu.Unlock()
+		if failed && now.Before(retry.retryAt) {
+			continue
+		}
+
+		attempted++
+		if err := d.process(ctx, event); err != nil {
+			fmt.Printf("Error retrying event %s: %v\n", event.ID, err)
+		}
+	}
+
+	return attempted, nil
+}
+
+// Recover releases events abandoned by a crashed process and retries every
+// event that has not been handled yet. It should be called on startup.
+func (d *Dispatcher) Recover(ctx context.Context) error {
+	if _, err := d.inbox.ReleaseStale(ctx, time.Now().Add(-d.StaleClaimTimeout)); err != nil {
+		return err
+	}
+
+	pending, err := d.inbox.ListPending(ctx, d.MaxAttempts, 1000)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range pending {
+		if err := d.process(ctx, event); err != nil {
+			fmt.Printf("Error reprocessing event %s: %v\n", event.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// process claims an event and runs its handler
+func (d *Dispatcher) process(ctx context.Context, event types.Event) error {
+	claimed, err := d.inbox.Claim(ctx, event, d.MaxAttempts)
+	if err != nil {
+		return fmt.Errorf("failed to claim event %s: %w", event.ID, err)
+	}
+	if !claimed {
+		return nil
+	}
+
+	d.mu.RLock()
+	handler, exists := d.handlers[event.Type]
+	d.mu.RUnlock()
+
+	if !exists {
+		cause := fmt.Errorf("no handler registered for event type: %s", event.Type)
+		if err := d.inbox.MarkFailed(ctx, event, cause); err != nil {
+			return err
+		}
+		return cause
+	}
+
+	if err := handler.Handle(ctx, event); err != nil {
+		d.scheduleRetry(event)
+		if markErr := d.inbox.MarkFailed(ctx, event, err); markErr != nil {
+			return fmt.Errorf("handler failed: %v; %w", err, markErr)
+		}
+		return fmt.Errorf("handler failed for event %s: %w", event.ID, err)
+	}
+
+	d.retriesMu.Lock()
+	delete(d.retries, retryKeyForEvent(event))
+	d.retriesMu.Unlock()
+
+	return d.inbox.MarkProcessed(ctx, event)
+}
+
+// scheduleRetry backs off a failed event before RetryFailed picks it up again
+func (d *Dispatcher) scheduleRetry(event types.Event) {
+	d.retriesMu.Lock()
+	defer d.retriesMu.Unlock()
+
+	key := retryKeyForEvent(event)
+	retry := d.retries[key]
+	retry.failures++
+	if retry.failures >= d.MaxAttempts {
+		// The inbox will not hand the event out again
+		delete(d.retries, key)
+		return
+	}
+	retry.retryAt = d.now().Add(d.backoff(retry.failures))
+	d.retries[key] = retry
+}
+
+// backoff returns the delay after a number of failed attempts
+func (d *Dispatcher) backoff(failures int) time.Duration {
+	delay := d.BaseBackoff
+	for i := 1; i < failures; i++ {
+		delay *= 2
+		if delay >= d.MaxBackoff {
+			return d.MaxBackoff
+		}
+	}
+	return delay
+}
+
+func retryKeyForEvent(event types.Event) inboxRetryKey {
+	return inboxRetryKey{chainID: event.ChainID, txHash: event.TxHash, logIndex: event.LogIndex}
+}
@@ -0,0 +1,235 @@
+package relayer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+// countingHandler counts invocations and optionally fails the first calls
+type countingHandler struct {
+	mu        sync.Mutex
+	eventType types.EventType
+	calls     int
+	failFirst int
+}
+
+func (h *countingHandler) Handle(ctx context.Context, event types.Event) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.calls++
+	if h.calls <= h.failFirst {
+		return errors.New("temporary failure")
+	}
+	return nil
+}
+
+func (h *countingHandler) GetEventType() types.EventType {
+	return h.eventType
+}
+
+func (h *countingHandler) Calls() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.calls
+}
+
+func createTestEvent(logIndex uint) types.Event {
+	return types.Event{
+		ID:          "0xabcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890-0",
+		Type:        types.EventTypeLock,
+		ChainID:     types.ChainEthereum,
+		TxHash:      "0xabcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890",
+		LogIndex:    logIndex,
+		BlockNumber: 100,
+		Timestamp:   time.Now(),
+	}
+}
+
+func TestDispatcher_DuplicateEventsHandledOnce(t *testing.T) {
+	dispatcher := NewDispatcher(models.NewMemoryEventInbox())
+	handler := &countingHandler{eventType: types.EventTypeLock}
+	require.NoError(t, dispatcher.RegisterHandler(types.EventTypeLock, handler))
+
+	event := createTestEvent(0)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, dispatcher.Dispatch(context.Background(), event))
+	}
+
+	assert.Equal(t, 1, handler.Calls())
+}
+
+func TestDispatcher_ConcurrentDuplicatesHandledOnce(t *testing.T) {
+	dispatcher := NewDispatcher(models.NewMemoryEventInbox())
+	handler := &countingHandler{eventType: types.EventTypeLock}
+	require.NoError(t, dispatcher.RegisterHandler(types.EventTypeLock, handler))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = dispatcher.Dispatch(context.Background(), createTestEvent(0))
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, handler.Calls())
+}
+
+func TestDispatcher_RecoverRetriesFailedEvents(t *testing.T) {
+	inbox := models.NewMemoryEventInbox()
+	dispatcher := NewDispatcher(inbox)
+	handler := &countingHandler{eventType: types.EventTypeLock, failFirst: 1}
+	require.NoError(t, dispatcher.RegisterHandler(types.EventTypeLock, handler))
+
+	event := createTestEvent(0)
+	assert.Error(t, dispatcher.Dispatch(context.Background(), event))
+
+	status, _, _ := inbox.Status(event)
+	assert.Equal(t, models.InboxStatusFailed, status)
+
+	// A restarted process recovers the failed event
+	restarted := NewDispatcher(inbox)
+	require.NoError(t, restarted.RegisterHandler(types.EventTypeLock, handler))
+	require.NoError(t, restarted.Recover(context.Background()))
+
+	status, attempts, _ := inbox.Status(event)
+	assert.Equal(t, models.InboxStatusProcessed, status)
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, 2, handler.Calls())
+
+	// A rescan after recovery does not invoke the handler again
+	require.NoError(t, restarted.Dispatch(context.Background(), event))
+	assert.Equal(t, 2, handler.Calls())
+}
+
+func TestDispatcher_RecoverReleasesAbandonedClaims(t *testing.T) {
+	inbox := models.NewMemoryEventInbox()
+	event := createTestEvent(0)
+
+	// Simulate a process that crashed after claiming the event
+	_, err := inbox.Record(context.Background(), event)
+	require.NoError(t, err)
+	claimed, err := inbox.Claim(context.Background(), event, DefaultMaxEventAttempts)
+	require.NoError(t, err)
+	require.True(t, claimed)
+
+	dispatcher := NewDispatcher(inbox)
+	dispatcher.StaleClaimTimeout = 0
+	handler := &countingHandler{eventType: types.EventTypeLock}
+	require.NoError(t, dispatcher.RegisterHandler(types.EventTypeLock, handler))
+
+	require.NoError(t, dispatcher.Recover(context.Background()))
+	assert.Equal(t, 1, handler.Calls())
+}
+
+func TestDispatcher_GivesUpAfterMaxAttempts(t *testing.T) {
+	dispatcher := NewDispatcher(models.NewMemoryEventInbox())
+	dispatcher.MaxAttempts = 2
+	handler := &countingHandler{eventType: types.EventTypeLock, failFirst: 10}
+	require.NoError(t, dispatcher.RegisterHandler(types.EventTypeLock, handler))
+
+	event := createTestEvent(0)
+	for i := 0; i < 5; i++ {
+		_ = dispatcher.Dispatch(context.Background(), event)
+	}
+
+	assert.Equal(t, 2, handler.Calls())
+}
+
+func TestDispatcher_RegisterHandler(t *testing.T) {
+	dispatcher := NewDispatcher(models.NewMemoryEventInbox())
+	handler := &countingHandler{eventType: types.EventTypeLock}
+
+	assert.Error(t, dispatcher.RegisterHandler(types.EventTypeLock, nil))
+	assert.NoError(t, dispatcher.RegisterHandler(types.EventTypeLock, handler))
+	assert.Error(t, dispatcher.RegisterHandler(types.EventTypeLock, handler))
+}
+
+func TestDispatcher_MissingHandlerFailsEvent(t *testing.T) {
+	inbox := models.NewMemoryEventInbox()
+	dispatcher := NewDispatcher(inbox)
+
+	event := createTestEvent(0)
+	err := dispatcher.Dispatch(context.Background(), event)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no handler registered")
+
+	status, _, _ := inbox.Status(event)
+	assert.Equal(t, models.InboxStatusFailed, status)
+}
+
+func TestDispatcher_RunRetriesFailedEvents(t *testing.T) {
+	inbox := models.NewMemoryEventInbox()
+	dispatcher := NewDispatcher(inbox)
+	dispatcher.RetryInterval = 10 * time.Millisecond
+	dispatcher.BaseBackoff = 10 * time.Millisecond
+	handler := &countingHandler{eventType: types.EventTypeLock, failFirst: 2}
+	require.NoError(t, dispatcher.RegisterHandler(types.EventTypeLock, handler))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := make(chan types.Event, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- dispatcher.Run(ctx, events)
+	}()
+
+	event := createTestEvent(0)
+	events <- event
+
+	// The event is re-dispatched by the running process, without Recover
+	assert.Eventually(t, func() bool {
+		status, _, _ := inbox.Status(event)
+		return status == models.InboxStatusProcessed
+	}, 2*time.Second, 5*time.Millisecond)
+	assert.Equal(t, 3, handler.Calls())
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+}
+
+func TestDispatcher_RetryFailedWaitsForBackoff(t *testing.T) {
+	inbox := models.NewMemoryEventInbox()
+	dispatcher := NewDispatcher(inbox)
+	now := time.Now()
+	dispatcher.now = func() time.Time { return now }
+	dispatcher.BaseBackoff = time.Minute
+	handler := &countingHandler{eventType: types.EventTypeLock, failFirst: 2}
+	require.NoError(t, dispatcher.RegisterHandler(types.EventTypeLock, handler))
+
+	event := createTestEvent(0)
+	assert.Error(t, dispatcher.Dispatch(context.Background(), event))
+
+	attempted, err := dispatcher.RetryFailed(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, attempted)
+
+	now = now.Add(time.Minute)
+	attempted, err = dispatcher.RetryFailed(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, attempted)
+	assert.Equal(t, 2, handler.Calls())
+
+	// The second failure doubles the backoff
+	now = now.Add(time.Minute)
+	attempted, err = dispatcher.RetryFailed(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, attempted)
+
+	now = now.Add(time.Minute)
+	_, err = dispatcher.RetryFailed(context.Background())
+	require.NoError(t, err)
+	status, attempts, _ := inbox.Status(event)
+	assert.Equal(t, models.InboxStatusProcessed, status)
+	assert.Equal(t, 3, attempts)
+}
@@ -0,0 +1,155 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+const (
+	// DefaultLeaseDuration is how long a transfer stays leased without renewal
+	DefaultLeaseDuration = 2 * time.Minute
+	// DefaultPollInterval is how often an idle executor checks for work
+	DefaultPollInterval = 5 * time.Second
+	// DefaultLeaseBatchSize is how many transfers are leased at a time
+	DefaultLeaseBatchSize = 10
+)
+
+// TransferExecutor executes a single leased transfer
+type TransferExecutor interface {
+	Execute(ctx context.Context, transfer types.Transfer) error
+}
+
+// Executor drains a transfer status queue using row leases, so any number of
+// relayer replicas can run one without executing a transfer twice. Leases are
+// renewed while a transfer is executing; if renewal fails the execution is
+// cancelled, since another replica may take the transfer over.
+type Executor struct {
+	leaser        models.TransferLeaser
+	executor      TransferExecutor
+	owner         string
+	status        types.TransferStatus
+	LeaseDuration time.Duration
+	PollInterval  time.Duration
+	BatchSize     int
+
+	// SkipSignedBy, when set, leases only transfers this relayer address has
+	// not signed, so transfers waiting on other relayers are left to them
+	SkipSignedBy string
+}
+
+// NewExecutor creates an executor for transfers in the given status. The owner
+// must uniquely identify this relayer process.
+func NewExecutor(leaser models.TransferLeaser, executor TransferExecutor, owner string, status types.TransferStatus) *Executor {
+	return &Executor{
+		leaser:        leaser,
+		executor:      executor,
+		owner:         owner,
+		status:        status,
+		LeaseDuration: DefaultLeaseDuration,
+		PollInterval:  DefaultPollInterval,
+		BatchSize:     DefaultLeaseBatchSize,
+	}
+}
+
+// Run leases and executes transfers until the context ends
+func (e *Executor) Run(ctx context.Context) error {
+	ticker := time.NewTicker(e.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		processed, err := e.RunOnce(ctx)
+		if err != nil {
+			fmt.Printf("Error leasing %s transfers: %v\n", e.status, err)
+		}
+
+		// Keep draining while there is a full batch of work
+		if processed == e.BatchSize && err == nil {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+				continue
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce leases one batch of transfers and executes them concurrently. It
+// returns the number of transfers leased.
+func (e *Executor) RunOnce(ctx context.Context) (int, error) {
+	var transfers []types.Transfer
+	var err error
+	if e.SkipSignedBy != "" {
+		transfers, err = e.leaser.LeaseUnsignedTransfers(ctx, e.status, e.SkipSignedBy, e.owner, e.LeaseDuration, e.BatchSize)
+	} else {
+		transfers, err = e.leaser.LeaseTransfers(ctx, e.status, e.owner, e.LeaseDuration, e.BatchSize)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var wg sync.WaitGroup
+	for _, transfer := range transfers {
+		wg.Add(1)
+		go func(transfer types.Transfer) {
+			defer wg.Done()
+			if err := e.execute(ctx, transfer); err != nil {
+				fmt.Printf("Error executing transfer %s: %v\n", transfer.ID, err)
+			}
+		}(transfer)
+	}
+	wg.Wait()
+
+	return len(transfers), nil
+}
+
+// execute runs one leased transfer while keeping its lease alive
+func (e *Executor) execute(ctx context.Context, transfer types.Transfer) error {
+	execCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	done := make(chan struct{})
+	go e.renew(execCtx, cancel, transfer.ID, done)
+
+	err := e.executor.Execute(execCtx, transfer)
+	close(done)
+
+	if releaseErr := e.leaser.ReleaseLease(ctx, transfer.ID, e.owner); releaseErr != nil {
+		fmt.Printf("Error releasing lease on transfer %s: %v\n", transfer.ID, releaseErr)
+	}
+
+	return err
+}
+
+// renew extends a lease every third of its duration until done is closed,
+// cancelling the execution if the lease is lost
+func (e *Executor) renew(ctx context.Context, cancel context.CancelFunc, transferID string, done <-chan struct{}) {
+	ticker := time.NewTicker(e.LeaseDuration / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := e.leaser.RenewLease(ctx, transferID, e.owner, e.LeaseDuration); err != nil {
+				fmt.Printf("Lost lease on transfer %s: %v\n", transferID, err)
+				cancel()
+				return
+			}
+		}
+	}
+}
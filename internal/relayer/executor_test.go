@@ -0,0 +1,179 @@
+package relayer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+// recordingExecutor records executed transfers and moves them to executing
+type recordingExecutor struct {
+	mu       sync.Mutex
+	state    *models.MemoryStateManager
+	executed map[string]int
+	delay    time.Duration
+	err      error
+}
+
+func newRecordingExecutor(state *models.MemoryStateManager) *recordingExecutor {
+	return &recordingExecutor{state: state, executed: make(map[string]int)}
+}
+
+func (r *recordingExecutor) Execute(ctx context.Context, transfer types.Transfer) error {
+	select {
+	case <-time.After(r.delay):
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	r.mu.Lock()
+	r.executed[transfer.ID]++
+	r.mu.Unlock()
+
+	if r.err != nil {
+		return r.err
+	}
+	return r.state.UpdateTransferStatus(ctx, transfer.ID, types.StatusExecuting)
+}
+
+func (r *recordingExecutor) Executed() map[string]int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	executed := make(map[string]int, len(r.executed))
+	for id, count := range r.executed {
+		executed[id] = count
+	}
+	return executed
+}
+
+func TestExecutor_ReplicasShareQueue(t *testing.T) {
+	state := models.NewMemoryStateManager()
+	for n := 1; n <= 20; n++ {
+		createSignedTransfer(t, state, n)
+	}
+
+	executor := newRecordingExecutor(state)
+	executor.delay = 5 * time.Millisecond
+
+	var wg sync.WaitGroup
+	for _, owner := range []string{"relayer-a", "relayer-b", "relayer-c"} {
+		replica := NewExecutor(state, executor, owner, types.StatusSigned)
+		replica.BatchSize = 3
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				processed, err := replica.RunOnce(context.Background())
+				assert.NoError(t, err)
+				if processed == 0 {
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	executed := executor.Executed()
+	assert.Len(t, executed, 20)
+	for id, count := range executed {
+		assert.Equal(t, 1, count, "transfer %s executed more than once", id)
+	}
+}
+
+func TestExecutor_SkipsTransfersSignedByRelayer(t *testing.T) {
+	state := models.NewMemoryStateManager()
+	signed := createSignedTransfer(t, state, 1)
+	unsigned := createSignedTransfer(t, state, 2)
+	recordSignatures(t, state, signed.ID, relayerA)
+
+	executor := newRecordingExecutor(state)
+	replica := NewExecutor(state, executor, "relayer-a", types.StatusSigned)
+	replica.SkipSignedBy = relayerA
+
+	processed, err := replica.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	assert.Equal(t, map[string]int{unsigned.ID: 1}, executor.Executed())
+}
+
+func TestExecutor_ReleasesLeaseAfterFailure(t *testing.T) {
+	state := models.NewMemoryStateManager()
+	transfer := createSignedTransfer(t, state, 1)
+
+	executor := newRecordingExecutor(state)
+	executor.err = errors.New("destination chain unavailable")
+	replica := NewExecutor(state, executor, "relayer-a", types.StatusSigned)
+
+	processed, err := replica.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, processed)
+
+	// The transfer is still signed and immediately available for a retry
+	leased, err := state.LeaseTransfers(context.Background(), types.StatusSigned, "relayer-b", time.Minute, 1)
+	require.NoError(t, err)
+	require.Len(t, leased, 1)
+	assert.Equal(t, transfer.ID, leased[0].ID)
+}
+
+func TestExecutor_RenewsLeaseDuringLongExecution(t *testing.T) {
+	state := models.NewMemoryStateManager()
+	transfer := createSignedTransfer(t, state, 1)
+
+	executor := newRecordingExecutor(state)
+	executor.delay = 150 * time.Millisecond
+	replica := NewExecutor(state, executor, "relayer-a", types.StatusSigned)
+	replica.LeaseDuration = 60 * time.Millisecond
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = replica.RunOnce(context.Background())
+	}()
+
+	// Past the original lease duration the transfer is still held
+	time.Sleep(100 * time.Millisecond)
+	leased, err := state.LeaseTransfers(context.Background(), types.StatusSigned, "relayer-b", time.Minute, 1)
+	require.NoError(t, err)
+	assert.Empty(t, leased)
+
+	<-done
+	assert.Equal(t, 1, executor.Executed()[transfer.ID])
+}
+
+func TestExecutor_CancelsExecutionWhenLeaseIsLost(t *testing.T) {
+	state := models.NewMemoryStateManager()
+	transfer := createSignedTransfer(t, state, 1)
+
+	executor := newRecordingExecutor(state)
+	executor.delay = time.Second
+	replica := NewExecutor(state, executor, "relayer-a", types.StatusSigned)
+	replica.LeaseDuration = 60 * time.Millisecond
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = replica.RunOnce(context.Background())
+	}()
+
+	// Another replica takes over the lease before it is renewed
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, state.ReleaseLease(context.Background(), transfer.ID, "relayer-a"))
+	_, err := state.LeaseTransfers(context.Background(), types.StatusSigned, "relayer-b", time.Minute, 1)
+	require.NoError(t, err)
+
+	select {
+	case <-done:
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("Expected execution to be cancelled after losing the lease")
+	}
+	assert.Empty(t, executor.Executed())
+}
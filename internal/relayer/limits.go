@@ -0,0 +1,167 @@
+package relayer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+// ErrCapReached is returned when signing a transfer would take a token over
+// a volume cap. The transfer stays queued and is retried once enough volume
+// has left the window.
+var ErrCapReached = errors.New("volume cap reached")
+
+// limitsActor is recorded as the author of cap reviews in the audit log
+const limitsActor = "limits"
+
+// CapUsage is the audited volume of a cap a transfer was reviewed for not
+// fitting in
+type CapUsage struct {
+	Cap              string        `json:"cap"`
+	Ceiling          *types.BigInt `json:"ceiling"`
+	Volume           *types.BigInt `json:"volume"`
+	Amount           *types.BigInt `json:"amount"`
+	DestinationChain types.ChainID `json:"destination_chain,omitempty"`
+}
+
+// LimitStore provides the limits of transfers and the volume counted
+// against their caps
+type LimitStore interface {
+	GetTransferLimits(ctx context.Context, transfer types.Transfer) ([]models.TokenLimit, error)
+	SignedVolume(ctx context.Context, query models.VolumeQuery) (*big.Int, error)
+	LockTokenVolume(ctx context.Context, sourceChain types.ChainID, token string) (func(), error)
+}
+
+// LimitCheck enforces per-token and per-route transfer limits. Transfers
+// outside the minimum and maximum amounts, or larger than a cap on their
+// own, are rejected for review. Transfers that fit a cap but not the volume
+// left in its window are held back with ErrCapReached, or rejected for
+// review when ReviewOverCap is set. A review's reason leaves out the volume,
+// which changes as transfers are signed, so an approval keeps matching; the
+// volume is recorded in Audit instead. The token's volume stays locked from
+// the check until the signature is recorded, so relayers signing
+// concurrently count each other's transfers.
+type LimitCheck struct {
+	store         LimitStore
+	ReviewOverCap bool
+	Audit         AuditRecorder
+	now           func() time.Time
+}
+
+// NewLimitCheck creates a pre-sign check enforcing the limits in a store
+func NewLimitCheck(store LimitStore) *LimitCheck {
+	return &LimitCheck{
+		store: store,
+		now:   time.Now,
+	}
+}
+
+var (
+	_ PreSignCheck = (*LimitCheck)(nil)
+	_ SignLocker   = (*LimitCheck)(nil)
+)
+
+// LockSigning locks the volume of the transfer's token
+func (c *LimitCheck) LockSigning(ctx context.Context, transfer types.Transfer) (func(), error) {
+	return c.store.LockTokenVolume(ctx, transfer.SourceChain, transfer.Token)
+}
+
+// Check validates a transfer against every limit applying to it
+func (c *LimitCheck) Check(ctx context.Context, transfer types.Transfer) error {
+	limits, err := c.store.GetTransferLimits(ctx, transfer)
+	if err != nil {
+		return fmt.Errorf("failed to get transfer limits: %w", err)
+	}
+
+	amount := big.NewInt(0)
+	if transfer.Amount != nil && transfer.Amount.Int != nil {
+		amount = transfer.Amount.Int
+	}
+
+	for _, limit := range limits {
+		if limit.MinAmount != nil && limit.MinAmount.Int != nil && amount.Cmp(limit.MinAmount.Int) < 0 {
+			return fmt.Errorf("%w: amount %s is below the minimum of %s%s", ErrTransferRejected, amount, limit.MinAmount, route(limit))
+		}
+		if limit.MaxAmount != nil && limit.MaxAmount.Int != nil && amount.Cmp(limit.MaxAmount.Int) > 0 {
+			return fmt.Errorf("%w: amount %s is above the maximum of %s%s", ErrTransferRejected, amount, limit.MaxAmount, route(limit))
+		}
+		if err := c.checkCap(ctx, transfer, amount, limit, limit.HourlyCap, time.Hour, "hourly"); err != nil {
+			return err
+		}
+		if err := c.checkCap(ctx, transfer, amount, limit, limit.DailyCap, 24*time.Hour, "daily"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkCap checks that a transfer fits in what is left of a cap over a
+// rolling window
+func (c *LimitCheck) checkCap(ctx context.Context, transfer types.Transfer, amount *big.Int, limit models.TokenLimit, ceiling *types.BigInt, window time.Duration, name string) error {
+	if ceiling == nil || ceiling.Int == nil {
+		return nil
+	}
+	if amount.Cmp(ceiling.Int) > 0 {
+		return fmt.Errorf("%w: amount %s is above the %s cap of %s%s", ErrTransferRejected, amount, name, ceiling, route(limit))
+	}
+
+	volume, err := c.store.SignedVolume(ctx, models.VolumeQuery{
+		SourceChain:      transfer.SourceChain,
+		Token:            transfer.Token,
+		DestinationChain: limit.DestinationChain,
+		Since:            c.now().Add(-window),
+		ExcludeTransfer:  transfer.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get signed volume: %w", err)
+	}
+
+	total := new(big.Int).Add(volume, amount)
+	if total.Cmp(ceiling.Int) <= 0 {
+		return nil
+	}
+	if c.ReviewOverCap {
+		if err := c.recordOverCap(ctx, transfer, limit, ceiling, volume, amount, name); err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: %w: amount %s does not fit in the %s cap of %s%s", ErrTransferRejected, ErrCapReached, amount, name, ceiling, route(limit))
+	}
+	return fmt.Errorf("%w: %s of the %s cap of %s already used%s", ErrCapReached, volume, name, ceiling, route(limit))
+}
+
+// recordOverCap audits the volume of a cap a transfer is reviewed for
+func (c *LimitCheck) recordOverCap(ctx context.Context, transfer types.Transfer, limit models.TokenLimit, ceiling *types.BigInt, volume, amount *big.Int, name string) error {
+	if c.Audit == nil {
+		return nil
+	}
+
+	usage := CapUsage{
+		Cap:              name,
+		Ceiling:          ceiling,
+		Volume:           types.NewBigInt(volume),
+		Amount:           types.NewBigInt(amount),
+		DestinationChain: limit.DestinationChain,
+	}
+	entry, err := models.NewAuditEntry(models.AuditTransferOverCap, models.AuditEntityTransfer, transfer.ID, limitsActor, nil, usage)
+	if err == nil {
+		err = c.Audit.RecordAudit(ctx, entry)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to record cap usage: %w", err)
+	}
+	return nil
+}
+
+// route describes the route a limit applies to in errors
+func route(limit models.TokenLimit) string {
+	if limit.DestinationChain == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" to %s", limit.DestinationChain)
+}
@@ -0,0 +1,172 @@
+package relayer
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+// ether returns an amount of whole tokens with 18 decimals
+func ether(n int64) *types.BigInt {
+	return types.NewBigInt(new(big.Int).Mul(big.NewInt(n), big.NewInt(1e18)))
+}
+
+// setupLimitedToken registers the test transfers' token with a limit
+func setupLimitedToken(t *testing.T, state *models.MemoryStateManager, limit models.TokenLimit) {
+	token := &types.SupportedToken{
+		ChainID:      types.ChainEthereum,
+		TokenAddress: strings.ToLower("0xA0b86a33E6441E6C7D3E4C2C4C6C6C6C6C6C6C6C"),
+		Name:         "USD Coin",
+		Symbol:       "USDC",
+		Decimals:     18,
+		Enabled:      true,
+	}
+	require.NoError(t, state.AddSupportedToken(context.Background(), token, nil))
+
+	limit.TokenID = token.ID
+	require.NoError(t, state.SetTokenLimit(context.Background(), &limit, nil))
+}
+
+// signTransfer records a signature so a transfer counts against caps
+func signTransfer(t *testing.T, state *models.MemoryStateManager, transfer types.Transfer) {
+	signature := types.Signature{RelayerAddress: "0x00000000000000000000000000000000000000a2", Signature: make([]byte, 65)}
+	require.NoError(t, state.RecordSignature(context.Background(), transfer.ID, signature))
+}
+
+func TestLimitCheck_Amounts(t *testing.T) {
+	state := models.NewMemoryStateManager()
+	setupLimitedToken(t, state, models.TokenLimit{MinAmount: ether(2), MaxAmount: ether(5)})
+	check := NewLimitCheck(state)
+	transfer := createSignedTransfer(t, state, 1)
+
+	// The test transfer moves a single token
+	assert.ErrorIs(t, check.Check(context.Background(), transfer), ErrTransferRejected)
+
+	transfer.Amount = ether(2)
+	assert.NoError(t, check.Check(context.Background(), transfer))
+
+	transfer.Amount = ether(5)
+	assert.NoError(t, check.Check(context.Background(), transfer))
+
+	transfer.Amount = ether(6)
+	assert.ErrorIs(t, check.Check(context.Background(), transfer), ErrTransferRejected)
+
+	// Limits only apply to the token on its own chain
+	transfer.SourceChain = types.ChainPolygon
+	assert.NoError(t, check.Check(context.Background(), transfer))
+}
+
+func TestLimitCheck_RouteLimit(t *testing.T) {
+	state := models.NewMemoryStateManager()
+	setupLimitedToken(t, state, models.TokenLimit{DestinationChain: types.ChainPolygon, MaxAmount: ether(5)})
+	check := NewLimitCheck(state)
+	transfer := createSignedTransfer(t, state, 1)
+
+	transfer.Amount = ether(6)
+	assert.ErrorIs(t, check.Check(context.Background(), transfer), ErrTransferRejected)
+
+	transfer.DestinationChain = types.ChainCosmos
+	assert.NoError(t, check.Check(context.Background(), transfer))
+}
+
+func TestLimitCheck_CapReachedHoldsTransfer(t *testing.T) {
+	state := models.NewMemoryStateManager()
+	setupLimitedToken(t, state, models.TokenLimit{HourlyCap: ether(2), DailyCap: ether(10)})
+	check := NewLimitCheck(state)
+
+	signTransfer(t, state, createSignedTransfer(t, state, 1))
+	second := createSignedTransfer(t, state, 2)
+	assert.NoError(t, check.Check(context.Background(), second))
+	signTransfer(t, state, second)
+
+	// A transfer is not counted against itself when checked again
+	assert.NoError(t, check.Check(context.Background(), second))
+
+	third := createSignedTransfer(t, state, 3)
+	err := check.Check(context.Background(), third)
+	assert.ErrorIs(t, err, ErrCapReached)
+	assert.NotErrorIs(t, err, ErrTransferRejected)
+
+	// Failed transfers release their volume
+	require.NoError(t, state.UpdateTransferStatus(context.Background(), second.ID, types.StatusFailed))
+	assert.NoError(t, check.Check(context.Background(), third))
+}
+
+func TestLimitCheck_WindowsRoll(t *testing.T) {
+	state := models.NewMemoryStateManager()
+	setupLimitedToken(t, state, models.TokenLimit{HourlyCap: ether(1), DailyCap: ether(2)})
+	check := NewLimitCheck(state)
+
+	signTransfer(t, state, createSignedTransfer(t, state, 1))
+	second := createSignedTransfer(t, state, 2)
+	assert.ErrorIs(t, check.Check(context.Background(), second), ErrCapReached)
+
+	// An hour later only the daily cap counts the first transfer
+	check.now = func() time.Time { return time.Now().Add(time.Hour + time.Minute) }
+	assert.NoError(t, check.Check(context.Background(), second))
+	signTransfer(t, state, second)
+
+	third := createSignedTransfer(t, state, 3)
+	check.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	err := check.Check(context.Background(), third)
+	assert.ErrorIs(t, err, ErrCapReached)
+	assert.Contains(t, err.Error(), "daily")
+
+	check.now = func() time.Time { return time.Now().Add(25 * time.Hour) }
+	assert.NoError(t, check.Check(context.Background(), third))
+}
+
+func TestLimitCheck_OverCap(t *testing.T) {
+	state := models.NewMemoryStateManager()
+	setupLimitedToken(t, state, models.TokenLimit{HourlyCap: ether(1)})
+	check := NewLimitCheck(state)
+	transfer := createSignedTransfer(t, state, 1)
+
+	// A transfer larger than a cap could never be signed, so it is reviewed
+	transfer.Amount = ether(2)
+	assert.ErrorIs(t, check.Check(context.Background(), transfer), ErrTransferRejected)
+
+	signTransfer(t, state, createSignedTransfer(t, state, 2))
+	transfer.Amount = ether(1)
+	check.ReviewOverCap = true
+	check.Audit = state
+	err := check.Check(context.Background(), transfer)
+	assert.ErrorIs(t, err, ErrTransferRejected)
+	assert.ErrorIs(t, err, ErrCapReached)
+
+	// The reason stays the same as the volume grows, which is audited
+	signTransfer(t, state, createSignedTransfer(t, state, 3))
+	assert.EqualError(t, check.Check(context.Background(), transfer), err.Error())
+
+	trail, err := state.GetAuditTrail(context.Background(), models.AuditEntityTransfer, transfer.ID)
+	require.NoError(t, err)
+	require.Len(t, trail, 2)
+	assert.Equal(t, models.AuditTransferOverCap, trail[1].EventType)
+	assert.Equal(t, "limits", trail[1].PerformedBy)
+	assert.JSONEq(t, `{"cap":"hourly","ceiling":"1000000000000000000","volume":"2000000000000000000","amount":"1000000000000000000"}`, string(trail[1].NewValues))
+}
+
+func TestSigner_CapReachedLeavesTransferQueued(t *testing.T) {
+	signer, validator, state := newTestSigner()
+	setupLimitedToken(t, state, models.TokenLimit{HourlyCap: ether(1)})
+	signer.AddCheck(NewLimitCheck(state))
+
+	signTransfer(t, state, createSignedTransfer(t, state, 1))
+	transfer := createSignedTransfer(t, state, 2)
+
+	_, err := signer.Sign(context.Background(), transfer)
+	assert.ErrorIs(t, err, ErrCapReached)
+	assert.Empty(t, validator.signed)
+
+	status, err := state.GetTransferStatus(context.Background(), transfer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, transfer.Status, *status)
+}
@@ -0,0 +1,50 @@
+package relayer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+// LockHandler records transfers locked on a source bridge as confirming,
+// where an executor running the ConfirmationTracker picks them up
+type LockHandler struct {
+	state types.StateManager
+}
+
+var _ types.EventHandler = (*LockHandler)(nil)
+
+// NewLockHandler creates a handler recording transfers in the state manager
+func NewLockHandler(state types.StateManager) *LockHandler {
+	return &LockHandler{state: state}
+}
+
+// Handle records the transfer of a lock event unless it is already known
+func (h *LockHandler) Handle(ctx context.Context, event types.Event) error {
+	transfer := event.Transfer
+	if transfer.ID == "" {
+		transfer.ID = event.TransferID
+	}
+
+	_, err := h.state.GetTransferStatus(ctx, transfer.ID)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, models.ErrTransferNotFound) {
+		return fmt.Errorf("failed to get transfer status: %w", err)
+	}
+
+	transfer.Status = types.StatusConfirming
+	if err := h.state.RecordTransfer(ctx, transfer); err != nil {
+		return fmt.Errorf("failed to record transfer: %w", err)
+	}
+	return nil
+}
+
+// GetEventType returns the lock event type
+func (h *LockHandler) GetEventType() types.EventType {
+	return types.EventTypeLock
+}
@@ -0,0 +1,41 @@
+package relayer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+func TestLockHandler_RecordsConfirmingTransfer(t *testing.T) {
+	state := models.NewMemoryStateManager()
+	handler := NewLockHandler(state)
+	assert.Equal(t, types.EventTypeLock, handler.GetEventType())
+
+	event := createTestEvent(0)
+	event.Transfer = createValidatorTransfer()
+	event.Transfer.Status = types.StatusSigned
+	event.TransferID = event.Transfer.ID
+
+	require.NoError(t, handler.Handle(context.Background(), event))
+	assertStatus(t, state, event.TransferID, types.StatusConfirming)
+
+	// A transfer already known keeps its progress
+	require.NoError(t, state.UpdateTransferStatus(context.Background(), event.TransferID, types.StatusExecuting))
+	require.NoError(t, handler.Handle(context.Background(), event))
+	assertStatus(t, state, event.TransferID, types.StatusExecuting)
+}
+
+func TestLockHandler_InvalidTransfer(t *testing.T) {
+	handler := NewLockHandler(models.NewMemoryStateManager())
+
+	event := createTestEvent(0)
+	event.Transfer = createValidatorTransfer()
+	event.Transfer.Amount = nil
+
+	assert.Error(t, handler.Handle(context.Background(), event))
+}
@@ -0,0 +1,114 @@
+package relayer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"nexus-bridge/pkg/types"
+)
+
+// ErrTransferProcessed is returned when the destination bridge already
+// completed a transfer, which was reconciled to completed instead, or held
+// for review when its destination transaction was not found
+var ErrTransferProcessed = errors.New("transfer already processed on destination chain")
+
+// ProcessedChecker queries a destination bridge for the transfers it
+// completed
+type ProcessedChecker interface {
+	IsTransferProcessed(ctx context.Context, transferID string) (bool, error)
+	// FindTransferExecution returns the hash of the transaction completing
+	// a transfer, or an empty hash if it cannot be found
+	FindTransferExecution(ctx context.Context, transferID string) (string, error)
+}
+
+// ProcessedReconciler reconciles transfers completed on their destination
+// chain
+type ProcessedReconciler interface {
+	Reconcile(ctx context.Context, transferID string, chainID types.ChainID) (bool, error)
+}
+
+// TransferCompleter records how a processed transfer was reconciled
+type TransferCompleter interface {
+	MarkTransferComplete(ctx context.Context, transferID string, destinationTxHash string) error
+	MarkTransferForReview(ctx context.Context, transferID string, reason string) error
+}
+
+// ProcessedCheck keeps the relayer from signing or submitting transfers the
+// destination bridge already completed, such as self-submitted transfers or
+// those completed before a database restore, which would only revert with
+// TransferAlreadyProcessed. Such transfers are marked completed with the
+// destination transaction recovered from the bridge's logs. The bridge is
+// read at a confirmed block, and a transfer whose destination transaction
+// cannot be found is held for review rather than completed without one.
+type ProcessedCheck struct {
+	state  TransferCompleter
+	chains map[types.ChainID]ProcessedChecker
+}
+
+var (
+	_ PreSignCheck        = (*ProcessedCheck)(nil)
+	_ ProcessedReconciler = (*ProcessedCheck)(nil)
+)
+
+// NewProcessedCheck creates a check reconciling processed transfers in the
+// state manager
+func NewProcessedCheck(state TransferCompleter) *ProcessedCheck {
+	return &ProcessedCheck{
+		state:  state,
+		chains: make(map[types.ChainID]ProcessedChecker),
+	}
+}
+
+// AddChain registers the bridge of a destination chain
+func (c *ProcessedCheck) AddChain(chainID types.ChainID, checker ProcessedChecker) {
+	c.chains[chainID] = checker
+}
+
+// Check refuses to sign a transfer completed on its destination chain
+func (c *ProcessedCheck) Check(ctx context.Context, transfer types.Transfer) error {
+	processed, err := c.Reconcile(ctx, transfer.ID, transfer.DestinationChain)
+	if err != nil {
+		return err
+	}
+	if processed {
+		return fmt.Errorf("%w: %s", ErrTransferProcessed, transfer.ID)
+	}
+	return nil
+}
+
+// Reconcile reports whether the bridge on a chain completed a transfer, and
+// if so marks it completed
+func (c *ProcessedCheck) Reconcile(ctx context.Context, transferID string, chainID types.ChainID) (bool, error) {
+	checker, exists := c.chains[chainID]
+	if !exists {
+		return false, fmt.Errorf("no bridge registered for chain: %s", chainID)
+	}
+
+	processed, err := checker.IsTransferProcessed(ctx, transferID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check whether transfer is processed: %w", err)
+	}
+	if !processed {
+		return false, nil
+	}
+
+	txHash, err := checker.FindTransferExecution(ctx, transferID)
+	if err != nil {
+		return false, fmt.Errorf("failed to find transfer execution: %w", err)
+	}
+	if txHash == "" {
+		reason := fmt.Sprintf("processed on %s but its execution log was not found", chainID)
+		if err := c.state.MarkTransferForReview(ctx, transferID, reason); err != nil {
+			return false, fmt.Errorf("failed to mark processed transfer for review: %w", err)
+		}
+		fmt.Printf("Transfer %s is %s, held for review\n", transferID, reason)
+		return true, nil
+	}
+
+	if err := c.state.MarkTransferComplete(ctx, transferID, txHash); err != nil {
+		return false, fmt.Errorf("failed to reconcile processed transfer: %w", err)
+	}
+	fmt.Printf("Reconciled transfer %s processed on %s by %s\n", transferID, chainID, txHash)
+	return true, nil
+}
@@ -0,0 +1,145 @@
+package relayer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/pkg/types"
+)
+
+// fakeBridge is a ProcessedChecker over the transfers a bridge completed
+type fakeBridge struct {
+	// executions maps processed transfers to the transaction completing
+	// them, empty if its log is not found
+	executions map[string]string
+	err        error
+}
+
+func newFakeBridge() *fakeBridge {
+	return &fakeBridge{executions: make(map[string]string)}
+}
+
+func (f *fakeBridge) IsTransferProcessed(ctx context.Context, transferID string) (bool, error) {
+	if f.err != nil {
+		return false, f.err
+	}
+	_, processed := f.executions[transferID]
+	return processed, nil
+}
+
+func (f *fakeBridge) FindTransferExecution(ctx context.Context, transferID string) (string, error) {
+	return f.executions[transferID], nil
+}
+
+const selfSubmittedTx = "0x00000000000000000000000000000000000000000000000000000000000000e1"
+
+func TestProcessedCheck_ReconcilesProcessedTransfer(t *testing.T) {
+	signer, validator, state := newTestSigner()
+	bridge := newFakeBridge()
+	check := NewProcessedCheck(state)
+	check.AddChain(types.ChainPolygon, bridge)
+	signer.AddCheck(check)
+
+	pending := createSignedTransfer(t, state, 1)
+	_, err := signer.Sign(context.Background(), pending)
+	require.NoError(t, err)
+
+	processed := createSignedTransfer(t, state, 2)
+	bridge.executions[processed.ID] = selfSubmittedTx
+	_, err = signer.Sign(context.Background(), processed)
+	assert.ErrorIs(t, err, ErrTransferProcessed)
+	assert.NotErrorIs(t, err, ErrTransferRejected)
+	assert.Equal(t, []string{pending.ID}, validator.signed)
+
+	retrieved, err := state.GetTransfer(context.Background(), processed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, types.StatusCompleted, retrieved.Status)
+	assert.Equal(t, selfSubmittedTx, retrieved.DestinationTxHash)
+}
+
+func TestProcessedCheck_MissingLogGoesToReview(t *testing.T) {
+	_, _, state := newTestSigner()
+	bridge := newFakeBridge()
+	check := NewProcessedCheck(state)
+	check.AddChain(types.ChainPolygon, bridge)
+
+	transfer := createSignedTransfer(t, state, 1)
+	bridge.executions[transfer.ID] = ""
+	assert.ErrorIs(t, check.Check(context.Background(), transfer), ErrTransferProcessed)
+
+	retrieved, err := state.GetTransfer(context.Background(), transfer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, types.StatusUnderReview, retrieved.Status)
+	assert.Empty(t, retrieved.DestinationTxHash)
+}
+
+func TestProcessedCheck_TransientErrors(t *testing.T) {
+	_, _, state := newTestSigner()
+	bridge := newFakeBridge()
+	check := NewProcessedCheck(state)
+	check.AddChain(types.ChainPolygon, bridge)
+	transfer := createSignedTransfer(t, state, 1)
+
+	bridge.err = errors.New("connection refused")
+	err := check.Check(context.Background(), transfer)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrTransferProcessed)
+
+	// A destination without a registered bridge cannot be checked
+	transfer.DestinationChain = types.ChainCosmos
+	assert.Error(t, check.Check(context.Background(), transfer))
+
+	status, err := state.GetTransferStatus(context.Background(), transfer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, types.StatusSigned, *status)
+}
+
+func TestSubmitter_SkipsProcessedTransfers(t *testing.T) {
+	submitter, outbox, state, sender := newTestSubmitter()
+	bridge := newFakeBridge()
+	check := NewProcessedCheck(state)
+	check.AddChain(types.ChainPolygon, bridge)
+	submitter.Processed = check
+
+	transfer := createSignedTransfer(t, state, 1)
+	bridge.executions[transfer.ID] = selfSubmittedTx
+
+	_, err := submitter.Submit(context.Background(), transfer.ID, types.ChainPolygon, types.Transaction{To: "0x01"})
+	assert.ErrorIs(t, err, ErrTransferProcessed)
+	assert.Empty(t, sender.Broadcasts())
+
+	live, err := outbox.GetLiveByTransfer(context.Background(), transfer.ID)
+	require.NoError(t, err)
+	assert.Nil(t, live, "no nonce is spent on a processed transfer")
+
+	retrieved, err := state.GetTransfer(context.Background(), transfer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, types.StatusCompleted, retrieved.Status)
+	assert.Equal(t, selfSubmittedTx, retrieved.DestinationTxHash)
+}
+
+func TestSubmitter_RecoverReconcilesTransfersProcessedElsewhere(t *testing.T) {
+	submitter, _, state, sender := newTestSubmitter()
+	bridge := newFakeBridge()
+	check := NewProcessedCheck(state)
+	check.AddChain(types.ChainPolygon, bridge)
+	submitter.Processed = check
+
+	transfer := createSignedTransfer(t, state, 1)
+	entry, err := submitter.Submit(context.Background(), transfer.ID, types.ChainPolygon, types.Transaction{To: "0x01"})
+	require.NoError(t, err)
+
+	// Someone completed the transfer first, so ours reverted
+	bridge.executions[transfer.ID] = selfSubmittedTx
+	sender.results[entry.TxHash] = &types.TxResult{TxHash: entry.TxHash, BlockNumber: 42, Status: false}
+	require.NoError(t, submitter.Recover(context.Background()))
+
+	retrieved, err := state.GetTransfer(context.Background(), transfer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, types.StatusCompleted, retrieved.Status)
+	assert.Equal(t, selfSubmittedTx, retrieved.DestinationTxHash)
+}
@@ -0,0 +1,309 @@
+package relayer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"nexus-bridge/internal/adapters"
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+// ErrRelayerSetMismatch is returned when the local relayer configuration
+// disagrees with the relayer set of a bridge
+var ErrRelayerSetMismatch = errors.New("local relayer config disagrees with bridge")
+
+// RelayerSetReader reads the authorized relayers of a bridge and follows
+// their changes
+type RelayerSetReader interface {
+	RelayerSet(ctx context.Context) (*adapters.RelayerSet, error)
+	// RelayerSetChanges returns the changes from a block, in order, and the
+	// last block searched
+	RelayerSetChanges(ctx context.Context, fromBlock uint64) ([]adapters.RelayerSetChange, uint64, error)
+}
+
+var _ RelayerSetReader = (*adapters.EthereumAdapter)(nil)
+
+// AuthorizedSetUpdater is implemented by signature validators whose
+// authorized relayers and threshold follow the bridges
+type AuthorizedSetUpdater interface {
+	SetAuthorizedRelayers(relayers []string, requiredSignatures uint64)
+}
+
+// LocalRelayerConfig is the relayer set this relayer was configured with,
+// checked against every bridge at startup
+type LocalRelayerConfig struct {
+	// Address is this relayer's address, which every bridge must authorize
+	Address            string
+	SignatureThreshold uint64
+	RelayerCount       uint64
+}
+
+// chainRelayerSet is the relayer set of one bridge
+type chainRelayerSet struct {
+	reader    RelayerSetReader
+	relayers  map[string]bool
+	required  uint64
+	nextBlock uint64
+}
+
+// RelayerSync keeps relayer_config and the validator's authorized set in
+// step with the bridges. Start reads every bridge's relayers and
+// requiredSignatures and refuses to run on a local config disagreeing with
+// them; Run then follows RelayerAdded, RelayerRemoved and
+// RequiredSignaturesUpdated. A relayer is active while every bridge
+// authorizes it, and the validator requires the highest requiredSignatures
+// of any bridge.
+type RelayerSync struct {
+	store        models.RelayerConfigStore
+	Validator    AuthorizedSetUpdater
+	PollInterval time.Duration
+
+	mu     sync.RWMutex
+	chains map[types.ChainID]*chainRelayerSet
+}
+
+// NewRelayerSync creates a sync persisting the relayer set in the store. A
+// nil store only follows the bridges, leaving relayer_config to the
+// relayers.
+func NewRelayerSync(store models.RelayerConfigStore) *RelayerSync {
+	return &RelayerSync{
+		store:        store,
+		PollInterval: DefaultPollInterval,
+		chains:       make(map[types.ChainID]*chainRelayerSet),
+	}
+}
+
+// AddChain registers the bridge of a chain
+func (s *RelayerSync) AddChain(chainID types.ChainID, reader RelayerSetReader) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.chains[chainID] = &chainRelayerSet{reader: reader}
+}
+
+// Start reads the relayer set of every bridge, checks it against the local
+// config and applies it
+func (s *RelayerSync) Start(ctx context.Context, local LocalRelayerConfig) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	address := strings.ToLower(local.Address)
+	var mismatches []string
+	for _, chainID := range s.chainIDs() {
+		set, err := s.read(ctx, chainID)
+		if err != nil {
+			return err
+		}
+
+		if set.RequiredSignatures != local.SignatureThreshold {
+			mismatches = append(mismatches, fmt.Sprintf("%s requires %d signatures, SIGNATURE_THRESHOLD is %d",
+				chainID, set.RequiredSignatures, local.SignatureThreshold))
+		}
+		if uint64(len(set.Relayers)) != local.RelayerCount {
+			mismatches = append(mismatches, fmt.Sprintf("%s has %d relayers, RELAYER_COUNT is %d",
+				chainID, len(set.Relayers), local.RelayerCount))
+		}
+
+		if address != "" && !s.chains[chainID].relayers[address] {
+			mismatches = append(mismatches, fmt.Sprintf("%s does not authorize relayer %s", chainID, address))
+		}
+	}
+	if len(mismatches) > 0 {
+		return fmt.Errorf("%w: %s", ErrRelayerSetMismatch, strings.Join(mismatches, "; "))
+	}
+
+	return s.apply(ctx)
+}
+
+// Load reads the relayer set of every bridge without checking it against a
+// local config, for processes following the set rather than signing
+func (s *RelayerSync) Load(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, chainID := range s.chainIDs() {
+		if _, err := s.read(ctx, chainID); err != nil {
+			return err
+		}
+	}
+
+	return s.apply(ctx)
+}
+
+// read reads the relayer set of a chain's bridge and follows it from there.
+// The caller holds the lock.
+func (s *RelayerSync) read(ctx context.Context, chainID types.ChainID) (*adapters.RelayerSet, error) {
+	chain := s.chains[chainID]
+	set, err := chain.reader.RelayerSet(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read relayer set on %s: %w", chainID, err)
+	}
+
+	chain.relayers = make(map[string]bool, len(set.Relayers))
+	for _, relayer := range set.Relayers {
+		chain.relayers[relayer] = true
+	}
+	chain.required = set.RequiredSignatures
+	chain.nextBlock = set.BlockNumber + 1
+	return set, nil
+}
+
+// Run follows relayer set changes until the context is cancelled
+func (s *RelayerSync) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		if err := s.Sync(ctx); err != nil && ctx.Err() == nil {
+			fmt.Printf("Error syncing relayer set: %v\n", err)
+		}
+	}
+}
+
+// Sync applies the relayer set changes of every bridge since the last sync
+func (s *RelayerSync) Sync(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	changed := false
+	for _, chainID := range s.chainIDs() {
+		chain := s.chains[chainID]
+		if chain.relayers == nil {
+			continue
+		}
+
+		changes, lastBlock, err := chain.reader.RelayerSetChanges(ctx, chain.nextBlock)
+		if err != nil {
+			return fmt.Errorf("failed to read relayer set changes on %s: %w", chainID, err)
+		}
+		for _, change := range changes {
+			switch change.Kind {
+			case adapters.RelayerAdded:
+				chain.relayers[change.Relayer] = true
+				fmt.Printf("Relayer %s added on %s\n", change.Relayer, chainID)
+			case adapters.RelayerRemoved:
+				delete(chain.relayers, change.Relayer)
+				fmt.Printf("Relayer %s removed on %s\n", change.Relayer, chainID)
+			case adapters.RequiredSignaturesUpdated:
+				chain.required = change.RequiredSignatures
+				fmt.Printf("Required signatures on %s updated to %d\n", chainID, change.RequiredSignatures)
+			}
+		}
+		chain.nextBlock = lastBlock + 1
+		changed = changed || len(changes) > 0
+	}
+
+	if !changed {
+		return nil
+	}
+	return s.apply(ctx)
+}
+
+// Relayers returns the relayers a chain's bridge authorizes, sorted
+func (s *RelayerSync) Relayers(chainID types.ChainID) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	chain, exists := s.chains[chainID]
+	if !exists {
+		return nil
+	}
+	return sortedRelayers(chain.relayers)
+}
+
+// RequiredSignatures returns the signatures a chain's bridge requires, or 0
+// if its relayer set is not known
+func (s *RelayerSync) RequiredSignatures(chainID types.ChainID) uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	chain, exists := s.chains[chainID]
+	if !exists {
+		return 0
+	}
+	return chain.required
+}
+
+// IsAuthorized reports whether a chain's bridge authorizes a relayer
+func (s *RelayerSync) IsAuthorized(chainID types.ChainID, address string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	chain, exists := s.chains[chainID]
+	return exists && chain.relayers[strings.ToLower(address)]
+}
+
+// apply persists the relayers every bridge authorizes and passes them to
+// the validator. The caller holds the lock.
+func (s *RelayerSync) apply(ctx context.Context) error {
+	var active []string
+	var required uint64
+	first := true
+	for _, chainID := range s.chainIDs() {
+		chain := s.chains[chainID]
+		if chain.relayers == nil {
+			continue
+		}
+		if first {
+			active = sortedRelayers(chain.relayers)
+			first = false
+		} else {
+			active = intersectRelayers(active, chain.relayers)
+		}
+		if chain.required > required {
+			required = chain.required
+		}
+	}
+
+	if s.store != nil {
+		if _, err := s.store.SetActiveRelayers(ctx, active); err != nil {
+			return fmt.Errorf("failed to update relayer config: %w", err)
+		}
+	}
+	if s.Validator != nil {
+		s.Validator.SetAuthorizedRelayers(active, required)
+	}
+	return nil
+}
+
+// chainIDs returns the registered chains in a stable order
+func (s *RelayerSync) chainIDs() []types.ChainID {
+	chainIDs := make([]types.ChainID, 0, len(s.chains))
+	for chainID := range s.chains {
+		chainIDs = append(chainIDs, chainID)
+	}
+	sort.Slice(chainIDs, func(i, j int) bool { return chainIDs[i] < chainIDs[j] })
+	return chainIDs
+}
+
+// sortedRelayers returns the addresses of a relayer set, sorted
+func sortedRelayers(relayers map[string]bool) []string {
+	sorted := make([]string, 0, len(relayers))
+	for relayer := range relayers {
+		sorted = append(sorted, relayer)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// intersectRelayers keeps the relayers also in a set
+func intersectRelayers(relayers []string, set map[string]bool) []string {
+	kept := relayers[:0]
+	for _, relayer := range relayers {
+		if set[relayer] {
+			kept = append(kept, relayer)
+		}
+	}
+	return kept
+}
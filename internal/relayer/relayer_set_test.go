@@ -0,0 +1,173 @@
+package relayer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/internal/adapters"
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+const (
+	relayerA = "0x00000000000000000000000000000000000000a1"
+	relayerB = "0x00000000000000000000000000000000000000b2"
+	relayerC = "0x00000000000000000000000000000000000000c3"
+)
+
+// fakeRelayerSet is a RelayerSetReader over a bridge's relayers and the
+// changes after them
+type fakeRelayerSet struct {
+	set     adapters.RelayerSet
+	changes []adapters.RelayerSetChange
+	head    uint64
+	from    []uint64
+	err     error
+}
+
+func (f *fakeRelayerSet) RelayerSet(ctx context.Context) (*adapters.RelayerSet, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	set := f.set
+	return &set, nil
+}
+
+func (f *fakeRelayerSet) RelayerSetChanges(ctx context.Context, fromBlock uint64) ([]adapters.RelayerSetChange, uint64, error) {
+	if f.err != nil {
+		return nil, 0, f.err
+	}
+	f.from = append(f.from, fromBlock)
+	var changes []adapters.RelayerSetChange
+	for _, change := range f.changes {
+		if change.BlockNumber >= fromBlock && change.BlockNumber <= f.head {
+			changes = append(changes, change)
+		}
+	}
+	return changes, f.head, nil
+}
+
+// recordingValidator is an AuthorizedSetUpdater recording its last update
+type recordingValidator struct {
+	relayers []string
+	required uint64
+}
+
+func (v *recordingValidator) SetAuthorizedRelayers(relayers []string, requiredSignatures uint64) {
+	v.relayers = relayers
+	v.required = requiredSignatures
+}
+
+var localRelayer = LocalRelayerConfig{Address: relayerA, SignatureThreshold: 2, RelayerCount: 3}
+
+func newTestRelayerSync() (*RelayerSync, *fakeRelayerSet, *fakeRelayerSet, *models.MemoryStateManager, *recordingValidator) {
+	state := models.NewMemoryStateManager()
+	validator := &recordingValidator{}
+	sync := NewRelayerSync(state)
+	sync.Validator = validator
+
+	ethereum := &fakeRelayerSet{set: adapters.RelayerSet{Relayers: []string{relayerA, relayerB, relayerC}, RequiredSignatures: 2, BlockNumber: 10}, head: 10}
+	polygon := &fakeRelayerSet{set: adapters.RelayerSet{Relayers: []string{relayerA, relayerB, relayerC}, RequiredSignatures: 2, BlockNumber: 20}, head: 20}
+	sync.AddChain(types.ChainEthereum, ethereum)
+	sync.AddChain(types.ChainPolygon, polygon)
+	return sync, ethereum, polygon, state, validator
+}
+
+func TestRelayerSync_StartAppliesBridgeSet(t *testing.T) {
+	sync, _, _, state, validator := newTestRelayerSync()
+	require.NoError(t, sync.Start(context.Background(), localRelayer))
+
+	relayers, err := state.ListRelayerConfigs(context.Background())
+	require.NoError(t, err)
+	require.Len(t, relayers, 3)
+	for _, relayer := range relayers {
+		assert.True(t, relayer.IsActive)
+	}
+	assert.Equal(t, []string{relayerA, relayerB, relayerC}, validator.relayers)
+	assert.Equal(t, uint64(2), validator.required)
+	assert.Equal(t, uint64(2), sync.RequiredSignatures(types.ChainPolygon))
+	assert.True(t, sync.IsAuthorized(types.ChainPolygon, "0x00000000000000000000000000000000000000B2"))
+}
+
+func TestRelayerSync_StartRejectsMismatchedConfig(t *testing.T) {
+	sync, ethereum, polygon, state, validator := newTestRelayerSync()
+	ethereum.set.RequiredSignatures = 3
+	polygon.set.Relayers = []string{relayerB, relayerC}
+
+	err := sync.Start(context.Background(), localRelayer)
+	require.ErrorIs(t, err, ErrRelayerSetMismatch)
+	assert.Contains(t, err.Error(), "SIGNATURE_THRESHOLD")
+	assert.Contains(t, err.Error(), "RELAYER_COUNT")
+	assert.Contains(t, err.Error(), "does not authorize relayer "+relayerA)
+
+	relayers, err := state.ListRelayerConfigs(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, relayers, "nothing is applied on a mismatch")
+	assert.Nil(t, validator.relayers)
+}
+
+func TestRelayerSync_StartReadErrors(t *testing.T) {
+	sync, ethereum, _, _, _ := newTestRelayerSync()
+	ethereum.err = errors.New("connection refused")
+
+	err := sync.Start(context.Background(), localRelayer)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrRelayerSetMismatch)
+}
+
+func TestRelayerSync_LoadFollowsBridgesWithoutStore(t *testing.T) {
+	sync, _, polygon, _, validator := newTestRelayerSync()
+	sync.store = nil
+	polygon.set.RequiredSignatures = 3
+
+	// Nothing is checked against a local config
+	require.NoError(t, sync.Load(context.Background()))
+	assert.Equal(t, uint64(3), sync.RequiredSignatures(types.ChainPolygon))
+	assert.True(t, sync.IsAuthorized(types.ChainEthereum, relayerC))
+	assert.Equal(t, []string{relayerA, relayerB, relayerC}, validator.relayers)
+
+	polygon.changes = []adapters.RelayerSetChange{{Kind: adapters.RelayerRemoved, BlockNumber: 21, Relayer: relayerC}}
+	polygon.head = 21
+	require.NoError(t, sync.Sync(context.Background()))
+	assert.False(t, sync.IsAuthorized(types.ChainPolygon, relayerC))
+}
+
+func TestRelayerSync_FollowsChanges(t *testing.T) {
+	sync, ethereum, polygon, state, validator := newTestRelayerSync()
+	require.NoError(t, sync.Start(context.Background(), localRelayer))
+
+	// No changes leave everything as is
+	require.NoError(t, sync.Sync(context.Background()))
+	assert.Equal(t, []uint64{11}, ethereum.from)
+
+	polygon.changes = []adapters.RelayerSetChange{
+		{Kind: adapters.RelayerRemoved, BlockNumber: 21, Relayer: relayerC},
+		{Kind: adapters.RequiredSignaturesUpdated, BlockNumber: 22, RequiredSignatures: 3},
+	}
+	polygon.head = 25
+	require.NoError(t, sync.Sync(context.Background()))
+
+	// Relayers removed on one bridge are no longer active
+	assert.Equal(t, []string{relayerA, relayerB}, validator.relayers)
+	assert.Equal(t, uint64(3), validator.required)
+	assert.False(t, sync.IsAuthorized(types.ChainPolygon, relayerC))
+	assert.True(t, sync.IsAuthorized(types.ChainEthereum, relayerC))
+	assert.Equal(t, []string{relayerA, relayerB}, sync.Relayers(types.ChainPolygon))
+
+	relayers, err := state.ListRelayerConfigs(context.Background())
+	require.NoError(t, err)
+	require.Len(t, relayers, 3)
+	assert.False(t, relayers[2].IsActive)
+
+	// Changes are read once
+	polygon.changes = append(polygon.changes, adapters.RelayerSetChange{Kind: adapters.RelayerAdded, BlockNumber: 26, Relayer: relayerC})
+	polygon.head = 30
+	require.NoError(t, sync.Sync(context.Background()))
+	assert.Equal(t, []uint64{21, 26}, polygon.from[1:])
+	assert.Equal(t, []string{relayerA, relayerB, relayerC}, validator.relayers)
+	assert.Equal(t, uint64(3), sync.RequiredSignatures(types.ChainPolygon))
+}
@@ -0,0 +1,328 @@
+package relayer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+// RuleStore provides the transfer history and limits rules are evaluated
+// against
+type RuleStore interface {
+	SearchTransfers(ctx context.Context, filter models.TransferFilter) (*models.TransferPage, error)
+	GetTransferLimits(ctx context.Context, transfer types.Transfer) ([]models.TokenLimit, error)
+}
+
+// ContractChecker reports whether an address on a chain is a contract
+type ContractChecker interface {
+	IsContract(ctx context.Context, address string) (bool, error)
+}
+
+// Rule flags transfers matching every condition it sets. Conditions left
+// at their zero value are ignored, but a rule must set at least one.
+type Rule struct {
+	Name string
+	// DryRun logs matches of the rule without holding transfers
+	DryRun bool
+
+	SourceChain      types.ChainID
+	DestinationChain types.ChainID
+	Token            string
+	// MinAmount matches transfers of at least this many base units
+	MinAmount *big.Int
+	// NewRecipient matches recipients without a completed transfer
+	NewRecipient bool
+	// SenderTransfers matches senders with at least this many transfers,
+	// this one included, created over the last SenderWindow
+	SenderTransfers int
+	SenderWindow    time.Duration
+	// NearLimitBps matches amounts within the tightest maximum amount or
+	// cap of the transfer's limits but at least this share of it
+	NearLimitBps uint64
+	// ContractRecipient matches recipients that are contracts on the
+	// destination chain
+	ContractRecipient bool
+}
+
+// ruleFile is the JSON document rules are loaded from
+type ruleFile struct {
+	Rules []ruleSpec `json:"rules"`
+}
+
+// ruleSpec is a rule as written in a rule file
+type ruleSpec struct {
+	Name              string        `json:"name"`
+	DryRun            bool          `json:"dry_run"`
+	SourceChain       string        `json:"source_chain"` // name or numeric ID
+	DestinationChain  string        `json:"destination_chain"`
+	Token             string        `json:"token"`
+	MinAmount         *types.BigInt `json:"min_amount"`
+	NewRecipient      bool          `json:"new_recipient"`
+	SenderTransfers   int           `json:"sender_transfers"`
+	SenderWindow      string        `json:"sender_window"`
+	NearLimitBps      uint64        `json:"near_limit_bps"`
+	ContractRecipient bool          `json:"contract_recipient"`
+}
+
+// LoadRules reads a rule file
+func LoadRules(path string) ([]Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rule file: %w", err)
+	}
+	return ParseRules(data)
+}
+
+// ParseRules parses and validates the rules of a rule file
+func ParseRules(data []byte) ([]Rule, error) {
+	var file ruleFile
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&file); err != nil {
+		return nil, fmt.Errorf("invalid rule file: %w", err)
+	}
+
+	rules := make([]Rule, 0, len(file.Rules))
+	names := make(map[string]bool)
+	for i, spec := range file.Rules {
+		rule, err := spec.parse()
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule %d: %w", i, err)
+		}
+		if names[rule.Name] {
+			return nil, fmt.Errorf("invalid rule %d: duplicate name %s", i, rule.Name)
+		}
+		names[rule.Name] = true
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// parse converts and validates a rule from a rule file
+func (s ruleSpec) parse() (Rule, error) {
+	rule := Rule{
+		Name:              strings.TrimSpace(s.Name),
+		DryRun:            s.DryRun,
+		Token:             strings.TrimSpace(s.Token),
+		NewRecipient:      s.NewRecipient,
+		SenderTransfers:   s.SenderTransfers,
+		NearLimitBps:      s.NearLimitBps,
+		ContractRecipient: s.ContractRecipient,
+	}
+	if rule.Name == "" {
+		return Rule{}, fmt.Errorf("name is required")
+	}
+
+	var err error
+	if s.SourceChain != "" {
+		if rule.SourceChain, err = types.ParseChainID(s.SourceChain); err != nil {
+			return Rule{}, err
+		}
+	}
+	if s.DestinationChain != "" {
+		if rule.DestinationChain, err = types.ParseChainID(s.DestinationChain); err != nil {
+			return Rule{}, err
+		}
+	}
+	if s.MinAmount != nil && s.MinAmount.Int != nil {
+		if s.MinAmount.Sign() < 0 {
+			return Rule{}, fmt.Errorf("min_amount must not be negative")
+		}
+		rule.MinAmount = s.MinAmount.Int
+	}
+
+	switch {
+	case s.SenderTransfers < 0 || s.SenderTransfers > models.MaxTransferPageSize:
+		return Rule{}, fmt.Errorf("sender_transfers must be 0 (disabled) or between 1 and %d", models.MaxTransferPageSize)
+	case s.SenderTransfers > 0 && s.SenderWindow == "":
+		return Rule{}, fmt.Errorf("sender_window is required with sender_transfers")
+	case s.SenderTransfers == 0 && s.SenderWindow != "":
+		return Rule{}, fmt.Errorf("sender_window requires sender_transfers")
+	}
+	if s.SenderWindow != "" {
+		if rule.SenderWindow, err = time.ParseDuration(s.SenderWindow); err != nil || rule.SenderWindow <= 0 {
+			return Rule{}, fmt.Errorf("invalid sender_window %q", s.SenderWindow)
+		}
+	}
+	if rule.NearLimitBps > 10000 {
+		return Rule{}, fmt.Errorf("near_limit_bps must be at most 10000")
+	}
+
+	if rule.MinAmount == nil && !rule.NewRecipient && rule.SenderTransfers == 0 &&
+		rule.NearLimitBps == 0 && !rule.ContractRecipient {
+		return Rule{}, fmt.Errorf("rule %s sets no condition", rule.Name)
+	}
+	return rule, nil
+}
+
+// RuleMatch rejects a transfer that matched rules. Its message is the names
+// of the rules, sorted and comma-separated, which the signer records as the
+// reason for review. An approval then covers exactly that set of rules.
+type RuleMatch struct {
+	Rules []string
+}
+
+func (m *RuleMatch) Error() string {
+	return strings.Join(m.Rules, ", ")
+}
+
+// Unwrap makes a match a rejection
+func (m *RuleMatch) Unwrap() error {
+	return ErrTransferRejected
+}
+
+// RuleCheck holds transfers matching a rule for review. Matches of rules in
+// dry run, or of every rule when DryRun is set, are only logged so rules
+// can be tuned against live traffic.
+type RuleCheck struct {
+	store  RuleStore
+	rules  []Rule
+	chains map[types.ChainID]ContractChecker
+	DryRun bool
+	now    func() time.Time
+}
+
+// NewRuleCheck creates a pre-sign check evaluating rules in order
+func NewRuleCheck(store RuleStore, rules []Rule) *RuleCheck {
+	return &RuleCheck{
+		store:  store,
+		rules:  rules,
+		chains: make(map[types.ChainID]ContractChecker),
+		now:    time.Now,
+	}
+}
+
+// AddChain sets how recipients on a destination chain are checked for code
+func (c *RuleCheck) AddChain(chainID types.ChainID, checker ContractChecker) {
+	c.chains[chainID] = checker
+}
+
+// Check evaluates every rule against a transfer and rejects it for all the
+// rules it matches that are not in dry run
+func (c *RuleCheck) Check(ctx context.Context, transfer types.Transfer) error {
+	var matched []string
+	for _, rule := range c.rules {
+		match, err := c.matches(ctx, rule, transfer)
+		if err != nil {
+			return fmt.Errorf("failed to evaluate rule %s: %w", rule.Name, err)
+		}
+		if !match {
+			continue
+		}
+		if c.DryRun || rule.DryRun {
+			fmt.Printf("Rule %s matched transfer %s (dry run)\n", rule.Name, transfer.ID)
+			continue
+		}
+		matched = append(matched, rule.Name)
+	}
+	if len(matched) == 0 {
+		return nil
+	}
+
+	sort.Strings(matched)
+	return &RuleMatch{Rules: matched}
+}
+
+// matches reports whether a transfer meets every condition of a rule,
+// evaluating those needing the store or the chain last
+func (c *RuleCheck) matches(ctx context.Context, rule Rule, transfer types.Transfer) (bool, error) {
+	amount := big.NewInt(0)
+	if transfer.Amount != nil && transfer.Amount.Int != nil {
+		amount = transfer.Amount.Int
+	}
+
+	if rule.SourceChain != 0 && rule.SourceChain != transfer.SourceChain {
+		return false, nil
+	}
+	if rule.DestinationChain != 0 && rule.DestinationChain != transfer.DestinationChain {
+		return false, nil
+	}
+	if rule.Token != "" && !strings.EqualFold(rule.Token, transfer.Token) {
+		return false, nil
+	}
+	if rule.MinAmount != nil && amount.Cmp(rule.MinAmount) < 0 {
+		return false, nil
+	}
+
+	if rule.NewRecipient {
+		page, err := c.store.SearchTransfers(ctx, models.TransferFilter{
+			Status:    types.StatusCompleted,
+			Recipient: transfer.Recipient,
+			Limit:     1,
+		})
+		if err != nil {
+			return false, fmt.Errorf("failed to search recipient transfers: %w", err)
+		}
+		if len(page.Transfers) > 0 {
+			return false, nil
+		}
+	}
+
+	if rule.SenderTransfers > 0 {
+		page, err := c.store.SearchTransfers(ctx, models.TransferFilter{
+			Sender:       transfer.Sender,
+			CreatedAfter: c.now().Add(-rule.SenderWindow),
+			Limit:        rule.SenderTransfers,
+		})
+		if err != nil {
+			return false, fmt.Errorf("failed to search sender transfers: %w", err)
+		}
+		if len(page.Transfers) < rule.SenderTransfers {
+			return false, nil
+		}
+	}
+
+	if rule.NearLimitBps > 0 {
+		near, err := c.nearLimit(ctx, transfer, amount, rule.NearLimitBps)
+		if err != nil || !near {
+			return false, err
+		}
+	}
+
+	if rule.ContractRecipient {
+		checker, exists := c.chains[transfer.DestinationChain]
+		if !exists {
+			return false, fmt.Errorf("no contract checker for %s", transfer.DestinationChain)
+		}
+		contract, err := checker.IsContract(ctx, transfer.Recipient)
+		if err != nil || !contract {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// nearLimit reports whether an amount is within the tightest maximum amount
+// or cap of a transfer's limits, and at least bps basis points of it
+func (c *RuleCheck) nearLimit(ctx context.Context, transfer types.Transfer, amount *big.Int, bps uint64) (bool, error) {
+	limits, err := c.store.GetTransferLimits(ctx, transfer)
+	if err != nil {
+		return false, fmt.Errorf("failed to get transfer limits: %w", err)
+	}
+
+	var ceiling *big.Int
+	for _, limit := range limits {
+		for _, bound := range []*types.BigInt{limit.MaxAmount, limit.HourlyCap, limit.DailyCap} {
+			if bound != nil && bound.Int != nil && (ceiling == nil || bound.Cmp(ceiling) < 0) {
+				ceiling = bound.Int
+			}
+		}
+	}
+	if ceiling == nil || amount.Cmp(ceiling) > 0 {
+		return false, nil
+	}
+
+	threshold := new(big.Int).Mul(ceiling, new(big.Int).SetUint64(bps))
+	threshold.Quo(threshold, big.NewInt(10000))
+	return amount.Cmp(threshold) >= 0, nil
+}
@@ -0,0 +1,251 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+// fakeContracts is a ContractChecker over a fixed set of contract addresses
+type fakeContracts map[string]bool
+
+func (f fakeContracts) IsContract(ctx context.Context, address string) (bool, error) {
+	return f[strings.ToLower(address)], nil
+}
+
+func mustParseRules(t *testing.T, document string) []Rule {
+	rules, err := ParseRules([]byte(document))
+	require.NoError(t, err)
+	return rules
+}
+
+// recordTransfer records a transfer of the test token from a sender to a
+// recipient
+func recordTransfer(t *testing.T, state *models.MemoryStateManager, n int, sender, recipient string, status types.TransferStatus) types.Transfer {
+	transfer := types.Transfer{
+		ID:               fmt.Sprintf("0x%064x", n),
+		SourceChain:      types.ChainEthereum,
+		DestinationChain: types.ChainPolygon,
+		Token:            "0xA0b86a33E6441E6C7D3E4C2C4C6C6C6C6C6C6C6C",
+		Amount:           ether(1),
+		Sender:           sender,
+		Recipient:        recipient,
+		Status:           status,
+	}
+	require.NoError(t, state.RecordTransfer(context.Background(), transfer))
+	return transfer
+}
+
+func TestParseRules(t *testing.T) {
+	rules := mustParseRules(t, `{"rules": [
+		{"name": "large_first_transfer", "new_recipient": true, "min_amount": "100000000000000000000"},
+		{"name": "sender_burst", "sender_transfers": 5, "sender_window": "10m", "dry_run": true},
+		{"name": "just_under_cap", "destination_chain": "polygon", "near_limit_bps": 9500}
+	]}`)
+	require.Len(t, rules, 3)
+	assert.Equal(t, "large_first_transfer", rules[0].Name)
+	assert.Equal(t, "100000000000000000000", rules[0].MinAmount.String())
+	assert.True(t, rules[1].DryRun)
+	assert.Equal(t, 10*time.Minute, rules[1].SenderWindow)
+	assert.Equal(t, types.ChainPolygon, rules[2].DestinationChain)
+
+	invalid := map[string]string{
+		"NoName":           `{"rules": [{"new_recipient": true}]}`,
+		"NoCondition":      `{"rules": [{"name": "everything", "token": "0x01"}]}`,
+		"DuplicateName":    `{"rules": [{"name": "a", "new_recipient": true}, {"name": "a", "min_amount": "1"}]}`,
+		"UnknownField":     `{"rules": [{"name": "a", "new_recipient": true, "action": "block"}]}`,
+		"UnknownChain":     `{"rules": [{"name": "a", "new_recipient": true, "source_chain": "nowhere"}]}`,
+		"NegativeAmount":   `{"rules": [{"name": "a", "min_amount": "-1"}]}`,
+		"NoWindow":         `{"rules": [{"name": "a", "sender_transfers": 3}]}`,
+		"WindowOnly":       `{"rules": [{"name": "a", "new_recipient": true, "sender_window": "1h"}]}`,
+		"BadWindow":        `{"rules": [{"name": "a", "sender_transfers": 3, "sender_window": "soon"}]}`,
+		"TooManyTransfers": `{"rules": [{"name": "a", "sender_transfers": 1000, "sender_window": "1h"}]}`,
+		"BpsOverWhole":     `{"rules": [{"name": "a", "near_limit_bps": 10001}]}`,
+	}
+	for name, document := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseRules([]byte(document))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLoadRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"rules": [{"name": "contracts", "contract_recipient": true}]}`), 0o600))
+
+	rules, err := LoadRules(path)
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	assert.True(t, rules[0].ContractRecipient)
+
+	_, err = LoadRules(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestRuleCheck_NewRecipient(t *testing.T) {
+	state := models.NewMemoryStateManager()
+	check := NewRuleCheck(state, mustParseRules(t, `{"rules": [
+		{"name": "large_first_transfer", "new_recipient": true, "min_amount": "2000000000000000000"}
+	]}`))
+	transfer := createSignedTransfer(t, state, 1)
+
+	// Below the amount
+	assert.NoError(t, check.Check(context.Background(), transfer))
+
+	transfer.Amount = ether(2)
+	err := check.Check(context.Background(), transfer)
+	assert.ErrorIs(t, err, ErrTransferRejected)
+	assert.EqualError(t, err, "large_first_transfer")
+
+	// Transfers that did not complete do not make a recipient known
+	recordTransfer(t, state, 2, transfer.Sender, transfer.Recipient, types.StatusFailed)
+	assert.Error(t, check.Check(context.Background(), transfer))
+
+	recordTransfer(t, state, 3, transfer.Sender, strings.ToLower(transfer.Recipient), types.StatusCompleted)
+	assert.NoError(t, check.Check(context.Background(), transfer))
+}
+
+func TestRuleCheck_SenderBurst(t *testing.T) {
+	state := models.NewMemoryStateManager()
+	check := NewRuleCheck(state, mustParseRules(t, `{"rules": [
+		{"name": "sender_burst", "sender_transfers": 3, "sender_window": "10m"}
+	]}`))
+	sender := "0x00000000000000000000000000000000000000c1"
+
+	recordTransfer(t, state, 1, sender, "0x00000000000000000000000000000000000000d1", types.StatusCompleted)
+	transfer := recordTransfer(t, state, 2, sender, "0x00000000000000000000000000000000000000d2", types.StatusPending)
+	assert.NoError(t, check.Check(context.Background(), transfer))
+
+	transfer = recordTransfer(t, state, 3, "0x00000000000000000000000000000000000000C1", "0x00000000000000000000000000000000000000d3", types.StatusPending)
+	assert.ErrorIs(t, check.Check(context.Background(), transfer), ErrTransferRejected)
+
+	// The burst leaves the window
+	check.now = func() time.Time { return time.Now().Add(time.Hour) }
+	assert.NoError(t, check.Check(context.Background(), transfer))
+}
+
+func TestRuleCheck_NearLimit(t *testing.T) {
+	state := models.NewMemoryStateManager()
+	setupLimitedToken(t, state, models.TokenLimit{MaxAmount: ether(10), DailyCap: ether(5)})
+	check := NewRuleCheck(state, mustParseRules(t, `{"rules": [
+		{"name": "just_under_cap", "near_limit_bps": 9000}
+	]}`))
+	transfer := createSignedTransfer(t, state, 1)
+
+	tests := []struct {
+		amount  int64
+		matches bool
+	}{
+		{4, false},
+		{5, true},
+		// Over the daily cap is for the limit check to reject
+		{6, false},
+	}
+	for _, tt := range tests {
+		transfer.Amount = ether(tt.amount)
+		err := check.Check(context.Background(), transfer)
+		if tt.matches {
+			assert.ErrorIs(t, err, ErrTransferRejected, tt.amount)
+		} else {
+			assert.NoError(t, err, tt.amount)
+		}
+	}
+
+	// Transfers without limits are never near one
+	transfer.SourceChain = types.ChainPolygon
+	transfer.Amount = ether(5)
+	assert.NoError(t, check.Check(context.Background(), transfer))
+}
+
+func TestRuleCheck_ContractRecipient(t *testing.T) {
+	state := models.NewMemoryStateManager()
+	check := NewRuleCheck(state, mustParseRules(t, `{"rules": [
+		{"name": "contract_recipient", "contract_recipient": true}
+	]}`))
+	transfer := createSignedTransfer(t, state, 1)
+
+	// Without a way to read the destination chain the rule cannot be
+	// evaluated, which is not a rejection
+	err := check.Check(context.Background(), transfer)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrTransferRejected)
+
+	contracts := fakeContracts{}
+	check.AddChain(types.ChainPolygon, contracts)
+	assert.NoError(t, check.Check(context.Background(), transfer))
+
+	contracts[strings.ToLower(transfer.Recipient)] = true
+	assert.ErrorIs(t, check.Check(context.Background(), transfer), ErrTransferRejected)
+}
+
+func TestRuleCheck_DryRun(t *testing.T) {
+	state := models.NewMemoryStateManager()
+	rules := mustParseRules(t, `{"rules": [
+		{"name": "any_amount", "min_amount": "1", "dry_run": true},
+		{"name": "new_recipient", "new_recipient": true},
+		{"name": "also_new_recipient", "new_recipient": true}
+	]}`)
+	transfer := createSignedTransfer(t, state, 1)
+
+	// Every matching rule not in dry run names the rejection
+	err := NewRuleCheck(state, rules).Check(context.Background(), transfer)
+	assert.EqualError(t, err, "also_new_recipient, new_recipient")
+
+	check := NewRuleCheck(state, rules)
+	check.DryRun = true
+	assert.NoError(t, check.Check(context.Background(), transfer))
+}
+
+func TestSigner_RuleMatchIsReviewReason(t *testing.T) {
+	signer, validator, state := newTestSigner()
+	signer.AddCheck(NewRuleCheck(state, mustParseRules(t, `{"rules": [
+		{"name": "new_recipient", "new_recipient": true}
+	]}`)))
+	transfer := createSignedTransfer(t, state, 1)
+
+	_, err := signer.Sign(context.Background(), transfer)
+	assert.ErrorIs(t, err, ErrTransferRejected)
+	assert.Empty(t, validator.signed)
+
+	review, err := state.GetOpenReview(context.Background(), transfer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "new_recipient", review.Reason)
+}
+
+func TestSigner_RuleApprovalCoversMatchedRules(t *testing.T) {
+	signer, validator, state := newTestSigner()
+	signer.Approvals = state
+	signer.AddCheck(NewRuleCheck(state, mustParseRules(t, `{"rules": [
+		{"name": "new_recipient", "new_recipient": true},
+		{"name": "large", "min_amount": "2000000000000000000"}
+	]}`)))
+	transfer := createSignedTransfer(t, state, 1)
+
+	_, err := signer.Sign(context.Background(), transfer)
+	assert.ErrorIs(t, err, ErrTransferRejected)
+	vote := models.ReviewVote{Operator: "alice", Decision: models.DecisionApprove}
+	_, err = state.VoteOnReview(context.Background(), transfer.ID, vote, 1, nil)
+	require.NoError(t, err)
+
+	// Approving the new recipient does not vouch for the transfer once it
+	// also matches another rule
+	transfer.Amount = ether(5)
+	_, err = signer.Sign(context.Background(), transfer)
+	assert.ErrorIs(t, err, ErrTransferRejected)
+	assert.Empty(t, validator.signed)
+
+	review, err := state.GetOpenReview(context.Background(), transfer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "large, new_recipient", review.Reason)
+}
@@ -0,0 +1,66 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+// ScannedChain reports how far a chain has been scanned for events
+type ScannedChain interface {
+	GetChainID() types.ChainID
+	ScannedBlock() uint64
+}
+
+// ScanReporter persists the scan progress of this relayer's chains, so
+// processes that do not scan, such as the API, can report how far the
+// relayer trails each chain
+type ScanReporter struct {
+	store    models.ScanProgressStore
+	chains   []ScannedChain
+	Interval time.Duration
+}
+
+// NewScanReporter creates a reporter recording progress in a store
+func NewScanReporter(store models.ScanProgressStore) *ScanReporter {
+	return &ScanReporter{
+		store:    store,
+		Interval: DefaultPollInterval,
+	}
+}
+
+// AddChain registers a chain whose progress is reported
+func (r *ScanReporter) AddChain(chain ScannedChain) {
+	r.chains = append(r.chains, chain)
+}
+
+// Report records the current scan progress of every chain
+func (r *ScanReporter) Report(ctx context.Context) error {
+	for _, chain := range r.chains {
+		if err := r.store.RecordScannedBlock(ctx, chain.GetChainID(), chain.ScannedBlock()); err != nil {
+			return fmt.Errorf("failed to report scan progress of %s: %w", chain.GetChainID(), err)
+		}
+	}
+	return nil
+}
+
+// Run reports scan progress every Interval until the context ends
+func (r *ScanReporter) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.Report(ctx); err != nil && ctx.Err() == nil {
+			fmt.Printf("Error reporting scan progress: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
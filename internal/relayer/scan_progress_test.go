@@ -0,0 +1,47 @@
+package relayer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/internal/adapters"
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+var _ ScannedChain = (*adapters.EthereumAdapter)(nil)
+
+// fakeScannedChain is a ScannedChain with settable progress
+type fakeScannedChain struct {
+	chainID types.ChainID
+	scanned uint64
+}
+
+func (f *fakeScannedChain) GetChainID() types.ChainID { return f.chainID }
+func (f *fakeScannedChain) ScannedBlock() uint64      { return f.scanned }
+
+func TestScanReporter_RecordsProgress(t *testing.T) {
+	ctx := context.Background()
+	store := models.NewMemoryStateManager()
+	ethereum := &fakeScannedChain{chainID: types.ChainEthereum, scanned: 100}
+	polygon := &fakeScannedChain{chainID: types.ChainPolygon, scanned: 5000}
+
+	reporter := NewScanReporter(store)
+	reporter.AddChain(ethereum)
+	reporter.AddChain(polygon)
+	require.NoError(t, reporter.Report(ctx))
+
+	ethereum.scanned = 110
+	require.NoError(t, reporter.Report(ctx))
+
+	block, scanned, err := store.GetScannedBlock(ctx, types.ChainEthereum)
+	require.NoError(t, err)
+	assert.True(t, scanned)
+	assert.Equal(t, uint64(110), block)
+
+	block, _, _ = store.GetScannedBlock(ctx, types.ChainPolygon)
+	assert.Equal(t, uint64(5000), block)
+}
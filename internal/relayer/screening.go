@@ -0,0 +1,73 @@
+package relayer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/internal/screening"
+	"nexus-bridge/pkg/types"
+)
+
+// ErrScreeningHit is returned when a transfer party is on a denylist. The
+// transfer is held for review, and an approval does not let it be signed.
+var ErrScreeningHit = errors.New("screening hit")
+
+// screeningActor is recorded as the author of screening hits in the audit log
+const screeningActor = "screening"
+
+// AuditRecorder records entries in the audit log
+type AuditRecorder interface {
+	RecordAudit(ctx context.Context, entry *models.AuditEntry) error
+}
+
+// ScreeningHit is the audited record of a transfer party found on a denylist
+type ScreeningHit struct {
+	// Party is sender or recipient
+	Party string `json:"party"`
+	screening.Hit
+}
+
+// ScreeningCheck rejects transfers whose sender or recipient is denied by a
+// screener, recording each hit in the audit log against the transfer
+type ScreeningCheck struct {
+	screener screening.Screener
+	audit    AuditRecorder
+}
+
+// NewScreeningCheck creates a pre-sign check screening transfer parties
+func NewScreeningCheck(screener screening.Screener, audit AuditRecorder) *ScreeningCheck {
+	return &ScreeningCheck{
+		screener: screener,
+		audit:    audit,
+	}
+}
+
+// Check screens the sender and the recipient of a transfer
+func (c *ScreeningCheck) Check(ctx context.Context, transfer types.Transfer) error {
+	parties := []struct{ name, address string }{
+		{"sender", transfer.Sender},
+		{"recipient", transfer.Recipient},
+	}
+	for _, party := range parties {
+		hit, err := c.screener.Screen(ctx, party.address)
+		if err != nil {
+			return fmt.Errorf("failed to screen %s %s: %w", party.name, party.address, err)
+		}
+		if hit == nil {
+			continue
+		}
+
+		record := ScreeningHit{Party: party.name, Hit: *hit}
+		entry, err := models.NewAuditEntry(models.AuditTransferScreened, models.AuditEntityTransfer, transfer.ID, screeningActor, nil, record)
+		if err == nil {
+			err = c.audit.RecordAudit(ctx, entry)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to record screening hit: %w", err)
+		}
+		return fmt.Errorf("%w: %w: %s %s is on denylist %s", ErrTransferRejected, ErrScreeningHit, party.name, party.address, hit.List)
+	}
+	return nil
+}
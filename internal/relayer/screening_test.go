@@ -0,0 +1,69 @@
+package relayer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/internal/screening"
+	"nexus-bridge/pkg/types"
+)
+
+// unreachableScreener is a screening provider that cannot be reached
+type unreachableScreener struct{}
+
+func (unreachableScreener) Screen(ctx context.Context, address string) (*screening.Hit, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestScreeningCheck(t *testing.T) {
+	state := models.NewMemoryStateManager()
+	denylist := screening.NewDenylist("sdn.csv")
+	check := NewScreeningCheck(denylist, state)
+	transfer := createSignedTransfer(t, state, 1)
+
+	assert.NoError(t, check.Check(context.Background(), transfer))
+
+	denylist.Add(transfer.Recipient, "Lazarus Group")
+	err := check.Check(context.Background(), transfer)
+	assert.ErrorIs(t, err, ErrTransferRejected)
+	assert.ErrorIs(t, err, ErrScreeningHit)
+	assert.Contains(t, err.Error(), "recipient")
+
+	trail, err := state.GetAuditTrail(context.Background(), models.AuditEntityTransfer, transfer.ID)
+	require.NoError(t, err)
+	require.Len(t, trail, 1)
+	assert.Equal(t, models.AuditTransferScreened, trail[0].EventType)
+	assert.Equal(t, "screening", trail[0].PerformedBy)
+	assert.JSONEq(t, `{"party":"recipient","address":"`+transfer.Recipient+`","list":"sdn.csv","entry":"Lazarus Group"}`, string(trail[0].NewValues))
+}
+
+func TestScreeningCheck_ProviderFailureIsTransient(t *testing.T) {
+	state := models.NewMemoryStateManager()
+	check := NewScreeningCheck(unreachableScreener{}, state)
+	transfer := createSignedTransfer(t, state, 1)
+
+	err := check.Check(context.Background(), transfer)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrTransferRejected)
+}
+
+func TestSigner_ScreeningHitGoesToReview(t *testing.T) {
+	signer, validator, state := newTestSigner()
+	denylist := screening.NewDenylist("internal")
+	signer.AddCheck(NewScreeningCheck(denylist, state))
+	transfer := createSignedTransfer(t, state, 1)
+	denylist.Add(transfer.Sender, "")
+
+	_, err := signer.Sign(context.Background(), transfer)
+	assert.ErrorIs(t, err, ErrTransferRejected)
+	assert.Empty(t, validator.signed)
+
+	status, err := state.GetTransferStatus(context.Background(), transfer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, types.StatusUnderReview, *status)
+}
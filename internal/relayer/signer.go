@@ -0,0 +1,198 @@
+package relayer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"nexus-bridge/internal/fees"
+	"nexus-bridge/pkg/types"
+)
+
+// ErrTransferRejected is wrapped by pre-sign checks that refuse a transfer.
+// Any other check error is treated as transient and the transfer is left as is.
+var ErrTransferRejected = errors.New("transfer rejected")
+
+// PreSignCheck decides whether this relayer may sign a transfer
+type PreSignCheck interface {
+	Check(ctx context.Context, transfer types.Transfer) error
+}
+
+// SignLocker is implemented by pre-sign checks whose decision must hold
+// until the signature is recorded, such as those counting signed volume.
+// The signer takes every lock before running checks and releases them once
+// the transfer is signed or refused.
+type SignLocker interface {
+	LockSigning(ctx context.Context, transfer types.Transfer) (unlock func(), err error)
+}
+
+// ApprovalChecker reports whether operators approved a transfer held for
+// review, and the reason it was held for
+type ApprovalChecker interface {
+	GetApprovedReviewReason(ctx context.Context, transferID string) (string, bool, error)
+}
+
+// SignerState is the state a signer records signatures and reviews in
+type SignerState interface {
+	types.StateManager
+	HasRelayerSigned(ctx context.Context, transferID, relayerAddress string) (bool, error)
+}
+
+// Signer signs transfers with this relayer's key once every pre-sign check
+// passes. Transfers rejected by a check are marked for review instead,
+// unless Approvals reports that operators already approved the same
+// rejection. Screening hits are never bypassed. As a TransferExecutor it
+// adds this relayer's signature to pending and signed transfers, moving a
+// pending transfer to the signed queue once Aggregator reports it ready.
+type Signer struct {
+	validator  types.SignatureValidator
+	state      SignerState
+	checks     []PreSignCheck
+	Approvals  ApprovalChecker
+	Aggregator Aggregator
+}
+
+var _ TransferExecutor = (*Signer)(nil)
+
+// NewSigner creates a signer recording signatures in the state manager
+func NewSigner(validator types.SignatureValidator, state SignerState) *Signer {
+	return &Signer{
+		validator: validator,
+		state:     state,
+	}
+}
+
+// AddCheck adds a check that must pass before a transfer is signed. Checks
+// run in the order they were added.
+func (s *Signer) AddCheck(check PreSignCheck) {
+	s.checks = append(s.checks, check)
+}
+
+// Sign checks, signs and records this relayer's signature for a transfer
+func (s *Signer) Sign(ctx context.Context, transfer types.Transfer) (*types.Signature, error) {
+	for _, check := range s.checks {
+		locker, ok := check.(SignLocker)
+		if !ok {
+			continue
+		}
+		unlock, err := locker.LockSigning(ctx, transfer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to lock signing: %w", err)
+		}
+		defer unlock()
+	}
+
+	for _, check := range s.checks {
+		err := check.Check(ctx, transfer)
+		if err == nil {
+			continue
+		}
+		if errors.Is(err, ErrTransferRejected) {
+			approved, approvalErr := s.isApproved(ctx, transfer.ID, err)
+			if approvalErr != nil {
+				return nil, approvalErr
+			}
+			if approved {
+				continue
+			}
+			if reviewErr := s.state.MarkTransferForReview(ctx, transfer.ID, err.Error()); reviewErr != nil {
+				return nil, fmt.Errorf("failed to mark rejected transfer for review: %w", reviewErr)
+			}
+		}
+		return nil, err
+	}
+
+	signature, err := s.validator.SignTransfer(transfer.ID, transfer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transfer: %w", err)
+	}
+
+	if err := s.state.RecordSignature(ctx, transfer.ID, *signature); err != nil {
+		return nil, fmt.Errorf("failed to record signature: %w", err)
+	}
+
+	return signature, nil
+}
+
+// Execute signs a transfer unless this relayer already did, and queues a
+// pending transfer for submission as signed once enough relayers signed it.
+// Relayers share the transfer, so it stays pending until the last signature
+// it needs. A transfer held for review or reconciled as processed by a check
+// is left in the status the check moved it to.
+func (s *Signer) Execute(ctx context.Context, transfer types.Transfer) error {
+	signed, err := s.state.HasRelayerSigned(ctx, transfer.ID, s.validator.GetRelayerAddress())
+	if err != nil {
+		return fmt.Errorf("failed to check signature: %w", err)
+	}
+	if !signed {
+		if _, err := s.Sign(ctx, transfer); err != nil {
+			if errors.Is(err, ErrTransferRejected) || errors.Is(err, ErrTransferProcessed) {
+				return nil
+			}
+			return err
+		}
+	}
+
+	if transfer.Status != types.StatusPending {
+		return nil
+	}
+	if s.Aggregator == nil {
+		return fmt.Errorf("no aggregator to tell when transfer %s is ready", transfer.ID)
+	}
+	aggregation, err := s.Aggregator.Aggregate(ctx, transfer)
+	if err != nil {
+		return fmt.Errorf("failed to aggregate signatures: %w", err)
+	}
+	if !aggregation.Ready {
+		return nil
+	}
+
+	if err := s.state.UpdateTransferStatus(ctx, transfer.ID, types.StatusSigned); err != nil {
+		return fmt.Errorf("failed to mark transfer signed: %w", err)
+	}
+	return nil
+}
+
+// isApproved reports whether operators approved a transfer on review of
+// the same rejection. An approval covers only the reason it was reviewed
+// for, so a different check refusing the transfer sends it back to review.
+func (s *Signer) isApproved(ctx context.Context, transferID string, rejection error) (bool, error) {
+	if s.Approvals == nil || errors.Is(rejection, ErrScreeningHit) {
+		return false, nil
+	}
+
+	reason, approved, err := s.Approvals.GetApprovedReviewReason(ctx, transferID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check review approval: %w", err)
+	}
+	return approved && reason == rejection.Error(), nil
+}
+
+// FeeCheck rejects transfers whose recorded fee does not cover a fresh
+// quote. The bridge contracts do not emit the fee paid yet, so transfers
+// without a recorded fee are passed unchecked rather than treated as
+// paying nothing.
+type FeeCheck struct {
+	calculator types.FeeCalculator
+}
+
+// NewFeeCheck creates a pre-sign check enforcing fees with a calculator
+func NewFeeCheck(calculator types.FeeCalculator) *FeeCheck {
+	return &FeeCheck{calculator: calculator}
+}
+
+// Check validates the fee paid for a transfer, if one was recorded
+func (c *FeeCheck) Check(ctx context.Context, transfer types.Transfer) error {
+	if transfer.Fee == nil || transfer.Fee.Int == nil {
+		return nil
+	}
+
+	if err := c.calculator.ValidateFee(ctx, transfer, transfer.Fee.Int); err != nil {
+		if errors.Is(err, fees.ErrInsufficientFee) || errors.Is(err, fees.ErrUnsupportedRoute) {
+			return fmt.Errorf("%w: %v", ErrTransferRejected, err)
+		}
+		return fmt.Errorf("failed to validate fee: %w", err)
+	}
+
+	return nil
+}
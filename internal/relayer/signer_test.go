@@ -0,0 +1,329 @@
+package relayer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/internal/fees"
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+// fakeValidator is a SignatureValidator producing a fixed signature
+type fakeValidator struct {
+	signed []string
+}
+
+func (f *fakeValidator) SignTransfer(transferID string, transfer types.Transfer) (*types.Signature, error) {
+	f.signed = append(f.signed, transferID)
+	return &types.Signature{
+		RelayerAddress: f.GetRelayerAddress(),
+		Signature:      make([]byte, 65),
+		CreatedAt:      time.Now(),
+	}, nil
+}
+
+func (f *fakeValidator) ValidateSignatures(transferID string, signatures []types.Signature) error {
+	return nil
+}
+
+func (f *fakeValidator) GetRequiredThreshold() uint64 { return 2 }
+func (f *fakeValidator) GetRelayerAddress() string {
+	return "0x00000000000000000000000000000000000000a1"
+}
+func (f *fakeValidator) IsAuthorizedRelayer(address string) bool { return true }
+func (f *fakeValidator) RotateKey(newPrivateKey []byte) error    { return nil }
+
+// checkFunc adapts a function to a PreSignCheck
+type checkFunc func(ctx context.Context, transfer types.Transfer) error
+
+func (f checkFunc) Check(ctx context.Context, transfer types.Transfer) error {
+	return f(ctx, transfer)
+}
+
+// fixedFees is a FeeCalculator requiring a fixed minimum fee
+type fixedFees struct {
+	minimum *big.Int
+	err     error
+}
+
+func (f *fixedFees) EstimateFee(ctx context.Context, transfer types.Transfer) (*types.FeeEstimate, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fixedFees) GetGasPrice(ctx context.Context, chainID types.ChainID) (*big.Int, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fixedFees) ValidateFee(ctx context.Context, transfer types.Transfer, providedFee *big.Int) error {
+	if f.err != nil {
+		return f.err
+	}
+	if providedFee.Cmp(f.minimum) < 0 {
+		return fmt.Errorf("%w: provided %s", fees.ErrInsufficientFee, providedFee)
+	}
+	return nil
+}
+
+func newTestSigner() (*Signer, *fakeValidator, *models.MemoryStateManager) {
+	validator := &fakeValidator{}
+	state := models.NewMemoryStateManager()
+	return NewSigner(validator, state), validator, state
+}
+
+func TestSigner_SignsAfterChecks(t *testing.T) {
+	signer, validator, state := newTestSigner()
+	transfer := createSignedTransfer(t, state, 1)
+
+	var order []string
+	signer.AddCheck(checkFunc(func(ctx context.Context, transfer types.Transfer) error {
+		order = append(order, "first")
+		return nil
+	}))
+	signer.AddCheck(checkFunc(func(ctx context.Context, transfer types.Transfer) error {
+		order = append(order, "second")
+		return nil
+	}))
+
+	signature, err := signer.Sign(context.Background(), transfer)
+	require.NoError(t, err)
+	assert.Equal(t, validator.GetRelayerAddress(), signature.RelayerAddress)
+	assert.Equal(t, []string{"first", "second"}, order)
+
+	signatures, err := state.GetSignatures(context.Background(), transfer.ID)
+	require.NoError(t, err)
+	assert.Len(t, signatures, 1)
+}
+
+func TestSigner_RejectedTransferGoesToReview(t *testing.T) {
+	signer, validator, state := newTestSigner()
+	transfer := createSignedTransfer(t, state, 1)
+	signer.AddCheck(checkFunc(func(ctx context.Context, transfer types.Transfer) error {
+		return fmt.Errorf("%w: recipient is denied", ErrTransferRejected)
+	}))
+
+	_, err := signer.Sign(context.Background(), transfer)
+	assert.ErrorIs(t, err, ErrTransferRejected)
+	assert.Empty(t, validator.signed)
+
+	status, err := state.GetTransferStatus(context.Background(), transfer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, types.StatusUnderReview, *status)
+}
+
+func TestSigner_ApprovedTransferSkipsRejection(t *testing.T) {
+	signer, validator, state := newTestSigner()
+	signer.Approvals = state
+	transfer := createSignedTransfer(t, state, 1)
+	signer.AddCheck(checkFunc(func(ctx context.Context, transfer types.Transfer) error {
+		return fmt.Errorf("%w: fee too low", ErrTransferRejected)
+	}))
+
+	_, err := signer.Sign(context.Background(), transfer)
+	assert.ErrorIs(t, err, ErrTransferRejected)
+
+	vote := models.ReviewVote{Operator: "alice", Decision: models.DecisionApprove}
+	_, err = state.VoteOnReview(context.Background(), transfer.ID, vote, 1, nil)
+	require.NoError(t, err)
+
+	signature, err := signer.Sign(context.Background(), transfer)
+	require.NoError(t, err)
+	assert.Equal(t, validator.GetRelayerAddress(), signature.RelayerAddress)
+
+	status, err := state.GetTransferStatus(context.Background(), transfer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, transfer.Status, *status)
+}
+
+func TestSigner_ApprovalCoversOnlyReviewedRejection(t *testing.T) {
+	signer, validator, state := newTestSigner()
+	signer.Approvals = state
+	transfer := createSignedTransfer(t, state, 1)
+
+	reason := "fee too low"
+	signer.AddCheck(checkFunc(func(ctx context.Context, transfer types.Transfer) error {
+		return fmt.Errorf("%w: %s", ErrTransferRejected, reason)
+	}))
+
+	_, err := signer.Sign(context.Background(), transfer)
+	assert.ErrorIs(t, err, ErrTransferRejected)
+	vote := models.ReviewVote{Operator: "alice", Decision: models.DecisionApprove}
+	_, err = state.VoteOnReview(context.Background(), transfer.ID, vote, 1, nil)
+	require.NoError(t, err)
+
+	// The approved fee does not vouch for a transfer now over its limit
+	reason = "amount 500 is above the maximum of 100"
+	_, err = signer.Sign(context.Background(), transfer)
+	assert.ErrorIs(t, err, ErrTransferRejected)
+	assert.Empty(t, validator.signed)
+
+	review, err := state.GetOpenReview(context.Background(), transfer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "transfer rejected: "+reason, review.Reason)
+}
+
+func TestSigner_ApprovalNeverBypassesScreening(t *testing.T) {
+	signer, validator, state := newTestSigner()
+	signer.Approvals = state
+	transfer := createSignedTransfer(t, state, 1)
+	signer.AddCheck(checkFunc(func(ctx context.Context, transfer types.Transfer) error {
+		return fmt.Errorf("%w: %w: recipient is on denylist ofac", ErrTransferRejected, ErrScreeningHit)
+	}))
+
+	_, err := signer.Sign(context.Background(), transfer)
+	assert.ErrorIs(t, err, ErrScreeningHit)
+	vote := models.ReviewVote{Operator: "alice", Decision: models.DecisionApprove}
+	_, err = state.VoteOnReview(context.Background(), transfer.ID, vote, 1, nil)
+	require.NoError(t, err)
+
+	_, err = signer.Sign(context.Background(), transfer)
+	assert.ErrorIs(t, err, ErrScreeningHit)
+	assert.Empty(t, validator.signed)
+
+	status, err := state.GetTransferStatus(context.Background(), transfer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, types.StatusUnderReview, *status)
+}
+
+// lockingCheck is a PreSignCheck recording when its lock is held
+type lockingCheck struct {
+	events *[]string
+}
+
+func (c lockingCheck) Check(ctx context.Context, transfer types.Transfer) error {
+	*c.events = append(*c.events, "check")
+	return nil
+}
+
+func (c lockingCheck) LockSigning(ctx context.Context, transfer types.Transfer) (func(), error) {
+	*c.events = append(*c.events, "lock")
+	return func() { *c.events = append(*c.events, "unlock") }, nil
+}
+
+func TestSigner_HoldsLocksUntilRecorded(t *testing.T) {
+	signer, _, state := newTestSigner()
+	transfer := createSignedTransfer(t, state, 1)
+
+	var events []string
+	signer.AddCheck(lockingCheck{events: &events})
+	signer.AddCheck(checkFunc(func(ctx context.Context, transfer types.Transfer) error {
+		signatures, err := state.GetSignatures(ctx, transfer.ID)
+		require.NoError(t, err)
+		assert.Empty(t, signatures)
+		return nil
+	}))
+
+	_, err := signer.Sign(context.Background(), transfer)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"lock", "check", "unlock"}, events)
+}
+
+func TestSigner_TransientCheckErrorLeavesTransfer(t *testing.T) {
+	signer, validator, state := newTestSigner()
+	transfer := createSignedTransfer(t, state, 1)
+	signer.AddCheck(checkFunc(func(ctx context.Context, transfer types.Transfer) error {
+		return errors.New("connection refused")
+	}))
+
+	_, err := signer.Sign(context.Background(), transfer)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrTransferRejected)
+	assert.Empty(t, validator.signed)
+
+	status, err := state.GetTransferStatus(context.Background(), transfer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, transfer.Status, *status)
+}
+
+func TestSigner_ExecuteQueuesTransferOnceReady(t *testing.T) {
+	signer, validator, state := newTestSigner()
+	signer.Aggregator = NewSignatureAggregator(state, fixedRelayers{
+		{Address: relayerA, IsActive: true, ThresholdWeight: 1},
+		{Address: relayerB, IsActive: true, ThresholdWeight: 1},
+	}, &fixedDestination{required: 2, authorized: map[string]bool{relayerA: true, relayerB: true}})
+	transfer := createSignedTransfer(t, state, 1)
+	transfer.Status = types.StatusPending
+	require.NoError(t, state.UpdateTransferStatus(context.Background(), transfer.ID, types.StatusPending))
+
+	// One signature leaves the transfer pending for the other relayers
+	require.NoError(t, signer.Execute(context.Background(), transfer))
+	assert.Equal(t, []string{transfer.ID}, validator.signed)
+	assertStatus(t, state, transfer.ID, types.StatusPending)
+
+	// The last signature it needs queues it, without this relayer signing twice
+	recordSignatures(t, state, transfer.ID, relayerB)
+	require.NoError(t, signer.Execute(context.Background(), transfer))
+	assert.Len(t, validator.signed, 1)
+	assertStatus(t, state, transfer.ID, types.StatusSigned)
+}
+
+func TestSigner_ExecuteAddsSignatureToSignedTransfer(t *testing.T) {
+	signer, validator, state := newTestSigner()
+	transfer := createSignedTransfer(t, state, 1)
+	recordSignatures(t, state, transfer.ID, relayerB)
+
+	require.NoError(t, signer.Execute(context.Background(), transfer))
+	assert.Equal(t, []string{transfer.ID}, validator.signed)
+	assertStatus(t, state, transfer.ID, types.StatusSigned)
+}
+
+func TestSigner_ExecuteLeavesRejectedTransferInReview(t *testing.T) {
+	signer, validator, state := newTestSigner()
+	transfer := createSignedTransfer(t, state, 1)
+	require.NoError(t, state.UpdateTransferStatus(context.Background(), transfer.ID, types.StatusPending))
+	signer.AddCheck(checkFunc(func(ctx context.Context, transfer types.Transfer) error {
+		return fmt.Errorf("%w: recipient is denied", ErrTransferRejected)
+	}))
+
+	require.NoError(t, signer.Execute(context.Background(), transfer))
+	assert.Empty(t, validator.signed)
+	assertStatus(t, state, transfer.ID, types.StatusUnderReview)
+
+	// Transient errors are returned so the transfer is tried again
+	signer, _, state = newTestSigner()
+	transfer = createSignedTransfer(t, state, 2)
+	signer.AddCheck(checkFunc(func(ctx context.Context, transfer types.Transfer) error {
+		return errors.New("connection refused")
+	}))
+	assert.Error(t, signer.Execute(context.Background(), transfer))
+}
+
+func TestFeeCheck(t *testing.T) {
+	calculator := &fixedFees{minimum: big.NewInt(100)}
+	check := NewFeeCheck(calculator)
+	transfer := types.Transfer{ID: "0x01"}
+
+	transfer.Fee = types.NewBigInt(big.NewInt(99))
+	assert.ErrorIs(t, check.Check(context.Background(), transfer), ErrTransferRejected)
+
+	transfer.Fee = types.NewBigInt(big.NewInt(100))
+	assert.NoError(t, check.Check(context.Background(), transfer))
+
+	calculator.err = fmt.Errorf("%w: cosmos", fees.ErrUnsupportedRoute)
+	assert.ErrorIs(t, check.Check(context.Background(), transfer), ErrTransferRejected)
+
+	calculator.err = errors.New("connection refused")
+	err := check.Check(context.Background(), transfer)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrTransferRejected)
+}
+
+func TestFeeCheck_SkipsTransfersWithoutRecordedFee(t *testing.T) {
+	calculator := &fixedFees{minimum: big.NewInt(100)}
+	check := NewFeeCheck(calculator)
+
+	// Lock events carry no fee, so there is nothing to hold the transfer to
+	assert.NoError(t, check.Check(context.Background(), types.Transfer{ID: "0x01"}))
+	assert.NoError(t, check.Check(context.Background(), types.Transfer{ID: "0x01", Fee: &types.BigInt{}}))
+
+	// Even a failing calculator is not consulted
+	calculator.err = errors.New("connection refused")
+	assert.NoError(t, check.Check(context.Background(), types.Transfer{ID: "0x01"}))
+}
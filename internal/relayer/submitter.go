@@ -0,0 +1,267 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+// Submitter sends destination-chain transactions through the transaction
+// outbox. Every transaction is signed and persisted before it is broadcast,
+// so a crash at any point leaves enough state to rebroadcast or reconcile it.
+// When Processed is set, transfers the destination bridge already completed
+// are reconciled instead of submitted.
+type Submitter struct {
+	outbox    models.TxOutbox
+	state     types.StateManager
+	senders   map[types.ChainID]types.TransactionSender
+	locks     map[types.ChainID]*sync.Mutex
+	mu        sync.Mutex
+	Processed ProcessedReconciler
+}
+
+// NewSubmitter creates a new outbox-backed submitter
+func NewSubmitter(outbox models.TxOutbox, state types.StateManager) *Submitter {
+	return &Submitter{
+		outbox:  outbox,
+		state:   state,
+		senders: make(map[types.ChainID]types.TransactionSender),
+		locks:   make(map[types.ChainID]*sync.Mutex),
+	}
+}
+
+// RegisterSender registers the transaction sender for a destination chain
+func (s *Submitter) RegisterSender(chainID types.ChainID, sender types.TransactionSender) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.senders[chainID] = sender
+	s.locks[chainID] = &sync.Mutex{}
+}
+
+// Submit signs, persists and broadcasts the destination transaction for a
+// transfer. If the transfer already has a live transaction it is returned
+// instead of submitting a second one. A failed broadcast leaves the entry
+// pending for Recover to retry. A transfer already completed on chain is
+// reconciled and ErrTransferProcessed returned.
+func (s *Submitter) Submit(ctx context.Context, transferID string, chainID types.ChainID, tx types.Transaction) (*models.OutboxEntry, error) {
+	sender, lock, err := s.senderFor(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.Processed != nil {
+		processed, err := s.Processed.Reconcile(ctx, transferID, chainID)
+		if err != nil {
+			return nil, err
+		}
+		if processed {
+			return nil, fmt.Errorf("%w: %s", ErrTransferProcessed, transferID)
+		}
+	}
+
+	// Nonce allocation and enqueueing must not interleave for a sender
+	lock.Lock()
+	entry, err := s.enqueue(ctx, transferID, chainID, sender, tx)
+	lock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	if entry.Status != models.OutboxStatusPending {
+		return entry, nil
+	}
+
+	if err := s.state.UpdateTransferStatus(ctx, transferID, types.StatusExecuting); err != nil {
+		return nil, fmt.Errorf("failed to mark transfer executing: %w", err)
+	}
+
+	if err := s.broadcast(ctx, sender, entry); err != nil {
+		return entry, err
+	}
+
+	entry.Status = models.OutboxStatusBroadcast
+	return entry, nil
+}
+
+// Recover reconciles every outstanding transaction against chain state. It
+// should run on startup before new submissions are made.
+func (s *Submitter) Recover(ctx context.Context) error {
+	s.mu.Lock()
+	chains := make([]types.ChainID, 0, len(s.senders))
+	for chainID := range s.senders {
+		chains = append(chains, chainID)
+	}
+	s.mu.Unlock()
+
+	for _, chainID := range chains {
+		if err := s.recoverChain(ctx, chainID); err != nil {
+			return fmt.Errorf("failed to recover %s transactions: %w", chainID, err)
+		}
+	}
+
+	return nil
+}
+
+// recoverChain reconciles the outstanding transactions of one chain
+func (s *Submitter) recoverChain(ctx context.Context, chainID types.ChainID) error {
+	sender, lock, err := s.senderFor(chainID)
+	if err != nil {
+		return err
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	entries, err := s.outbox.ListOutstanding(ctx, chainID)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	confirmedNonce, err := sender.ConfirmedNonce(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get confirmed nonce: %w", err)
+	}
+
+	for i := range entries {
+		if err := s.reconcile(ctx, sender, &entries[i], confirmedNonce); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reconcile settles a single outstanding transaction
+func (s *Submitter) reconcile(ctx context.Context, sender types.TransactionSender, entry *models.OutboxEntry, confirmedNonce uint64) error {
+	result, err := sender.GetTransactionResult(ctx, entry.TxHash)
+	if err != nil {
+		return fmt.Errorf("failed to get result of %s: %w", entry.TxHash, err)
+	}
+
+	if result != nil {
+		if err := s.outbox.MarkMined(ctx, entry.ID, *result); err != nil {
+			return err
+		}
+		if result.Status {
+			return s.state.MarkTransferComplete(ctx, entry.TransferID, entry.TxHash)
+		}
+		// The transaction may have reverted because the transfer was completed by another one
+		if s.Processed != nil {
+			processed, err := s.Processed.Reconcile(ctx, entry.TransferID, entry.ChainID)
+			if err != nil {
+				return err
+			}
+			if processed {
+				return nil
+			}
+		}
+		return s.state.MarkTransferForReview(ctx, entry.TransferID,
+			fmt.Sprintf("destination transaction %s reverted", entry.TxHash))
+	}
+
+	// Not mined and the nonce is already used: another transaction took its place
+	if entry.Nonce < confirmedNonce {
+		if err := s.outbox.MarkDropped(ctx, entry.ID); err != nil {
+			return err
+		}
+		return s.state.UpdateTransferStatus(ctx, entry.TransferID, types.StatusSigned)
+	}
+
+	// Still mineable: make sure a node knows about it
+	if err := s.broadcast(ctx, sender, entry); err != nil {
+		fmt.Printf("Error rebroadcasting transaction %s: %v\n", entry.TxHash, err)
+	}
+
+	return nil
+}
+
+// enqueue returns the live transaction for a transfer, or signs and persists
+// a new one using the next free nonce
+func (s *Submitter) enqueue(ctx context.Context, transferID string, chainID types.ChainID, sender types.TransactionSender, tx types.Transaction) (*models.OutboxEntry, error) {
+	existing, err := s.outbox.GetLiveByTransfer(ctx, transferID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return existing, nil
+	}
+
+	nonce, err := s.nextNonce(ctx, chainID, sender)
+	if err != nil {
+		return nil, err
+	}
+	tx.Nonce = nonce
+
+	signed, err := sender.SignTransaction(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	return s.outbox.Enqueue(ctx, transferID, *signed)
+}
+
+// nextNonce picks the higher of the chain's pending nonce and the outbox's
+// next nonce, so transactions persisted but never broadcast are not reused
+func (s *Submitter) nextNonce(ctx context.Context, chainID types.ChainID, sender types.TransactionSender) (uint64, error) {
+	pending, err := sender.PendingNonce(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get pending nonce: %w", err)
+	}
+
+	outboxNonce, err := s.outbox.NextNonce(ctx, chainID, sender.GetSenderAddress())
+	if err != nil {
+		return 0, err
+	}
+
+	if outboxNonce > pending {
+		return outboxNonce, nil
+	}
+	return pending, nil
+}
+
+// broadcast sends an entry and records the attempt. Nodes that already know
+// the transaction are treated as a successful broadcast.
+func (s *Submitter) broadcast(ctx context.Context, sender types.TransactionSender, entry *models.OutboxEntry) error {
+	err := sender.BroadcastTransaction(ctx, entry.SignedTransaction())
+	if err != nil && isAlreadyKnown(err) {
+		err = nil
+	}
+
+	if markErr := s.outbox.MarkBroadcast(ctx, entry.ID, err); markErr != nil {
+		return markErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to broadcast transaction %s: %w", entry.TxHash, err)
+	}
+
+	return nil
+}
+
+// senderFor returns the sender and nonce lock for a chain
+func (s *Submitter) senderFor(chainID types.ChainID) (types.TransactionSender, *sync.Mutex, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sender, exists := s.senders[chainID]
+	if !exists {
+		return nil, nil, fmt.Errorf("no transaction sender registered for chain: %s", chainID)
+	}
+
+	return sender, s.locks[chainID], nil
+}
+
+// isAlreadyKnown reports whether a broadcast error means the node already has
+// the transaction
+func isAlreadyKnown(err error) bool {
+	message := strings.ToLower(err.Error())
+	return strings.Contains(message, "already known") ||
+		strings.Contains(message, "known transaction") ||
+		strings.Contains(message, "already imported")
+}
@@ -0,0 +1,249 @@
+package relayer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+// fakeSender is an in-memory TransactionSender that simulates a chain
+type fakeSender struct {
+	mu             sync.Mutex
+	address        string
+	pendingNonce   uint64
+	confirmedNonce uint64
+	broadcasts     []string
+	results        map[string]*types.TxResult
+	broadcastErr   error
+}
+
+func newFakeSender() *fakeSender {
+	return &fakeSender{
+		address: "0x742d35Cc6634C0532925a3b8D4C9db96590C4C4C",
+		results: make(map[string]*types.TxResult),
+	}
+}
+
+func (f *fakeSender) SignTransaction(ctx context.Context, tx types.Transaction) (*types.SignedTransaction, error) {
+	hash := fmt.Sprintf("0x%064x", tx.Nonce+1)
+	return &types.SignedTransaction{
+		ChainID: types.ChainPolygon,
+		From:    f.address,
+		Nonce:   tx.Nonce,
+		TxHash:  hash,
+		Raw:     []byte(hash),
+	}, nil
+}
+
+func (f *fakeSender) BroadcastTransaction(ctx context.Context, signed types.SignedTransaction) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.broadcastErr != nil {
+		return f.broadcastErr
+	}
+	f.broadcasts = append(f.broadcasts, signed.TxHash)
+	if signed.Nonce >= f.pendingNonce {
+		f.pendingNonce = signed.Nonce + 1
+	}
+	return nil
+}
+
+func (f *fakeSender) GetTransactionResult(ctx context.Context, txHash string) (*types.TxResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.results[txHash], nil
+}
+
+func (f *fakeSender) PendingNonce(ctx context.Context) (uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.pendingNonce, nil
+}
+
+func (f *fakeSender) ConfirmedNonce(ctx context.Context) (uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.confirmedNonce, nil
+}
+
+func (f *fakeSender) GetSenderAddress() string {
+	return f.address
+}
+
+func (f *fakeSender) Broadcasts() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.broadcasts...)
+}
+
+func createSignedTransfer(t *testing.T, state *models.MemoryStateManager, n int) types.Transfer {
+	transfer := types.Transfer{
+		ID:               fmt.Sprintf("0x%064x", n),
+		SourceChain:      types.ChainEthereum,
+		DestinationChain: types.ChainPolygon,
+		Token:            "0xA0b86a33E6441E6C7D3E4C2C4C6C6C6C6C6C6C6C",
+		Amount:           types.NewBigInt(big.NewInt(1000000000000000000)),
+		Sender:           "0x742d35Cc6634C0532925a3b8D4C9db96590C4C4C",
+		Recipient:        "0x8ba1f109551bD432803012645Hac136c22C4C4C",
+		Status:           types.StatusSigned,
+	}
+	require.NoError(t, state.RecordTransfer(context.Background(), transfer))
+	return transfer
+}
+
+func newTestSubmitter() (*Submitter, *models.MemoryTxOutbox, *models.MemoryStateManager, *fakeSender) {
+	outbox := models.NewMemoryTxOutbox()
+	state := models.NewMemoryStateManager()
+	sender := newFakeSender()
+	submitter := NewSubmitter(outbox, state)
+	submitter.RegisterSender(types.ChainPolygon, sender)
+	return submitter, outbox, state, sender
+}
+
+func TestSubmitter_PersistsBeforeBroadcast(t *testing.T) {
+	submitter, outbox, state, sender := newTestSubmitter()
+	transfer := createSignedTransfer(t, state, 1)
+	sender.broadcastErr = errors.New("connection refused")
+
+	entry, err := submitter.Submit(context.Background(), transfer.ID, types.ChainPolygon, types.Transaction{To: "0x01"})
+	assert.Error(t, err)
+	require.NotNil(t, entry)
+
+	// The signed transaction survives the failed broadcast
+	live, err := outbox.GetLiveByTransfer(context.Background(), transfer.ID)
+	require.NoError(t, err)
+	require.NotNil(t, live)
+	assert.Equal(t, models.OutboxStatusPending, live.Status)
+	assert.Equal(t, 1, live.Attempts)
+	assert.NotEmpty(t, live.RawTx)
+
+	status, err := state.GetTransferStatus(context.Background(), transfer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, types.StatusExecuting, *status)
+}
+
+func TestSubmitter_DoesNotSubmitTwice(t *testing.T) {
+	submitter, _, state, sender := newTestSubmitter()
+	transfer := createSignedTransfer(t, state, 1)
+
+	first, err := submitter.Submit(context.Background(), transfer.ID, types.ChainPolygon, types.Transaction{To: "0x01"})
+	require.NoError(t, err)
+	second, err := submitter.Submit(context.Background(), transfer.ID, types.ChainPolygon, types.Transaction{To: "0x01"})
+	require.NoError(t, err)
+
+	assert.Equal(t, first.TxHash, second.TxHash)
+	assert.Len(t, sender.Broadcasts(), 1)
+}
+
+func TestSubmitter_AllocatesNoncesPastUnbroadcastEntries(t *testing.T) {
+	submitter, _, state, sender := newTestSubmitter()
+	first := createSignedTransfer(t, state, 1)
+	second := createSignedTransfer(t, state, 2)
+
+	sender.broadcastErr = errors.New("connection refused")
+	entry, _ := submitter.Submit(context.Background(), first.ID, types.ChainPolygon, types.Transaction{To: "0x01"})
+	require.NotNil(t, entry)
+
+	sender.broadcastErr = nil
+	next, err := submitter.Submit(context.Background(), second.ID, types.ChainPolygon, types.Transaction{To: "0x01"})
+	require.NoError(t, err)
+	assert.Equal(t, entry.Nonce+1, next.Nonce)
+}
+
+func TestSubmitter_RecoverRebroadcastsPending(t *testing.T) {
+	submitter, outbox, state, sender := newTestSubmitter()
+	transfer := createSignedTransfer(t, state, 1)
+
+	sender.broadcastErr = errors.New("connection refused")
+	_, _ = submitter.Submit(context.Background(), transfer.ID, types.ChainPolygon, types.Transaction{To: "0x01"})
+
+	// The process restarts with the node reachable again
+	sender.broadcastErr = nil
+	restarted := NewSubmitter(outbox, state)
+	restarted.RegisterSender(types.ChainPolygon, sender)
+	require.NoError(t, restarted.Recover(context.Background()))
+
+	live, err := outbox.GetLiveByTransfer(context.Background(), transfer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.OutboxStatusBroadcast, live.Status)
+	assert.Equal(t, []string{live.TxHash}, sender.Broadcasts())
+}
+
+func TestSubmitter_RecoverCompletesMinedTransfers(t *testing.T) {
+	submitter, outbox, state, sender := newTestSubmitter()
+	transfer := createSignedTransfer(t, state, 1)
+
+	entry, err := submitter.Submit(context.Background(), transfer.ID, types.ChainPolygon, types.Transaction{To: "0x01"})
+	require.NoError(t, err)
+
+	sender.results[entry.TxHash] = &types.TxResult{TxHash: entry.TxHash, BlockNumber: 42, Status: true}
+	require.NoError(t, submitter.Recover(context.Background()))
+
+	retrieved, err := state.GetTransfer(context.Background(), transfer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, types.StatusCompleted, retrieved.Status)
+	assert.Equal(t, entry.TxHash, retrieved.DestinationTxHash)
+
+	outstanding, err := outbox.ListOutstanding(context.Background(), types.ChainPolygon)
+	require.NoError(t, err)
+	assert.Empty(t, outstanding)
+}
+
+func TestSubmitter_RecoverSendsRevertedTransfersToReview(t *testing.T) {
+	submitter, _, state, sender := newTestSubmitter()
+	transfer := createSignedTransfer(t, state, 1)
+
+	entry, err := submitter.Submit(context.Background(), transfer.ID, types.ChainPolygon, types.Transaction{To: "0x01"})
+	require.NoError(t, err)
+
+	sender.results[entry.TxHash] = &types.TxResult{TxHash: entry.TxHash, BlockNumber: 42, Status: false}
+	require.NoError(t, submitter.Recover(context.Background()))
+
+	status, err := state.GetTransferStatus(context.Background(), transfer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, types.StatusUnderReview, *status)
+}
+
+func TestSubmitter_RecoverDropsReplacedTransactions(t *testing.T) {
+	submitter, outbox, state, sender := newTestSubmitter()
+	transfer := createSignedTransfer(t, state, 1)
+
+	entry, err := submitter.Submit(context.Background(), transfer.ID, types.ChainPolygon, types.Transaction{To: "0x01"})
+	require.NoError(t, err)
+
+	// Another transaction consumed the nonce and ours was never mined
+	sender.confirmedNonce = entry.Nonce + 1
+	require.NoError(t, submitter.Recover(context.Background()))
+
+	live, err := outbox.GetLiveByTransfer(context.Background(), transfer.ID)
+	require.NoError(t, err)
+	assert.Nil(t, live)
+
+	status, err := state.GetTransferStatus(context.Background(), transfer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, types.StatusSigned, *status)
+}
+
+func TestSubmitter_UnknownChain(t *testing.T) {
+	submitter, _, state, _ := newTestSubmitter()
+	transfer := createSignedTransfer(t, state, 1)
+
+	_, err := submitter.Submit(context.Background(), transfer.ID, types.ChainCosmos, types.Transaction{To: "0x01"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no transaction sender registered")
+}
+
+func TestIsAlreadyKnown(t *testing.T) {
+	assert.True(t, isAlreadyKnown(errors.New("already known")))
+	assert.True(t, isAlreadyKnown(errors.New("Known transaction: abc")))
+	assert.False(t, isAlreadyKnown(errors.New("nonce too low")))
+}
@@ -0,0 +1,49 @@
+package relayer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+// UnlockHandler marks transfers completed when their destination bridge
+// unlocks the tokens, whichever relayer submitted the unlock
+type UnlockHandler struct {
+	state types.StateManager
+}
+
+var _ types.EventHandler = (*UnlockHandler)(nil)
+
+// NewUnlockHandler creates a handler completing transfers in the state
+// manager
+func NewUnlockHandler(state types.StateManager) *UnlockHandler {
+	return &UnlockHandler{state: state}
+}
+
+// Handle completes the transfer of an unlock event. Unlocks of transfers
+// this bridge never recorded are ignored.
+func (h *UnlockHandler) Handle(ctx context.Context, event types.Event) error {
+	status, err := h.state.GetTransferStatus(ctx, event.TransferID)
+	if errors.Is(err, models.ErrTransferNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get transfer status: %w", err)
+	}
+	if *status == types.StatusCompleted {
+		return nil
+	}
+
+	if err := h.state.MarkTransferComplete(ctx, event.TransferID, event.TxHash); err != nil {
+		return fmt.Errorf("failed to complete transfer: %w", err)
+	}
+	return nil
+}
+
+// GetEventType returns the unlock event type
+func (h *UnlockHandler) GetEventType() types.EventType {
+	return types.EventTypeUnlock
+}
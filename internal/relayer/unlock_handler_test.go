@@ -0,0 +1,33 @@
+package relayer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+func TestUnlockHandler_CompletesTransfer(t *testing.T) {
+	state := models.NewMemoryStateManager()
+	handler := NewUnlockHandler(state)
+	assert.Equal(t, types.EventTypeUnlock, handler.GetEventType())
+	transfer := createSignedTransfer(t, state, 1)
+
+	event := createTestEvent(0)
+	event.Type = types.EventTypeUnlock
+	event.TransferID = transfer.ID
+
+	require.NoError(t, handler.Handle(context.Background(), event))
+	recorded, err := state.GetTransfer(context.Background(), transfer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, types.StatusCompleted, recorded.Status)
+	assert.Equal(t, event.TxHash, recorded.DestinationTxHash)
+
+	// Unlocks of transfers never recorded are ignored
+	event.TransferID = createValidatorTransfer().ID
+	assert.NoError(t, handler.Handle(context.Background(), event))
+}
@@ -0,0 +1,153 @@
+package relayer
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"nexus-bridge/internal/adapters"
+	"nexus-bridge/pkg/types"
+)
+
+// Validator signs transfers with this relayer's key in the form their
+// destination bridge verifies, and tracks the relayers the bridges
+// authorize. The bridge method of each destination chain must be added
+// before its transfers are signed.
+type Validator struct {
+	mu         sync.RWMutex
+	key        *ecdsa.PrivateKey
+	address    string
+	methods    map[types.ChainID]string
+	authorized map[string]bool
+	required   uint64
+}
+
+var (
+	_ types.SignatureValidator = (*Validator)(nil)
+	_ AuthorizedSetUpdater     = (*Validator)(nil)
+)
+
+// NewValidator creates a validator signing with a relayer key
+func NewValidator(key *ecdsa.PrivateKey) *Validator {
+	return &Validator{
+		key:        key,
+		address:    strings.ToLower(crypto.PubkeyToAddress(key.PublicKey).Hex()),
+		methods:    make(map[types.ChainID]string),
+		authorized: make(map[string]bool),
+	}
+}
+
+// AddChain registers the bridge method completing transfers on a
+// destination chain, unlockTokens or mintTokens
+func (v *Validator) AddChain(chainID types.ChainID, method string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.methods[chainID] = method
+}
+
+// SignTransfer signs the digest the destination bridge of a transfer
+// recovers relayers from
+func (v *Validator) SignTransfer(transferID string, transfer types.Transfer) (*types.Signature, error) {
+	v.mu.RLock()
+	key, address := v.key, v.address
+	method, exists := v.methods[transfer.DestinationChain]
+	v.mu.RUnlock()
+	if !exists {
+		return nil, fmt.Errorf("no bridge registered for chain: %s", transfer.DestinationChain)
+	}
+
+	transfer.ID = transferID
+	digest, err := adapters.TransferDigest(method, transfer)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := crypto.Sign(digest, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transfer digest: %w", err)
+	}
+	// The bridges recover signatures with a recovery ID of 27 or 28
+	signature[crypto.RecoveryIDOffset] += 27
+
+	return &types.Signature{
+		RelayerAddress: address,
+		Signature:      signature,
+		CreatedAt:      time.Now(),
+	}, nil
+}
+
+// ValidateSignatures checks that signatures of distinct authorized relayers
+// meet the required threshold. Signers are not recovered here, as only
+// their transfer's destination bridge knows the digest they signed.
+func (v *Validator) ValidateSignatures(transferID string, signatures []types.Signature) error {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	signed := make(map[string]bool, len(signatures))
+	for _, signature := range signatures {
+		if len(signature.Signature) != crypto.SignatureLength {
+			return fmt.Errorf("invalid signature length from %s: %d", signature.RelayerAddress, len(signature.Signature))
+		}
+		address := strings.ToLower(signature.RelayerAddress)
+		if v.authorized[address] {
+			signed[address] = true
+		}
+	}
+
+	if uint64(len(signed)) < v.required {
+		return fmt.Errorf("transfer %s has %d authorized signatures, %d required", transferID, len(signed), v.required)
+	}
+	return nil
+}
+
+// GetRequiredThreshold returns the signatures the bridges require
+func (v *Validator) GetRequiredThreshold() uint64 {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.required
+}
+
+// GetRelayerAddress returns the lowercase address of this relayer's key
+func (v *Validator) GetRelayerAddress() string {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.address
+}
+
+// IsAuthorizedRelayer reports whether every bridge authorizes an address
+func (v *Validator) IsAuthorizedRelayer(address string) bool {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.authorized[strings.ToLower(address)]
+}
+
+// RotateKey replaces the signing key. The new address must be authorized
+// on the bridges for its signatures to count.
+func (v *Validator) RotateKey(newPrivateKey []byte) error {
+	key, err := crypto.ToECDSA(newPrivateKey)
+	if err != nil {
+		return fmt.Errorf("invalid relayer key: %w", err)
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.key = key
+	v.address = strings.ToLower(crypto.PubkeyToAddress(key.PublicKey).Hex())
+	return nil
+}
+
+// SetAuthorizedRelayers replaces the authorized relayers and threshold
+func (v *Validator) SetAuthorizedRelayers(relayers []string, requiredSignatures uint64) {
+	authorized := make(map[string]bool, len(relayers))
+	for _, relayer := range relayers {
+		authorized[strings.ToLower(relayer)] = true
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.authorized = authorized
+	v.required = requiredSignatures
+}
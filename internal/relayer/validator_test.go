@@ -0,0 +1,97 @@
+package relayer
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/internal/adapters"
+	"nexus-bridge/pkg/types"
+)
+
+func newTestValidator(t *testing.T) *Validator {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	validator := NewValidator(key)
+	validator.AddChain(types.ChainPolygon, adapters.MethodMintTokens)
+	return validator
+}
+
+func createValidatorTransfer() types.Transfer {
+	return types.Transfer{
+		ID:               "0x00000000000000000000000000000000000000000000000000000000000000aa",
+		SourceChain:      types.ChainEthereum,
+		DestinationChain: types.ChainPolygon,
+		Token:            "0xA0b86a33E6441E6C7D3E4C2C4C6C6C6C6C6C6C6C",
+		Amount:           types.NewBigInt(big.NewInt(5000)),
+		Sender:           "0x742d35Cc6634C0532925a3b8D4C9db96590C4C4C",
+		Recipient:        "0x1234567890123456789012345678901234567890",
+	}
+}
+
+func TestValidator_SignsDestinationDigest(t *testing.T) {
+	validator := newTestValidator(t)
+	transfer := createValidatorTransfer()
+
+	signature, err := validator.SignTransfer(transfer.ID, transfer)
+	require.NoError(t, err)
+	assert.Equal(t, validator.GetRelayerAddress(), signature.RelayerAddress)
+	require.Len(t, signature.Signature, crypto.SignatureLength)
+	assert.Contains(t, []byte{27, 28}, signature.Signature[crypto.RecoveryIDOffset])
+
+	// The bridge recovers the signer from the digest of its own method
+	digest, err := adapters.TransferDigest(adapters.MethodMintTokens, transfer)
+	require.NoError(t, err)
+	recoverable := append([]byte(nil), signature.Signature...)
+	recoverable[crypto.RecoveryIDOffset] -= 27
+	pub, err := crypto.SigToPub(digest, recoverable)
+	require.NoError(t, err)
+	assert.Equal(t, validator.GetRelayerAddress(), strings.ToLower(crypto.PubkeyToAddress(*pub).Hex()))
+
+	unknown := transfer
+	unknown.DestinationChain = types.ChainID(56)
+	_, err = validator.SignTransfer(unknown.ID, unknown)
+	assert.Error(t, err)
+}
+
+func TestValidator_ValidateSignatures(t *testing.T) {
+	validator := newTestValidator(t)
+	transfer := createValidatorTransfer()
+	first := "0x00000000000000000000000000000000000000A1"
+	second := "0x00000000000000000000000000000000000000a2"
+	validator.SetAuthorizedRelayers([]string{first, second}, 2)
+
+	assert.True(t, validator.IsAuthorizedRelayer(strings.ToLower(first)))
+	assert.False(t, validator.IsAuthorizedRelayer(validator.GetRelayerAddress()))
+	assert.Equal(t, uint64(2), validator.GetRequiredThreshold())
+
+	sign := func(address string) types.Signature {
+		return types.Signature{RelayerAddress: address, Signature: make([]byte, crypto.SignatureLength)}
+	}
+	assert.NoError(t, validator.ValidateSignatures(transfer.ID, []types.Signature{sign(first), sign(second)}))
+
+	// Duplicates and unauthorized relayers do not count
+	assert.Error(t, validator.ValidateSignatures(transfer.ID, []types.Signature{sign(first), sign(first)}))
+	assert.Error(t, validator.ValidateSignatures(transfer.ID, []types.Signature{sign(first), sign(validator.GetRelayerAddress())}))
+
+	short := sign(second)
+	short.Signature = short.Signature[:64]
+	assert.Error(t, validator.ValidateSignatures(transfer.ID, []types.Signature{sign(first), short}))
+}
+
+func TestValidator_RotateKey(t *testing.T) {
+	validator := newTestValidator(t)
+	previous := validator.GetRelayerAddress()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	require.NoError(t, validator.RotateKey(crypto.FromECDSA(key)))
+	assert.NotEqual(t, previous, validator.GetRelayerAddress())
+	assert.Equal(t, strings.ToLower(crypto.PubkeyToAddress(key.PublicKey).Hex()), validator.GetRelayerAddress())
+
+	assert.Error(t, validator.RotateKey([]byte("short")))
+}
@@ -0,0 +1,166 @@
+package screening
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// denylistFile is the JSON form of a denylist file
+type denylistFile struct {
+	Addresses []fileEntry `json:"addresses"`
+}
+
+// fileEntry is a denied address with what it is listed for
+type fileEntry struct {
+	Address string `json:"address"`
+	Name    string `json:"name"`
+}
+
+// FileList is a Screener reading a denylist from a CSV or JSON file, for
+// lists exported from sanctions sources. The file is read again whenever it
+// changes; if it becomes invalid, the addresses read last are kept.
+//
+// JSON files hold {"addresses": [{"address": ..., "name": ...}]}. CSV files
+// start with a header naming an address column and optionally a name
+// column; other columns are ignored, as are lines starting with #.
+type FileList struct {
+	path     string
+	list     *Denylist
+	modified time.Time
+	mu       sync.Mutex
+}
+
+var _ Screener = (*FileList)(nil)
+
+// NewFileList creates a screener from a denylist file, which must be valid.
+// Files ending in .json are read as JSON and any other as CSV.
+func NewFileList(path string) (*FileList, error) {
+	f := &FileList{path: path}
+	if err := f.reload(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Screen checks an address against the latest valid file
+func (f *FileList) Screen(ctx context.Context, address string) (*Hit, error) {
+	f.mu.Lock()
+	if err := f.reload(); err != nil {
+		fmt.Printf("Error reloading denylist %s: %v\n", f.path, err)
+	}
+	list := f.list
+	f.mu.Unlock()
+
+	return list.Screen(ctx, address)
+}
+
+// Len returns the number of addresses in the list read last
+func (f *FileList) Len() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.list.Len()
+}
+
+// reload reads the file if it changed since it was last read
+func (f *FileList) reload() error {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return fmt.Errorf("failed to read denylist: %w", err)
+	}
+	if f.list != nil && info.ModTime().Equal(f.modified) {
+		return nil
+	}
+
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return fmt.Errorf("failed to read denylist: %w", err)
+	}
+
+	var entries []fileEntry
+	if strings.EqualFold(filepath.Ext(f.path), ".json") {
+		entries, err = parseJSONList(data)
+	} else {
+		entries, err = parseCSVList(data)
+	}
+	if err != nil {
+		return err
+	}
+
+	list := NewDenylist(f.path)
+	for _, entry := range entries {
+		list.Add(entry.Address, entry.Name)
+	}
+	f.list = list
+	f.modified = info.ModTime()
+	return nil
+}
+
+// parseJSONList parses the entries of a JSON denylist
+func parseJSONList(data []byte) ([]fileEntry, error) {
+	var file denylistFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid denylist: %w", err)
+	}
+	for i, entry := range file.Addresses {
+		if strings.TrimSpace(entry.Address) == "" {
+			return nil, fmt.Errorf("invalid denylist entry %d: address is required", i)
+		}
+	}
+	return file.Addresses, nil
+}
+
+// parseCSVList parses the entries of a CSV denylist
+func parseCSVList(data []byte) ([]fileEntry, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("invalid denylist: missing header: %w", err)
+	}
+	addressColumn, nameColumn := -1, -1
+	for i, column := range header {
+		switch strings.ToLower(strings.TrimSpace(column)) {
+		case "address":
+			addressColumn = i
+		case "name":
+			nameColumn = i
+		}
+	}
+	if addressColumn < 0 {
+		return nil, fmt.Errorf("invalid denylist: no address column in header")
+	}
+
+	var entries []fileEntry
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid denylist: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		if addressColumn >= len(record) || strings.TrimSpace(record[addressColumn]) == "" {
+			return nil, fmt.Errorf("invalid denylist line %d: address is required", line)
+		}
+		entry := fileEntry{Address: record[addressColumn]}
+		if nameColumn >= 0 && nameColumn < len(record) {
+			entry.Name = strings.TrimSpace(record[nameColumn])
+		}
+		entries = append(entries, entry)
+	}
+}
@@ -0,0 +1,86 @@
+package screening
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeDenylist(t *testing.T, path, content string, modified time.Time) {
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	require.NoError(t, os.Chtimes(path, modified, modified))
+}
+
+func TestFileList_CSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sdn.csv")
+	modified := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	writeDenylist(t, path, `# exported 2026-10-18
+Name,Program,Address
+"Lazarus Group",DPRK3,`+deniedAddress+`
+Sanctioned Mixer,CYBER2, 0x722122dF12D4e14e13Ac3b6895a86e84145b6967
+`, modified)
+
+	list, err := NewFileList(path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, list.Len())
+
+	hit, err := list.Screen(context.Background(), "0x722122df12d4e14e13ac3b6895a86e84145b6967")
+	require.NoError(t, err)
+	require.NotNil(t, hit)
+	assert.Equal(t, path, hit.List)
+	assert.Equal(t, "Sanctioned Mixer", hit.Entry)
+
+	// Changes are picked up
+	writeDenylist(t, path, "address\n0x742d35Cc6634C0532925a3b8D4C9db96590C4C4C\n", modified.Add(time.Hour))
+	hit, err = list.Screen(context.Background(), deniedAddress)
+	require.NoError(t, err)
+	assert.Nil(t, hit)
+	hit, err = list.Screen(context.Background(), "0x742d35Cc6634C0532925a3b8D4C9db96590C4C4C")
+	require.NoError(t, err)
+	assert.NotNil(t, hit)
+
+	// An invalid file keeps the last addresses
+	writeDenylist(t, path, "name\nsomeone\n", modified.Add(2*time.Hour))
+	hit, err = list.Screen(context.Background(), "0x742d35Cc6634C0532925a3b8D4C9db96590C4C4C")
+	require.NoError(t, err)
+	assert.NotNil(t, hit)
+}
+
+func TestFileList_JSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "denylist.json")
+	writeDenylist(t, path, `{"addresses": [{"address": "`+deniedAddress+`", "name": "Lazarus Group"}]}`, time.Now())
+
+	list, err := NewFileList(path)
+	require.NoError(t, err)
+
+	hit, err := list.Screen(context.Background(), deniedAddress)
+	require.NoError(t, err)
+	require.NotNil(t, hit)
+	assert.Equal(t, "Lazarus Group", hit.Entry)
+}
+
+func TestNewFileList_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	_, err := NewFileList(filepath.Join(dir, "missing.csv"))
+	assert.Error(t, err)
+
+	files := map[string]string{
+		"empty.csv":      "",
+		"no-address.csv": "name,program\nsomeone,SDGT\n",
+		"blank.csv":      "name,address\nsomeone,\n",
+		"quotes.csv":     "address\n\"0x01\n",
+		"syntax.json":    `{"addresses": [`,
+		"blank.json":     `{"addresses": [{"name": "someone"}]}`,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		writeDenylist(t, path, content, time.Now())
+		_, err := NewFileList(path)
+		assert.Error(t, err, name)
+	}
+}
@@ -0,0 +1,88 @@
+package screening
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// Hit is an address found on a denylist
+type Hit struct {
+	Address string `json:"address"`
+	// List names the denylist the address is on
+	List string `json:"list"`
+	// Entry describes the listing, such as the sanctioned party
+	Entry string `json:"entry,omitempty"`
+}
+
+// Screener checks addresses against a source of denied parties. Screen
+// returns nil for an address that is not denied.
+type Screener interface {
+	Screen(ctx context.Context, address string) (*Hit, error)
+}
+
+// Screeners screens an address with each screener in turn, returning the
+// first hit
+type Screeners []Screener
+
+var _ Screener = Screeners(nil)
+
+// Screen returns the hit of the first screener denying an address
+func (s Screeners) Screen(ctx context.Context, address string) (*Hit, error) {
+	for _, screener := range s {
+		hit, err := screener.Screen(ctx, address)
+		if err != nil || hit != nil {
+			return hit, err
+		}
+	}
+	return nil, nil
+}
+
+// Denylist is a Screener over addresses set in process
+type Denylist struct {
+	name    string
+	entries map[string]string
+	mu      sync.RWMutex
+}
+
+var _ Screener = (*Denylist)(nil)
+
+// NewDenylist creates an empty denylist reported as name in hits
+func NewDenylist(name string) *Denylist {
+	return &Denylist{
+		name:    name,
+		entries: make(map[string]string),
+	}
+}
+
+// Add denies an address, described by entry
+func (d *Denylist) Add(address, entry string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.entries[normalize(address)] = entry
+}
+
+// Len returns the number of denied addresses
+func (d *Denylist) Len() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return len(d.entries)
+}
+
+// Screen reports whether an address is on the list. Addresses are compared
+// without case.
+func (d *Denylist) Screen(ctx context.Context, address string) (*Hit, error) {
+	d.mu.RLock()
+	entry, denied := d.entries[normalize(address)]
+	d.mu.RUnlock()
+
+	if !denied {
+		return nil, nil
+	}
+	return &Hit{Address: address, List: d.name, Entry: entry}, nil
+}
+
+// normalize keys addresses by their trimmed lowercase form
+func normalize(address string) string {
+	return strings.ToLower(strings.TrimSpace(address))
+}
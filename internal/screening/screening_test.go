@@ -0,0 +1,53 @@
+package screening
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const deniedAddress = "0x8589427373D6D84E98730D7795D8f6f8731FDA16"
+
+// failingScreener is a provider that cannot be reached
+type failingScreener struct{}
+
+func (failingScreener) Screen(ctx context.Context, address string) (*Hit, error) {
+	return nil, errors.New("provider unavailable")
+}
+
+func TestDenylist(t *testing.T) {
+	list := NewDenylist("internal")
+	list.Add(deniedAddress, "mixer")
+
+	hit, err := list.Screen(context.Background(), "0x8589427373d6d84e98730d7795d8f6f8731fda16")
+	require.NoError(t, err)
+	require.NotNil(t, hit)
+	assert.Equal(t, "internal", hit.List)
+	assert.Equal(t, "mixer", hit.Entry)
+
+	hit, err = list.Screen(context.Background(), "0x742d35Cc6634C0532925a3b8D4C9db96590C4C4C")
+	require.NoError(t, err)
+	assert.Nil(t, hit)
+}
+
+func TestScreeners(t *testing.T) {
+	first := NewDenylist("first")
+	second := NewDenylist("second")
+	second.Add(deniedAddress, "")
+
+	hit, err := Screeners{first, second}.Screen(context.Background(), deniedAddress)
+	require.NoError(t, err)
+	require.NotNil(t, hit)
+	assert.Equal(t, "second", hit.List)
+
+	// A provider failing is not a clear result
+	_, err = Screeners{first, failingScreener{}, second}.Screen(context.Background(), deniedAddress)
+	assert.Error(t, err)
+
+	hit, err = Screeners{}.Screen(context.Background(), deniedAddress)
+	require.NoError(t, err)
+	assert.Nil(t, hit)
+}
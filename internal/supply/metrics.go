@@ -0,0 +1,82 @@
+package supply
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// metrics exports the latest supplies and deltas of every checked token,
+// in whole tokens
+type metrics struct {
+	locked      *prometheus.GaugeVec
+	wrapped     *prometheus.GaugeVec
+	delta       *prometheus.GaugeVec
+	breach      *prometheus.GaugeVec
+	lastSuccess prometheus.Gauge
+}
+
+func newMetrics() *metrics {
+	tokenLabels := []string{"chain", "token", "symbol"}
+	checkLabels := []string{"chain", "token", "symbol", "check"}
+
+	return &metrics{
+		locked: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "nexus_bridge_supply_locked",
+			Help: "Tokens locked by the bridge on the token's chain.",
+		}, tokenLabels),
+		wrapped: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "nexus_bridge_supply_wrapped",
+			Help: "Total supply of the token's wrapped form on a mint chain.",
+		}, tokenLabels),
+		delta: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "nexus_bridge_supply_delta",
+			Help: "Difference found by a supply check, in tokens.",
+		}, checkLabels),
+		breach: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "nexus_bridge_supply_breach",
+			Help: "Whether a supply check is off by more than the tolerance (1) or not (0).",
+		}, checkLabels),
+		lastSuccess: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "nexus_bridge_supply_last_success_timestamp_seconds",
+			Help: "Time of the last check that read every token.",
+		}),
+	}
+}
+
+// Register adds the monitor's metrics to a registry
+func (m *Monitor) Register(registry prometheus.Registerer) error {
+	for _, collector := range []prometheus.Collector{m.metrics.locked, m.metrics.wrapped, m.metrics.delta, m.metrics.breach, m.metrics.lastSuccess} {
+		if err := registry.Register(collector); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// record exports the supplies and deltas of a token
+func (m *metrics) record(supply TokenSupply) {
+	token := strings.ToLower(supply.Token)
+	m.locked.WithLabelValues(supply.Chain.String(), token, supply.Symbol).Set(tokens(supply.Locked, supply.Decimals))
+	for _, wrapped := range supply.Wrapped {
+		m.wrapped.WithLabelValues(wrapped.Chain.String(), token, supply.Symbol).Set(tokens(wrapped.TotalSupply, supply.Decimals))
+	}
+
+	for _, delta := range supply.Deltas {
+		labels := []string{delta.Chain.String(), token, supply.Symbol, delta.Check}
+		m.delta.WithLabelValues(labels...).Set(tokens(delta.Amount, supply.Decimals))
+		breach := 0.0
+		if delta.Breach {
+			breach = 1
+		}
+		m.breach.WithLabelValues(labels...).Set(breach)
+	}
+}
+
+// tokens converts an amount in base units into whole tokens
+func tokens(amount *big.Int, decimals uint8) float64 {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	value, _ := new(big.Rat).SetFrac(amount, scale).Float64()
+	return value
+}
@@ -0,0 +1,320 @@
+package supply
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"nexus-bridge/internal/adapters"
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+const (
+	// DefaultInterval is how often supplies are checked
+	DefaultInterval = time.Minute
+	// DefaultToleranceBps is how far, in basis points of the locked
+	// collateral, a delta may go before it is a breach. It absorbs the
+	// transfers in flight between a chain and the database.
+	DefaultToleranceBps = 10
+)
+
+// Checks comparing supplies. Each delta is the first amount minus the
+// second.
+const (
+	// CheckCollateral compares the wrapped supply on every mint chain with
+	// the collateral locked on the token's chain
+	CheckCollateral = "collateral"
+	// CheckMinted compares the wrapped token's total supply with what the
+	// bridge reports having minted
+	CheckMinted = "minted"
+	// CheckLockedDB compares the locked collateral with the amount the
+	// recorded transfers locked
+	CheckLockedDB = "locked_db"
+	// CheckWrappedDB compares the wrapped supply with the amount the
+	// recorded transfers minted
+	CheckWrappedDB = "wrapped_db"
+)
+
+// CollateralReader reads the collateral held by a bridge that locks tokens
+type CollateralReader interface {
+	LockedBalance(ctx context.Context, token string) (*big.Int, error)
+}
+
+// MintReader reads the wrapped supply of a bridge that mints tokens from
+// other chains
+type MintReader interface {
+	WrappedToken(ctx context.Context, originalToken string, originalChain types.ChainID) (string, error)
+	TotalMinted(ctx context.Context, originalToken string, originalChain types.ChainID) (*big.Int, error)
+	TotalSupply(ctx context.Context, token string) (*big.Int, error)
+}
+
+var (
+	_ CollateralReader = (*adapters.EthereumAdapter)(nil)
+	_ MintReader       = (*adapters.EthereumAdapter)(nil)
+)
+
+// Store provides the tokens to check and the transfers moving them
+type Store interface {
+	ListSupportedTokens(ctx context.Context) ([]types.SupportedToken, error)
+	models.RouteTotaler
+}
+
+// Delta is the difference found by a check on a chain
+type Delta struct {
+	Check  string
+	Chain  types.ChainID
+	Amount *big.Int
+	Breach bool
+}
+
+// WrappedSupply is the supply of a token's wrapped form on a mint chain
+type WrappedSupply struct {
+	Chain        types.ChainID
+	WrappedToken string
+	TotalSupply  *big.Int
+	TotalMinted  *big.Int
+	// Recorded is what the recorded transfers minted, net of burns
+	Recorded *big.Int
+}
+
+// TokenSupply reconciles a token locked on its chain with its wrapped forms
+type TokenSupply struct {
+	Chain    types.ChainID
+	Token    string
+	Symbol   string
+	Decimals uint8
+	Locked   *big.Int
+	// Recorded is what the recorded transfers locked, net of unlocks
+	Recorded *big.Int
+	Wrapped  []WrappedSupply
+	Deltas   []Delta
+}
+
+// Breaches returns the deltas beyond the tolerance
+func (s TokenSupply) Breaches() []Delta {
+	var breaches []Delta
+	for _, delta := range s.Deltas {
+		if delta.Breach {
+			breaches = append(breaches, delta)
+		}
+	}
+	return breaches
+}
+
+// Monitor periodically reconciles the collateral locked on chains whose
+// bridge unlocks tokens with the wrapped supply on chains whose bridge mints
+// them, and both with the transfers in the database
+type Monitor struct {
+	store        Store
+	lockChains   map[types.ChainID]CollateralReader
+	mintChains   map[types.ChainID]MintReader
+	metrics      *metrics
+	now          func() time.Time
+	Interval     time.Duration
+	ToleranceBps int64
+	// OnBreach, when set, is called with every token that has a breach
+	OnBreach func(ctx context.Context, supply TokenSupply)
+}
+
+// NewMonitor creates a monitor checking the tokens of a store
+func NewMonitor(store Store) *Monitor {
+	return &Monitor{
+		store:        store,
+		lockChains:   make(map[types.ChainID]CollateralReader),
+		mintChains:   make(map[types.ChainID]MintReader),
+		metrics:      newMetrics(),
+		now:          time.Now,
+		Interval:     DefaultInterval,
+		ToleranceBps: DefaultToleranceBps,
+	}
+}
+
+// AddLockChain registers a chain whose bridge locks its own tokens
+func (m *Monitor) AddLockChain(chainID types.ChainID, reader CollateralReader) {
+	m.lockChains[chainID] = reader
+}
+
+// AddMintChain registers a chain whose bridge mints wrapped tokens
+func (m *Monitor) AddMintChain(chainID types.ChainID, reader MintReader) {
+	m.mintChains[chainID] = reader
+}
+
+// Run checks supplies until the context ends
+func (m *Monitor) Run(ctx context.Context) error {
+	ticker := time.NewTicker(m.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := m.Check(ctx); err != nil && ctx.Err() == nil {
+			fmt.Printf("Error checking token supplies: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check reconciles every supported token on a lock chain and exports the
+// deltas. Breaches are logged. Tokens that cannot be read are skipped and
+// reported in the returned error once the others are checked.
+func (m *Monitor) Check(ctx context.Context) ([]TokenSupply, error) {
+	tokens, err := m.store.ListSupportedTokens(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tokens: %w", err)
+	}
+	sort.Slice(tokens, func(i, j int) bool { return tokens[i].ID < tokens[j].ID })
+
+	var supplies []TokenSupply
+	var failed []string
+	for _, token := range tokens {
+		reader, isLockChain := m.lockChains[token.ChainID]
+		if !isLockChain {
+			continue
+		}
+
+		supply, err := m.checkToken(ctx, token, reader)
+		if err != nil {
+			fmt.Printf("Error checking supply of %s on %s: %v\n", token.Symbol, token.ChainID, err)
+			failed = append(failed, token.Symbol)
+			continue
+		}
+
+		breaches := supply.Breaches()
+		for _, breach := range breaches {
+			fmt.Printf("Supply invariant breached: %s %s check on %s is off by %s base units\n",
+				supply.Symbol, breach.Check, breach.Chain, breach.Amount)
+		}
+		if len(breaches) > 0 && m.OnBreach != nil {
+			m.OnBreach(ctx, *supply)
+		}
+		m.metrics.record(*supply)
+		supplies = append(supplies, *supply)
+	}
+
+	if len(failed) > 0 {
+		return supplies, fmt.Errorf("failed to check %d tokens: %s", len(failed), strings.Join(failed, ", "))
+	}
+	m.metrics.lastSuccess.Set(float64(m.now().Unix()))
+	return supplies, nil
+}
+
+// checkToken reads the supplies of a token and computes its deltas
+func (m *Monitor) checkToken(ctx context.Context, token types.SupportedToken, reader CollateralReader) (*TokenSupply, error) {
+	supply := &TokenSupply{
+		Chain:    token.ChainID,
+		Token:    token.TokenAddress,
+		Symbol:   token.Symbol,
+		Decimals: token.Decimals,
+		Recorded: new(big.Int),
+	}
+
+	locked, err := reader.LockedBalance(ctx, token.TokenAddress)
+	if err != nil {
+		return nil, err
+	}
+	supply.Locked = locked
+
+	wrappedTotal := new(big.Int)
+	for _, chainID := range m.mintChainIDs() {
+		wrapped, err := m.readWrapped(ctx, token, chainID)
+		if err != nil {
+			return nil, err
+		}
+		if wrapped == nil {
+			continue
+		}
+
+		// Tokens lock on the way out and unlock on the way back
+		out, err := m.store.GetRouteTotals(ctx, token.ChainID, chainID, token.TokenAddress)
+		if err != nil {
+			return nil, err
+		}
+		back, err := m.store.GetRouteTotals(ctx, chainID, token.ChainID, token.TokenAddress)
+		if err != nil {
+			return nil, err
+		}
+		supply.Recorded.Add(supply.Recorded, out.Recorded)
+		supply.Recorded.Sub(supply.Recorded, back.Completed)
+		// Wrapped tokens mint on arrival and burn on the way back
+		wrapped.Recorded = new(big.Int).Sub(out.Completed, back.Recorded)
+
+		supply.Wrapped = append(supply.Wrapped, *wrapped)
+		wrappedTotal.Add(wrappedTotal, wrapped.TotalSupply)
+	}
+
+	tolerance := new(big.Int).Mul(locked, big.NewInt(m.ToleranceBps))
+	tolerance.Quo(tolerance, big.NewInt(10000))
+
+	// Only wrapped supply in excess of the collateral is unbacked
+	collateral := new(big.Int).Sub(wrappedTotal, locked)
+	supply.Deltas = append(supply.Deltas, Delta{
+		Check:  CheckCollateral,
+		Chain:  token.ChainID,
+		Amount: collateral,
+		Breach: collateral.Cmp(tolerance) > 0,
+	})
+	supply.Deltas = append(supply.Deltas, newDelta(CheckLockedDB, token.ChainID, locked, supply.Recorded, tolerance))
+	for _, wrapped := range supply.Wrapped {
+		supply.Deltas = append(supply.Deltas,
+			newDelta(CheckMinted, wrapped.Chain, wrapped.TotalSupply, wrapped.TotalMinted, tolerance),
+			newDelta(CheckWrappedDB, wrapped.Chain, wrapped.TotalSupply, wrapped.Recorded, tolerance))
+	}
+
+	return supply, nil
+}
+
+// readWrapped reads the wrapped form of a token on a mint chain, returning
+// nil if the chain's bridge does not map the token
+func (m *Monitor) readWrapped(ctx context.Context, token types.SupportedToken, chainID types.ChainID) (*WrappedSupply, error) {
+	reader := m.mintChains[chainID]
+
+	address, err := reader.WrappedToken(ctx, token.TokenAddress, token.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	if common.HexToAddress(address) == (common.Address{}) {
+		return nil, nil
+	}
+
+	minted, err := reader.TotalMinted(ctx, token.TokenAddress, token.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	totalSupply, err := reader.TotalSupply(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WrappedSupply{Chain: chainID, WrappedToken: address, TotalSupply: totalSupply, TotalMinted: minted}, nil
+}
+
+// mintChainIDs returns the mint chains in a stable order
+func (m *Monitor) mintChainIDs() []types.ChainID {
+	chainIDs := make([]types.ChainID, 0, len(m.mintChains))
+	for chainID := range m.mintChains {
+		chainIDs = append(chainIDs, chainID)
+	}
+	sort.Slice(chainIDs, func(i, j int) bool { return chainIDs[i] < chainIDs[j] })
+	return chainIDs
+}
+
+// newDelta compares two amounts that should match, breaching when they
+// differ by more than the tolerance either way
+func newDelta(check string, chainID types.ChainID, actual, expected, tolerance *big.Int) Delta {
+	amount := new(big.Int).Sub(actual, expected)
+	return Delta{
+		Check:  check,
+		Chain:  chainID,
+		Amount: amount,
+		Breach: new(big.Int).Abs(amount).Cmp(tolerance) > 0,
+	}
+}
@@ -0,0 +1,235 @@
+package supply
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+const (
+	testToken   = "0xA0b86a33E6441E6C7D3E4C2C4C6C6C6C6C6C6C6C"
+	testWrapped = "0x00000000000000000000000000000000000000b1"
+)
+
+// fakeLockChain is a CollateralReader with fixed balances
+type fakeLockChain struct {
+	locked map[string]*big.Int
+	err    error
+}
+
+func (f *fakeLockChain) LockedBalance(ctx context.Context, token string) (*big.Int, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if locked, exists := f.locked[strings.ToLower(token)]; exists {
+		return locked, nil
+	}
+	return big.NewInt(0), nil
+}
+
+// fakeMintChain is a MintReader mapping tokens to wrapped tokens with fixed
+// supplies
+type fakeMintChain struct {
+	wrapped map[string]string
+	minted  map[string]*big.Int
+	supply  map[string]*big.Int
+}
+
+func (f *fakeMintChain) WrappedToken(ctx context.Context, originalToken string, originalChain types.ChainID) (string, error) {
+	if wrapped, exists := f.wrapped[strings.ToLower(originalToken)]; exists {
+		return wrapped, nil
+	}
+	return "0x0000000000000000000000000000000000000000", nil
+}
+
+func (f *fakeMintChain) TotalMinted(ctx context.Context, originalToken string, originalChain types.ChainID) (*big.Int, error) {
+	return f.minted[strings.ToLower(originalToken)], nil
+}
+
+func (f *fakeMintChain) TotalSupply(ctx context.Context, token string) (*big.Int, error) {
+	return f.supply[strings.ToLower(token)], nil
+}
+
+// newTestMonitor creates a monitor over a token bridged from Ethereum to
+// Polygon, with 1000 locked and minted and a transfer back in flight
+func newTestMonitor(t *testing.T) (*Monitor, *fakeLockChain, *fakeMintChain) {
+	store := models.NewMemoryStateManager()
+	token := &types.SupportedToken{ChainID: types.ChainEthereum, TokenAddress: testToken, Name: "USD Coin", Symbol: "USDC", Decimals: 6, Enabled: true}
+	require.NoError(t, store.AddSupportedToken(context.Background(), token, nil))
+
+	// Tokens on Polygon are only reconciled from their own chain
+	other := &types.SupportedToken{ChainID: types.ChainPolygon, TokenAddress: testWrapped, Name: "Wrapped", Symbol: "WUSDC", Decimals: 6, Enabled: true}
+	require.NoError(t, store.AddSupportedToken(context.Background(), other, nil))
+
+	recordTransfer(t, store, 1, types.ChainEthereum, types.ChainPolygon, 1200, types.StatusCompleted)
+	recordTransfer(t, store, 2, types.ChainPolygon, types.ChainEthereum, 200, types.StatusCompleted)
+	recordTransfer(t, store, 3, types.ChainPolygon, types.ChainEthereum, 50, types.StatusSigned)
+
+	lock := &fakeLockChain{locked: map[string]*big.Int{strings.ToLower(testToken): big.NewInt(1000)}}
+	mint := &fakeMintChain{
+		wrapped: map[string]string{strings.ToLower(testToken): testWrapped},
+		minted:  map[string]*big.Int{strings.ToLower(testToken): big.NewInt(950)},
+		supply:  map[string]*big.Int{strings.ToLower(testWrapped): big.NewInt(950)},
+	}
+
+	monitor := NewMonitor(store)
+	monitor.ToleranceBps = 0
+	monitor.AddLockChain(types.ChainEthereum, lock)
+	monitor.AddMintChain(types.ChainPolygon, mint)
+	return monitor, lock, mint
+}
+
+func recordTransfer(t *testing.T, store *models.MemoryStateManager, n int, source, destination types.ChainID, amount int64, status types.TransferStatus) {
+	transfer := types.Transfer{
+		ID:               fmt.Sprintf("0x%064x", n),
+		SourceChain:      source,
+		DestinationChain: destination,
+		Token:            testToken,
+		Amount:           types.NewBigInt(big.NewInt(amount)),
+		Sender:           "0x742d35Cc6634C0532925a3b8D4C9db96590C4C4C",
+		Recipient:        "0x8ba1f109551bD432803012645Hac136c22C4C4C",
+		Status:           status,
+	}
+	require.NoError(t, store.RecordTransfer(context.Background(), transfer))
+}
+
+func deltaOf(t *testing.T, supply TokenSupply, check string) Delta {
+	for _, delta := range supply.Deltas {
+		if delta.Check == check {
+			return delta
+		}
+	}
+	t.Fatalf("No %s delta in %+v", check, supply.Deltas)
+	return Delta{}
+}
+
+func TestMonitor_Balanced(t *testing.T) {
+	monitor, _, _ := newTestMonitor(t)
+
+	supplies, err := monitor.Check(context.Background())
+	require.NoError(t, err)
+	require.Len(t, supplies, 1)
+
+	supply := supplies[0]
+	assert.Equal(t, "USDC", supply.Symbol)
+	assert.Equal(t, int64(1000), supply.Recorded.Int64())
+	require.Len(t, supply.Wrapped, 1)
+	assert.Equal(t, int64(950), supply.Wrapped[0].Recorded.Int64())
+	assert.Empty(t, supply.Breaches())
+
+	// The transfer back is burned but not yet unlocked
+	assert.Equal(t, int64(-50), deltaOf(t, supply, CheckCollateral).Amount.Int64())
+	for _, check := range []string{CheckMinted, CheckLockedDB, CheckWrappedDB} {
+		assert.Zero(t, deltaOf(t, supply, check).Amount.Sign(), check)
+	}
+}
+
+func TestMonitor_UnbackedSupply(t *testing.T) {
+	monitor, _, mint := newTestMonitor(t)
+	// Tokens minted outside the bridge
+	mint.supply[strings.ToLower(testWrapped)] = big.NewInt(1100)
+
+	supplies, err := monitor.Check(context.Background())
+	require.NoError(t, err)
+
+	breaches := supplies[0].Breaches()
+	require.Len(t, breaches, 3)
+	assert.Equal(t, CheckCollateral, breaches[0].Check)
+	assert.Equal(t, types.ChainEthereum, breaches[0].Chain)
+	assert.Equal(t, int64(100), breaches[0].Amount.Int64())
+	assert.Equal(t, CheckMinted, breaches[1].Check)
+	assert.Equal(t, types.ChainPolygon, breaches[1].Chain)
+	assert.Equal(t, CheckWrappedDB, breaches[2].Check)
+
+	// Within the tolerance nothing is breached
+	monitor.ToleranceBps = 1500
+	supplies, err = monitor.Check(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, supplies[0].Breaches())
+}
+
+func TestMonitor_DatabaseMismatch(t *testing.T) {
+	monitor, lock, _ := newTestMonitor(t)
+	lock.locked[strings.ToLower(testToken)] = big.NewInt(990)
+
+	supplies, err := monitor.Check(context.Background())
+	require.NoError(t, err)
+
+	breaches := supplies[0].Breaches()
+	require.Len(t, breaches, 1)
+	assert.Equal(t, CheckLockedDB, breaches[0].Check)
+	assert.Equal(t, int64(-10), breaches[0].Amount.Int64())
+}
+
+func TestMonitor_UnmappedToken(t *testing.T) {
+	monitor, _, mint := newTestMonitor(t)
+	mint.wrapped = map[string]string{}
+
+	supplies, err := monitor.Check(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, supplies[0].Wrapped)
+	// Nothing should be locked without a wrapped form to back
+	assert.Equal(t, int64(-1000), deltaOf(t, supplies[0], CheckCollateral).Amount.Int64())
+	assert.False(t, deltaOf(t, supplies[0], CheckCollateral).Breach)
+}
+
+func TestMonitor_ExportsMetrics(t *testing.T) {
+	monitor, lock, mint := newTestMonitor(t)
+	registry := prometheus.NewRegistry()
+	require.NoError(t, monitor.Register(registry))
+
+	mint.supply[strings.ToLower(testWrapped)] = big.NewInt(1_500_000)
+	mint.minted[strings.ToLower(testToken)] = big.NewInt(1_500_000)
+	lock.locked[strings.ToLower(testToken)] = big.NewInt(1_000_000)
+	_, err := monitor.Check(context.Background())
+	require.NoError(t, err)
+
+	token := strings.ToLower(testToken)
+	assert.Equal(t, 1.0, testutil.ToFloat64(monitor.metrics.locked.WithLabelValues("ethereum", token, "USDC")))
+	assert.Equal(t, 1.5, testutil.ToFloat64(monitor.metrics.wrapped.WithLabelValues("polygon", token, "USDC")))
+	assert.Equal(t, 0.5, testutil.ToFloat64(monitor.metrics.delta.WithLabelValues("ethereum", token, "USDC", CheckCollateral)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(monitor.metrics.breach.WithLabelValues("ethereum", token, "USDC", CheckCollateral)))
+	assert.Equal(t, 0.0, testutil.ToFloat64(monitor.metrics.breach.WithLabelValues("polygon", token, "USDC", CheckMinted)))
+	assert.NotZero(t, testutil.ToFloat64(monitor.metrics.lastSuccess))
+}
+
+func TestMonitor_ReadFailure(t *testing.T) {
+	monitor, lock, _ := newTestMonitor(t)
+	registry := prometheus.NewRegistry()
+	require.NoError(t, monitor.Register(registry))
+	lock.err = errors.New("connection refused")
+
+	supplies, err := monitor.Check(context.Background())
+	assert.Error(t, err)
+	assert.Empty(t, supplies)
+	assert.Zero(t, testutil.ToFloat64(monitor.metrics.lastSuccess))
+}
+
+func TestMonitor_OnBreach(t *testing.T) {
+	monitor, lock, _ := newTestMonitor(t)
+	var breached []TokenSupply
+	monitor.OnBreach = func(ctx context.Context, supply TokenSupply) {
+		breached = append(breached, supply)
+	}
+
+	_, err := monitor.Check(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, breached)
+
+	lock.locked[strings.ToLower(testToken)] = big.NewInt(990)
+	_, err = monitor.Check(context.Background())
+	require.NoError(t, err)
+	require.Len(t, breached, 1)
+	assert.Equal(t, "USDC", breached[0].Symbol)
+}
@@ -0,0 +1,200 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"nexus-bridge/internal/models"
+)
+
+const (
+	// DefaultMaxAttempts is how many times a delivery is attempted before it
+	// is dead-lettered
+	DefaultMaxAttempts = 8
+	// DefaultBaseBackoff is the delay before the first retry. Each further
+	// retry doubles it.
+	DefaultBaseBackoff = 30 * time.Second
+	// DefaultMaxBackoff caps the delay between retries
+	DefaultMaxBackoff = time.Hour
+	// DefaultPollInterval is how often due deliveries are looked for
+	DefaultPollInterval = 5 * time.Second
+	// DefaultBatchSize is how many deliveries are claimed at once
+	DefaultBatchSize = 50
+	// DefaultTimeout bounds a single delivery request
+	DefaultTimeout = 10 * time.Second
+
+	// maxErrorBodyBytes bounds how much of a failed response is recorded
+	maxErrorBodyBytes = 512
+)
+
+// envelope is the body sent for every webhook event
+type envelope struct {
+	ID        int64           `json:"id"`
+	Event     string          `json:"event"`
+	CreatedAt time.Time       `json:"created_at"`
+	Transfer  json.RawMessage `json:"transfer"`
+}
+
+// Deliverer sends queued webhook deliveries, retrying failures with
+// exponential backoff until they succeed or run out of attempts
+type Deliverer struct {
+	store        models.WebhookStore
+	client       *http.Client
+	now          func() time.Time
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	PollInterval time.Duration
+	BatchSize    int
+}
+
+// NewDeliverer creates a deliverer draining a webhook store
+func NewDeliverer(store models.WebhookStore) *Deliverer {
+	return &Deliverer{
+		store:        store,
+		client:       &http.Client{Timeout: DefaultTimeout},
+		now:          time.Now,
+		MaxAttempts:  DefaultMaxAttempts,
+		BaseBackoff:  DefaultBaseBackoff,
+		MaxBackoff:   DefaultMaxBackoff,
+		PollInterval: DefaultPollInterval,
+		BatchSize:    DefaultBatchSize,
+	}
+}
+
+// Run delivers due deliveries until the context ends
+func (d *Deliverer) Run(ctx context.Context) error {
+	ticker := time.NewTicker(d.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := d.DeliverDue(ctx); err != nil && ctx.Err() == nil {
+			fmt.Printf("Error delivering webhooks: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverDue claims and attempts due deliveries until none are left,
+// returning how many were attempted
+func (d *Deliverer) DeliverDue(ctx context.Context) (int, error) {
+	attempted := 0
+	for {
+		// A claim is held for longer than a batch can take to send
+		lease := time.Duration(d.BatchSize+1) * d.client.Timeout
+		deliveries, err := d.store.ClaimDeliveries(ctx, d.BatchSize, lease)
+		if err != nil {
+			return attempted, err
+		}
+
+		webhooks := make(map[int]*models.Webhook)
+		for _, delivery := range deliveries {
+			webhook, cached := webhooks[delivery.WebhookID]
+			if !cached {
+				webhook, err = d.store.GetWebhook(ctx, delivery.WebhookID)
+				if err != nil {
+					if errors.Is(err, models.ErrWebhookNotFound) {
+						// Deleted while claimed; its deliveries went with it
+						continue
+					}
+					return attempted, err
+				}
+				webhooks[delivery.WebhookID] = webhook
+			}
+
+			// A delivery replayed or deleted mid-attempt is no longer pending
+			if err := d.attempt(ctx, webhook, delivery); err != nil && !errors.Is(err, models.ErrDeliveryNotFound) {
+				return attempted, err
+			}
+			attempted++
+		}
+
+		if len(deliveries) < d.BatchSize {
+			return attempted, nil
+		}
+	}
+}
+
+// attempt sends a delivery once and records the outcome
+func (d *Deliverer) attempt(ctx context.Context, webhook *models.Webhook, delivery models.WebhookDelivery) error {
+	statusCode, sendErr := d.send(ctx, webhook, delivery)
+	if sendErr == nil {
+		return d.store.MarkDelivered(ctx, delivery.ID, statusCode)
+	}
+	if ctx.Err() != nil {
+		// Shutting down; the claim expires and the delivery is retried
+		return ctx.Err()
+	}
+
+	var retryAt time.Time
+	if attempts := delivery.Attempts + 1; attempts < d.MaxAttempts {
+		retryAt = d.now().Add(d.backoff(attempts))
+	}
+
+	return d.store.MarkDeliveryFailed(ctx, delivery.ID, statusCode, sendErr.Error(), retryAt)
+}
+
+// send posts a delivery to its webhook, returning the response status code.
+// Only 2xx responses count as delivered.
+func (d *Deliverer) send(ctx context.Context, webhook *models.Webhook, delivery models.WebhookDelivery) (int, error) {
+	body, err := json.Marshal(envelope{
+		ID:        delivery.ID,
+		Event:     delivery.EventType,
+		CreatedAt: delivery.CreatedAt.UTC(),
+		Transfer:  delivery.Payload,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode delivery: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	sentAt := d.now()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(sentAt.Unix(), 10))
+	req.Header.Set(HeaderSignature, Sign(webhook.Secret, sentAt, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		excerpt, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+		return resp.StatusCode, fmt.Errorf("endpoint returned %d: %s", resp.StatusCode, bytes.TrimSpace(excerpt))
+	}
+
+	// Drain the body so the connection can be reused
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxErrorBodyBytes))
+	return resp.StatusCode, nil
+}
+
+// backoff returns the delay after a number of failed attempts
+func (d *Deliverer) backoff(attempts int) time.Duration {
+	delay := d.BaseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= d.MaxBackoff {
+			return d.MaxBackoff
+		}
+	}
+	return delay
+}
@@ -0,0 +1,195 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+// receivedDelivery is a request received by a test endpoint
+type receivedDelivery struct {
+	header http.Header
+	body   []byte
+}
+
+// endpoint is a webhook receiver answering with scripted status codes
+type endpoint struct {
+	mu       sync.Mutex
+	statuses []int
+	received []receivedDelivery
+}
+
+func (e *endpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.received = append(e.received, receivedDelivery{header: r.Header.Clone(), body: body})
+	status := http.StatusOK
+	if len(e.statuses) > 0 {
+		status, e.statuses = e.statuses[0], e.statuses[1:]
+	}
+	w.WriteHeader(status)
+	fmt.Fprint(w, "ack")
+}
+
+func (e *endpoint) requests() []receivedDelivery {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]receivedDelivery(nil), e.received...)
+}
+
+func createTestTransfer(n int) types.Transfer {
+	return types.Transfer{
+		ID:               fmt.Sprintf("0x%064x", n),
+		SourceChain:      types.ChainEthereum,
+		DestinationChain: types.ChainPolygon,
+		Token:            "0xA0b86a33E6441E6C7D3E4C2C4C6C6C6C6C6C6C6C",
+		Amount:           types.NewBigInt(big.NewInt(1000000000000000000)),
+		Sender:           "0x742d35Cc6634C0532925a3b8D4C9db96590C4C4C",
+		Recipient:        "0x8ba1f109551bD432803012645Hac136c22C4C4C",
+		Status:           types.StatusPending,
+	}
+}
+
+// newTestDeliverer registers a webhook for the endpoint and records a
+// transfer, queueing its pending event
+func newTestDeliverer(t *testing.T, receiver *endpoint) (*Deliverer, *models.MemoryStateManager, *models.Webhook) {
+	server := httptest.NewServer(receiver)
+	t.Cleanup(server.Close)
+
+	store := models.NewMemoryStateManager()
+	webhook := &models.Webhook{URL: server.URL, Secret: "secret", Enabled: true}
+	require.NoError(t, store.CreateWebhook(context.Background(), webhook, nil))
+	require.NoError(t, store.RecordTransfer(context.Background(), createTestTransfer(1)))
+
+	return NewDeliverer(store), store, webhook
+}
+
+func TestDeliverer_DeliversSignedEvents(t *testing.T) {
+	receiver := &endpoint{}
+	deliverer, store, webhook := newTestDeliverer(t, receiver)
+	ctx := context.Background()
+
+	attempted, err := deliverer.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, attempted)
+
+	requests := receiver.requests()
+	require.Len(t, requests, 1)
+	request := requests[0]
+	assert.Equal(t, "transfer.pending", request.header.Get(HeaderEvent))
+	assert.Equal(t, "application/json", request.header.Get("Content-Type"))
+	assert.NoError(t, Verify("secret", request.header.Get(HeaderSignature),
+		request.header.Get(HeaderTimestamp), request.body, time.Minute, time.Now()))
+
+	var body struct {
+		ID       int64  `json:"id"`
+		Event    string `json:"event"`
+		Transfer struct {
+			ID     string `json:"id"`
+			Amount string `json:"amount"`
+			Status string `json:"status"`
+		} `json:"transfer"`
+	}
+	require.NoError(t, json.Unmarshal(request.body, &body))
+	assert.Equal(t, request.header.Get(HeaderDelivery), fmt.Sprint(body.ID))
+	assert.Equal(t, "transfer.pending", body.Event)
+	assert.Equal(t, createTestTransfer(1).ID, body.Transfer.ID)
+	assert.Equal(t, "1000000000000000000", body.Transfer.Amount)
+	assert.Equal(t, "pending", body.Transfer.Status)
+
+	delivered, err := store.ListDeliveries(ctx, webhook.ID, models.DeliveryDelivered, 10)
+	require.NoError(t, err)
+	require.Len(t, delivered, 1)
+	assert.Equal(t, 200, delivered[0].LastStatusCode)
+
+	// Nothing is sent twice
+	attempted, err = deliverer.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, attempted)
+}
+
+func TestDeliverer_RetriesThenDeadLetters(t *testing.T) {
+	receiver := &endpoint{statuses: []int{500, 503, 500}}
+	deliverer, store, webhook := newTestDeliverer(t, receiver)
+	deliverer.MaxAttempts = 3
+	ctx := context.Background()
+
+	// Retries are scheduled an hour ahead of a clock wound back further, so
+	// they are due at once
+	deliverer.now = func() time.Time { return time.Now().Add(-2 * time.Hour) }
+	deliverer.BaseBackoff = time.Hour
+
+	for attempt := 1; attempt <= 2; attempt++ {
+		_, err := deliverer.DeliverDue(ctx)
+		require.NoError(t, err)
+
+		pending, err := store.ListDeliveries(ctx, webhook.ID, models.DeliveryPending, 10)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		assert.Equal(t, attempt, pending[0].Attempts)
+		assert.Contains(t, pending[0].LastError, "endpoint returned")
+	}
+
+	_, err := deliverer.DeliverDue(ctx)
+	require.NoError(t, err)
+
+	dead, err := store.ListDeliveries(ctx, webhook.ID, models.DeliveryDead, 10)
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	assert.Equal(t, 3, dead[0].Attempts)
+	assert.Equal(t, 500, dead[0].LastStatusCode)
+	assert.Len(t, receiver.requests(), 3)
+
+	// A replayed delivery gets a fresh set of attempts
+	_, err = store.ReplayDelivery(ctx, webhook.ID, dead[0].ID, nil)
+	require.NoError(t, err)
+	_, err = deliverer.DeliverDue(ctx)
+	require.NoError(t, err)
+
+	delivered, err := store.ListDeliveries(ctx, webhook.ID, models.DeliveryDelivered, 10)
+	require.NoError(t, err)
+	require.Len(t, delivered, 1)
+	assert.Equal(t, 1, delivered[0].Attempts)
+}
+
+func TestDeliverer_SchedulesBackoff(t *testing.T) {
+	receiver := &endpoint{statuses: []int{500}}
+	deliverer, store, webhook := newTestDeliverer(t, receiver)
+	now := time.Now()
+	deliverer.now = func() time.Time { return now }
+
+	_, err := deliverer.DeliverDue(context.Background())
+	require.NoError(t, err)
+
+	pending, err := store.ListDeliveries(context.Background(), webhook.ID, models.DeliveryPending, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.True(t, pending[0].NextAttemptAt.Equal(now.Add(DefaultBaseBackoff)))
+}
+
+func TestDeliverer_Backoff(t *testing.T) {
+	deliverer := NewDeliverer(models.NewMemoryStateManager())
+	deliverer.BaseBackoff = time.Second
+	deliverer.MaxBackoff = 10 * time.Second
+
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second}
+	for i, delay := range expected {
+		assert.Equal(t, delay, deliverer.backoff(i+1), "attempt %d", i+1)
+	}
+}
@@ -0,0 +1,70 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Headers sent with every delivery
+const (
+	HeaderEvent     = "X-NexusBridge-Event"
+	HeaderDelivery  = "X-NexusBridge-Delivery"
+	HeaderTimestamp = "X-NexusBridge-Timestamp"
+	HeaderSignature = "X-NexusBridge-Signature"
+)
+
+// signaturePrefix names the algorithm of a signature header value
+const signaturePrefix = "sha256="
+
+// secretPrefix marks generated webhook secrets
+const secretPrefix = "whsec_"
+
+// GenerateSecret returns a new random signing secret
+func GenerateSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return secretPrefix + hex.EncodeToString(buf), nil
+}
+
+// Sign returns the signature header value for a payload sent at a time. The
+// HMAC-SHA256 covers the Unix timestamp and the body joined by a dot, so a
+// captured delivery cannot be replayed with a different timestamp.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature and timestamp headers of a delivery, rejecting
+// deliveries signed more than tolerance away from now
+func Verify(secret, signature, timestamp string, body []byte, tolerance time.Duration, now time.Time) error {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp: %s", timestamp)
+	}
+
+	signedAt := time.Unix(seconds, 0)
+	if skew := now.Sub(signedAt); skew > tolerance || skew < -tolerance {
+		return fmt.Errorf("timestamp outside tolerance: %s", signedAt.UTC().Format(time.RFC3339))
+	}
+
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return fmt.Errorf("unsupported signature: %s", signature)
+	}
+	expected := Sign(secret, signedAt, body)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return fmt.Errorf("signature mismatch")
+	}
+
+	return nil
+}
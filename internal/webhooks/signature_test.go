@@ -0,0 +1,57 @@
+package webhooks
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateSecret(t *testing.T) {
+	first, err := GenerateSecret()
+	require.NoError(t, err)
+	second, err := GenerateSecret()
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(first, "whsec_"))
+	assert.Len(t, first, len("whsec_")+64)
+	assert.NotEqual(t, first, second)
+}
+
+func TestSignAndVerify(t *testing.T) {
+	body := []byte(`{"id":1,"event":"transfer.completed"}`)
+	sentAt := time.Unix(1760000000, 0)
+	timestamp := strconv.FormatInt(sentAt.Unix(), 10)
+
+	signature := Sign("secret", sentAt, body)
+	assert.True(t, strings.HasPrefix(signature, "sha256="))
+	assert.Equal(t, signature, Sign("secret", sentAt, body))
+
+	require.NoError(t, Verify("secret", signature, timestamp, body, time.Minute, sentAt.Add(30*time.Second)))
+
+	tests := []struct {
+		name      string
+		secret    string
+		signature string
+		timestamp string
+		body      []byte
+		now       time.Time
+	}{
+		{"WrongSecret", "other", signature, timestamp, body, sentAt},
+		{"TamperedBody", "secret", signature, timestamp, []byte(`{"id":2}`), sentAt},
+		{"ChangedTimestamp", "secret", signature, strconv.FormatInt(sentAt.Unix()+1, 10), body, sentAt},
+		{"Stale", "secret", signature, timestamp, body, sentAt.Add(2 * time.Minute)},
+		{"FromTheFuture", "secret", signature, timestamp, body, sentAt.Add(-2 * time.Minute)},
+		{"InvalidTimestamp", "secret", signature, "yesterday", body, sentAt},
+		{"UnknownAlgorithm", "secret", strings.Replace(signature, "sha256=", "md5=", 1), timestamp, body, sentAt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, Verify(tt.secret, tt.signature, tt.timestamp, tt.body, time.Minute, tt.now))
+		})
+	}
+}
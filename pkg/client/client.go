@@ -0,0 +1,312 @@
+// Package client is a typed Go client for the NexusBridge HTTP API
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"nexus-bridge/pkg/types"
+)
+
+// DefaultTimeout bounds every request except streams
+const DefaultTimeout = 30 * time.Second
+
+// ErrNotFound is wrapped by the APIError of a 404 response
+var ErrNotFound = errors.New("not found")
+
+// APIError is a non-2xx response from the API
+type APIError struct {
+	StatusCode int
+	Message    string
+	// RetryAfter is how long to wait before retrying a rate limited request
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("nexus-bridge API returned %d: %s", e.StatusCode, e.Message)
+}
+
+// Unwrap lets errors.Is match ErrNotFound against 404 responses
+func (e *APIError) Unwrap() error {
+	if e.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Client calls the NexusBridge API
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+
+	// Token is sent as a bearer token when set. It may be an API key, a JWT
+	// or an operator admin key.
+	Token string
+	// Timeout bounds each request except streams
+	Timeout time.Duration
+}
+
+// New creates a client for the API at a base URL such as
+// http://localhost:8080
+func New(baseURL string) *Client {
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{},
+		Timeout:    DefaultTimeout,
+	}
+}
+
+// TransferFilter selects transfers to list. Zero fields match every
+// transfer.
+type TransferFilter struct {
+	Status           types.TransferStatus
+	Sender           string
+	Recipient        string
+	Token            string
+	SourceChain      types.ChainID
+	DestinationChain types.ChainID
+	// Chain matches either the source or the destination chain
+	Chain         types.ChainID
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+
+	// Cursor is the NextCursor of the previous page
+	Cursor string
+	Limit  int
+}
+
+// TransferPage is a page of transfers, newest first
+type TransferPage struct {
+	Transfers []types.Transfer `json:"transfers"`
+	// NextCursor fetches the next page, and is empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// QuoteRequest describes a prospective transfer
+type QuoteRequest struct {
+	SourceChain      types.ChainID `json:"source_chain"`
+	DestinationChain types.ChainID `json:"destination_chain"`
+	Token            string        `json:"token"`
+	Amount           *types.BigInt `json:"amount"`
+	Recipient        string        `json:"recipient"`
+}
+
+// Quote is the fee the bridge charges for a transfer, in the transferred
+// token
+type Quote struct {
+	SourceChain      types.ChainID      `json:"source_chain"`
+	DestinationChain types.ChainID      `json:"destination_chain"`
+	Token            string             `json:"token"`
+	Amount           *types.BigInt      `json:"amount"`
+	Fee              *types.FeeEstimate `json:"fee"`
+	QuotedAt         time.Time          `json:"quoted_at"`
+}
+
+// TransferDelay holds a large transfer back from execution until its
+// release time, unless an operator cancels it first
+type TransferDelay struct {
+	ID             int                  `json:"id"`
+	TransferID     string               `json:"transfer_id"`
+	PreviousStatus types.TransferStatus `json:"previous_status"`
+	// Status is waiting, released or cancelled
+	Status      string          `json:"status"`
+	ReleaseAt   time.Time       `json:"release_at"`
+	CancelledBy string          `json:"cancelled_by,omitempty"`
+	Reason      string          `json:"reason,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	ResolvedAt  *time.Time      `json:"resolved_at,omitempty"`
+	Transfer    *types.Transfer `json:"transfer,omitempty"`
+}
+
+// GetTransfer returns a transfer by ID. It returns an error wrapping
+// ErrNotFound if the transfer does not exist.
+func (c *Client) GetTransfer(ctx context.Context, transferID string) (*types.Transfer, error) {
+	var transfer types.Transfer
+	if err := c.do(ctx, http.MethodGet, "/api/v1/transfers/"+url.PathEscape(transferID), nil, nil, &transfer); err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+// ListTransfers returns a page of transfers matching a filter
+func (c *Client) ListTransfers(ctx context.Context, filter TransferFilter) (*TransferPage, error) {
+	query := url.Values{}
+	setQuery(query, "status", string(filter.Status))
+	setQuery(query, "sender", filter.Sender)
+	setQuery(query, "recipient", filter.Recipient)
+	setQuery(query, "token", filter.Token)
+	setChainQuery(query, "source_chain", filter.SourceChain)
+	setChainQuery(query, "destination_chain", filter.DestinationChain)
+	setChainQuery(query, "chain", filter.Chain)
+	if !filter.CreatedAfter.IsZero() {
+		query.Set("created_after", filter.CreatedAfter.Format(time.RFC3339Nano))
+	}
+	if !filter.CreatedBefore.IsZero() {
+		query.Set("created_before", filter.CreatedBefore.Format(time.RFC3339Nano))
+	}
+	setQuery(query, "cursor", filter.Cursor)
+	if filter.Limit > 0 {
+		query.Set("limit", strconv.Itoa(filter.Limit))
+	}
+
+	var page TransferPage
+	if err := c.do(ctx, http.MethodGet, "/api/v1/transfers", query, nil, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// Quote returns the fee for a prospective transfer
+func (c *Client) Quote(ctx context.Context, req QuoteRequest) (*Quote, error) {
+	var quote Quote
+	if err := c.do(ctx, http.MethodPost, "/api/v1/quote", nil, req, &quote); err != nil {
+		return nil, err
+	}
+	return &quote, nil
+}
+
+// ListTokens returns the enabled supported tokens, on a single chain unless
+// chain is zero
+func (c *Client) ListTokens(ctx context.Context, chain types.ChainID) ([]types.SupportedToken, error) {
+	query := url.Values{}
+	setChainQuery(query, "chain", chain)
+
+	var body struct {
+		Tokens []types.SupportedToken `json:"tokens"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/api/v1/tokens", query, nil, &body); err != nil {
+		return nil, err
+	}
+	return body.Tokens, nil
+}
+
+// ListDelays returns the transfers waiting out their delay, soonest release
+// first. It requires an operator token.
+func (c *Client) ListDelays(ctx context.Context) ([]TransferDelay, error) {
+	var body struct {
+		Delays []TransferDelay `json:"delays"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/api/v1/admin/delays", nil, nil, &body); err != nil {
+		return nil, err
+	}
+	return body.Delays, nil
+}
+
+// CancelDelay fails a delayed transfer before it is executed. It returns an
+// error wrapping ErrNotFound if the transfer is not waiting out a delay.
+func (c *Client) CancelDelay(ctx context.Context, transferID, reason string) (*TransferDelay, error) {
+	req := struct {
+		Reason string `json:"reason"`
+	}{Reason: reason}
+
+	var delay TransferDelay
+	path := "/api/v1/admin/delays/" + url.PathEscape(transferID) + "/cancel"
+	if err := c.do(ctx, http.MethodPost, path, nil, req, &delay); err != nil {
+		return nil, err
+	}
+	return &delay, nil
+}
+
+// do sends a request with an optional JSON body and decodes the JSON
+// response into out
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, in, out interface{}) error {
+	if c.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.Timeout)
+		defer cancel()
+	}
+
+	var body io.Reader
+	if in != nil {
+		encoded, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		body = bytes.NewReader(encoded)
+	}
+
+	req, err := c.newRequest(ctx, method, path, query, body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call %s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp); err != nil {
+		return err
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response of %s %s: %w", method, path, err)
+	}
+
+	return nil
+}
+
+// newRequest builds an authenticated request to an API path
+func (c *Client) newRequest(ctx context.Context, method, path string, query url.Values, body io.Reader) (*http.Request, error) {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	return req, nil
+}
+
+// checkResponse returns an APIError for a non-2xx response
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	apiErr := &APIError{StatusCode: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+	var body struct {
+		Error string `json:"error"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<16)).Decode(&body); err == nil && body.Error != "" {
+		apiErr.Message = body.Error
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		apiErr.RetryAfter = time.Duration(seconds) * time.Second
+	}
+
+	return apiErr
+}
+
+// setQuery sets a query parameter unless the value is empty
+func setQuery(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
+
+// setChainQuery sets a chain query parameter unless the chain is zero
+func setChainQuery(query url.Values, key string, chain types.ChainID) {
+	if chain != 0 {
+		query.Set(key, strconv.FormatUint(uint64(chain), 10))
+	}
+}
@@ -0,0 +1,250 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nexus-bridge/internal/api"
+	"nexus-bridge/internal/config"
+	"nexus-bridge/internal/models"
+	"nexus-bridge/pkg/types"
+)
+
+const (
+	testToken     = "0xa0b86a33e6441e6c7d3e4c2c4c6c6c6c6c6c6c6c"
+	testRecipient = "0x8ba1f109551bd432803012645ac136c22c4c4c4c"
+	// testAdminKey is the admin key of the operator alice
+	testAdminKey = "alice-secret"
+)
+
+// staticFees is a FeeCalculator quoting a fixed fee
+type staticFees struct{}
+
+func (staticFees) EstimateFee(ctx context.Context, transfer types.Transfer) (*types.FeeEstimate, error) {
+	return &types.FeeEstimate{
+		SourceChainFee:      types.NewBigInt(big.NewInt(0)),
+		DestinationChainFee: types.NewBigInt(big.NewInt(1000)),
+		RelayerFee:          types.NewBigInt(big.NewInt(100)),
+		TotalFee:            types.NewBigInt(big.NewInt(1100)),
+		EstimatedTime:       144,
+	}, nil
+}
+
+func (staticFees) GetGasPrice(ctx context.Context, chainID types.ChainID) (*big.Int, error) {
+	return big.NewInt(1), nil
+}
+
+func (staticFees) ValidateFee(ctx context.Context, transfer types.Transfer, providedFee *big.Int) error {
+	return nil
+}
+
+func testTransfer(n int) types.Transfer {
+	return types.Transfer{
+		ID:               fmt.Sprintf("0x%064x", n),
+		SourceChain:      types.ChainEthereum,
+		DestinationChain: types.ChainPolygon,
+		Token:            testToken,
+		Amount:           types.NewBigInt(big.NewInt(1000000)),
+		Sender:           "0x742d35cc6634c0532925a3b8d4c9db96590c4c4c",
+		Recipient:        testRecipient,
+		Status:           types.StatusPending,
+		SourceTxHash:     fmt.Sprintf("0x%064x", 1000+n),
+	}
+}
+
+// newTestAPI serves the real API handlers over a memory store
+func newTestAPI(t *testing.T, transfers ...types.Transfer) (*Client, *api.Server, *models.MemoryStateManager) {
+	store := models.NewMemoryStateManager()
+	for _, transfer := range transfers {
+		require.NoError(t, store.RecordTransfer(context.Background(), transfer))
+	}
+
+	server := api.NewServer(config.APIConfig{Port: "0", AdminKeys: map[string]string{"alice": testAdminKey}}, store)
+	server.Fees = staticFees{}
+	ts := httptest.NewServer(server.Handler())
+	t.Cleanup(ts.Close)
+	// Streams must end before the test server can close
+	t.Cleanup(server.Updates.Close)
+
+	return New(ts.URL + "/"), server, store
+}
+
+func TestGetTransfer(t *testing.T) {
+	transfer := testTransfer(1)
+	client, _, _ := newTestAPI(t, transfer)
+
+	got, err := client.GetTransfer(context.Background(), transfer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, transfer.ID, got.ID)
+	assert.Equal(t, types.ChainPolygon, got.DestinationChain)
+	assert.Equal(t, "1000000", got.Amount.String())
+
+	_, err = client.GetTransfer(context.Background(), testTransfer(2).ID)
+	assert.ErrorIs(t, err, ErrNotFound)
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal(t, "transfer not found", apiErr.Message)
+}
+
+func TestListTransfers(t *testing.T) {
+	first, second, third := testTransfer(1), testTransfer(2), testTransfer(3)
+	third.Status = types.StatusCompleted
+	client, _, _ := newTestAPI(t, first, second, third)
+
+	page, err := client.ListTransfers(context.Background(), TransferFilter{Status: types.StatusPending, Chain: types.ChainPolygon, Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, page.Transfers, 1)
+	require.NotEmpty(t, page.NextCursor)
+
+	next, err := client.ListTransfers(context.Background(), TransferFilter{Status: types.StatusPending, Chain: types.ChainPolygon, Limit: 1, Cursor: page.NextCursor})
+	require.NoError(t, err)
+	require.Len(t, next.Transfers, 1)
+	assert.Empty(t, next.NextCursor)
+	assert.ElementsMatch(t, []string{first.ID, second.ID}, []string{page.Transfers[0].ID, next.Transfers[0].ID})
+
+	_, err = client.ListTransfers(context.Background(), TransferFilter{Status: "unknown"})
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+}
+
+func TestQuote(t *testing.T) {
+	client, _, _ := newTestAPI(t)
+
+	quote, err := client.Quote(context.Background(), QuoteRequest{
+		SourceChain:      types.ChainEthereum,
+		DestinationChain: types.ChainPolygon,
+		Token:            testToken,
+		Amount:           types.NewBigInt(big.NewInt(5000)),
+		Recipient:        testRecipient,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, types.ChainPolygon, quote.DestinationChain)
+	assert.Equal(t, "5000", quote.Amount.String())
+	assert.Equal(t, "1100", quote.Fee.TotalFee.String())
+	assert.Equal(t, int64(144), quote.Fee.EstimatedTime)
+	assert.False(t, quote.QuotedAt.IsZero())
+}
+
+func TestListTokens(t *testing.T) {
+	client, _, store := newTestAPI(t)
+	for _, token := range []types.SupportedToken{
+		{ChainID: types.ChainEthereum, TokenAddress: testToken, Name: "Alpha", Symbol: "ALPHA", Decimals: 18, Enabled: true},
+		{ChainID: types.ChainPolygon, TokenAddress: testRecipient, Name: "Beta", Symbol: "BETA", Decimals: 6, Enabled: true},
+	} {
+		token := token
+		require.NoError(t, store.AddSupportedToken(context.Background(), &token, nil))
+	}
+
+	tokens, err := client.ListTokens(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Len(t, tokens, 2)
+
+	tokens, err = client.ListTokens(context.Background(), types.ChainPolygon)
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	assert.Equal(t, "BETA", tokens[0].Symbol)
+}
+
+func TestClient_SendsToken(t *testing.T) {
+	client, server, _ := newTestAPI(t)
+	server.APIKeys = models.NewMemoryStateManager()
+
+	client.Token = "nbk_revoked"
+	_, err := client.ListTokens(context.Background(), 0)
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+}
+
+func TestDelays(t *testing.T) {
+	transfer := testTransfer(1)
+	client, server, store := newTestAPI(t, transfer)
+	server.Delays = store
+	_, err := store.DelayTransfer(context.Background(), transfer.ID, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	// Delays are operator endpoints
+	_, err = client.ListDelays(context.Background())
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+
+	client.Token = testAdminKey
+	delays, err := client.ListDelays(context.Background())
+	require.NoError(t, err)
+	require.Len(t, delays, 1)
+	assert.Equal(t, transfer.ID, delays[0].TransferID)
+	assert.Equal(t, "waiting", delays[0].Status)
+	require.NotNil(t, delays[0].Transfer)
+	assert.Equal(t, types.StatusDelayed, delays[0].Transfer.Status)
+
+	delay, err := client.CancelDelay(context.Background(), transfer.ID, "exploit")
+	require.NoError(t, err)
+	assert.Equal(t, "cancelled", delay.Status)
+	assert.Equal(t, "alice", delay.CancelledBy)
+
+	_, err = client.CancelDelay(context.Background(), transfer.ID, "again")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestStreamTransfer(t *testing.T) {
+	transfer := testTransfer(1)
+	client, server, _ := newTestAPI(t, transfer)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var received []TransferUpdate
+	err := client.StreamTransfer(ctx, transfer.ID, func(update TransferUpdate) error {
+		received = append(received, update)
+		if len(received) == 1 {
+			// Subscribed with the snapshot; move the transfer along
+			next := models.UpdateFromTransfer(transfer)
+			next.Status = types.StatusSigned
+			server.Updates.Publish(next)
+			next.Status = types.StatusCompleted
+			next.DestinationTxHash = "0xabc"
+			server.Updates.Publish(next)
+		}
+		return nil
+	})
+	require.NoError(t, err, "the stream ends once the transfer completes")
+
+	require.Len(t, received, 3)
+	assert.Equal(t, types.StatusPending, received[0].Status)
+	assert.Equal(t, types.StatusSigned, received[1].Status)
+	assert.Equal(t, types.StatusCompleted, received[2].Status)
+	assert.Equal(t, "0xabc", received[2].DestinationTxHash)
+	assert.Equal(t, transfer.Recipient, received[2].Recipient)
+}
+
+func TestStreamTransfer_Stops(t *testing.T) {
+	transfer := testTransfer(1)
+	client, server, _ := newTestAPI(t, transfer)
+
+	stop := errors.New("stop")
+	err := client.StreamTransfer(context.Background(), transfer.ID, func(TransferUpdate) error { return stop })
+	assert.ErrorIs(t, err, stop)
+
+	err = client.StreamTransfer(context.Background(), transfer.ID, func(TransferUpdate) error {
+		server.Updates.Close()
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrStreamClosed)
+
+	err = client.StreamTransfer(context.Background(), "", func(TransferUpdate) error { return nil })
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+}
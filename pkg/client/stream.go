@@ -0,0 +1,99 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"nexus-bridge/pkg/types"
+)
+
+// ErrStreamClosed is returned when the API ends a stream, for example when
+// it shuts down or the client fell too far behind. Callers may reconnect;
+// the new stream starts with the transfer's current state.
+var ErrStreamClosed = errors.New("stream closed by server")
+
+// TransferUpdate is a change to a transfer's progress
+type TransferUpdate struct {
+	TransferID        string               `json:"transfer_id"`
+	SourceChain       types.ChainID        `json:"source_chain"`
+	DestinationChain  types.ChainID        `json:"destination_chain"`
+	Sender            string               `json:"sender"`
+	Recipient         string               `json:"recipient"`
+	Status            types.TransferStatus `json:"status"`
+	Confirmations     uint64               `json:"confirmations"`
+	DestinationTxHash string               `json:"destination_tx_hash"`
+	UpdatedAt         time.Time            `json:"updated_at"`
+}
+
+// Final reports whether the transfer will not change again
+func (u TransferUpdate) Final() bool {
+	return u.Status == types.StatusCompleted || u.Status == types.StatusFailed
+}
+
+// StreamTransfer calls handle with the current state of a transfer and then
+// with every change to it. It returns nil once the transfer completes or
+// fails, and otherwise runs until the context ends, handle returns an error
+// or the stream is closed.
+func (c *Client) StreamTransfer(ctx context.Context, transferID string, handle func(TransferUpdate) error) error {
+	query := url.Values{}
+	query.Set("transfer_id", transferID)
+
+	req, err := c.newRequest(ctx, http.MethodGet, "/api/v1/transfers/stream", query, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to open transfer stream: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp); err != nil {
+		return err
+	}
+
+	// Events are an "event:" line naming the type and a "data:" line, ended
+	// by a blank line. Lines starting with ":" are keep-alives.
+	scanner := bufio.NewScanner(resp.Body)
+	var event, data string
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data = strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		case line == "":
+			if event == "transfer" {
+				var update TransferUpdate
+				if err := json.Unmarshal([]byte(data), &update); err != nil {
+					return fmt.Errorf("failed to decode transfer update: %w", err)
+				}
+				if err := handle(update); err != nil {
+					return err
+				}
+				if update.Final() {
+					return nil
+				}
+			}
+			event, data = "", ""
+		}
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read transfer stream: %w", err)
+	}
+	return ErrStreamClosed
+}
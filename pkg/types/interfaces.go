@@ -32,6 +32,28 @@ type ChainAdapter interface {
 	Close() error
 }
 
+// TransactionSender signs and broadcasts transactions in separate steps so
+// the signed bytes can be persisted before they leave the process
+type TransactionSender interface {
+	// SignTransaction signs a transaction using tx.Nonce without broadcasting it
+	SignTransaction(ctx context.Context, tx Transaction) (*SignedTransaction, error)
+	
+	// BroadcastTransaction sends a previously signed transaction
+	BroadcastTransaction(ctx context.Context, signed SignedTransaction) error
+	
+	// GetTransactionResult returns the mined result of a transaction, or nil if it is not mined
+	GetTransactionResult(ctx context.Context, txHash string) (*TxResult, error)
+	
+	// PendingNonce returns the next nonce including pending transactions
+	PendingNonce(ctx context.Context) (uint64, error)
+	
+	// ConfirmedNonce returns the next nonce according to the latest block
+	ConfirmedNonce(ctx context.Context) (uint64, error)
+	
+	// GetSenderAddress returns the address transactions are signed with
+	GetSenderAddress() string
+}
+
 // SignatureValidator handles multi-signature operations
 type SignatureValidator interface {
 	// SignTransfer generates a signature for a transfer
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -31,6 +33,22 @@ func (c ChainID) String() string {
 	}
 }
 
+// ParseChainID parses a chain from its name (e.g. "ethereum") or numeric ID
+func ParseChainID(value string) (ChainID, error) {
+	for _, chain := range []ChainID{ChainEthereum, ChainPolygon, ChainCosmos} {
+		if strings.EqualFold(value, chain.String()) {
+			return chain, nil
+		}
+	}
+
+	id, err := strconv.ParseUint(value, 10, 64)
+	if err != nil || id == 0 {
+		return 0, fmt.Errorf("invalid chain: %s", value)
+	}
+
+	return ChainID(id), nil
+}
+
 // ChainType represents the type of blockchain
 type ChainType string
 
@@ -50,8 +68,20 @@ const (
 	StatusCompleted   TransferStatus = "completed"
 	StatusFailed      TransferStatus = "failed"
 	StatusUnderReview TransferStatus = "under_review"
+	StatusDelayed     TransferStatus = "delayed"
 )
 
+// IsValid reports whether the status is one of the known transfer statuses
+func (s TransferStatus) IsValid() bool {
+	switch s {
+	case StatusPending, StatusConfirming, StatusSigned, StatusExecuting,
+		StatusCompleted, StatusFailed, StatusUnderReview, StatusDelayed:
+		return true
+	default:
+		return false
+	}
+}
+
 // EventType represents the type of blockchain event
 type EventType string
 
@@ -145,6 +175,7 @@ type Event struct {
 	Type        EventType `json:"type"`
 	ChainID     ChainID   `json:"chain_id"`
 	TxHash      string    `json:"tx_hash"`
+	LogIndex    uint      `json:"log_index"`
 	BlockNumber uint64    `json:"block_number"`
 	TransferID  string    `json:"transfer_id"`
 	Transfer    Transfer  `json:"transfer"`
@@ -177,6 +208,16 @@ type TxResult struct {
 	Status      bool   `json:"status"`
 }
 
+// SignedTransaction is a signed transaction that has not necessarily been
+// broadcast yet
+type SignedTransaction struct {
+	ChainID ChainID `json:"chain_id"`
+	From    string  `json:"from"`
+	Nonce   uint64  `json:"nonce"`
+	TxHash  string  `json:"tx_hash"`
+	Raw     []byte  `json:"raw"`
+}
+
 // SupportedToken represents a token supported by the bridge
 type SupportedToken struct {
 	ID           int     `json:"id" db:"id"`
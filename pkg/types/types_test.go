@@ -112,6 +112,54 @@ func TestChainID_String(t *testing.T) {
 	}
 }
 
+func TestParseChainID(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected ChainID
+		wantErr  bool
+	}{
+		{"ethereum", ChainEthereum, false},
+		{"Polygon", ChainPolygon, false},
+		{"118", ChainCosmos, false},
+		{"31337", ChainID(31337), false},
+		{"0", 0, true},
+		{"solana", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := ParseChainID(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error for %s", tt.input)
+				}
+				return
+			}
+			if err != nil || result != tt.expected {
+				t.Errorf("Expected %d, got %d (%v)", tt.expected, result, err)
+			}
+		})
+	}
+}
+
+func TestTransferStatus_IsValid(t *testing.T) {
+	valid := []TransferStatus{
+		StatusPending, StatusConfirming, StatusSigned, StatusExecuting,
+		StatusCompleted, StatusFailed, StatusUnderReview, StatusDelayed,
+	}
+	for _, status := range valid {
+		if !status.IsValid() {
+			t.Errorf("Expected status %s to be valid", status)
+		}
+	}
+
+	for _, status := range []TransferStatus{"", "unknown", "PENDING"} {
+		if status.IsValid() {
+			t.Errorf("Expected status %q to be invalid", status)
+		}
+	}
+}
+
 func TestTransfer_Validate(t *testing.T) {
 	validTransfer := &Transfer{
 		ID:               "0x1234567890abcdef1234567890abcdef12345678901234567890abcdef123456",
@@ -0,0 +1,16 @@
+-- Migration: 002_transfer_search_indexes.sql
+-- Description: Indexes supporting filtered transfer search with keyset pagination
+-- Created: 2026-10-18
+
+-- Keyset pagination orders by (created_at, id)
+CREATE INDEX IF NOT EXISTS idx_transfers_created_at_id ON transfers(created_at DESC, id DESC);
+
+-- Address filters are case-insensitive
+CREATE INDEX IF NOT EXISTS idx_transfers_sender_lower ON transfers(LOWER(sender), created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_transfers_recipient_lower ON transfers(LOWER(recipient), created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_transfers_token_lower ON transfers(LOWER(token), created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_transfers_source_tx_hash_lower ON transfers(LOWER(source_tx_hash));
+CREATE INDEX IF NOT EXISTS idx_transfers_destination_tx_hash_lower ON transfers(LOWER(destination_tx_hash));
+
+-- Route filters
+CREATE INDEX IF NOT EXISTS idx_transfers_route ON transfers(source_chain, destination_chain, created_at DESC, id DESC);
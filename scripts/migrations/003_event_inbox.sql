@@ -0,0 +1,34 @@
+-- Migration: 003_event_inbox.sql
+-- Description: Inbox of raw observed chain events, deduplicated by log position
+-- Created: 2026-10-18
+
+CREATE TABLE IF NOT EXISTS event_inbox (
+    id BIGSERIAL PRIMARY KEY,
+    event_id VARCHAR(100) NOT NULL,
+    chain_id INTEGER NOT NULL,
+    tx_hash VARCHAR(66) NOT NULL,
+    log_index INTEGER NOT NULL,
+    block_number BIGINT NOT NULL,
+    event_type VARCHAR(20) NOT NULL,
+    transfer_id VARCHAR(66),
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'received',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    received_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    processed_at TIMESTAMP,
+
+    -- Constraints
+    CONSTRAINT uq_event_inbox_position UNIQUE (chain_id, tx_hash, log_index),
+    CONSTRAINT chk_event_inbox_status CHECK (status IN ('received', 'processing', 'processed', 'failed')),
+    CONSTRAINT chk_event_inbox_attempts CHECK (attempts >= 0)
+);
+
+-- Create indexes for event_inbox table
+CREATE INDEX IF NOT EXISTS idx_event_inbox_status ON event_inbox(status, received_at);
+CREATE INDEX IF NOT EXISTS idx_event_inbox_transfer_id ON event_inbox(transfer_id);
+
+CREATE TRIGGER update_event_inbox_updated_at
+    BEFORE UPDATE ON event_inbox
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
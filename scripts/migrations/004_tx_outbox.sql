@@ -0,0 +1,37 @@
+-- Migration: 004_tx_outbox.sql
+-- Description: Outbox of signed destination-chain transactions, persisted before broadcast
+-- Created: 2026-10-18
+
+CREATE TABLE IF NOT EXISTS tx_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    transfer_id VARCHAR(66) NOT NULL REFERENCES transfers(id) ON DELETE CASCADE,
+    chain_id INTEGER NOT NULL,
+    from_address VARCHAR(42) NOT NULL,
+    nonce BIGINT NOT NULL,
+    tx_hash VARCHAR(66) NOT NULL UNIQUE,
+    raw_tx BYTEA NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    block_number BIGINT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    -- Constraints
+    CONSTRAINT chk_tx_outbox_status CHECK (status IN ('pending', 'broadcast', 'confirmed', 'reverted', 'dropped')),
+    CONSTRAINT chk_tx_outbox_nonce CHECK (nonce >= 0)
+);
+
+-- A nonce can only be used by one live transaction per sender
+CREATE UNIQUE INDEX IF NOT EXISTS uq_tx_outbox_sender_nonce
+    ON tx_outbox(chain_id, from_address, nonce) WHERE status <> 'dropped';
+
+-- A transfer can only have one live destination transaction
+CREATE UNIQUE INDEX IF NOT EXISTS uq_tx_outbox_active_transfer
+    ON tx_outbox(transfer_id) WHERE status IN ('pending', 'broadcast', 'confirmed');
+
+CREATE INDEX IF NOT EXISTS idx_tx_outbox_status ON tx_outbox(status, created_at);
+
+CREATE TRIGGER update_tx_outbox_updated_at
+    BEFORE UPDATE ON tx_outbox
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,9 @@
+-- Migration: 005_transfer_leases.sql
+-- Description: Row leases so several relayer replicas can share the transfer work queue
+-- Created: 2026-10-18
+
+ALTER TABLE transfers ADD COLUMN IF NOT EXISTS lease_owner VARCHAR(128);
+ALTER TABLE transfers ADD COLUMN IF NOT EXISTS lease_expires_at TIMESTAMP;
+
+-- Queue draining scans a status oldest first and skips live leases
+CREATE INDEX IF NOT EXISTS idx_transfers_status_queue ON transfers(status, created_at, id);
@@ -0,0 +1,34 @@
+-- Migration: 006_transfer_notifications.sql
+-- Description: Publish transfer status and confirmation changes on a NOTIFY channel
+-- Created: 2026-10-18
+
+CREATE OR REPLACE FUNCTION notify_transfer_update()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'UPDATE'
+        AND NEW.status IS NOT DISTINCT FROM OLD.status
+        AND NEW.confirmations IS NOT DISTINCT FROM OLD.confirmations
+        AND NEW.destination_tx_hash IS NOT DISTINCT FROM OLD.destination_tx_hash THEN
+        RETURN NEW;
+    END IF;
+
+    PERFORM pg_notify('transfer_updates', json_build_object(
+        'transfer_id', NEW.id,
+        'source_chain', NEW.source_chain,
+        'destination_chain', NEW.destination_chain,
+        'sender', NEW.sender,
+        'recipient', NEW.recipient,
+        'status', NEW.status,
+        'confirmations', NEW.confirmations,
+        'destination_tx_hash', COALESCE(NEW.destination_tx_hash, '')
+    )::text);
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS notify_transfers_update ON transfers;
+CREATE TRIGGER notify_transfers_update
+    AFTER INSERT OR UPDATE ON transfers
+    FOR EACH ROW
+    EXECUTE FUNCTION notify_transfer_update();
@@ -0,0 +1,93 @@
+-- Migration: 007_webhooks.sql
+-- Description: Webhook subscriptions and their durable delivery queue
+-- Created: 2026-10-18
+
+CREATE TABLE IF NOT EXISTS webhooks (
+    id SERIAL PRIMARY KEY,
+    url TEXT NOT NULL,
+    secret VARCHAR(128) NOT NULL,
+    address VARCHAR(42),
+    token VARCHAR(42),
+    source_chain INTEGER,
+    destination_chain INTEGER,
+    events TEXT[] NOT NULL DEFAULT '{}',
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by VARCHAR(64),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    webhook_id INTEGER NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    transfer_id VARCHAR(66) NOT NULL REFERENCES transfers(id) ON DELETE CASCADE,
+    event_type VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT,
+    last_status_code INTEGER,
+    delivered_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    -- Constraints
+    CONSTRAINT chk_webhook_deliveries_status CHECK (status IN ('pending', 'delivered', 'dead'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook ON webhook_deliveries(webhook_id, created_at);
+
+CREATE TRIGGER update_webhooks_updated_at
+    BEFORE UPDATE ON webhooks
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_webhook_deliveries_updated_at
+    BEFORE UPDATE ON webhook_deliveries
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Queue a delivery to every enabled webhook whose scope matches a transfer
+-- whenever the transfer is created or changes status, in the same transaction
+CREATE OR REPLACE FUNCTION enqueue_webhook_deliveries()
+RETURNS TRIGGER AS $$
+DECLARE
+    event_name TEXT := 'transfer.' || NEW.status;
+BEGIN
+    IF TG_OP = 'UPDATE' AND NEW.status IS NOT DISTINCT FROM OLD.status THEN
+        RETURN NEW;
+    END IF;
+
+    INSERT INTO webhook_deliveries (webhook_id, transfer_id, event_type, payload)
+    SELECT w.id, NEW.id, event_name, json_build_object(
+        'id', NEW.id,
+        'source_chain', NEW.source_chain,
+        'destination_chain', NEW.destination_chain,
+        'token', NEW.token,
+        'amount', NEW.amount::text,
+        'sender', NEW.sender,
+        'recipient', NEW.recipient,
+        'status', NEW.status,
+        'source_tx_hash', COALESCE(NEW.source_tx_hash, ''),
+        'destination_tx_hash', COALESCE(NEW.destination_tx_hash, ''),
+        'block_number', COALESCE(NEW.block_number, 0),
+        'confirmations', NEW.confirmations,
+        'fee', NEW.fee::text
+    )
+    FROM webhooks w
+    WHERE w.enabled
+        AND (w.address IS NULL OR LOWER(w.address) IN (LOWER(NEW.sender), LOWER(NEW.recipient)))
+        AND (w.token IS NULL OR LOWER(w.token) = LOWER(NEW.token))
+        AND (w.source_chain IS NULL OR w.source_chain = NEW.source_chain)
+        AND (w.destination_chain IS NULL OR w.destination_chain = NEW.destination_chain)
+        AND (cardinality(w.events) = 0 OR event_name = ANY(w.events));
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS enqueue_transfer_webhooks ON transfers;
+CREATE TRIGGER enqueue_transfer_webhooks
+    AFTER INSERT OR UPDATE ON transfers
+    FOR EACH ROW
+    EXECUTE FUNCTION enqueue_webhook_deliveries();
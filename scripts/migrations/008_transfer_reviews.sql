@@ -0,0 +1,33 @@
+-- Migration: 008_transfer_reviews.sql
+-- Description: Review queue for transfers held for manual review, with operator votes
+-- Created: 2026-10-18
+
+CREATE TABLE IF NOT EXISTS transfer_reviews (
+    id SERIAL PRIMARY KEY,
+    transfer_id VARCHAR(66) NOT NULL REFERENCES transfers(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL,
+    previous_status VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    resolution TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    resolved_at TIMESTAMP,
+
+    -- Constraints
+    CONSTRAINT chk_transfer_reviews_status CHECK (status IN ('open', 'approved', 'rejected'))
+);
+
+-- A transfer has at most one open review
+CREATE UNIQUE INDEX IF NOT EXISTS idx_transfer_reviews_open ON transfer_reviews(transfer_id) WHERE status = 'open';
+CREATE INDEX IF NOT EXISTS idx_transfer_reviews_transfer ON transfer_reviews(transfer_id, id);
+
+CREATE TABLE IF NOT EXISTS transfer_review_votes (
+    review_id INTEGER NOT NULL REFERENCES transfer_reviews(id) ON DELETE CASCADE,
+    operator VARCHAR(64) NOT NULL,
+    decision VARCHAR(10) NOT NULL,
+    reason TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    -- Each operator votes once per review
+    PRIMARY KEY (review_id, operator),
+    CONSTRAINT chk_transfer_review_votes_decision CHECK (decision IN ('approve', 'reject'))
+);
@@ -0,0 +1,20 @@
+-- Migration: 009_api_keys.sql
+-- Description: Hashed API keys with roles and per-key rate limits
+-- Created: 2026-10-18
+
+CREATE TABLE IF NOT EXISTS api_keys (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(64) NOT NULL,
+    prefix VARCHAR(16) NOT NULL,
+    key_hash CHAR(64) NOT NULL,
+    role VARCHAR(20) NOT NULL,
+    rate_limit INTEGER NOT NULL DEFAULT 0,
+    created_by VARCHAR(64),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP,
+
+    -- Constraints
+    CONSTRAINT uq_api_keys_hash UNIQUE (key_hash),
+    CONSTRAINT chk_api_keys_role CHECK (role IN ('integrator', 'operator', 'admin')),
+    CONSTRAINT chk_api_keys_rate_limit CHECK (rate_limit >= 0)
+);
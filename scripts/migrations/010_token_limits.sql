@@ -0,0 +1,25 @@
+-- Migration: 010_token_limits.sql
+-- Description: Per-token and per-route transfer amount limits and rolling volume caps
+-- Created: 2026-10-18
+
+CREATE TABLE IF NOT EXISTS token_limits (
+    id SERIAL PRIMARY KEY,
+    token_id INTEGER NOT NULL REFERENCES supported_tokens(id) ON DELETE CASCADE,
+    -- 0 applies the limit to every route of the token
+    destination_chain INTEGER NOT NULL DEFAULT 0,
+    min_amount DECIMAL(78,0),
+    max_amount DECIMAL(78,0),
+    hourly_cap DECIMAL(78,0),
+    daily_cap DECIMAL(78,0),
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    -- Constraints
+    CONSTRAINT uq_token_limits_route UNIQUE (token_id, destination_chain),
+    CONSTRAINT chk_token_limits_min CHECK (min_amount IS NULL OR min_amount >= 0),
+    CONSTRAINT chk_token_limits_max CHECK (max_amount IS NULL OR max_amount > 0),
+    CONSTRAINT chk_token_limits_range CHECK (min_amount IS NULL OR max_amount IS NULL OR min_amount <= max_amount),
+    CONSTRAINT chk_token_limits_caps CHECK ((hourly_cap IS NULL OR hourly_cap > 0) AND (daily_cap IS NULL OR daily_cap > 0))
+);
+
+-- Volume caps sum a token's transfers out of its chain
+CREATE INDEX IF NOT EXISTS idx_transfers_source_token ON transfers(source_chain, LOWER(token));
@@ -0,0 +1,30 @@
+-- Migration: 011_transfer_delays.sql
+-- Description: Delay of large transfers before execution, cancellable by operators
+-- Created: 2026-10-18
+
+ALTER TABLE transfers DROP CONSTRAINT IF EXISTS chk_valid_status;
+ALTER TABLE transfers ADD CONSTRAINT chk_valid_status
+    CHECK (status IN ('pending', 'confirming', 'signed', 'executing', 'completed', 'failed', 'under_review', 'delayed'));
+
+-- Transfers above the threshold wait before execution
+ALTER TABLE token_limits ADD COLUMN IF NOT EXISTS delay_threshold DECIMAL(78,0);
+ALTER TABLE token_limits DROP CONSTRAINT IF EXISTS chk_token_limits_delay;
+ALTER TABLE token_limits ADD CONSTRAINT chk_token_limits_delay CHECK (delay_threshold IS NULL OR delay_threshold >= 0);
+
+CREATE TABLE IF NOT EXISTS transfer_delays (
+    id SERIAL PRIMARY KEY,
+    -- A transfer is delayed at most once
+    transfer_id VARCHAR(66) NOT NULL UNIQUE REFERENCES transfers(id) ON DELETE CASCADE,
+    previous_status VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'waiting',
+    release_at TIMESTAMP NOT NULL,
+    cancelled_by VARCHAR(64),
+    reason TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    resolved_at TIMESTAMP,
+
+    -- Constraints
+    CONSTRAINT chk_transfer_delays_status CHECK (status IN ('waiting', 'released', 'cancelled'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_transfer_delays_waiting ON transfer_delays(release_at) WHERE status = 'waiting';
@@ -0,0 +1,7 @@
+-- Migration: 012_event_inbox_pending.sql
+-- Description: Index of events left to retry, skipping those out of attempts
+-- Created: 2026-10-18
+
+CREATE INDEX IF NOT EXISTS idx_event_inbox_pending
+    ON event_inbox(received_at, id, attempts)
+    WHERE status IN ('received', 'failed');
@@ -0,0 +1,12 @@
+-- Migration: 013_chain_scan_progress.sql
+-- Description: Last block the relayer scanned on each chain, read by API readiness
+-- Created: 2026-10-18
+
+CREATE TABLE IF NOT EXISTS chain_scan_progress (
+    chain_id INTEGER PRIMARY KEY,
+    scanned_block BIGINT NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    -- Constraints
+    CONSTRAINT chk_chain_scan_progress_block CHECK (scanned_block >= 0)
+);
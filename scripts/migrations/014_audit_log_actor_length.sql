@@ -0,0 +1,5 @@
+-- Migration: 014_audit_log_actor_length.sql
+-- Description: Widen audit_log.performed_by to fit operator names, API key names and token subjects
+-- Created: 2026-10-18
+
+ALTER TABLE audit_log ALTER COLUMN performed_by TYPE VARCHAR(64);
@@ -0,0 +1,28 @@
+-- Migration: 015_transfer_status_changed_at.sql
+-- Description: Track when a transfer last changed status, apart from lease and other row updates
+-- Created: 2026-10-18
+
+ALTER TABLE transfers ADD COLUMN IF NOT EXISTS status_changed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP;
+
+-- Existing transfers last changed status no later than their last update
+UPDATE transfers SET status_changed_at = updated_at WHERE status_changed_at > updated_at;
+
+-- Create function to update status_changed_at timestamp
+CREATE OR REPLACE FUNCTION update_status_changed_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.status_changed_at = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+-- Leases, confirmations and hashes update the row without moving its status
+DROP TRIGGER IF EXISTS update_transfers_status_changed_at ON transfers;
+CREATE TRIGGER update_transfers_status_changed_at
+    BEFORE UPDATE OF status ON transfers
+    FOR EACH ROW
+    WHEN (OLD.status IS DISTINCT FROM NEW.status)
+    EXECUTE FUNCTION update_status_changed_at_column();
+
+-- Readiness counts transfers stuck in a status
+CREATE INDEX IF NOT EXISTS idx_transfers_status_changed_at ON transfers(status, status_changed_at);
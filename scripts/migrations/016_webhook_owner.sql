@@ -0,0 +1,7 @@
+-- Migration: 016_webhook_owner.sql
+-- Description: Record the integrator owning a webhook, who may only manage their own
+-- Created: 2026-10-18
+
+ALTER TABLE webhooks ADD COLUMN IF NOT EXISTS owner VARCHAR(80);
+
+CREATE INDEX IF NOT EXISTS idx_webhooks_owner ON webhooks(owner) WHERE owner IS NOT NULL;